@@ -21,7 +21,6 @@ import (
 	"os"
 	"time"
 
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/util/log"
 	"kpt.dev/configsync/pkg/webhook"
@@ -29,6 +28,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
@@ -37,6 +37,7 @@ var (
 	healthProbeBindAddress  string
 	gracefulShutdownTimeout time.Duration
 	cacheSyncTimeout        time.Duration
+	prometheusMetricsAddr   string
 )
 
 func main() {
@@ -45,9 +46,9 @@ func main() {
 	flag.StringVar(&healthProbeBindAddress, "health-probe-bind-addr", fmt.Sprintf(":%d", configuration.HealthProbePort), "The address the healthz & readyz probes bind to.")
 	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", configuration.GracefulShutdownTimeout, "The duration of time to wait while shutting down for all controllers to stop.")
 	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", configuration.CacheSyncTimeout, "The duration of time to wait while informers synchronize.")
+	flag.StringVar(&prometheusMetricsAddr, "prometheus-metrics-addr", "", "The address to serve the metrics on /metrics in the Prometheus format, like :9464. Defaults to :8080 if empty.")
 
-	log.Setup()
-	logger := textlogger.NewLogger(textlogger.NewConfig())
+	logger := log.Setup()
 	setupLog := logger.WithName("setup")
 
 	profiler.Service()
@@ -59,6 +60,9 @@ func main() {
 			Port:    configuration.ContainerPort,
 			CertDir: configuration.CertDir,
 		}),
+		Metrics: metricsserver.Options{
+			BindAddress: prometheusMetricsAddr,
+		},
 		// Required for the ReadyzCheck
 		HealthProbeBindAddress:  healthProbeBindAddress,
 		GracefulShutdownTimeout: &gracefulShutdownTimeout,
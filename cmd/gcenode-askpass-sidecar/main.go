@@ -20,7 +20,6 @@ import (
 	"net/http"
 
 	"cloud.google.com/go/compute/metadata"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/askpass"
 	"kpt.dev/configsync/pkg/auth"
 	"kpt.dev/configsync/pkg/util"
@@ -60,8 +59,7 @@ func main() {
 		return
 	}
 
-	utillog.Setup()
-	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+	log := utillog.NewLogger(utillog.Setup(), *flRoot, *flErrorFile)
 
 	log.Info("starting askpass with arguments", "--port", *flPort,
 		"--email", *flGsaEmail, "--error-file", *flErrorFile, "--root", *flRoot)
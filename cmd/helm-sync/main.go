@@ -24,13 +24,13 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/auth"
 	"kpt.dev/configsync/pkg/helm"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/util"
 	utillog "kpt.dev/configsync/pkg/util/log"
+	"kpt.dev/configsync/pkg/util/render"
 )
 
 var (
@@ -75,6 +75,9 @@ var (
 		"the username to use for helm authantication")
 	flPassword = flag.String("password", util.EnvString("HELM_SYNC_PASSWORD", ""),
 		"the password or personal access token to use for helm authantication")
+
+	flRenderMemoryLimit = flag.String("render-memory-limit", util.EnvString("HELM_SYNC_RENDER_MEMORY_LIMIT", ""),
+		"the maximum heap of the process while rendering, like 512Mi, which defaults to 80% of the memory limit of the container. Rendering is not limited if zero.")
 )
 
 func errorBackoff() wait.Backoff {
@@ -83,8 +86,7 @@ func errorBackoff() wait.Backoff {
 }
 
 func main() {
-	utillog.Setup()
-	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+	log := utillog.NewLogger(utillog.Setup(), *flRoot, *flErrorFile)
 	log.Info("rendering Helm chart with arguments", "--repo", *flRepo,
 		"--chart", *flChart, "--version", *flVersion, "--root", *flRoot,
 		"--values", *flValuesYAML, "--values-file-paths", *flValuesFilePaths,
@@ -114,6 +116,12 @@ func main() {
 		}
 	}
 
+	memoryLimit, err := render.ParseMemoryLimit(*flRenderMemoryLimit)
+	if err != nil {
+		utillog.HandleError(log, true, "ERROR: invalid --render-memory-limit: %v", err)
+	}
+	render.SetMemoryLimit(memoryLimit)
+
 	initialSync := true
 	failCount := 0
 	backoff := errorBackoff()
@@ -17,19 +17,24 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
 	"os"
 	"strings"
 
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/helm"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/kmetrics"
+	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util/log"
+	"kpt.dev/configsync/pkg/util/render"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -67,32 +72,67 @@ var (
 	rehydratePeriod = flag.Duration("rehydrate-period", configsync.DefaultHydrationRetryPeriod,
 		"Period of time between rehydrating on errors.")
 
+	renderTimeout = flag.Duration("render-timeout", 0,
+		"The maximum duration of a single rendering. Rendering is not limited if zero.")
+
+	renderMemoryLimit = flag.String("render-memory-limit", "",
+		"The maximum heap of the process while rendering, like 512Mi. Defaults to 80% of the memory limit of the container. Rendering is not limited if zero.")
+
+	kptFunctions = flag.String("kpt-functions", "",
+		"Comma-separated list of image=path pairs of the KRM function images that Kptfile pipelines can run, and the local binaries that implement them. Exec functions can only run these binaries.")
+
+	renderCacheSize = flag.Int("render-cache-size", 5,
+		"The maximum number of renderings cached on the hydrated volume, keyed by the source commit and render inputs. The render cache is disabled if zero.")
+
 	reconcilerName = flag.String("reconciler-name", os.Getenv(reconcilermanager.ReconcilerNameKey),
 		"Name of the reconciler Deployment.")
 
+	scopeStr = flag.String("scope", os.Getenv(reconcilermanager.ScopeKey),
+		"Scope of the reconciler, either a namespace or ':root'.")
+
+	syncName = flag.String("sync-name", os.Getenv(reconcilermanager.SyncNameKey),
+		"Name of the RootSync or RepoSync object.")
+
 	reconcilerSignalsDir = flag.String("reconciler-signals", "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals written by the reconciler that unblock the rendering phase, for example, the latest image digest that is ready to render.")
 )
 
 func main() {
-	log.Setup()
+	// Kustomize runs the hydration-controller as its helm command, to inflate
+	// the charts in the `helmCharts` field of a kustomization with the Helm SDK.
+	if helm.IsCommand(os.Args[1:]) {
+		if err := helm.RunCommand(context.Background(), os.Args[1:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	logger := log.Setup()
 	profiler.Service()
-	ctrl.SetLogger(textlogger.NewLogger(textlogger.NewConfig()))
+	ctrl.SetLogger(log.WithValues(logger, log.KeyScope, *scopeStr, log.KeySyncName, *syncName))
 
-	// Register the kustomize usage metric views.
-	if err := kmetrics.RegisterKustomizeMetricsViews(); err != nil {
-		klog.Fatalf("Failed to register OpenCensus views: %v", err)
+	// Register the OTLP metrics exporter
+	mp, err := metrics.RegisterOTelExporter(context.Background(), reconcilermanager.HydrationController)
+	if err != nil {
+		klog.Fatalf("Failed to register the OTLP metrics exporter: %v", err)
 	}
 
-	// Register the OC Agent exporter
-	oce, err := kmetrics.RegisterOCAgentExporter(reconcilermanager.HydrationController)
+	defer func() {
+		if err := mp.Shutdown(context.Background()); err != nil {
+			klog.Fatalf("Unable to stop the OTLP metrics exporter: %v", err)
+		}
+	}()
+
+	// Register the OTLP trace exporter
+	tp, err := tracing.RegisterOTelExporter(context.Background(), reconcilermanager.HydrationController)
 	if err != nil {
-		klog.Fatalf("Failed to register the OC Agent exporter: %v", err)
+		klog.Fatalf("Failed to register the OTLP trace exporter: %v", err)
 	}
 
 	defer func() {
-		if err := oce.Stop(); err != nil {
-			klog.Fatalf("Unable to stop the OC Agent exporter: %v", err)
+		if err := tp.Shutdown(context.Background()); err != nil {
+			klog.Fatalf("Unable to stop the OTLP trace exporter: %v", err)
 		}
 	}()
 
@@ -116,6 +156,23 @@ func main() {
 	dir := strings.TrimPrefix(*syncDir, "/")
 	relSyncDir := cmpath.RelativeOS(dir)
 
+	memoryLimit, err := render.ParseMemoryLimit(*renderMemoryLimit)
+	if err != nil {
+		klog.Fatalf("Invalid --render-memory-limit: %v", err)
+	}
+	render.SetMemoryLimit(memoryLimit)
+
+	helmCommand, err := os.Executable()
+	if err != nil {
+		klog.Fatalf("Unable to find the hydration-controller binary: %v", err)
+	}
+	kmetrics.HelmCommand = helmCommand
+
+	fns, err := hydrate.ParseKptFunctions(*kptFunctions)
+	if err != nil {
+		klog.Fatalf("Invalid --kpt-functions: %v", err)
+	}
+
 	hydrator := &hydrate.Hydrator{
 		DonePath:            absDonePath,
 		SourceType:          configsync.SourceType(*sourceType),
@@ -128,6 +185,11 @@ func main() {
 		PollingPeriod:       *pollingPeriod,
 		RehydratePeriod:     *rehydratePeriod,
 		ReconcilerName:      *reconcilerName,
+		SyncName:            *syncName,
+		Scope:               declared.Scope(*scopeStr),
+		RenderTimeout:       *renderTimeout,
+		KptFunctions:        fns,
+		RenderCacheSize:     *renderCacheSize,
 	}
 
 	hydrator.Run(context.Background())
@@ -0,0 +1,284 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adopt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	nomosparse "kpt.dev/configsync/cmd/nomos/parse"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/adopt"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/hydrate"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/importer/reader"
+	"kpt.dev/configsync/pkg/resourcegroup"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// modeReport only reports the adoption state of the declared objects.
+const modeReport = "report"
+
+var (
+	syncName    string
+	clusterName string
+	mode        string
+)
+
+func init() {
+	flags.AddPath(Cmd)
+	flags.AddSourceFormat(Cmd)
+	flags.AddSkipAPIServerCheck(Cmd)
+	flags.AddAPIServerTimeout(Cmd)
+	Cmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout, "Timeout for connecting to the cluster")
+	Cmd.Flags().StringVar(&syncName, "sync-name", configsync.RootSyncName,
+		`Name of the RootSync adopting the objects.`)
+	Cmd.Flags().StringVar(&clusterName, "cluster", "",
+		`Name of the Cluster used to evaluate cluster selectors in the repository.`)
+	Cmd.Flags().StringVar(&mode, "mode", modeReport,
+		fmt.Sprintf(`Accepts %q, %q and %q. With %q, only reports the differences between the declared and live objects. `+
+			`With %q, unmanaged objects are adopted and keep their current state until their declared state changes. `+
+			`With %q, unmanaged objects are adopted and updated to their declared state on the next sync.`,
+			modeReport, adopt.CurrentState, adopt.Declared, modeReport, adopt.CurrentState, adopt.Declared))
+}
+
+// Cmd is the Cobra object representing the adopt command.
+var Cmd = &cobra.Command{
+	Use:   "adopt",
+	Short: "Adopts the existing unmanaged objects declared in the local repository into a RootSync.",
+	Long: `Adopts the existing unmanaged objects declared in the local repository into a RootSync.
+
+Compares the declared objects to the objects on the cluster of the current context and reports
+the declared fields which differ. With --mode, the unmanaged objects are marked as managed by the
+RootSync and added to its inventory, without applying them, so the RootSync can take over a
+cluster without rewriting every object.`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		switch mode {
+		case modeReport, string(adopt.CurrentState), string(adopt.Declared):
+		default:
+			return fmt.Errorf("invalid --mode value %q: must be one of %q, %q or %q", mode, modeReport, adopt.CurrentState, adopt.Declared)
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		objs, err := declaredObjects(cmd.Context())
+		if err != nil {
+			return err
+		}
+		cfg, err := restconfig.NewRestConfig(flags.ClientTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to create rest config: %w", err)
+		}
+		c, err := client.New(cfg, client.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+		return run(cmd.Context(), os.Stdout, c, objs)
+	},
+}
+
+// declaredObjects parses the objects declared in the local repository for the
+// selected cluster.
+func declaredObjects(ctx context.Context) ([]ast.FileObject, error) {
+	sourceFormat := configsync.SourceFormat(flags.SourceFormat)
+	if sourceFormat == "" {
+		sourceFormat = configsync.SourceFormatHierarchy
+	}
+	rootDir, needsHydrate, err := hydrate.ValidateHydrateFlags(sourceFormat)
+	if err != nil {
+		return nil, err
+	}
+	if needsHydrate {
+		if rootDir, err = hydrate.ValidateAndRunKustomize(rootDir.OSPath()); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = os.RemoveAll(rootDir.OSPath())
+		}()
+	}
+
+	files, err := nomosparse.FindFiles(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	validateOpts, err := hydrate.ValidateOptions(ctx, rootDir, flags.APIServerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	validateOpts.FieldManager = util.FieldManager
+	if sourceFormat == configsync.SourceFormatHierarchy {
+		files = filesystem.FilterHierarchyFiles(rootDir, files)
+	} else {
+		validateOpts.Scope = declared.RootScope
+	}
+	parseOpts := hydrate.ParseOptions{
+		Parser:       filesystem.NewParser(&reader.File{}),
+		SourceFormat: sourceFormat,
+		FilePaths: reader.FilePaths{
+			RootDir:   rootDir,
+			PolicyDir: cmpath.RelativeOS(rootDir.OSPath()),
+			Files:     files,
+		},
+	}
+
+	var objs []ast.FileObject
+	var errs status.MultiError
+	hydrate.ForEachCluster(ctx, parseOpts, validateOpts, func(name string, fileObjects []ast.FileObject, err status.MultiError) {
+		if name != clusterName {
+			return
+		}
+		objs = fileObjects
+		errs = err
+	})
+	if errs != nil {
+		return nil, errs
+	}
+	return objs, nil
+}
+
+// run compares the declared objects to the cluster, and adopts the unmanaged
+// objects unless only reporting.
+func run(ctx context.Context, out io.Writer, c client.Client, objs []ast.FileObject) error {
+	syncNamespace := configmanagement.ControllerNamespace
+	manager := declared.ResourceManager(declared.RootScope, syncName)
+
+	var toAdopt []*unstructured.Unstructured
+	var hashes []string
+	conflicts := 0
+	for _, obj := range objs {
+		live := &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get %s: %w", core.IDOf(obj), err)
+			}
+			live = nil
+		}
+		result, err := adopt.Classify(obj.Unstructured, live, manager)
+		if err != nil {
+			return err
+		}
+		printResult(out, result)
+		switch result.State {
+		case adopt.Conflict:
+			conflicts++
+		case adopt.Unmanaged:
+			hash := ""
+			if mode == string(adopt.CurrentState) {
+				if hash, err = adopt.Hash(obj); err != nil {
+					return err
+				}
+			}
+			toAdopt = append(toAdopt, live)
+			hashes = append(hashes, hash)
+		}
+	}
+	if conflicts > 0 {
+		util.MustFprintf(out, "%d objects are managed by another RootSync or RepoSync, and are not adopted\n", conflicts)
+	}
+	if mode == modeReport || len(toAdopt) == 0 {
+		util.MustFprintf(out, "%d unmanaged objects can be adopted\n", len(toAdopt))
+		return nil
+	}
+
+	// Update the inventory before marking the objects as managed, so that the
+	// reconciler knows which objects keep their current state.
+	if err := updateInventory(ctx, c, syncNamespace, toAdopt, hashes); err != nil {
+		return err
+	}
+	csm := adopt.ConfigSyncMetadata(declared.RootScope, syncName,
+		applyset.IDFromSync(syncName, declared.RootScope), applier.InventoryID(syncName, syncNamespace))
+	for i, live := range toAdopt {
+		patch := adopt.MetadataPatch(live, csm, hashes[i])
+		if err := c.Patch(ctx, patch, client.Apply, client.FieldOwner(configsync.FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to adopt %s: %w", core.IDOf(live), err)
+		}
+	}
+	util.MustFprintf(out, "%d objects are adopted into RootSync %s/%s with mode %q\n", len(toAdopt), syncNamespace, syncName, mode)
+	return nil
+}
+
+// updateInventory adds the objects to the inventory ResourceGroup of the
+// RootSync, creating it if it does not exist yet.
+func updateInventory(ctx context.Context, c client.Client, syncNamespace string, objs []*unstructured.Unstructured, hashes []string) error {
+	inv := resourcegroup.Unstructured(syncName, syncNamespace, applier.InventoryID(syncName, syncNamespace))
+	create := false
+	if err := c.Get(ctx, client.ObjectKeyFromObject(inv), inv); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the ResourceGroup inventory: %w", err)
+		}
+		create = true
+	}
+	existing := inv.DeepCopy()
+
+	ids := make([]core.ID, len(objs))
+	adopted, err := adopt.AdoptedObjects(inv)
+	if err != nil {
+		return err
+	}
+	for i, obj := range objs {
+		ids[i] = core.IDOf(obj)
+		if hashes[i] != "" {
+			adopted[core.GKNN(obj)] = hashes[i]
+		} else {
+			delete(adopted, core.GKNN(obj))
+		}
+	}
+	if _, err := adopt.AddToInventory(inv, ids); err != nil {
+		return err
+	}
+	if err := adopt.SetAdoptedObjects(inv, adopted); err != nil {
+		return err
+	}
+	if create {
+		if err := c.Create(ctx, inv); err != nil {
+			return fmt.Errorf("failed to create the ResourceGroup inventory: %w", err)
+		}
+		return nil
+	}
+	if err := c.Patch(ctx, inv, client.MergeFrom(existing), client.FieldOwner(configsync.FieldManager)); err != nil {
+		return fmt.Errorf("failed to update the ResourceGroup inventory: %w", err)
+	}
+	return nil
+}
+
+func printResult(out io.Writer, result adopt.Result) {
+	switch result.State {
+	case adopt.Conflict:
+		util.MustFprintf(out, "%s: %s, managed by %q\n", result.ID, result.State, result.Manager)
+	default:
+		util.MustFprintf(out, "%s: %s\n", result.ID, result.State)
+	}
+	for _, d := range result.Diffs {
+		util.MustFprintf(out, "\t%s\n", d)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bugreport
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"kpt.dev/configsync/pkg/bugreport"
+)
+
+// analyzeCmd prints the problems detected in a bug report zip file.
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze ZIP_FILE",
+	Short: "Prints the problems detected in a bug report zip file.",
+	Long: "Parses a zip file generated by bugreport, without connecting to the cluster, and prints the detected " +
+		"problems: crashlooping containers, KNV error codes, stale commits and conflicting managers.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		problems, err := bugreport.AnalyzeFile(args[0])
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		if len(problems) == 0 {
+			_, err = fmt.Fprintln(out, "No problems detected")
+			return err
+		}
+		for _, p := range problems {
+			if _, err := fmt.Fprintln(out, p.String()); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(out, "%d problems detected\n", len(problems))
+		return err
+	},
+}
@@ -16,6 +16,7 @@ package bugreport
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/kubernetes"
@@ -27,8 +28,30 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+var (
+	syncs          []string
+	namespaces     []string
+	since          time.Duration
+	until          string
+	redact         bool
+	redactPatterns []string
+)
+
 func init() {
 	Cmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout, "Timeout for connecting to the cluster")
+	Cmd.Flags().StringSliceVar(&syncs, "syncs", nil,
+		"Accepts a comma-separated list of RootSyncs and RepoSyncs, formatted as NAMESPACE/NAME, to collect. Defaults to all RootSyncs and RepoSyncs.")
+	Cmd.Flags().StringSliceVar(&namespaces, "namespaces", nil,
+		"Accepts a comma-separated list of namespaces of the RootSyncs and RepoSyncs to collect. Defaults to all namespaces.")
+	Cmd.Flags().DurationVar(&since, "since", 0,
+		"Only collect logs newer than a relative duration like 5s, 2m, or 3h. Defaults to all logs.")
+	Cmd.Flags().StringVar(&until, "until", "",
+		"Only collect logs older than an RFC3339 timestamp like 2026-01-02T15:04:05Z. Defaults to all logs.")
+	Cmd.Flags().BoolVar(&redact, "redact", true,
+		"If true, redacts Secret data, tokens, passwords and matches of --redact-pattern from the bug report.")
+	Cmd.Flags().StringArrayVar(&redactPatterns, "redact-pattern", nil,
+		"A regular expression to redact from the bug report. May be repeated.")
+	Cmd.AddCommand(analyzeCmd)
 }
 
 // Cmd retrieves readers for all relevant nomos container logs and cluster state commands and writes them to a zip file
@@ -45,6 +68,11 @@ var Cmd = &cobra.Command{
 			klog.Errorf("failed to increase logging STDERR threshold: %v", err)
 		}
 
+		opts, err := reportOptions()
+		if err != nil {
+			return err
+		}
+
 		cfg, err := restconfig.NewRestConfig(flags.ClientTimeout)
 		if err != nil {
 			return fmt.Errorf("failed to create rest config: %w", err)
@@ -58,7 +86,7 @@ var Cmd = &cobra.Command{
 			return fmt.Errorf("failed to create kubernetes client: %w", err)
 		}
 
-		report, err := bugreport.New(cmd.Context(), c, cs)
+		report, err := bugreport.New(cmd.Context(), c, cs, opts)
 		if err != nil {
 			return fmt.Errorf("failed to initialize bug reporter: %w", err)
 		}
@@ -77,3 +105,28 @@ var Cmd = &cobra.Command{
 		return nil
 	},
 }
+
+// reportOptions returns the bug report Options specified by the flags.
+func reportOptions() (bugreport.Options, error) {
+	opts := bugreport.Options{
+		Namespaces: namespaces,
+		Since:      since,
+	}
+	var err error
+	if opts.Syncs, err = bugreport.ParseSyncs(syncs); err != nil {
+		return opts, err
+	}
+	if until != "" {
+		if opts.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return opts, fmt.Errorf("invalid --until timestamp: %w", err)
+		}
+	}
+	if redact {
+		if opts.Redactor, err = bugreport.NewRedactor(redactPatterns); err != nil {
+			return opts, err
+		}
+	} else if len(redactPatterns) > 0 {
+		return opts, fmt.Errorf("--redact-pattern cannot be used with --redact=false")
+	}
+	return opts, nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"kpt.dev/configsync/cmd/nomos/flags"
+	"kpt.dev/configsync/cmd/nomos/initialize"
+	"kpt.dev/configsync/cmd/nomos/util"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/hydrate"
+	"kpt.dev/configsync/pkg/kinds"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	namespaces []string
+	groupKinds []string
+	outPath    string
+)
+
+// excludedGroupKinds are the kinds which are not exported unless selected with
+// --kinds, as they are created by the cluster or controllers.
+var excludedGroupKinds = map[schema.GroupKind]bool{
+	{Kind: "Event"}:                                                   true,
+	{Group: "events.k8s.io", Kind: "Event"}:                           true,
+	{Kind: "Endpoints"}:                                               true,
+	{Group: "discovery.k8s.io", Kind: "EndpointSlice"}:                true,
+	{Group: "coordination.k8s.io", Kind: "Lease"}:                     true,
+	{Group: "apps", Kind: "ControllerRevision"}:                       true,
+	{Group: "metrics.k8s.io", Kind: "PodMetrics"}:                     true,
+	kinds.ResourceGroup().GroupKind():                                 true,
+	{Group: "authorization.k8s.io", Kind: "LocalSubjectAccessReview"}: true,
+}
+
+// systemNamespacePrefixes are the prefixes of the namespaces which are not
+// exported unless selected with --namespaces, in addition to the Config Sync
+// controller namespaces.
+var systemNamespacePrefixes = []string{"kube-", "gke-", "gmp-"}
+
+func init() {
+	flags.AddSourceFormat(Cmd)
+	flags.AddOutputFormat(Cmd)
+	Cmd.Flags().DurationVar(&flags.ClientTimeout, "timeout", restconfig.DefaultTimeout, "Timeout for connecting to the cluster")
+	Cmd.Flags().StringSliceVar(&namespaces, "namespaces", nil,
+		`Accepts a comma-separated list of namespaces to export. Defaults to all namespaces, except system namespaces.`)
+	Cmd.Flags().StringSliceVar(&groupKinds, "kinds", nil,
+		`Accepts a comma-separated list of kinds to export, as KIND or KIND.GROUP, e.g. "Deployment.apps,ConfigMap". `+
+			`Defaults to all namespaced kinds, except kinds created by the cluster or controllers. Cluster-scoped kinds other than Namespace are only exported if listed.`)
+	Cmd.Flags().StringVar(&outPath, "output", "export",
+		`Directory to write the exported repository to. Must be empty or not exist.`)
+}
+
+// Cmd is the Cobra object representing the export command.
+var Cmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports the live state of a cluster as a Config Sync repository.",
+	Long: `Exports the live state of a cluster as a Config Sync repository.
+
+Reads the selected objects from the cluster of the current context, removes the fields populated by
+the API server, controllers and Config Sync, and writes them with the same file layout as
+"nomos hydrate". Objects created by controllers, like ReplicaSets owned by Deployments, are skipped.
+
+With --source-format=hierarchy, writes a hierarchical repository, with cluster-scoped objects in
+cluster/ and namespaced objects in namespaces/.`,
+	Example: `  nomos export --namespaces=bookstore --source-format=unstructured
+  nomos export --kinds=Deployment.apps,Service,ClusterRole.rbac.authorization.k8s.io --output=my-repo`,
+	Args: cobra.ExactArgs(0),
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		sourceFormat := configsync.SourceFormat(flags.SourceFormat)
+		if sourceFormat == "" {
+			sourceFormat = configsync.SourceFormatHierarchy
+		}
+		if sourceFormat != configsync.SourceFormatHierarchy && sourceFormat != configsync.SourceFormatUnstructured {
+			return fmt.Errorf("invalid --source-format value %q: must be %q or %q",
+				sourceFormat, configsync.SourceFormatHierarchy, configsync.SourceFormatUnstructured)
+		}
+		selected, err := parseGroupKinds(groupKinds)
+		if err != nil {
+			return err
+		}
+		// Don't show usage on error, as argument validation passed.
+		cmd.SilenceUsage = true
+
+		cfg, err := restconfig.NewRestConfig(flags.ClientTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to create rest config: %w", err)
+		}
+		dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create discovery client: %w", err)
+		}
+		c, err := client.New(cfg, client.Options{})
+		if err != nil {
+			return fmt.Errorf("failed to create kubernetes client: %w", err)
+		}
+
+		resources, err := dc.ServerPreferredResources()
+		if err != nil {
+			if !discovery.IsGroupDiscoveryFailedError(err) {
+				return fmt.Errorf("failed to discover the cluster resources: %w", err)
+			}
+			util.MustFprintf(os.Stderr, "Skipping resources that failed discovery: %v\n", err)
+		}
+		objs, err := exportObjects(cmd.Context(), c, exportedResources(resources, selected))
+		if err != nil {
+			return err
+		}
+
+		if sourceFormat == configsync.SourceFormatHierarchy {
+			if err := initialize.Initialize(outPath, false); err != nil {
+				return err
+			}
+		} else if err := checkEmptyOutput(outPath); err != nil {
+			return err
+		}
+		fileObjects := hydrate.ExportFileObjects(sourceFormat, flags.OutputFormat, objs)
+		if err := hydrate.PrintDirectoryOutput(outPath, flags.OutputFormat, fileObjects); err != nil {
+			return err
+		}
+		util.MustFprintf(os.Stdout, "Exported %d objects to %q\n", len(fileObjects), outPath)
+		return nil
+	},
+}
+
+// parseGroupKinds parses the values of --kinds.
+func parseGroupKinds(values []string) (map[schema.GroupKind]bool, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	selected := make(map[schema.GroupKind]bool, len(values))
+	for _, v := range values {
+		gk := schema.ParseGroupKind(v)
+		if gk.Kind == "" {
+			return nil, fmt.Errorf("invalid --kinds value %q: must be KIND or KIND.GROUP", v)
+		}
+		selected[gk] = true
+	}
+	return selected, nil
+}
+
+// exportedResources returns the resources to export, keyed by GroupVersionKind
+// with whether the resource is namespaced.
+func exportedResources(lists []*metav1.APIResourceList, selected map[schema.GroupKind]bool) map[schema.GroupVersionKind]bool {
+	result := make(map[schema.GroupVersionKind]bool)
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			// Skip subresources and resources which cannot be listed.
+			if strings.Contains(r.Name, "/") || !sets.New(r.Verbs...).Has("list") {
+				continue
+			}
+			gvk := gv.WithKind(r.Kind)
+			switch {
+			case gvk.GroupKind() == kinds.Namespace().GroupKind():
+				// Namespaces are always exported, so that the namespaced objects
+				// form a valid hierarchical repository.
+			case selected != nil:
+				if !selected[gvk.GroupKind()] {
+					continue
+				}
+			case excludedGroupKinds[gvk.GroupKind()]:
+				continue
+			case !r.Namespaced:
+				continue
+			}
+			result[gvk] = r.Namespaced
+		}
+	}
+	return result
+}
+
+// exportObjects lists the exportable objects of the resources in the selected
+// namespaces.
+func exportObjects(ctx context.Context, c client.Client, resources map[schema.GroupVersionKind]bool) ([]*unstructured.Unstructured, error) {
+	gvks := make([]schema.GroupVersionKind, 0, len(resources))
+	for gvk := range resources {
+		gvks = append(gvks, gvk)
+	}
+	sort.Slice(gvks, func(i, j int) bool {
+		return gvks[i].String() < gvks[j].String()
+	})
+
+	var objs []*unstructured.Unstructured
+	for _, gvk := range gvks {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) || apierrors.IsMethodNotSupported(err) {
+				util.MustFprintf(os.Stderr, "Skipping %s: %v\n", gvk.GroupKind(), err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.GroupKind(), err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			namespace := obj.GetNamespace()
+			if gvk.GroupKind() == kinds.Namespace().GroupKind() {
+				namespace = obj.GetName()
+			}
+			if (resources[gvk] || gvk.GroupKind() == kinds.Namespace().GroupKind()) && !selectsNamespace(namespace) {
+				continue
+			}
+			if !hydrate.IsExportable(obj) {
+				continue
+			}
+			obj.SetGroupVersionKind(gvk)
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+// selectsNamespace returns true if objects in the namespace are exported.
+func selectsNamespace(namespace string) bool {
+	if len(namespaces) > 0 {
+		for _, ns := range namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+		return false
+	}
+	if configmanagement.IsControllerNamespace(namespace) {
+		return false
+	}
+	for _, prefix := range systemNamespacePrefixes {
+		if strings.HasPrefix(namespace, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkEmptyOutput returns an error if the output directory is not empty.
+func checkEmptyOutput(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("output directory %q is not empty", dir)
+	}
+	return nil
+}
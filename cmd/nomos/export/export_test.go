@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var testResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list"}},
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Name: "events", Kind: "Event", Namespaced: true, Verbs: []string{"get", "list"}},
+			{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+			{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+		},
+	},
+	{
+		GroupVersion: "rbac.authorization.k8s.io/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "clusterroles", Kind: "ClusterRole", Verbs: []string{"get", "list"}},
+			{Name: "roles", Kind: "Role", Namespaced: true, Verbs: []string{"get", "list"}},
+		},
+	},
+}
+
+func TestExportedResources(t *testing.T) {
+	testCases := map[string]struct {
+		kinds []string
+		want  map[schema.GroupVersionKind]bool
+	}{
+		"default kinds": {
+			want: map[schema.GroupVersionKind]bool{
+				{Version: "v1", Kind: "Namespace"}:                                false,
+				{Version: "v1", Kind: "ConfigMap"}:                                true,
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"}: true,
+			},
+		},
+		"selected kinds": {
+			kinds: []string{"ClusterRole.rbac.authorization.k8s.io", "Event"},
+			want: map[schema.GroupVersionKind]bool{
+				{Version: "v1", Kind: "Namespace"}:                                       false,
+				{Version: "v1", Kind: "Event"}:                                           true,
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}: false,
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			selected, err := parseGroupKinds(tc.kinds)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, exportedResources(testResources, selected))
+		})
+	}
+}
+
+func TestSelectsNamespace(t *testing.T) {
+	defer func() { namespaces = nil }()
+
+	assert.True(t, selectsNamespace("default"))
+	assert.True(t, selectsNamespace("shop"))
+	assert.False(t, selectsNamespace("kube-system"))
+	assert.False(t, selectsNamespace("config-management-system"))
+
+	namespaces = []string{"kube-system"}
+	assert.True(t, selectsNamespace("kube-system"))
+	assert.False(t, selectsNamespace("shop"))
+}
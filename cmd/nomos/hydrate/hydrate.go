@@ -33,8 +33,9 @@ import (
 )
 
 var (
-	flat    bool
-	outPath string
+	flat             bool
+	outPath          string
+	substitutionFile string
 )
 
 func init() {
@@ -57,6 +58,10 @@ resources, contains a subdirectory for each Cluster.
 If --flat is enabled, writes to the, writes a single file holding all
 resource manifests. You may run "kubectl apply -f" on the result to
 apply the configuration to a cluster.`)
+	Cmd.Flags().StringVar(&substitutionFile, "substitution-file", "",
+		`Path to a file holding the ConfigMap referenced by spec.override.substitutionConfigMapRef.
+If set, substitutes its data for the ${VAR} placeholders in the declared objects, and the
+name of each Cluster for ${CLUSTER_NAME}, as the reconciler does.`)
 }
 
 // Cmd is the Cobra object representing the hydrate command.
@@ -105,6 +110,11 @@ which you could kubectl apply -fR to the cluster, or have Config Sync sync to th
 			return err
 		}
 		validateOpts.FieldManager = util.FieldManager
+		if substitutionFile != "" {
+			if validateOpts.Variables, err = hydrate.ReadSubstitutionFile(substitutionFile); err != nil {
+				return err
+			}
+		}
 
 		if sourceFormat == configsync.SourceFormatHierarchy {
 			files = filesystem.FilterHierarchyFiles(rootDir, files)
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// argoCDResourcesFinalizer makes Argo CD delete the deployed objects when
+	// the Application is deleted.
+	argoCDResourcesFinalizer = "resources-finalizer.argocd.argoproj.io"
+	// argoCDInClusterServer is the destination server of the local cluster.
+	argoCDInClusterServer = "https://kubernetes.default.svc"
+	// argoCDInClusterName is the destination name of the local cluster.
+	argoCDInClusterName = "in-cluster"
+)
+
+// argoCDApplicationGVK is the GroupVersionKind of Argo CD Applications.
+var argoCDApplicationGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Application"}
+
+// argoCDMigrations returns the migrations of the Argo CD Applications on the
+// cluster.
+func argoCDMigrations(ctx context.Context, c client.Client) ([]*gitOpsMigration, error) {
+	apps, err := listOrigins(ctx, c, argoCDApplicationGVK)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []*gitOpsMigration
+	for i := range apps {
+		m, err := argoCDMigration(&apps[i])
+		if err != nil {
+			printError(fmt.Errorf("skipping Application %s/%s: %w", apps[i].GetNamespace(), apps[i].GetName(), err))
+			continue
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+// argoCDMigration converts an Argo CD Application into a migration.
+func argoCDMigration(app *unstructured.Unstructured) (*gitOpsMigration, error) {
+	m := &gitOpsMigration{origin: app}
+
+	server, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "server")
+	destName, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "name")
+	if (server != "" && server != argoCDInClusterServer) || (destName != "" && destName != argoCDInClusterName) {
+		return nil, fmt.Errorf("the Application deploys to a remote cluster")
+	}
+	if _, found, _ := unstructured.NestedSlice(app.Object, "spec", "sources"); found {
+		return nil, fmt.Errorf("Applications with multiple sources are not supported")
+	}
+	source, found, err := unstructured.NestedMap(app.Object, "spec", "source")
+	if err != nil || !found {
+		return nil, fmt.Errorf("the Application has no source")
+	}
+	destNamespace, _, _ := unstructured.NestedString(app.Object, "spec", "destination", "namespace")
+
+	repoURL, _, _ := unstructured.NestedString(source, "repoURL")
+	revision, _, _ := unstructured.NestedString(source, "targetRevision")
+	chart, _, _ := unstructured.NestedString(source, "chart")
+	if chart != "" {
+		helm := &v1beta1.HelmBase{
+			Repo:    repoURL,
+			Chart:   chart,
+			Version: revision,
+			Auth:    configsync.AuthNone,
+		}
+		helm.ReleaseName, _, _ = unstructured.NestedString(source, "helm", "releaseName")
+		if helm.ReleaseName == "" {
+			helm.ReleaseName = app.GetName()
+		}
+		values, err := argoCDHelmValues(source)
+		if err != nil {
+			return nil, err
+		}
+		helm.Values = values
+		m.sourceType = configsync.HelmSource
+		m.helm = helm
+		m.helmNamespace = destNamespace
+	} else {
+		path, _, _ := unstructured.NestedString(source, "path")
+		if revision == "" {
+			revision = "HEAD"
+		}
+		m.sourceType = configsync.GitSource
+		m.git = &v1beta1.Git{
+			Repo:     repoURL,
+			Revision: revision,
+			Dir:      strings.TrimPrefix(path, "./"),
+			Auth:     configsync.AuthNone,
+		}
+		for _, unsupported := range []string{"kustomize", "directory", "plugin"} {
+			if _, found := source[unsupported]; found {
+				m.notices = append(m.notices, fmt.Sprintf("spec.source.%s is not migrated", unsupported))
+			}
+		}
+	}
+	m.notices = append(m.notices, "repository credentials are not migrated; set the auth and secretRef of the source if the repository is private")
+	if destNamespace != "" {
+		m.notices = append(m.notices, fmt.Sprintf("the objects must declare their namespace, as the destination namespace %q is not migrated", destNamespace))
+	}
+
+	_, automated, _ := unstructured.NestedMap(app.Object, "spec", "syncPolicy", "automated")
+	if !automated {
+		m.notices = append(m.notices, "the Application is synced manually, but Config Sync syncs automatically")
+	}
+	m.prune, _, _ = unstructured.NestedBool(app.Object, "spec", "syncPolicy", "automated", "prune")
+
+	resources, _, _ := unstructured.NestedSlice(app.Object, "status", "resources")
+	for _, r := range resources {
+		res, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(res, "group")
+		kind, _, _ := unstructured.NestedString(res, "kind")
+		namespace, _, _ := unstructured.NestedString(res, "namespace")
+		name, _, _ := unstructured.NestedString(res, "name")
+		m.objects = append(m.objects, object.ObjMetadata{
+			GroupKind: schema.GroupKind{Group: group, Kind: kind},
+			Namespace: namespace,
+			Name:      name,
+		})
+	}
+	return m, nil
+}
+
+// argoCDHelmValues returns the inline Helm values of an Application source.
+func argoCDHelmValues(source map[string]interface{}) (*apiextensionsv1.JSON, error) {
+	if valuesObject, found, _ := unstructured.NestedMap(source, "helm", "valuesObject"); found {
+		raw, err := json.Marshal(valuesObject)
+		if err != nil {
+			return nil, err
+		}
+		return &apiextensionsv1.JSON{Raw: raw}, nil
+	}
+	if values, found, _ := unstructured.NestedString(source, "helm", "values"); found && values != "" {
+		raw, err := yaml.YAMLToJSON([]byte(values))
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec.source.helm.values: %w", err)
+		}
+		return &apiextensionsv1.JSON{Raw: raw}, nil
+	}
+	return nil, nil
+}
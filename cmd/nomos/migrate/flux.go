@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fluxSystem is the name and namespace of the Kustomization which manages the
+// Flux installation.
+const fluxSystem = "flux-system"
+
+var (
+	// fluxKustomizationGVK is the GroupVersionKind of Flux Kustomizations.
+	fluxKustomizationGVK = schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+	// fluxGitRepositoryGVK is the GroupVersionKind of Flux GitRepositories.
+	fluxGitRepositoryGVK = schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "GitRepository"}
+	// fluxOCIRepositoryGVK is the GroupVersionKind of Flux OCIRepositories.
+	fluxOCIRepositoryGVK = schema.GroupVersionKind{Group: "source.toolkit.fluxcd.io", Version: "v1beta2", Kind: "OCIRepository"}
+)
+
+// fluxSources indexes Flux source objects by kind and NamespacedName.
+type fluxSources map[string]map[types.NamespacedName]*unstructured.Unstructured
+
+// fluxMigrations returns the migrations of the Flux Kustomizations on the
+// cluster.
+func fluxMigrations(ctx context.Context, c client.Client) ([]*gitOpsMigration, error) {
+	kustomizations, err := listOrigins(ctx, c, fluxKustomizationGVK)
+	if err != nil {
+		return nil, err
+	}
+	sources := fluxSources{}
+	for _, gvk := range []schema.GroupVersionKind{fluxGitRepositoryGVK, fluxOCIRepositoryGVK} {
+		objs, err := listOrigins(ctx, c, gvk)
+		if err != nil {
+			return nil, err
+		}
+		sources[gvk.Kind] = make(map[types.NamespacedName]*unstructured.Unstructured, len(objs))
+		for i := range objs {
+			sources[gvk.Kind][client.ObjectKeyFromObject(&objs[i])] = &objs[i]
+		}
+	}
+
+	var migrations []*gitOpsMigration
+	for i := range kustomizations {
+		if kustomizations[i].GetNamespace() == fluxSystem && kustomizations[i].GetName() == fluxSystem {
+			printNotice("Skipping Kustomization %s/%s, which manages the Flux installation", fluxSystem, fluxSystem)
+			continue
+		}
+		m, err := fluxMigration(&kustomizations[i], sources)
+		if err != nil {
+			printError(fmt.Errorf("skipping Kustomization %s/%s: %w", kustomizations[i].GetNamespace(), kustomizations[i].GetName(), err))
+			continue
+		}
+		migrations = append(migrations, m)
+	}
+	return migrations, nil
+}
+
+// fluxMigration converts a Flux Kustomization, and its source, into a
+// migration.
+func fluxMigration(ks *unstructured.Unstructured, sources fluxSources) (*gitOpsMigration, error) {
+	m := &gitOpsMigration{origin: ks}
+
+	sourceKind, _, _ := unstructured.NestedString(ks.Object, "spec", "sourceRef", "kind")
+	sourceName, _, _ := unstructured.NestedString(ks.Object, "spec", "sourceRef", "name")
+	sourceNamespace, _, _ := unstructured.NestedString(ks.Object, "spec", "sourceRef", "namespace")
+	if sourceNamespace == "" {
+		sourceNamespace = ks.GetNamespace()
+	}
+	source, found := sources[sourceKind][types.NamespacedName{Namespace: sourceNamespace, Name: sourceName}]
+	if !found {
+		return nil, fmt.Errorf("the %s source %s/%s is not found or not supported", sourceKind, sourceNamespace, sourceName)
+	}
+
+	path, _, _ := unstructured.NestedString(ks.Object, "spec", "path")
+	dir := strings.TrimPrefix(path, "./")
+	period, err := fluxInterval(source)
+	if err != nil {
+		return nil, err
+	}
+	url, _, _ := unstructured.NestedString(source.Object, "spec", "url")
+	secretName, _, _ := unstructured.NestedString(source.Object, "spec", "secretRef", "name")
+
+	switch sourceKind {
+	case fluxGitRepositoryGVK.Kind:
+		git := &v1beta1.Git{
+			Repo:   url,
+			Dir:    dir,
+			Period: period,
+			Auth:   configsync.AuthNone,
+		}
+		ref, _, _ := unstructured.NestedStringMap(source.Object, "spec", "ref")
+		switch {
+		case ref["semver"] != "":
+			return nil, fmt.Errorf("semver references are not supported")
+		case ref["commit"] != "":
+			git.Revision = ref["commit"]
+		case ref["tag"] != "":
+			git.Revision = ref["tag"]
+		case ref["name"] != "":
+			git.Revision = ref["name"]
+		case ref["branch"] != "":
+			git.Branch = ref["branch"]
+		}
+		if secretName != "" {
+			git.Auth = configsync.AuthToken
+			if strings.HasPrefix(url, "ssh://") || strings.HasPrefix(url, "git@") {
+				git.Auth = configsync.AuthSSH
+			}
+			git.SecretRef = &v1beta1.SecretReference{Name: secretName}
+			m.notices = append(m.notices, fmt.Sprintf("the Secret %q must exist in the namespace of the RootSync or RepoSync, with the keys expected for %q auth",
+				secretName, git.Auth))
+		}
+		m.sourceType = configsync.GitSource
+		m.git = git
+	case fluxOCIRepositoryGVK.Kind:
+		image := strings.TrimPrefix(url, "oci://")
+		ref, _, _ := unstructured.NestedStringMap(source.Object, "spec", "ref")
+		switch {
+		case ref["semver"] != "":
+			return nil, fmt.Errorf("semver references are not supported")
+		case ref["digest"] != "":
+			image += "@" + ref["digest"]
+		case ref["tag"] != "":
+			image += ":" + ref["tag"]
+		}
+		if secretName != "" {
+			m.notices = append(m.notices, "the OCI repository credentials are not migrated; set spec.oci.auth to use workload identity")
+		}
+		m.sourceType = configsync.OciSource
+		m.oci = &v1beta1.Oci{
+			Image:  image,
+			Dir:    dir,
+			Period: period,
+			Auth:   configsync.AuthNone,
+		}
+	}
+
+	if targetNamespace, _, _ := unstructured.NestedString(ks.Object, "spec", "targetNamespace"); targetNamespace != "" {
+		m.notices = append(m.notices, fmt.Sprintf("spec.targetNamespace %q is not migrated; the objects must declare their namespace", targetNamespace))
+	}
+	if _, found, _ := unstructured.NestedMap(ks.Object, "spec", "postBuild"); found {
+		m.notices = append(m.notices, "spec.postBuild variable substitution is not migrated")
+	}
+	if suspended, _, _ := unstructured.NestedBool(ks.Object, "spec", "suspend"); suspended {
+		m.notices = append(m.notices, "the Kustomization is suspended, but Config Sync syncs automatically")
+	}
+	m.prune, _, _ = unstructured.NestedBool(ks.Object, "spec", "prune")
+
+	entries, _, _ := unstructured.NestedSlice(ks.Object, "status", "inventory", "entries")
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _, _ := unstructured.NestedString(entry, "id")
+		obj, err := object.ParseObjMetadata(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inventory entry %q: %w", id, err)
+		}
+		m.objects = append(m.objects, obj)
+	}
+	return m, nil
+}
+
+// fluxInterval returns the polling period of a Flux source.
+func fluxInterval(source *unstructured.Unstructured) (metav1.Duration, error) {
+	interval, _, _ := unstructured.NestedString(source.Object, "spec", "interval")
+	if interval == "" {
+		return metav1.Duration{}, nil
+	}
+	d, err := time.ParseDuration(interval)
+	if err != nil {
+		return metav1.Duration{}, fmt.Errorf("invalid spec.interval %q of %s %s/%s: %w",
+			interval, source.GetKind(), source.GetNamespace(), source.GetName(), err)
+	}
+	return metav1.Duration{Duration: d}, nil
+}
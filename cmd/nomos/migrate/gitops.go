@@ -0,0 +1,375 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/cmd/nomos/status"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/resourcegroup"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// fromConfigManagement migrates from the ConfigManagement operator.
+	fromConfigManagement = "configmanagement"
+	// fromArgoCD migrates from Argo CD Applications.
+	fromArgoCD = "argocd"
+	// fromFlux migrates from Flux Kustomizations.
+	fromFlux = "flux"
+
+	gitOpsMigrateDir = "nomos-migrate-%s"
+)
+
+// gitOpsMigration is the RootSync or RepoSync which replaces an Argo CD
+// Application or a Flux Kustomization, and the deployed objects it adopts.
+type gitOpsMigration struct {
+	// origin is the Application or Kustomization being migrated.
+	origin *unstructured.Unstructured
+	// name is the name of the RSync, if it differs from the name of the
+	// origin.
+	name string
+	// sourceType is the type of the source of the RSync.
+	sourceType configsync.SourceType
+	git        *v1beta1.Git
+	oci        *v1beta1.Oci
+	helm       *v1beta1.HelmBase
+	// helmNamespace is the namespace of the namespace-scoped objects rendered
+	// from a Helm chart synced by a RootSync.
+	helmNamespace string
+	// prune is false if the origin never deletes the objects removed from
+	// the source.
+	prune bool
+	// objects are the objects deployed by the origin.
+	objects object.ObjMetadataSet
+	// notices describe the settings of the origin which are not migrated.
+	notices []string
+}
+
+// originID returns a human readable identifier of the migrated object.
+func (m *gitOpsMigration) originID() string {
+	return fmt.Sprintf("%s %s/%s", m.origin.GetKind(), m.origin.GetNamespace(), m.origin.GetName())
+}
+
+// syncName returns the name of the RSync.
+func (m *gitOpsMigration) syncName() string {
+	if m.name != "" {
+		return m.name
+	}
+	return m.origin.GetName()
+}
+
+// syncNamespace returns the namespace of the RSync. Objects which are all
+// deployed into a single namespace, other than the Config Sync namespace,
+// are synced by a RepoSync in that namespace. Otherwise, a RootSync is used.
+func (m *gitOpsMigration) syncNamespace() string {
+	ns := ""
+	for _, obj := range m.objects {
+		if obj.Namespace == "" || (ns != "" && obj.Namespace != ns) {
+			return configmanagement.ControllerNamespace
+		}
+		ns = obj.Namespace
+	}
+	if ns == "" {
+		return configmanagement.ControllerNamespace
+	}
+	return ns
+}
+
+// prunePolicy returns the prune policy which keeps the deployed objects from
+// being pruned, if the origin does not prune.
+func (m *gitOpsMigration) prunePolicy() *v1beta1.PrunePolicy {
+	if m.prune || len(m.objects) == 0 {
+		return nil
+	}
+	gks := make(map[schema.GroupKind]struct{})
+	for _, obj := range m.objects {
+		gks[obj.GroupKind] = struct{}{}
+	}
+	policy := &v1beta1.PrunePolicy{}
+	for gk := range gks {
+		policy.ProtectedKinds = append(policy.ProtectedKinds, v1beta1.PruneProtectedKind{Group: gk.Group, Kind: gk.Kind})
+	}
+	sort.Slice(policy.ProtectedKinds, func(i, j int) bool {
+		return schema.GroupKind(policy.ProtectedKinds[i]).String() < schema.GroupKind(policy.ProtectedKinds[j]).String()
+	})
+	return policy
+}
+
+// rsync returns the RootSync or RepoSync which replaces the origin.
+func (m *gitOpsMigration) rsync() client.Object {
+	name := m.syncName()
+	namespace := m.syncNamespace()
+	if namespace == configmanagement.ControllerNamespace {
+		rs := &v1beta1.RootSync{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       configsync.RootSyncKind,
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1beta1.RootSyncSpec{
+				SourceFormat: configsync.SourceFormatUnstructured,
+				SourceType:   m.sourceType,
+				Git:          m.git,
+				Oci:          m.oci,
+			},
+		}
+		if m.helm != nil {
+			rs.Spec.Helm = &v1beta1.HelmRootSync{HelmBase: *m.helm, Namespace: m.helmNamespace}
+		}
+		if policy := m.prunePolicy(); policy != nil {
+			rs.Spec.Override = &v1beta1.RootSyncOverrideSpec{OverrideSpec: v1beta1.OverrideSpec{PrunePolicy: policy}}
+		}
+		return rs
+	}
+	rs := &v1beta1.RepoSync{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       configsync.RepoSyncKind,
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1beta1.RepoSyncSpec{
+			SourceFormat: configsync.SourceFormatUnstructured,
+			SourceType:   m.sourceType,
+			Git:          m.git,
+			Oci:          m.oci,
+		},
+	}
+	if m.helm != nil {
+		rs.Spec.Helm = &v1beta1.HelmRepoSync{HelmBase: *m.helm}
+	}
+	if policy := m.prunePolicy(); policy != nil {
+		rs.Spec.Override = &v1beta1.RepoSyncOverrideSpec{OverrideSpec: v1beta1.OverrideSpec{PrunePolicy: policy}}
+	}
+	return rs
+}
+
+// inventory returns the ResourceGroup inventory of the RSync, which adopts the
+// deployed objects. Objects in the inventory which are not declared in the
+// source are pruned by the reconciler.
+func (m *gitOpsMigration) inventory() *unstructured.Unstructured {
+	name := m.syncName()
+	namespace := m.syncNamespace()
+	rg := resourcegroup.Unstructured(name, namespace, applier.InventoryID(name, namespace))
+	resources := make([]interface{}, 0, len(m.objects))
+	for _, obj := range m.objects {
+		resources = append(resources, map[string]interface{}{
+			"group":     obj.GroupKind.Group,
+			"kind":      obj.GroupKind.Kind,
+			"namespace": obj.Namespace,
+			"name":      obj.Name,
+		})
+	}
+	_ = unstructured.SetNestedSlice(rg.Object, resources, "spec", "resources")
+	return rg
+}
+
+// migrationYAML returns the RSync and its inventory as a multi-document YAML.
+func (m *gitOpsMigration) migrationYAML() ([]byte, error) {
+	rsContent, err := yaml.Marshal(m.rsync())
+	if err != nil {
+		return nil, err
+	}
+	rgContent, err := yaml.Marshal(m.inventory())
+	if err != nil {
+		return nil, err
+	}
+	content := append(rgContent, []byte("---\n")...)
+	return append(content, rsContent...), nil
+}
+
+// assignSyncNames prefixes the namespace of their origin to the names of the
+// RSyncs which would otherwise collide with each other, e.g. when
+// Kustomizations with the same name in different namespaces are all migrated
+// to RootSyncs. It returns an error if the names still collide.
+func assignSyncNames(migrations []*gitOpsMigration) error {
+	byKey := make(map[types.NamespacedName][]*gitOpsMigration)
+	for _, m := range migrations {
+		key := types.NamespacedName{Namespace: m.syncNamespace(), Name: m.syncName()}
+		byKey[key] = append(byKey[key], m)
+	}
+	for _, ms := range byKey {
+		if len(ms) < 2 {
+			continue
+		}
+		for _, m := range ms {
+			m.name = fmt.Sprintf("%s-%s", m.origin.GetNamespace(), m.origin.GetName())
+		}
+	}
+
+	owners := make(map[types.NamespacedName]*gitOpsMigration)
+	for _, m := range migrations {
+		key := types.NamespacedName{Namespace: m.syncNamespace(), Name: m.syncName()}
+		if other, found := owners[key]; found {
+			return fmt.Errorf("%s and %s would both be migrated to %s; rename one of them before migrating",
+				other.originID(), m.originID(), key)
+		}
+		owners[key] = m
+	}
+	return nil
+}
+
+// listOrigins lists the objects of the specified kind in all namespaces. A
+// missing CRD means nothing to migrate.
+func listOrigins(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list %s objects: %w", gvk.Kind, err)
+	}
+	return list.Items, nil
+}
+
+// migrateGitOps converts the Argo CD Applications or Flux Kustomizations on
+// the cluster into RootSyncs and RepoSyncs.
+func migrateGitOps(ctx context.Context, cc *status.ClusterClient, kubeCtx, from string) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := cc.Client.Get(ctx, client.ObjectKey{Name: configsync.RootSyncCRDName}, crd); err != nil {
+		return fmt.Errorf("Config Sync must be installed before migrating from %s: %w", from, err)
+	}
+
+	var migrations []*gitOpsMigration
+	var err error
+	switch from {
+	case fromArgoCD:
+		migrations, err = argoCDMigrations(ctx, cc.Client)
+	case fromFlux:
+		migrations, err = fluxMigrations(ctx, cc.Client)
+	default:
+		return fmt.Errorf("unsupported migration source %q", from)
+	}
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		printNotice("No objects to migrate from %s were found", from)
+		return nil
+	}
+	if err := assignSyncNames(migrations); err != nil {
+		return err
+	}
+
+	fmt.Printf("Migrating from %s on cluster %q ...\n", from, kubeCtx)
+	var errs []string
+	for _, m := range migrations {
+		if err := migrateGitOpsObject(ctx, cc, kubeCtx, from, m); err != nil {
+			printError(fmt.Errorf("failed to migrate %s: %w", m.originID(), err))
+			errs = append(errs, m.originID())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to migrate %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func migrateGitOpsObject(ctx context.Context, cc *status.ClusterClient, kubeCtx, from string, m *gitOpsMigration) error {
+	rs := m.rsync()
+	printInfo("Migrating %s to %s %s/%s, adopting %d objects",
+		m.originID(), rs.GetObjectKind().GroupVersionKind().Kind, rs.GetNamespace(), rs.GetName(), len(m.objects))
+	for _, notice := range m.notices {
+		printNotice("%s: %s", m.originID(), notice)
+	}
+	if rs.GetNamespace() != configmanagement.ControllerNamespace {
+		printHint("The %s reconciler needs permission to manage the adopted objects. Grant it by binding a Role or ClusterRole to the ServiceAccount %s/%s",
+			configsync.RepoSyncKind, configmanagement.ControllerNamespace, core.NsReconcilerName(rs.GetNamespace(), rs.GetName()))
+	}
+
+	content, err := m.migrationYAML()
+	if err != nil {
+		return err
+	}
+	if dryRun {
+		fmt.Printf("---\n%s", content)
+		return nil
+	}
+
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf(gitOpsMigrateDir, from), kubeCtx)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	yamlFile := filepath.Join(dir, fmt.Sprintf("%s-%s-%s.yaml", m.origin.GetKind(), m.origin.GetNamespace(), m.origin.GetName()))
+	if err := os.WriteFile(yamlFile, content, 0644); err != nil {
+		return err
+	}
+	printInfo("The generated objects are saved in %q", yamlFile)
+
+	// Stop the origin from syncing or deleting the objects before Config Sync
+	// takes over.
+	if err := detachOrigin(ctx, cc.Client, from, m.origin); err != nil {
+		return fmt.Errorf("failed to detach %s: %w", m.originID(), err)
+	}
+	if err := cc.Client.Create(ctx, m.inventory()); err != nil {
+		return fmt.Errorf("failed to create the ResourceGroup inventory: %w", err)
+	}
+	if err := cc.Client.Create(ctx, rs); err != nil {
+		return fmt.Errorf("failed to create %s: %w", rs.GetObjectKind().GroupVersionKind().Kind, err)
+	}
+	printSuccess("%s is migrated. Once the %s is synced, %s can be deleted with `kubectl delete %s -n %s %s`",
+		m.originID(), rs.GetObjectKind().GroupVersionKind().Kind, m.originID(),
+		strings.ToLower(m.origin.GetKind()), m.origin.GetNamespace(), m.origin.GetName())
+	return nil
+}
+
+// detachOrigin disables automated syncing of the origin, and ensures that
+// deleting it does not delete the deployed objects.
+func detachOrigin(ctx context.Context, c client.Client, from string, origin *unstructured.Unstructured) error {
+	var patch []byte
+	switch from {
+	case fromArgoCD:
+		var finalizers []string
+		for _, f := range origin.GetFinalizers() {
+			if f != argoCDResourcesFinalizer {
+				finalizers = append(finalizers, f)
+			}
+		}
+		var err error
+		patch, err = json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"finalizers": finalizers},
+			"spec":     map[string]interface{}{"syncPolicy": map[string]interface{}{"automated": nil}},
+		})
+		if err != nil {
+			return err
+		}
+	case fromFlux:
+		patch = []byte(`{"spec":{"suspend":true,"prune":false}}`)
+	default:
+		return fmt.Errorf("unsupported migration source %q", from)
+	}
+	return c.Patch(ctx, origin, client.RawPatch(types.MergePatchType, patch))
+}
@@ -0,0 +1,396 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+func mustParseUnstructured(t *testing.T, content string) *unstructured.Unstructured {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), &u.Object))
+	return u
+}
+
+func TestArgoCDMigration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		app       string
+		wantRSync interface{}
+		wantObjs  object.ObjMetadataSet
+		wantErr   bool
+	}{
+		{
+			name: "git source with pruning and namespaced objects",
+			app: `
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: guestbook
+  namespace: argocd
+spec:
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: guestbook
+  source:
+    repoURL: https://github.com/argoproj/argocd-example-apps.git
+    targetRevision: main
+    path: ./guestbook
+  syncPolicy:
+    automated:
+      prune: true
+status:
+  resources:
+  - kind: Service
+    version: v1
+    namespace: guestbook
+    name: guestbook-ui
+  - group: apps
+    kind: Deployment
+    version: v1
+    namespace: guestbook
+    name: guestbook-ui
+`,
+			wantRSync: &v1beta1.RepoSync{
+				TypeMeta:   metav1.TypeMeta{Kind: configsync.RepoSyncKind, APIVersion: v1beta1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: "guestbook"},
+				Spec: v1beta1.RepoSyncSpec{
+					SourceFormat: configsync.SourceFormatUnstructured,
+					SourceType:   configsync.GitSource,
+					Git: &v1beta1.Git{
+						Repo:     "https://github.com/argoproj/argocd-example-apps.git",
+						Revision: "main",
+						Dir:      "guestbook",
+						Auth:     configsync.AuthNone,
+					},
+				},
+			},
+			wantObjs: object.ObjMetadataSet{
+				{GroupKind: schema.GroupKind{Kind: "Service"}, Namespace: "guestbook", Name: "guestbook-ui"},
+				{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "guestbook", Name: "guestbook-ui"},
+			},
+		},
+		{
+			name: "helm source without pruning and cluster-scoped objects",
+			app: `
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: cert-manager
+  namespace: argocd
+spec:
+  destination:
+    name: in-cluster
+    namespace: cert-manager
+  source:
+    repoURL: https://charts.jetstack.io
+    chart: cert-manager
+    targetRevision: v1.14.0
+    helm:
+      valuesObject:
+        installCRDs: true
+  syncPolicy:
+    automated: {}
+status:
+  resources:
+  - group: apiextensions.k8s.io
+    kind: CustomResourceDefinition
+    version: v1
+    name: certificates.cert-manager.io
+`,
+			wantRSync: &v1beta1.RootSync{
+				TypeMeta:   metav1.TypeMeta{Kind: configsync.RootSyncKind, APIVersion: v1beta1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Name: "cert-manager", Namespace: configsync.ControllerNamespace},
+				Spec: v1beta1.RootSyncSpec{
+					SourceFormat: configsync.SourceFormatUnstructured,
+					SourceType:   configsync.HelmSource,
+					Helm: &v1beta1.HelmRootSync{
+						HelmBase: v1beta1.HelmBase{
+							Repo:        "https://charts.jetstack.io",
+							Chart:       "cert-manager",
+							Version:     "v1.14.0",
+							ReleaseName: "cert-manager",
+							Values:      &apiextensionsv1.JSON{Raw: []byte(`{"installCRDs":true}`)},
+							Auth:        configsync.AuthNone,
+						},
+						Namespace: "cert-manager",
+					},
+					Override: &v1beta1.RootSyncOverrideSpec{OverrideSpec: v1beta1.OverrideSpec{
+						PrunePolicy: &v1beta1.PrunePolicy{
+							ProtectedKinds: []v1beta1.PruneProtectedKind{
+								{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+							},
+						},
+					}},
+				},
+			},
+			wantObjs: object.ObjMetadataSet{
+				{GroupKind: schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}, Name: "certificates.cert-manager.io"},
+			},
+		},
+		{
+			name: "remote cluster",
+			app: `
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: remote
+  namespace: argocd
+spec:
+  destination:
+    server: https://remote.example.com
+  source:
+    repoURL: https://github.com/example/repo.git
+`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := argoCDMigration(mustParseUnstructured(t, tc.app))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRSync, m.rsync())
+			assert.Equal(t, tc.wantObjs, m.objects)
+		})
+	}
+}
+
+func TestFluxMigration(t *testing.T) {
+	gitRepo := mustParseUnstructured(t, `
+apiVersion: source.toolkit.fluxcd.io/v1
+kind: GitRepository
+metadata:
+  name: podinfo
+  namespace: flux-system
+spec:
+  url: ssh://git@github.com/stefanprodan/podinfo
+  interval: 5m
+  ref:
+    branch: master
+  secretRef:
+    name: podinfo-ssh
+`)
+	ociRepo := mustParseUnstructured(t, `
+apiVersion: source.toolkit.fluxcd.io/v1beta2
+kind: OCIRepository
+metadata:
+  name: podinfo-oci
+  namespace: flux-system
+spec:
+  url: oci://ghcr.io/stefanprodan/manifests/podinfo
+  interval: 10m
+  ref:
+    tag: 6.5.0
+`)
+	sources := fluxSources{
+		fluxGitRepositoryGVK.Kind: {types.NamespacedName{Namespace: "flux-system", Name: "podinfo"}: gitRepo},
+		fluxOCIRepositoryGVK.Kind: {types.NamespacedName{Namespace: "flux-system", Name: "podinfo-oci"}: ociRepo},
+	}
+
+	testCases := []struct {
+		name      string
+		ks        string
+		wantRSync interface{}
+		wantErr   bool
+	}{
+		{
+			name: "git source with pruning",
+			ks: `
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: podinfo
+  namespace: flux-system
+spec:
+  sourceRef:
+    kind: GitRepository
+    name: podinfo
+  path: ./kustomize
+  prune: true
+status:
+  inventory:
+    entries:
+    - id: podinfo_podinfo_apps_Deployment
+      v: v1
+    - id: podinfo_podinfo__Service
+      v: v1
+`,
+			wantRSync: &v1beta1.RepoSync{
+				TypeMeta:   metav1.TypeMeta{Kind: configsync.RepoSyncKind, APIVersion: v1beta1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "podinfo"},
+				Spec: v1beta1.RepoSyncSpec{
+					SourceFormat: configsync.SourceFormatUnstructured,
+					SourceType:   configsync.GitSource,
+					Git: &v1beta1.Git{
+						Repo:      "ssh://git@github.com/stefanprodan/podinfo",
+						Branch:    "master",
+						Dir:       "kustomize",
+						Period:    metav1.Duration{Duration: 5 * time.Minute},
+						Auth:      configsync.AuthSSH,
+						SecretRef: &v1beta1.SecretReference{Name: "podinfo-ssh"},
+					},
+				},
+			},
+		},
+		{
+			name: "oci source without pruning",
+			ks: `
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: podinfo-oci
+  namespace: flux-system
+spec:
+  sourceRef:
+    kind: OCIRepository
+    name: podinfo-oci
+  path: ./
+  prune: false
+status:
+  inventory:
+    entries:
+    - id: _podinfo__Namespace
+      v: v1
+`,
+			wantRSync: &v1beta1.RootSync{
+				TypeMeta:   metav1.TypeMeta{Kind: configsync.RootSyncKind, APIVersion: v1beta1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Name: "podinfo-oci", Namespace: configsync.ControllerNamespace},
+				Spec: v1beta1.RootSyncSpec{
+					SourceFormat: configsync.SourceFormatUnstructured,
+					SourceType:   configsync.OciSource,
+					Oci: &v1beta1.Oci{
+						Image:  "ghcr.io/stefanprodan/manifests/podinfo:6.5.0",
+						Period: metav1.Duration{Duration: 10 * time.Minute},
+						Auth:   configsync.AuthNone,
+					},
+					Override: &v1beta1.RootSyncOverrideSpec{OverrideSpec: v1beta1.OverrideSpec{
+						PrunePolicy: &v1beta1.PrunePolicy{
+							ProtectedKinds: []v1beta1.PruneProtectedKind{{Kind: "Namespace"}},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "missing source",
+			ks: `
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: missing
+  namespace: flux-system
+spec:
+  sourceRef:
+    kind: Bucket
+    name: missing
+`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := fluxMigration(mustParseUnstructured(t, tc.ks), sources)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRSync, m.rsync())
+		})
+	}
+}
+
+func TestGitOpsMigrationInventory(t *testing.T) {
+	m := &gitOpsMigration{
+		origin: mustParseUnstructured(t, `
+apiVersion: kustomize.toolkit.fluxcd.io/v1
+kind: Kustomization
+metadata:
+  name: podinfo
+  namespace: flux-system
+`),
+		objects: object.ObjMetadataSet{
+			{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "podinfo", Name: "podinfo"},
+		},
+	}
+	want := mustParseUnstructured(t, `
+apiVersion: kpt.dev/v1alpha1
+kind: ResourceGroup
+metadata:
+  name: podinfo
+  namespace: podinfo
+  labels:
+    cli-utils.sigs.k8s.io/inventory-id: podinfo_podinfo
+spec:
+  resources:
+  - group: apps
+    kind: Deployment
+    namespace: podinfo
+    name: podinfo
+`)
+	assert.Equal(t, want, m.inventory())
+}
+
+func TestAssignSyncNames(t *testing.T) {
+	kustomization := func(namespace, name string) *gitOpsMigration {
+		origin := &unstructured.Unstructured{}
+		origin.SetKind("Kustomization")
+		origin.SetNamespace(namespace)
+		origin.SetName(name)
+		return &gitOpsMigration{origin: origin}
+	}
+
+	t.Run("unique names are kept", func(t *testing.T) {
+		migrations := []*gitOpsMigration{kustomization("team-a", "apps"), kustomization("team-b", "infra")}
+		require.NoError(t, assignSyncNames(migrations))
+		assert.Equal(t, "apps", migrations[0].rsync().GetName())
+		assert.Equal(t, "infra", migrations[1].rsync().GetName())
+	})
+
+	t.Run("colliding names are prefixed with the namespace", func(t *testing.T) {
+		migrations := []*gitOpsMigration{kustomization("team-a", "apps"), kustomization("team-b", "apps")}
+		require.NoError(t, assignSyncNames(migrations))
+		assert.Equal(t, "team-a-apps", migrations[0].rsync().GetName())
+		assert.Equal(t, "team-b-apps", migrations[1].rsync().GetName())
+		assert.Equal(t, "team-a-apps", migrations[0].inventory().GetName())
+	})
+
+	t.Run("prefixed names which still collide are an error", func(t *testing.T) {
+		migrations := []*gitOpsMigration{
+			kustomization("team-a", "apps"),
+			kustomization("team-b", "apps"),
+			kustomization("flux-system", "team-a-apps"),
+		}
+		assert.ErrorContains(t, assignSyncNames(migrations), "would both be migrated to config-management-system/team-a-apps")
+	})
+}
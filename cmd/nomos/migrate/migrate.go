@@ -67,6 +67,7 @@ const (
 var dryRun bool
 var waitTimeout time.Duration
 var removeConfigManagement bool
+var from string
 
 func init() {
 	Cmd.Flags().StringSliceVar(&flags.Contexts, "contexts", nil,
@@ -77,15 +78,29 @@ func init() {
 	Cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", defaultWaitTimeout, "Timeout for waiting for condition to be true")
 	Cmd.Flags().BoolVar(&removeConfigManagement, "remove-configmanagement", false,
 		`If enabled, removes the ConfigManagement operator and CRD. This establishes a standalone OSS Config Sync install.`)
+	Cmd.Flags().StringVar(&from, "from", fromConfigManagement,
+		fmt.Sprintf(`The installation to migrate from. Accepts %q, %q and %q. `+
+			`With %q or %q, Argo CD Applications or Flux Kustomizations are converted into RootSyncs and RepoSyncs, which adopt the deployed objects.`,
+			fromConfigManagement, fromArgoCD, fromFlux, fromArgoCD, fromFlux))
 }
 
 // Cmd performs the migration from mono-repo to multi-repo for all the provided contexts.
 var Cmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Migrates to the new Config Sync architecture by enabling the multi-repo mode.",
-	Long:  "Migrates to the new Config Sync architecture by enabling the multi-repo mode. It provides you with additional features and gives you the flexibility to sync to a single repository, or multiple repositories.",
-	Args:  cobra.ExactArgs(0),
+	Long: "Migrates to the new Config Sync architecture by enabling the multi-repo mode. It provides you with additional features and gives you the flexibility to sync to a single repository, or multiple repositories. " +
+		"Use --from to migrate from Argo CD or Flux instead.",
+	Args: cobra.ExactArgs(0),
 	RunE: func(cmd *cobra.Command, _ []string) error {
+		switch from {
+		case fromConfigManagement, fromArgoCD, fromFlux:
+		default:
+			return fmt.Errorf("invalid --from value %q: must be one of %q, %q or %q", from, fromConfigManagement, fromArgoCD, fromFlux)
+		}
+		if removeConfigManagement && from != fromConfigManagement {
+			return fmt.Errorf("--remove-configmanagement is only supported with --from=%s", fromConfigManagement)
+		}
+
 		// Don't show usage on error, as argument validation passed.
 		cmd.SilenceUsage = true
 
@@ -111,6 +126,15 @@ var Cmd = &cobra.Command{
 			migrationContexts = append(migrationContexts, context)
 			fmt.Println()
 			fmt.Println(util.Separator)
+			if from != fromConfigManagement {
+				if err := migrateGitOps(cmd.Context(), c, context, from); err != nil {
+					printError(err)
+					migrationError = true
+					continue
+				}
+				printSuccess(migrationSuccess)
+				continue
+			}
 			cs := &status.ClusterState{Ref: context}
 			if !c.IsInstalled(cmd.Context(), cs) {
 				printError(cs.Error)
@@ -23,7 +23,9 @@ import (
 	// kubectl auth provider plugins - needed for oidc plugin
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/cmd/nomos/adopt"
 	"kpt.dev/configsync/cmd/nomos/bugreport"
+	"kpt.dev/configsync/cmd/nomos/export"
 	"kpt.dev/configsync/cmd/nomos/hydrate"
 	"kpt.dev/configsync/cmd/nomos/initialize"
 	"kpt.dev/configsync/cmd/nomos/migrate"
@@ -62,6 +64,8 @@ func init() {
 	rootCmd.AddCommand(status.Cmd)
 	rootCmd.AddCommand(bugreport.Cmd)
 	rootCmd.AddCommand(migrate.Cmd)
+	rootCmd.AddCommand(adopt.Cmd)
+	rootCmd.AddCommand(export.Cmd)
 }
 
 func main() {
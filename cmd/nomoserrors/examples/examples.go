@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"kpt.dev/configsync/pkg/api/configmanagement"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
@@ -44,6 +45,8 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/client"
 	"kpt.dev/configsync/pkg/util/clusterconfig"
+	finalhydrate "kpt.dev/configsync/pkg/validate/final/hydrate"
+	"kpt.dev/configsync/pkg/validate/raw/hydrate"
 	"kpt.dev/configsync/pkg/validate/raw/validate"
 	rsyncvalidate "kpt.dev/configsync/pkg/validate/rsync/validate"
 	"kpt.dev/configsync/pkg/vet"
@@ -325,6 +328,22 @@ func Generate() AllExamples {
 	// 1070
 	result.add(system.MaxObjectCountError(system.DefaultMaxObjectCount, system.DefaultMaxObjectCount+1))
 
+	// 1071
+	result.add(hydrate.MissingVariablesError(k8sobjects.ConfigMapObject(core.Name("settings"), core.Namespace("foo")),
+		map[string]bool{"REGION": true}))
+
+	// 1072
+	result.add(selectors.NamespaceTemplateError(k8sobjects.RoleBindingObject(core.Name("team-admins")),
+		`Namespace "team-a" does not define ${namespace.labels[team]}`))
+
+	// 1073
+	result.add(finalhydrate.SyncWaveError(k8sobjects.DeploymentObject(core.Name("web"), core.Namespace("shipping")),
+		`the value "first" of the configsync.gke.io/sync-wave annotation must be an integer`))
+
+	// 1074
+	result.add(validate.InvalidHookError(k8sobjects.DeploymentObject(core.Name("migrate"), core.Namespace("shipping")),
+		"only Jobs can be hooks"))
+
 	// 2001
 	result.add(status.PathWrapError(errors.New("error creating directory"), "namespaces/foo"))
 
@@ -343,6 +362,9 @@ func Generate() AllExamples {
 	// 2006
 	result.add(status.EmptySourceError(10, "namespaces"))
 	result.add(declared.DeleteAllNamespacesError([]string{"shipping", "billing"}))
+	result.add(declared.PruneLimitExceededError("example-commit", 20,
+		[]core.ID{core.IDOf(k8sobjects.RoleObject(core.Name("foo"), core.Namespace("bar")))},
+		declared.PrunePolicy{MaxPrunePercent: 1}))
 
 	// 2007 is Deprecated.
 	result.markDeprecated("2007")
@@ -384,6 +406,13 @@ func Generate() AllExamples {
 	// 2017
 	result.add(selectors.ListNamespaceError(errors.New("k8s api List error")))
 
+	// 2018
+	result.add(applier.HookError(v1beta1.HookStatus{
+		Phase:   csmetadata.PreSyncHook.String(),
+		Commit:  "1a2b3c4d",
+		Message: "Job has reached the specified backoff limit",
+	}, k8sobjects.Unstructured(kinds.Job(), core.Name("migrate"), core.Namespace("shipping"))))
+
 	// 9998
 	result.add(status.InternalError("we made a mistake"))
 
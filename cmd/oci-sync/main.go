@@ -26,7 +26,6 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/auth"
 	"kpt.dev/configsync/pkg/oci"
@@ -61,8 +60,7 @@ func errorBackoff() wait.Backoff {
 }
 
 func main() {
-	utillog.Setup()
-	log := utillog.NewLogger(textlogger.NewLogger(textlogger.NewConfig()), *flRoot, *flErrorFile)
+	log := utillog.NewLogger(utillog.Setup(), *flRoot, *flErrorFile)
 
 	log.Info("pulling OCI image with arguments", "--image", *flImage,
 		"--auth", *flAuth, "--root", *flRoot, "--dest", *flDest, "--wait", *flWait,
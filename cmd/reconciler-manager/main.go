@@ -23,13 +23,13 @@ import (
 
 	traceapi "cloud.google.com/go/trace/apiv2"
 	"github.com/go-logr/logr"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/auth"
 	"kpt.dev/configsync/pkg/core"
@@ -43,6 +43,7 @@ import (
 	utilwatch "kpt.dev/configsync/pkg/util/watch"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -63,12 +64,15 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	var prometheusMetricsAddr string
+	flag.StringVar(&prometheusMetricsAddr, "prometheus-metrics-addr", "",
+		"The address to serve the metrics on /metrics in the Prometheus format, like :9464. "+
+			"Overrides --metrics-addr, and also serves the Config Sync metrics. The Config Sync metrics are not served if empty.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 
-	log.Setup()
-	logger := textlogger.NewLogger(textlogger.NewConfig())
+	logger := log.Setup()
 	setupLog := logger.WithName("setup")
 
 	profiler.Service()
@@ -77,6 +81,10 @@ func main() {
 	setupLog.Info(fmt.Sprintf("running with flags --cluster-name=%s; --reconciler-polling-period=%s; --hydration-polling-period=%s",
 		*clusterName, *reconcilerPollingPeriod, *hydrationPollingPeriod))
 
+	if prometheusMetricsAddr != "" {
+		metricsAddr = prometheusMetricsAddr
+	}
+
 	cfg := ctrl.GetConfigOrDie()
 
 	mapper, err := utilwatch.ReplaceOnResetRESTMapperFromConfig(cfg)
@@ -91,6 +99,7 @@ func main() {
 		MapperProvider: func(_ *rest.Config, _ *http.Client) (meta.RESTMapper, error) {
 			return mapper, nil
 		},
+		Metrics: metricsserver.Options{BindAddress: metricsAddr},
 	})
 	if err != nil {
 		setupLog.Error(err, "failed to start manager")
@@ -182,21 +191,26 @@ func main() {
 	}
 	setupLog.Info("OtelSA controller registration successful")
 
-	// Register the OpenCensus views
-	if err := metrics.RegisterReconcilerManagerMetricsViews(); err != nil {
-		setupLog.Error(err, "failed to register OpenCensus views")
+	// Register the OTLP metrics exporter, and the Prometheus reader if the
+	// Config Sync metrics are served on /metrics.
+	var metricReaders []sdkmetric.Reader
+	if prometheusMetricsAddr != "" {
+		reader, err := metrics.NewPrometheusReader()
+		if err != nil {
+			setupLog.Error(err, "failed to create the Prometheus metrics reader")
+			os.Exit(1)
+		}
+		metricReaders = append(metricReaders, reader)
 	}
-
-	// Register the OC Agent exporter
-	oce, err := metrics.RegisterOCAgentExporter(reconcilermanager.ManagerName)
+	mp, err := metrics.RegisterOTelExporter(context.Background(), reconcilermanager.ManagerName, metricReaders...)
 	if err != nil {
-		setupLog.Error(err, "failed to register the OC Agent exporter")
+		setupLog.Error(err, "failed to register the OTLP metrics exporter")
 		os.Exit(1)
 	}
 
 	defer func() {
-		if err := oce.Stop(); err != nil {
-			setupLog.Error(err, "failed to stop the OC Agent exporter")
+		if err := mp.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "failed to stop the OTLP metrics exporter")
 		}
 	}()
 
@@ -205,9 +219,9 @@ func main() {
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
-		// os.Exit(1) does not run deferred functions so explicitly stopping the OC Agent exporter.
-		if err := oce.Stop(); err != nil {
-			setupLog.Error(err, "failed to stop the OC Agent exporter")
+		// os.Exit(1) does not run deferred functions so explicitly stopping the OTLP metrics exporter.
+		if err := mp.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "failed to stop the OTLP metrics exporter")
 		}
 		os.Exit(1)
 	}
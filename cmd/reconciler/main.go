@@ -15,24 +15,30 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
 
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/commitstatus"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
-	ocmetrics "kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/profiler"
 	"kpt.dev/configsync/pkg/reconciler"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util"
 	"kpt.dev/configsync/pkg/util/log"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -103,6 +109,46 @@ var (
 
 	dynamicNSSelectorEnabled = flag.Bool("dynamic-ns-selector-enabled", util.EnvBool(reconcilermanager.DynamicNSSelectorEnabled, false), "")
 
+	pruneMaxCount = flag.Int("prune-max-count", util.EnvInt(reconcilermanager.PruneMaxCount, 0),
+		"The maximum number of managed objects to prune in a single sync. Zero means no limit.")
+	pruneMaxPercent = flag.Int("prune-max-percent", util.EnvInt(reconcilermanager.PruneMaxPercent, 0),
+		"The maximum percentage of managed objects to prune in a single sync. Zero means no limit.")
+	pruneProtectedKinds = flag.String("prune-protected-kinds", util.EnvString(reconcilermanager.PruneProtectedKinds, ""),
+		"Comma-separated list of GroupKinds (Kind.group) to never prune.")
+	pruneConfirmedCommit = flag.String("prune-confirmed-commit", util.EnvString(reconcilermanager.PruneConfirmedCommit, ""),
+		"The source commit which is allowed to exceed the prune limits.")
+
+	objectMetricsKinds = flag.String("object-metrics-kinds", util.EnvString(reconcilermanager.ObjectMetricsKinds, ""),
+		"Comma-separated list of GroupKinds (Kind.group) to record per-object apply, prune and wait metrics for.")
+	objectMetricsNamespaces = flag.Bool("object-metrics-namespaces", util.EnvBool(reconcilermanager.ObjectMetricsNamespaces, false),
+		"Whether to record the namespace of the objects in the per-object metrics.")
+
+	substitutionVariables = flag.String("substitution-variables", util.EnvString(reconcilermanager.SubstitutionVariables, ""),
+		"JSON object of the variables to substitute for the ${VAR} placeholders in the declared objects. Substitution is disabled if empty.")
+
+	notifications = flag.String("notifications", util.EnvString(reconcilermanager.Notifications, ""),
+		"JSON list of the HTTP endpoints to notify of sync results. Notifications are disabled if empty.")
+
+	auditLog = flag.String("audit-log", util.EnvString(reconcilermanager.AuditLog, ""),
+		"JSON object of the audit log file and sink of the writes to the managed objects. The writes are not audited if empty.")
+
+	prometheusMetricsAddr = flag.String("prometheus-metrics-addr", util.EnvString(reconcilermanager.PrometheusMetricsAddr, ""),
+		"The address to serve the metrics on /metrics in the Prometheus format, like :9464. The Config Sync metrics are not served if empty.")
+
+	commitStatusProvider = flag.String("commit-status-provider", util.EnvString(reconcilermanager.CommitStatusProvider, ""),
+		fmt.Sprintf("The API of the Git provider to post commit statuses to. Must be %s, %s, %s or empty to disable commit statuses.",
+			configsync.GitProviderGitHub, configsync.GitProviderGitLab, configsync.GitProviderGitea))
+	commitStatusAPIURL = flag.String("commit-status-api-url", util.EnvString(reconcilermanager.CommitStatusAPIURL, ""),
+		"The base URL of the API of the Git provider. Derived from the repo URL if empty.")
+	commitStatusTargetURL = flag.String("commit-status-target-url", util.EnvString(reconcilermanager.CommitStatusTargetURL, ""),
+		"The link of the commit statuses.")
+
+	clusterLabelsSourceKind = flag.String("cluster-labels-source-kind", util.EnvString(reconcilermanager.ClusterLabelsSourceKind, ""),
+		fmt.Sprintf("The kind of the object to read the cluster labels from. Must be %s, %s or empty to only use the declared Cluster objects.",
+			configsync.ClusterLabelsSourceConfigMap, configsync.ClusterLabelsSourceMembership))
+	clusterLabelsSourceName = flag.String("cluster-labels-source-name", util.EnvString(reconcilermanager.ClusterLabelsSourceName, ""),
+		"The name of the ConfigMap to read the cluster labels from.")
+
 	webhookEnabled       = flag.Bool("webhook-enabled", util.EnvBool(reconcilermanager.WebhookEnabled, false), "")
 	reconcilerSignalsDir = flag.String(flags.reconcilerSignalDir, "/reconciler-signals",
 		"The absolute path in the container that contains reconciler signals that unblock the rendering phase, for example, the latest image digest that is ready to render.")
@@ -131,29 +177,45 @@ var flags = struct {
 }
 
 func main() {
-	log.Setup()
+	logger := log.Setup()
 	profiler.Service()
-	logger := textlogger.NewLogger(textlogger.NewConfig())
+	logger = log.WithValues(logger, log.KeyScope, *scopeStr, log.KeySyncName, *syncName)
 	ctrl.SetLogger(logger)
 
 	if *debug {
 		status.EnablePanicOnMisuse()
 	}
 
-	// Register the OpenCensus views
-	if err := ocmetrics.RegisterReconcilerMetricsViews(); err != nil {
-		klog.Fatalf("Failed to register OpenCensus views: %v", err)
+	// Register the OTLP metrics exporter, and the Prometheus reader if the
+	// Config Sync metrics are served on /metrics.
+	var metricReaders []sdkmetric.Reader
+	if *prometheusMetricsAddr != "" {
+		reader, err := metrics.NewPrometheusReader()
+		if err != nil {
+			klog.Fatalf("Failed to create the Prometheus metrics reader: %v", err)
+		}
+		metricReaders = append(metricReaders, reader)
+	}
+	mp, err := metrics.RegisterOTelExporter(context.Background(), reconcilermanager.Reconciler, metricReaders...)
+	if err != nil {
+		klog.Fatalf("Failed to register the OTLP metrics exporter: %v", err)
 	}
 
-	// Register the OC Agent exporter
-	oce, err := ocmetrics.RegisterOCAgentExporter(reconcilermanager.Reconciler)
+	defer func() {
+		if err := mp.Shutdown(context.Background()); err != nil {
+			klog.Fatalf("Unable to stop the OTLP metrics exporter: %v", err)
+		}
+	}()
+
+	// Register the OTLP trace exporter
+	tp, err := tracing.RegisterOTelExporter(context.Background(), reconcilermanager.Reconciler)
 	if err != nil {
-		klog.Fatalf("Failed to register the OC Agent exporter: %v", err)
+		klog.Fatalf("Failed to register the OTLP trace exporter: %v", err)
 	}
 
 	defer func() {
-		if err := oce.Stop(); err != nil {
-			klog.Fatalf("Unable to stop the OC Agent exporter: %v", err)
+		if err := tp.Shutdown(context.Background()); err != nil {
+			klog.Fatalf("Unable to stop the OTLP trace exporter: %v", err)
 		}
 	}()
 
@@ -188,6 +250,49 @@ func main() {
 		klog.Fatal(err)
 	}
 
+	var variables map[string]string
+	if *substitutionVariables != "" {
+		if err := json.Unmarshal([]byte(*substitutionVariables), &variables); err != nil {
+			klog.Fatalf("Invalid substitution variables: %v", err)
+		}
+	}
+
+	var notificationEndpoints []v1beta1.NotificationEndpoint
+	if *notifications != "" {
+		if err := json.Unmarshal([]byte(*notifications), &notificationEndpoints); err != nil {
+			klog.Fatalf("Invalid notification endpoints: %v", err)
+		}
+	}
+
+	var auditLogSpec *v1beta1.AuditLog
+	if *auditLog != "" {
+		auditLogSpec = &v1beta1.AuditLog{}
+		if err := json.Unmarshal([]byte(*auditLog), auditLogSpec); err != nil {
+			klog.Fatalf("Invalid audit log: %v", err)
+		}
+	}
+
+	var commitStatus *commitstatus.Options
+	if *commitStatusProvider != "" {
+		// The credentials are only read from the environment, so they aren't
+		// exposed in the command line.
+		commitStatus = &commitstatus.Options{
+			Provider:  configsync.GitProvider(*commitStatusProvider),
+			APIURL:    *commitStatusAPIURL,
+			TargetURL: *commitStatusTargetURL,
+			Token:     os.Getenv(reconcilermanager.CommitStatusToken),
+		}
+		if key := os.Getenv(reconcilermanager.CommitStatusGithubAppPrivateKey); key != "" {
+			commitStatus.GithubApp = &commitstatus.GithubApp{
+				PrivateKey:     key,
+				ClientID:       os.Getenv(reconcilermanager.CommitStatusGithubAppClientID),
+				AppID:          os.Getenv(reconcilermanager.CommitStatusGithubAppApplicationID),
+				InstallationID: os.Getenv(reconcilermanager.CommitStatusGithubAppInstallationID),
+				BaseURL:        os.Getenv(reconcilermanager.CommitStatusGithubAppBaseURL),
+			}
+		}
+	}
+
 	opts := reconciler.Options{
 		Logger:                   logger,
 		ClusterName:              *clusterName,
@@ -216,6 +321,21 @@ func main() {
 		DynamicNSSelectorEnabled: *dynamicNSSelectorEnabled,
 		WebhookEnabled:           *webhookEnabled,
 		ReconcilerSignalsDir:     absReconcilerSignalDir,
+		PrunePolicy: declared.PrunePolicy{
+			MaxPruneCount:       *pruneMaxCount,
+			MaxPrunePercent:     *pruneMaxPercent,
+			ProtectedGroupKinds: declared.ParseGroupKinds(*pruneProtectedKinds),
+			ConfirmedCommit:     *pruneConfirmedCommit,
+		},
+		ObjectMetrics: applier.ObjectMetricsOptions{
+			GroupKinds:       declared.ParseGroupKinds(*objectMetricsKinds),
+			IncludeNamespace: *objectMetricsNamespaces,
+		},
+		SubstitutionVariables: variables,
+		Notifications:         notificationEndpoints,
+		CommitStatus:          commitStatus,
+		AuditLog:              auditLogSpec,
+		PrometheusMetricsAddr: *prometheusMetricsAddr,
 	}
 
 	if scope == declared.RootScope {
@@ -232,8 +352,10 @@ func main() {
 
 		klog.Info("Starting reconciler for: root")
 		opts.RootOptions = &reconciler.RootOptions{
-			SourceFormat:      format,
-			NamespaceStrategy: nsStrat,
+			SourceFormat:            format,
+			NamespaceStrategy:       nsStrat,
+			ClusterLabelsSourceKind: configsync.ClusterLabelsSourceKind(*clusterLabelsSourceKind),
+			ClusterLabelsSourceName: *clusterLabelsSourceName,
 		}
 	} else {
 		klog.Infof("Starting reconciler for: %s", scope)
@@ -246,6 +368,10 @@ func main() {
 			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
 				flags.namespaceStrategy, reconcilermanager.NamespaceStrategy)
 		}
+		if *clusterLabelsSourceKind != "" {
+			klog.Fatalf("Flag %s and environment variable %s must not be passed to a Namespace reconciler",
+				"cluster-labels-source-kind", reconcilermanager.ClusterLabelsSourceKind)
+		}
 	}
 	reconciler.Run(opts)
 }
@@ -0,0 +1,368 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adopt implements adopting unmanaged cluster objects into a RootSync
+// or RepoSync.
+//
+// An object can be adopted in one of two modes:
+//   - Declared: the object is marked as managed, and the next sync applies the
+//     declared state, like any other managed object.
+//   - CurrentState: the object is marked as managed, but the reconciler keeps
+//     its current cluster state until the declared object changes. This avoids
+//     rewriting every adopted object when taking over a cluster whose live
+//     state has drifted from the source of truth.
+//
+// Objects adopted with their current state are indexed on the inventory
+// ResourceGroup by the hash of the declared object at adoption time, and each
+// object carries the same hash in the AdoptedHashAnnotationKey annotation.
+package adopt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/metadata"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Mode is how an unmanaged object is adopted.
+type Mode string
+
+const (
+	// CurrentState adopts an object while keeping its current cluster state
+	// until the declared object changes.
+	CurrentState Mode = "current-state"
+	// Declared adopts an object and applies its declared state on the next
+	// sync.
+	Declared Mode = "declared"
+)
+
+// State is the adoption state of a declared object.
+type State string
+
+const (
+	// NotFound means the object does not exist on the cluster yet, so it is
+	// created by the next sync without adoption.
+	NotFound State = "NotFound"
+	// Managed means the object is already managed by the RootSync or RepoSync.
+	Managed State = "Managed"
+	// Conflict means the object is managed by another RootSync or RepoSync,
+	// so it cannot be adopted.
+	Conflict State = "Conflict"
+	// Unmanaged means the object exists and is not managed by Config Sync, so
+	// it can be adopted.
+	Unmanaged State = "Unmanaged"
+)
+
+// FieldDiff is a declared field whose value differs from the live object.
+type FieldDiff struct {
+	// Path is the path of the field, e.g. `.spec.replicas`.
+	Path string
+	// Declared is the declared value of the field.
+	Declared interface{}
+	// Live is the value of the field on the cluster, or nil if it is not set.
+	Live interface{}
+}
+
+// String returns a human-readable description of the difference.
+func (d FieldDiff) String() string {
+	if d.Live == nil {
+		return fmt.Sprintf("%s: declared %s, not set on the cluster", d.Path, formatValue(d.Declared))
+	}
+	return fmt.Sprintf("%s: declared %s, live %s", d.Path, formatValue(d.Declared), formatValue(d.Live))
+}
+
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// Result is the outcome of comparing a declared object to the cluster.
+type Result struct {
+	// ID identifies the object.
+	ID core.ID
+	// State is the adoption state of the object.
+	State State
+	// Manager is the manager of the live object, if it is managed by another
+	// RootSync or RepoSync.
+	Manager string
+	// Diffs are the declared fields that differ on the cluster.
+	Diffs []FieldDiff
+}
+
+// Classify compares a declared object to its live object, which is nil if the
+// object does not exist on the cluster. The manager is the ResourceManagerKey
+// annotation value of the adopting RootSync or RepoSync.
+func Classify(declaredObj, live *unstructured.Unstructured, manager string) (Result, error) {
+	result := Result{ID: core.IDOf(declaredObj)}
+	if live == nil {
+		result.State = NotFound
+		return result, nil
+	}
+	liveManager := core.GetAnnotation(live, metadata.ResourceManagerKey)
+	switch {
+	case liveManager == manager:
+		result.State = Managed
+	case liveManager != "" && !metadata.IsManagementDisabled(live):
+		result.State = Conflict
+		result.Manager = liveManager
+	default:
+		result.State = Unmanaged
+	}
+	diffs, err := Diff(declaredObj, live)
+	if err != nil {
+		return result, err
+	}
+	result.Diffs = diffs
+	return result, nil
+}
+
+// Diff returns the declared fields of an object whose values differ on the
+// cluster. Fields which are only set on the live object, like defaulted fields
+// and status, are not differences. Config Sync metadata is ignored.
+func Diff(declaredObj, live client.Object) ([]FieldDiff, error) {
+	d, err := normalize(declaredObj)
+	if err != nil {
+		return nil, err
+	}
+	l, err := normalize(live)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []FieldDiff
+	diffFields("", d, l, &diffs)
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs, nil
+}
+
+func diffFields(path string, declaredFields, liveFields map[string]interface{}, diffs *[]FieldDiff) {
+	for k, dv := range declaredFields {
+		p := path + "." + k
+		lv, found := liveFields[k]
+		if !found {
+			*diffs = append(*diffs, FieldDiff{Path: p, Declared: dv})
+			continue
+		}
+		dm, dIsMap := dv.(map[string]interface{})
+		lm, lIsMap := lv.(map[string]interface{})
+		if dIsMap && lIsMap {
+			diffFields(p, dm, lm, diffs)
+			continue
+		}
+		if !reflect.DeepEqual(dv, lv) {
+			*diffs = append(*diffs, FieldDiff{Path: p, Declared: dv, Live: lv})
+		}
+	}
+}
+
+// Hash returns the hash of the declared state of an object, ignoring Config
+// Sync metadata, so that it is the same whether computed from the local source
+// by nomos or from the parsed source by the reconciler.
+func Hash(obj client.Object) (string, error) {
+	n, err := normalize(obj)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(n)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// normalize returns the JSON representation of an object without status,
+// server-set metadata and Config Sync metadata.
+func normalize(obj client.Object) (map[string]interface{}, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("converting %s to unstructured: %w", core.IDOf(obj), err)
+	}
+	n := make(map[string]interface{}, len(u))
+	for k, v := range u {
+		switch k {
+		case "status":
+		case "metadata":
+			n[k] = normalizeMetadata(obj)
+		default:
+			n[k] = v
+		}
+	}
+	// Round-trip through JSON, so that numbers have the same type regardless
+	// of how the object was decoded.
+	b, err := json.Marshal(n)
+	if err != nil {
+		return nil, err
+	}
+	n = map[string]interface{}{}
+	if err := json.Unmarshal(b, &n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func normalizeMetadata(obj client.Object) map[string]interface{} {
+	m := map[string]interface{}{"name": obj.GetName()}
+	if obj.GetNamespace() != "" {
+		m["namespace"] = obj.GetNamespace()
+	}
+	labels := map[string]interface{}{}
+	for k, v := range obj.GetLabels() {
+		if !metadata.IsConfigSyncLabelKey(k) && k != metadata.ApplySetPartOfLabel {
+			labels[k] = v
+		}
+	}
+	if len(labels) > 0 {
+		m["labels"] = labels
+	}
+	annotations := map[string]interface{}{}
+	for k, v := range obj.GetAnnotations() {
+		if !metadata.IsConfigSyncAnnotationKey(k) {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) > 0 {
+		m["annotations"] = annotations
+	}
+	return m
+}
+
+// IsAdoptedWithCurrentState returns true if the live object was adopted with
+// its current state, and the declared object has not changed since.
+func IsAdoptedWithCurrentState(declaredObj, live client.Object) bool {
+	if declaredObj == nil || live == nil {
+		return false
+	}
+	adoptedHash := core.GetAnnotation(live, metadata.AdoptedHashAnnotationKey)
+	if adoptedHash == "" {
+		return false
+	}
+	hash, err := Hash(declaredObj)
+	return err == nil && hash == adoptedHash
+}
+
+// MetadataPatch returns the server-side apply patch which marks the live
+// object as managed by the RootSync or RepoSync. Only the metadata is
+// included, so adopting does not take ownership of any other field.
+// The hash is set for objects adopted with their current state.
+func MetadataPatch(live *unstructured.Unstructured, csm metadata.ConfigSyncMetadata, hash string) *unstructured.Unstructured {
+	patch := &unstructured.Unstructured{}
+	patch.SetGroupVersionKind(live.GroupVersionKind())
+	patch.SetNamespace(live.GetNamespace())
+	patch.SetName(live.GetName())
+	if metadata.IsManagementDisabled(live) {
+		core.SetAnnotation(patch, metadata.ManagementModeAnnotationKey, metadata.ManagementEnabled.String())
+	}
+	csm.SetConfigSyncMetadata(patch)
+	// The git context and source hash are set by the reconciler when it next
+	// applies the object.
+	core.RemoveAnnotations(patch, metadata.GitContextKey, metadata.SyncTokenAnnotationKey)
+	if hash != "" {
+		core.SetAnnotation(patch, metadata.AdoptedHashAnnotationKey, hash)
+	}
+	return patch
+}
+
+// ConfigSyncMetadata returns the Config Sync metadata of objects managed by
+// the specified RootSync or RepoSync, without the source context.
+func ConfigSyncMetadata(scope declared.Scope, syncName, applySetID, inventoryID string) metadata.ConfigSyncMetadata {
+	return metadata.ConfigSyncMetadata{
+		ApplySetID:   applySetID,
+		ManagerValue: declared.ResourceManager(scope, syncName),
+		InventoryID:  inventoryID,
+	}
+}
+
+// AdoptedObjects returns the objects adopted with their current state, keyed
+// by resource ID, with the hash of their declared state at adoption time.
+func AdoptedObjects(inventory client.Object) (map[string]string, error) {
+	value := core.GetAnnotation(inventory, metadata.AdoptedObjectsAnnotationKey)
+	adopted := map[string]string{}
+	if value == "" {
+		return adopted, nil
+	}
+	if err := json.Unmarshal([]byte(value), &adopted); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", metadata.AdoptedObjectsAnnotationKey, err)
+	}
+	return adopted, nil
+}
+
+// SetAdoptedObjects sets the objects adopted with their current state on the
+// inventory, removing the annotation if there are none.
+func SetAdoptedObjects(inventory client.Object, adopted map[string]string) error {
+	if len(adopted) == 0 {
+		core.RemoveAnnotations(inventory, metadata.AdoptedObjectsAnnotationKey)
+		return nil
+	}
+	b, err := json.Marshal(adopted)
+	if err != nil {
+		return err
+	}
+	core.SetAnnotation(inventory, metadata.AdoptedObjectsAnnotationKey, string(b))
+	return nil
+}
+
+// AddToInventory adds the objects to the resources of an inventory
+// ResourceGroup, if they are not already in it. Returns true if the inventory
+// was modified.
+func AddToInventory(inventory *unstructured.Unstructured, ids []core.ID) (bool, error) {
+	resources, _, err := unstructured.NestedSlice(inventory.Object, "spec", "resources")
+	if err != nil {
+		return false, fmt.Errorf("invalid ResourceGroup %s/%s: %w", inventory.GetNamespace(), inventory.GetName(), err)
+	}
+	existing := make(map[core.ID]bool, len(resources))
+	for _, r := range resources {
+		res, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var id core.ID
+		id.Group, _, _ = unstructured.NestedString(res, "group")
+		id.Kind, _, _ = unstructured.NestedString(res, "kind")
+		id.Namespace, _, _ = unstructured.NestedString(res, "namespace")
+		id.Name, _, _ = unstructured.NestedString(res, "name")
+		existing[id] = true
+	}
+	updated := false
+	for _, id := range ids {
+		if existing[id] {
+			continue
+		}
+		existing[id] = true
+		updated = true
+		resources = append(resources, map[string]interface{}{
+			"group":     id.Group,
+			"kind":      id.Kind,
+			"namespace": id.Namespace,
+			"name":      id.Name,
+		})
+	}
+	if !updated {
+		return false, nil
+	}
+	return true, unstructured.SetNestedSlice(inventory.Object, resources, "spec", "resources")
+}
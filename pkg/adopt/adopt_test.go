@@ -0,0 +1,235 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adopt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/resourcegroup"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func mustParse(t *testing.T, content string) *unstructured.Unstructured {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), &u.Object))
+	return u
+}
+
+const declaredDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app: web
+    app.kubernetes.io/managed-by: configmanagement.gke.io
+  annotations:
+    configsync.gke.io/declared-fields: '{}'
+spec:
+  replicas: 3
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v2
+`
+
+const liveDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  uid: 1234
+  resourceVersion: "42"
+  labels:
+    app: web
+spec:
+  replicas: 5
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: web
+        image: web:v1
+status:
+  replicas: 5
+`
+
+func TestDiff(t *testing.T) {
+	diffs, err := Diff(mustParse(t, declaredDeployment), mustParse(t, liveDeployment))
+	require.NoError(t, err)
+	assert.Equal(t, []FieldDiff{
+		{
+			Path:     ".spec.replicas",
+			Declared: float64(3),
+			Live:     float64(5),
+		},
+		{
+			Path:     ".spec.template.spec.containers",
+			Declared: []interface{}{map[string]interface{}{"name": "web", "image": "web:v2"}},
+			Live:     []interface{}{map[string]interface{}{"name": "web", "image": "web:v1"}},
+		},
+	}, diffs)
+	assert.Equal(t, ".spec.replicas: declared 3, live 5", diffs[0].String())
+
+	diffs, err = Diff(mustParse(t, declaredDeployment), mustParse(t, declaredDeployment))
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestHash(t *testing.T) {
+	obj := mustParse(t, declaredDeployment)
+	hash, err := Hash(obj)
+	require.NoError(t, err)
+
+	// Config Sync metadata does not change the hash.
+	withMetadata := obj.DeepCopy()
+	core.SetAnnotation(withMetadata, metadata.SyncTokenAnnotationKey, "abc123")
+	core.SetLabel(withMetadata, metadata.ApplySetPartOfLabel, "applyset-id")
+	withMetadataHash, err := Hash(withMetadata)
+	require.NoError(t, err)
+	assert.Equal(t, hash, withMetadataHash)
+
+	// Changing the declared state changes the hash.
+	changed := obj.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(changed.Object, int64(4), "spec", "replicas"))
+	changedHash, err := Hash(changed)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changedHash)
+
+	adopted := mustParse(t, liveDeployment)
+	assert.False(t, IsAdoptedWithCurrentState(obj, adopted))
+	core.SetAnnotation(adopted, metadata.AdoptedHashAnnotationKey, hash)
+	assert.True(t, IsAdoptedWithCurrentState(obj, adopted))
+	assert.False(t, IsAdoptedWithCurrentState(changed, adopted))
+}
+
+func TestClassify(t *testing.T) {
+	manager := declared.ResourceManager(declared.RootScope, "root-sync")
+	declaredObj := mustParse(t, declaredDeployment)
+
+	managedByOther := mustParse(t, liveDeployment)
+	core.SetAnnotation(managedByOther, metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, "other"))
+	managed := mustParse(t, liveDeployment)
+	core.SetAnnotation(managed, metadata.ResourceManagerKey, manager)
+
+	testCases := map[string]struct {
+		live        *unstructured.Unstructured
+		wantState   State
+		wantManager string
+	}{
+		"not found": {
+			wantState: NotFound,
+		},
+		"unmanaged": {
+			live:      mustParse(t, liveDeployment),
+			wantState: Unmanaged,
+		},
+		"managed": {
+			live:      managed,
+			wantState: Managed,
+		},
+		"managed by another sync": {
+			live:        managedByOther,
+			wantState:   Conflict,
+			wantManager: declared.ResourceManager(declared.RootScope, "other"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := Classify(declaredObj, tc.live, manager)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantState, result.State)
+			assert.Equal(t, tc.wantManager, result.Manager)
+			assert.Equal(t, core.IDOf(declaredObj), result.ID)
+		})
+	}
+}
+
+func TestMetadataPatch(t *testing.T) {
+	csm := ConfigSyncMetadata(declared.RootScope, "root-sync", "applyset-id", "config-management-system_root-sync")
+	patch := MetadataPatch(mustParse(t, liveDeployment), csm, "hash")
+
+	want := mustParse(t, `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app.kubernetes.io/managed-by: configmanagement.gke.io
+    applyset.kubernetes.io/part-of: applyset-id
+  annotations:
+    config.k8s.io/owning-inventory: config-management-system_root-sync
+    configmanagement.gke.io/managed: enabled
+    configsync.gke.io/adopted-hash: hash
+    configsync.gke.io/manager: :root
+    configsync.gke.io/resource-id: apps_deployment_shop_web
+`)
+	assert.Equal(t, want, patch)
+}
+
+func TestAdoptedObjects(t *testing.T) {
+	inv := resourcegroup.Unstructured("root-sync", "config-management-system", "config-management-system_root-sync")
+	adopted, err := AdoptedObjects(inv)
+	require.NoError(t, err)
+	assert.Empty(t, adopted)
+
+	require.NoError(t, SetAdoptedObjects(inv, map[string]string{"apps_deployment_shop_web": "hash"}))
+	adopted, err = AdoptedObjects(inv)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"apps_deployment_shop_web": "hash"}, adopted)
+
+	require.NoError(t, SetAdoptedObjects(inv, nil))
+	assert.NotContains(t, inv.GetAnnotations(), metadata.AdoptedObjectsAnnotationKey)
+
+	core.SetAnnotation(inv, metadata.AdoptedObjectsAnnotationKey, "invalid")
+	_, err = AdoptedObjects(inv)
+	assert.Error(t, err)
+}
+
+func TestAddToInventory(t *testing.T) {
+	inv := resourcegroup.Unstructured("root-sync", "config-management-system", "config-management-system_root-sync")
+	deployment := core.ID{GroupKind: kinds.Deployment().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "shop", Name: "web"}}
+	namespace := core.ID{GroupKind: kinds.Namespace().GroupKind(), ObjectKey: client.ObjectKey{Name: "shop"}}
+
+	updated, err := AddToInventory(inv, []core.ID{deployment})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	updated, err = AddToInventory(inv, []core.ID{deployment, namespace})
+	require.NoError(t, err)
+	assert.True(t, updated)
+	updated, err = AddToInventory(inv, []core.ID{namespace})
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	resources, _, err := unstructured.NestedSlice(inv.Object, "spec", "resources")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"group": "apps", "kind": "Deployment", "namespace": "shop", "name": "web"},
+		map[string]interface{}{"group": "", "kind": "Namespace", "namespace": "", "name": "shop"},
+	}, resources)
+}
@@ -274,12 +274,26 @@ type NamespaceSelector struct {
 }
 
 // NamespaceSelectorSpec contains spec fields for NamespaceSelector.
+// A Namespace is selected when it matches selector and each of annotationSelector
+// and namePatterns which are set.
 type NamespaceSelectorSpec struct {
 	// Selects namespaces.
 	// This field is NOT optional and follows standard label selector semantics. An empty selector
 	// matches all namespaces.
 	Selector metav1.LabelSelector `json:"selector"`
 
+	// annotationSelector selects namespaces by their annotations, with the same
+	// matchLabels and matchExpressions semantics as selector.
+	// It is only supported with the unstructured source format.
+	// +optional
+	AnnotationSelector *metav1.LabelSelector `json:"annotationSelector,omitempty"`
+
+	// namePatterns selects namespaces whose name matches at least one of the
+	// glob patterns, for example "team-*".
+	// It is only supported with the unstructured source format.
+	// +optional
+	NamePatterns []string `json:"namePatterns,omitempty"`
+
 	// mode specifies the selection mode of the NamespaceSelector.
 	// It must be set to either "static" or "dynamic" and is optional. If not specified, it defaults to "static."
 	// In static mode, only resources with labels matching Namespaces statically declared in the source of truth are selected.
@@ -6,6 +6,7 @@
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -606,6 +607,16 @@ func (in *NamespaceSelectorList) DeepCopyObject() runtime.Object {
 func (in *NamespaceSelectorSpec) DeepCopyInto(out *NamespaceSelectorSpec) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.AnnotationSelector != nil {
+		in, out := &in.AnnotationSelector, &out.AnnotationSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamePatterns != nil {
+		in, out := &in.NamePatterns, &out.NamePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -156,3 +156,66 @@ const (
 	// declared to be created by the reconciler.
 	NamespaceStrategyExplicit NamespaceStrategy = "explicit"
 )
+
+// ClusterLabelsSourceKind specifies the kind of the cluster-local object
+// whose labels are matched by ClusterSelectors.
+type ClusterLabelsSourceKind string
+
+const (
+	// ClusterLabelsSourceConfigMap indicates that the cluster labels are the
+	// data of a ConfigMap in the config-management-system namespace.
+	ClusterLabelsSourceConfigMap ClusterLabelsSourceKind = "ConfigMap"
+	// ClusterLabelsSourceMembership indicates that the cluster labels are the
+	// labels of the hub Membership of the cluster.
+	ClusterLabelsSourceMembership ClusterLabelsSourceKind = "Membership"
+)
+
+// LogFormat specifies the format of the logs of the Config Sync containers.
+type LogFormat string
+
+const (
+	// LogFormatText indicates klog text logs.
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON indicates structured JSON logs, one object per line.
+	LogFormatJSON LogFormat = "json"
+)
+
+// NotificationFormat specifies the format of the payload POSTed to a
+// notification endpoint.
+type NotificationFormat string
+
+const (
+	// NotificationFormatGeneric indicates a JSON payload with the sync result.
+	NotificationFormatGeneric NotificationFormat = "generic"
+	// NotificationFormatSlack indicates a Slack incoming webhook message.
+	NotificationFormatSlack NotificationFormat = "slack"
+	// NotificationFormatTeams indicates a Microsoft Teams incoming webhook
+	// message card.
+	NotificationFormatTeams NotificationFormat = "teams"
+)
+
+// NotificationTrigger specifies a sync result that a notification endpoint is
+// notified of.
+type NotificationTrigger string
+
+const (
+	// NotificationTriggerSynced indicates that a commit synced without errors.
+	NotificationTriggerSynced NotificationTrigger = "Synced"
+	// NotificationTriggerFailed indicates that a commit failed to sync.
+	NotificationTriggerFailed NotificationTrigger = "Failed"
+	// NotificationTriggerErrorsChanged indicates that the errors of a commit
+	// that failed to sync changed.
+	NotificationTriggerErrorsChanged NotificationTrigger = "ErrorsChanged"
+)
+
+// GitProvider specifies the API of a Git provider.
+type GitProvider string
+
+const (
+	// GitProviderGitHub indicates the GitHub REST API.
+	GitProviderGitHub GitProvider = "github"
+	// GitProviderGitLab indicates the GitLab REST API.
+	GitProviderGitLab GitProvider = "gitlab"
+	// GitProviderGitea indicates the Gitea REST API.
+	GitProviderGitea GitProvider = "gitea"
+)
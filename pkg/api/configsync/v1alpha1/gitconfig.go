@@ -93,6 +93,34 @@ type Git struct {
 	// +nullable
 	// +optional
 	CACertSecretRef *SecretReference `json:"caCertSecretRef,omitempty"`
+
+	// commitStatus reports whether each commit synced back to the Git
+	// provider, as a commit status keyed by the cluster name. The commit
+	// statuses are posted with the credentials in secretRef, so auth must be
+	// "token" or "githubapp".
+	// +optional
+	CommitStatus *GitCommitStatus `json:"commitStatus,omitempty"`
+}
+
+// GitCommitStatus configures the commit statuses reported to the Git provider.
+type GitCommitStatus struct {
+	// provider is the API of the Git provider. Required.
+	// Must be "github", "gitlab" or "gitea".
+	//
+	// +kubebuilder:validation:Enum=github;gitlab;gitea
+	Provider configsync.GitProvider `json:"provider"`
+
+	// apiURL is the base URL of the API of the Git provider.
+	// Default: derived from the host of the repo: "https://api.github.com" for
+	// github.com, "https://<host>/api/v3" for other GitHub hosts,
+	// "https://<host>/api/v4" for GitLab and "https://<host>/api/v1" for Gitea.
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// targetURL is the link of the commit statuses, for example to a
+	// dashboard of the cluster.
+	// +optional
+	TargetURL string `json:"targetURL,omitempty"`
 }
 
 // SecretReference contains the reference to the secret used to connect to
@@ -74,6 +74,58 @@ type OverrideSpec struct {
 	// +listMapKey=containerName
 	// +optional
 	LogLevels []ContainerLogLevelOverride `json:"logLevels,omitempty"`
+
+	// logFormat specifies the format of the logs of the Config Sync containers
+	// of the reconciler deployment: "text" or "json". Structured JSON logs
+	// include keys like the sync name, scope, commit, object and error code.
+	// The "git-sync" and "otel-agent" containers keep their own log format.
+	// Default: text.
+	// +kubebuilder:validation:Enum=text;json
+	// +optional
+	LogFormat configsync.LogFormat `json:"logFormat,omitempty"`
+
+	// prunePolicy limits which managed objects the reconciler may prune, and
+	// how many managed objects it may prune in a single sync.
+	// If unset, the reconciler prunes every managed object which is removed
+	// from the source.
+	// +optional
+	PrunePolicy *PrunePolicy `json:"prunePolicy,omitempty"`
+
+	// substitutionConfigMapRef references a ConfigMap in the same namespace as
+	// the RootSync/RepoSync, whose data defines the variables substituted for
+	// the `${VAR}` placeholders in the string fields of the declared objects.
+	// The built-in variable CLUSTER_NAME is set to the name of the cluster.
+	// Placeholders escaped as `$${VAR}` are replaced with a literal `${VAR}`.
+	// Objects referencing undefined variables fail validation.
+	// If unset, placeholders are not substituted.
+	// +nullable
+	// +optional
+	SubstitutionConfigMapRef *ConfigMapReference `json:"substitutionConfigMapRef,omitempty"`
+
+	// objectMetrics enables apply metrics broken down by the kind, and
+	// optionally the namespace, of the managed objects.
+	// If unset, apply metrics are only aggregated per sync.
+	// +optional
+	ObjectMetrics *ObjectMetrics `json:"objectMetrics,omitempty"`
+
+	// notifications is a list of HTTP endpoints that the reconciler notifies
+	// when a commit syncs, fails to sync, or when its errors change.
+	// +optional
+	Notifications []NotificationEndpoint `json:"notifications,omitempty"`
+
+	// auditLog records an audit record for each object that the reconciler
+	// creates, updates, patches or deletes, to a file and/or an HTTP sink.
+	// If unset, the writes are not audited.
+	// +optional
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+
+	// prometheusMetrics serves the metrics of the reconciler on /metrics in
+	// the Prometheus format, on a named container port of the reconciler
+	// container, so that they can be scraped without the otel-collector.
+	// If unset, the Config Sync metrics are only exported to the
+	// otel-collector.
+	// +optional
+	PrometheusMetrics *PrometheusMetrics `json:"prometheusMetrics,omitempty"`
 }
 
 // RootSyncOverrideSpec allows to override the settings for a RootSync reconciler pod
@@ -98,6 +150,16 @@ type RootSyncOverrideSpec struct {
 	//
 	// +optional
 	RoleRefs []RootSyncRoleRef `json:"roleRefs,omitempty"`
+
+	// clusterLabelsSource identifies a cluster-local object whose labels are
+	// matched by ClusterSelectors, in addition to the labels of the Cluster
+	// object declared in the source. Labels from this object take precedence.
+	// The reconciler watches the object and re-parses the source when its
+	// labels change, so clusters can be relabeled without a source commit.
+	// If unset, ClusterSelectors only match the declared Cluster objects.
+	// +nullable
+	// +optional
+	ClusterLabelsSource *ClusterLabelsSource `json:"clusterLabelsSource,omitempty"`
 }
 
 // each item references a Role or ClusterRole to create
@@ -170,3 +232,190 @@ type ContainerLogLevelOverride struct {
 	// +kubebuilder:validation:Required
 	LogLevel int `json:"logLevel"`
 }
+
+// PrunePolicy limits which managed objects the reconciler may prune, and how
+// many managed objects it may prune in a single sync.
+type PrunePolicy struct {
+	// maxPruneCount is the maximum number of managed objects the reconciler
+	// may prune in a single sync.
+	// If a sync would prune more objects, the sync is blocked with an error
+	// until the source commit is confirmed with confirmedCommit.
+	// Must be no less than 0. Zero or unset means no limit.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPruneCount *int64 `json:"maxPruneCount,omitempty"`
+
+	// maxPrunePercent is the maximum percentage of the managed objects the
+	// reconciler may prune in a single sync.
+	// If a sync would prune a larger percentage of objects, the sync is
+	// blocked with an error until the source commit is confirmed with
+	// confirmedCommit.
+	// Must be between 0 and 100. Zero or unset means no limit.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxPrunePercent *int64 `json:"maxPrunePercent,omitempty"`
+
+	// protectedKinds is a list of resource kinds the reconciler never prunes.
+	// Managed objects of these kinds which are removed from the source are
+	// unmanaged instead of deleted, as if they had the
+	// `client.lifecycle.config.k8s.io/deletion: detach` annotation.
+	// +optional
+	ProtectedKinds []PruneProtectedKind `json:"protectedKinds,omitempty"`
+
+	// confirmedCommit is the source commit (git commit hash, OCI image digest,
+	// or Helm chart version) which is allowed to exceed maxPruneCount and
+	// maxPrunePercent.
+	// Set this to the commit reported by the prune limit error to confirm that
+	// the prune is intended.
+	// +optional
+	ConfirmedCommit string `json:"confirmedCommit,omitempty"`
+}
+
+// PruneProtectedKind identifies a resource kind the reconciler never prunes.
+type PruneProtectedKind struct {
+	// group is the API group of the resource kind.
+	// Use an empty string for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the resource. Required.
+	Kind string `json:"kind"`
+}
+
+// ObjectMetrics configures the apply metrics broken down by the kind of the
+// managed objects. Only the allow-listed kinds are recorded, to keep the
+// number of time series bounded.
+type ObjectMetrics struct {
+	// kinds is the allow-list of resource kinds to record metrics for.
+	// Objects of other kinds are not recorded.
+	// +optional
+	Kinds []ObjectMetricsKind `json:"kinds,omitempty"`
+
+	// includeNamespace adds the namespace of the objects to the metrics.
+	// This multiplies the number of time series by the number of namespaces
+	// with objects of the allow-listed kinds. Default: false.
+	// +optional
+	IncludeNamespace bool `json:"includeNamespace,omitempty"`
+}
+
+// ObjectMetricsKind identifies a resource kind to record metrics for.
+type ObjectMetricsKind struct {
+	// group is the API group of the resource kind.
+	// Use an empty string for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the resource. Required.
+	Kind string `json:"kind"`
+}
+
+// NotificationEndpoint configures an HTTP endpoint that the reconciler POSTs
+// notifications of sync results to.
+type NotificationEndpoint struct {
+	// name identifies the endpoint in the reconciler logs. Required.
+	Name string `json:"name"`
+
+	// url is the HTTP or HTTPS URL to POST the notifications to. Required.
+	// The URL is stored in the reconciler Deployment, so it should not embed
+	// credentials that must be kept secret from users who can read it.
+	URL string `json:"url"`
+
+	// format is the format of the notification payload.
+	// Must be "generic", "slack" or "teams". Default: "generic".
+	// "generic" POSTs a JSON object with the commit, errors and sync stats.
+	// "slack" POSTs a Slack incoming webhook message.
+	// "teams" POSTs a Microsoft Teams incoming webhook message card.
+	//
+	// +kubebuilder:validation:Enum=generic;slack;teams
+	// +optional
+	Format configsync.NotificationFormat `json:"format,omitempty"`
+
+	// triggers is the list of sync results to notify the endpoint of.
+	// Must contain "Synced", "Failed" or "ErrorsChanged". Default: all of them.
+	// "Synced" notifies when a commit syncs without errors.
+	// "Failed" notifies when a commit fails to sync.
+	// "ErrorsChanged" notifies when the errors of a failing commit change.
+	//
+	// +kubebuilder:validation:items:Enum=Synced;Failed;ErrorsChanged
+	// +optional
+	Triggers []configsync.NotificationTrigger `json:"triggers,omitempty"`
+}
+
+// AuditLog configures where the reconciler writes the audit records of the
+// objects it creates, updates, patches and deletes.
+// Each record is chained to the previous record by its hash, so that records
+// which are modified, removed or reordered can be detected.
+type AuditLog struct {
+	// file writes the audit records to a file on a volume of the reconciler
+	// Pod, rotated by size.
+	// +optional
+	File *AuditLogFile `json:"file,omitempty"`
+
+	// sinkURL is the HTTP or HTTPS URL to POST batches of audit records to,
+	// as newline-delimited JSON.
+	// The URL is stored in the reconciler Deployment, so it should not embed
+	// credentials that must be kept secret from users who can read it.
+	// +optional
+	SinkURL string `json:"sinkURL,omitempty"`
+}
+
+// AuditLogFile configures the audit log file of the reconciler.
+type AuditLogFile struct {
+	// claimName is the name of a PersistentVolumeClaim in the
+	// config-management-system namespace to write the audit log file to.
+	// If unset, the file is written to an emptyDir volume, which is deleted
+	// with the reconciler Pod.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+
+	// maxSizeMB is the size in megabytes at which the audit log file is
+	// rotated. Default: 10.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxSizeMB int `json:"maxSizeMB,omitempty"`
+
+	// maxBackups is the number of rotated audit log files to keep.
+	// Default: 5.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBackups int `json:"maxBackups,omitempty"`
+}
+
+// PrometheusMetrics configures the /metrics endpoint of the reconciler.
+type PrometheusMetrics struct {
+	// port is the port of the /metrics endpoint. It's named prom-metrics,
+	// which is the port scraped by the Config Sync PodMonitors.
+	// Default: 9464.
+	// +kubebuilder:validation:Minimum=1024
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// ConfigMapReference references a ConfigMap in the same namespace as the
+// RootSync/RepoSync.
+type ConfigMapReference struct {
+	// name is the name of the ConfigMap. Required.
+	Name string `json:"name"`
+}
+
+// ClusterLabelsSource identifies a cluster-local object whose labels are
+// matched by ClusterSelectors.
+type ClusterLabelsSource struct {
+	// kind is the kind of the object. Required.
+	// Must be "ConfigMap" or "Membership".
+	// "ConfigMap" uses the data of the ConfigMap named by name, in the
+	// config-management-system namespace, as the cluster labels.
+	// "Membership" uses the labels of the hub Membership of the cluster,
+	// which exists when the cluster is registered to a fleet.
+	//
+	// +kubebuilder:validation:Enum=ConfigMap;Membership
+	Kind configsync.ClusterLabelsSourceKind `json:"kind"`
+
+	// name is the name of the ConfigMap. Required when kind is "ConfigMap".
+	// +optional
+	Name string `json:"name,omitempty"`
+}
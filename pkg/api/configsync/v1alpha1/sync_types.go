@@ -71,6 +71,18 @@ type SourceStatus struct {
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
+	// commitTime is the time of the commit: the committer time of the Git
+	// commit, the creation time of the OCI image, or the publish time of the
+	// Helm chart version. Unset if unknown.
+	// +optional
+	CommitTime *metav1.Time `json:"commitTime,omitempty"`
+
+	// lastFetchTime is the last time the source of truth was fetched
+	// successfully. It is refreshed at least every 5 minutes while the fetches
+	// succeed.
+	// +optional
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
+
 	// lastUpdate is the timestamp of when this status was last updated by a
 	// reconciler.
 	// +nullable
@@ -142,6 +154,12 @@ type SyncStatus struct {
 	// +optional
 	Commit string `json:"commit,omitempty"`
 
+	// commitTime is the time of the commit indicated by Commit: the committer
+	// time of the Git commit, the creation time of the OCI image, or the
+	// publish time of the Helm chart version. Unset if unknown.
+	// +optional
+	CommitTime *metav1.Time `json:"commitTime,omitempty"`
+
 	// lastUpdate is the timestamp of when this status was last updated by a
 	// reconciler.
 	// +nullable
@@ -156,6 +174,11 @@ type SyncStatus struct {
 	// errorSummary summarizes the errors encountered during the process of syncing the resources.
 	// +optional
 	ErrorSummary *ErrorSummary `json:"errorSummary,omitempty"`
+
+	// hooks is a list of the hooks run for the change indicated by Commit, in
+	// the order they ran.
+	// +optional
+	Hooks []HookStatus `json:"hooks,omitempty"`
 }
 
 // GitStatus describes the status of a Git source of truth.
@@ -196,6 +219,37 @@ type HelmStatus struct {
 	Chart string `json:"chart"`
 }
 
+// HookStatus describes the status of a hook run for a source commit.
+type HookStatus struct {
+	// phase is the value of the configsync.gke.io/hook annotation of the hook:
+	// pre-sync, post-sync or sync-fail.
+	Phase string `json:"phase"`
+
+	// group is the API group of the hook object.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// kind is the kind of the hook object.
+	Kind string `json:"kind"`
+
+	// namespace is the namespace of the hook object.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// name is the name of the hook object.
+	Name string `json:"name"`
+
+	// commit is the hash of the source of truth the hook ran for.
+	Commit string `json:"commit"`
+
+	// result is the result of the hook run: Running, Succeeded or Failed.
+	Result string `json:"result"`
+
+	// message describes why the hook failed, if it did.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // ConfigSyncError represents an error that occurs while parsing, applying, or
 // remediating a resource.
 type ConfigSyncError struct {
@@ -23,6 +23,46 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AuditLog)(nil), (*v1beta1.AuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AuditLog_To_v1beta1_AuditLog(a.(*AuditLog), b.(*v1beta1.AuditLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.AuditLog)(nil), (*AuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditLog_To_v1alpha1_AuditLog(a.(*v1beta1.AuditLog), b.(*AuditLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AuditLogFile)(nil), (*v1beta1.AuditLogFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AuditLogFile_To_v1beta1_AuditLogFile(a.(*AuditLogFile), b.(*v1beta1.AuditLogFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.AuditLogFile)(nil), (*AuditLogFile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AuditLogFile_To_v1alpha1_AuditLogFile(a.(*v1beta1.AuditLogFile), b.(*AuditLogFile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ClusterLabelsSource)(nil), (*v1beta1.ClusterLabelsSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ClusterLabelsSource_To_v1beta1_ClusterLabelsSource(a.(*ClusterLabelsSource), b.(*v1beta1.ClusterLabelsSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ClusterLabelsSource)(nil), (*ClusterLabelsSource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterLabelsSource_To_v1alpha1_ClusterLabelsSource(a.(*v1beta1.ClusterLabelsSource), b.(*ClusterLabelsSource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConfigMapReference)(nil), (*v1beta1.ConfigMapReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ConfigMapReference_To_v1beta1_ConfigMapReference(a.(*ConfigMapReference), b.(*v1beta1.ConfigMapReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ConfigMapReference)(nil), (*ConfigMapReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ConfigMapReference_To_v1alpha1_ConfigMapReference(a.(*v1beta1.ConfigMapReference), b.(*ConfigMapReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigSyncError)(nil), (*v1beta1.ConfigSyncError)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(a.(*ConfigSyncError), b.(*v1beta1.ConfigSyncError), scope)
 	}); err != nil {
@@ -73,6 +113,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GitCommitStatus)(nil), (*v1beta1.GitCommitStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_GitCommitStatus_To_v1beta1_GitCommitStatus(a.(*GitCommitStatus), b.(*v1beta1.GitCommitStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.GitCommitStatus)(nil), (*GitCommitStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GitCommitStatus_To_v1alpha1_GitCommitStatus(a.(*v1beta1.GitCommitStatus), b.(*GitCommitStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GitStatus)(nil), (*v1beta1.GitStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_GitStatus_To_v1beta1_GitStatus(a.(*GitStatus), b.(*v1beta1.GitStatus), scope)
 	}); err != nil {
@@ -113,6 +163,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*HookStatus)(nil), (*v1beta1.HookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus(a.(*HookStatus), b.(*v1beta1.HookStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.HookStatus)(nil), (*HookStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus(a.(*v1beta1.HookStatus), b.(*HookStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NotificationEndpoint)(nil), (*v1beta1.NotificationEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NotificationEndpoint_To_v1beta1_NotificationEndpoint(a.(*NotificationEndpoint), b.(*v1beta1.NotificationEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.NotificationEndpoint)(nil), (*NotificationEndpoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NotificationEndpoint_To_v1alpha1_NotificationEndpoint(a.(*v1beta1.NotificationEndpoint), b.(*NotificationEndpoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ObjectMetrics)(nil), (*v1beta1.ObjectMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ObjectMetrics_To_v1beta1_ObjectMetrics(a.(*ObjectMetrics), b.(*v1beta1.ObjectMetrics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ObjectMetrics)(nil), (*ObjectMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ObjectMetrics_To_v1alpha1_ObjectMetrics(a.(*v1beta1.ObjectMetrics), b.(*ObjectMetrics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ObjectMetricsKind)(nil), (*v1beta1.ObjectMetricsKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ObjectMetricsKind_To_v1beta1_ObjectMetricsKind(a.(*ObjectMetricsKind), b.(*v1beta1.ObjectMetricsKind), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.ObjectMetricsKind)(nil), (*ObjectMetricsKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ObjectMetricsKind_To_v1alpha1_ObjectMetricsKind(a.(*v1beta1.ObjectMetricsKind), b.(*ObjectMetricsKind), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Oci)(nil), (*v1beta1.Oci)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Oci_To_v1beta1_Oci(a.(*Oci), b.(*v1beta1.Oci), scope)
 	}); err != nil {
@@ -143,6 +233,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PrometheusMetrics)(nil), (*v1beta1.PrometheusMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrometheusMetrics_To_v1beta1_PrometheusMetrics(a.(*PrometheusMetrics), b.(*v1beta1.PrometheusMetrics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PrometheusMetrics)(nil), (*PrometheusMetrics)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PrometheusMetrics_To_v1alpha1_PrometheusMetrics(a.(*v1beta1.PrometheusMetrics), b.(*PrometheusMetrics), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrunePolicy)(nil), (*v1beta1.PrunePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(a.(*PrunePolicy), b.(*v1beta1.PrunePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PrunePolicy)(nil), (*PrunePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(a.(*v1beta1.PrunePolicy), b.(*PrunePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PruneProtectedKind)(nil), (*v1beta1.PruneProtectedKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PruneProtectedKind_To_v1beta1_PruneProtectedKind(a.(*PruneProtectedKind), b.(*v1beta1.PruneProtectedKind), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.PruneProtectedKind)(nil), (*PruneProtectedKind)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PruneProtectedKind_To_v1alpha1_PruneProtectedKind(a.(*v1beta1.PruneProtectedKind), b.(*PruneProtectedKind), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RenderingStatus)(nil), (*v1beta1.RenderingStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(a.(*RenderingStatus), b.(*v1beta1.RenderingStatus), scope)
 	}); err != nil {
@@ -356,6 +476,94 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_AuditLog_To_v1beta1_AuditLog(in *AuditLog, out *v1beta1.AuditLog, s conversion.Scope) error {
+	out.File = (*v1beta1.AuditLogFile)(unsafe.Pointer(in.File))
+	out.SinkURL = in.SinkURL
+	return nil
+}
+
+// Convert_v1alpha1_AuditLog_To_v1beta1_AuditLog is an autogenerated conversion function.
+func Convert_v1alpha1_AuditLog_To_v1beta1_AuditLog(in *AuditLog, out *v1beta1.AuditLog, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AuditLog_To_v1beta1_AuditLog(in, out, s)
+}
+
+func autoConvert_v1beta1_AuditLog_To_v1alpha1_AuditLog(in *v1beta1.AuditLog, out *AuditLog, s conversion.Scope) error {
+	out.File = (*AuditLogFile)(unsafe.Pointer(in.File))
+	out.SinkURL = in.SinkURL
+	return nil
+}
+
+// Convert_v1beta1_AuditLog_To_v1alpha1_AuditLog is an autogenerated conversion function.
+func Convert_v1beta1_AuditLog_To_v1alpha1_AuditLog(in *v1beta1.AuditLog, out *AuditLog, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditLog_To_v1alpha1_AuditLog(in, out, s)
+}
+
+func autoConvert_v1alpha1_AuditLogFile_To_v1beta1_AuditLogFile(in *AuditLogFile, out *v1beta1.AuditLogFile, s conversion.Scope) error {
+	out.ClaimName = in.ClaimName
+	out.MaxSizeMB = in.MaxSizeMB
+	out.MaxBackups = in.MaxBackups
+	return nil
+}
+
+// Convert_v1alpha1_AuditLogFile_To_v1beta1_AuditLogFile is an autogenerated conversion function.
+func Convert_v1alpha1_AuditLogFile_To_v1beta1_AuditLogFile(in *AuditLogFile, out *v1beta1.AuditLogFile, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AuditLogFile_To_v1beta1_AuditLogFile(in, out, s)
+}
+
+func autoConvert_v1beta1_AuditLogFile_To_v1alpha1_AuditLogFile(in *v1beta1.AuditLogFile, out *AuditLogFile, s conversion.Scope) error {
+	out.ClaimName = in.ClaimName
+	out.MaxSizeMB = in.MaxSizeMB
+	out.MaxBackups = in.MaxBackups
+	return nil
+}
+
+// Convert_v1beta1_AuditLogFile_To_v1alpha1_AuditLogFile is an autogenerated conversion function.
+func Convert_v1beta1_AuditLogFile_To_v1alpha1_AuditLogFile(in *v1beta1.AuditLogFile, out *AuditLogFile, s conversion.Scope) error {
+	return autoConvert_v1beta1_AuditLogFile_To_v1alpha1_AuditLogFile(in, out, s)
+}
+
+func autoConvert_v1alpha1_ClusterLabelsSource_To_v1beta1_ClusterLabelsSource(in *ClusterLabelsSource, out *v1beta1.ClusterLabelsSource, s conversion.Scope) error {
+	out.Kind = configsync.ClusterLabelsSourceKind(in.Kind)
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_ClusterLabelsSource_To_v1beta1_ClusterLabelsSource is an autogenerated conversion function.
+func Convert_v1alpha1_ClusterLabelsSource_To_v1beta1_ClusterLabelsSource(in *ClusterLabelsSource, out *v1beta1.ClusterLabelsSource, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ClusterLabelsSource_To_v1beta1_ClusterLabelsSource(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterLabelsSource_To_v1alpha1_ClusterLabelsSource(in *v1beta1.ClusterLabelsSource, out *ClusterLabelsSource, s conversion.Scope) error {
+	out.Kind = configsync.ClusterLabelsSourceKind(in.Kind)
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ClusterLabelsSource_To_v1alpha1_ClusterLabelsSource is an autogenerated conversion function.
+func Convert_v1beta1_ClusterLabelsSource_To_v1alpha1_ClusterLabelsSource(in *v1beta1.ClusterLabelsSource, out *ClusterLabelsSource, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterLabelsSource_To_v1alpha1_ClusterLabelsSource(in, out, s)
+}
+
+func autoConvert_v1alpha1_ConfigMapReference_To_v1beta1_ConfigMapReference(in *ConfigMapReference, out *v1beta1.ConfigMapReference, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_ConfigMapReference_To_v1beta1_ConfigMapReference is an autogenerated conversion function.
+func Convert_v1alpha1_ConfigMapReference_To_v1beta1_ConfigMapReference(in *ConfigMapReference, out *v1beta1.ConfigMapReference, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ConfigMapReference_To_v1beta1_ConfigMapReference(in, out, s)
+}
+
+func autoConvert_v1beta1_ConfigMapReference_To_v1alpha1_ConfigMapReference(in *v1beta1.ConfigMapReference, out *ConfigMapReference, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ConfigMapReference_To_v1alpha1_ConfigMapReference is an autogenerated conversion function.
+func Convert_v1beta1_ConfigMapReference_To_v1alpha1_ConfigMapReference(in *v1beta1.ConfigMapReference, out *ConfigMapReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConfigMapReference_To_v1alpha1_ConfigMapReference(in, out, s)
+}
+
 func autoConvert_v1alpha1_ConfigSyncError_To_v1beta1_ConfigSyncError(in *ConfigSyncError, out *v1beta1.ConfigSyncError, s conversion.Scope) error {
 	out.Code = in.Code
 	out.ErrorMessage = in.ErrorMessage
@@ -466,6 +674,7 @@ func autoConvert_v1alpha1_Git_To_v1beta1_Git(in *Git, out *v1beta1.Git, s conver
 	out.SecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*v1beta1.SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.CommitStatus = (*v1beta1.GitCommitStatus)(unsafe.Pointer(in.CommitStatus))
 	return nil
 }
 
@@ -486,6 +695,7 @@ func autoConvert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conver
 	out.SecretRef = (*SecretReference)(unsafe.Pointer(in.SecretRef))
 	out.NoSSLVerify = in.NoSSLVerify
 	out.CACertSecretRef = (*SecretReference)(unsafe.Pointer(in.CACertSecretRef))
+	out.CommitStatus = (*GitCommitStatus)(unsafe.Pointer(in.CommitStatus))
 	return nil
 }
 
@@ -494,6 +704,30 @@ func Convert_v1beta1_Git_To_v1alpha1_Git(in *v1beta1.Git, out *Git, s conversion
 	return autoConvert_v1beta1_Git_To_v1alpha1_Git(in, out, s)
 }
 
+func autoConvert_v1alpha1_GitCommitStatus_To_v1beta1_GitCommitStatus(in *GitCommitStatus, out *v1beta1.GitCommitStatus, s conversion.Scope) error {
+	out.Provider = configsync.GitProvider(in.Provider)
+	out.APIURL = in.APIURL
+	out.TargetURL = in.TargetURL
+	return nil
+}
+
+// Convert_v1alpha1_GitCommitStatus_To_v1beta1_GitCommitStatus is an autogenerated conversion function.
+func Convert_v1alpha1_GitCommitStatus_To_v1beta1_GitCommitStatus(in *GitCommitStatus, out *v1beta1.GitCommitStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_GitCommitStatus_To_v1beta1_GitCommitStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_GitCommitStatus_To_v1alpha1_GitCommitStatus(in *v1beta1.GitCommitStatus, out *GitCommitStatus, s conversion.Scope) error {
+	out.Provider = configsync.GitProvider(in.Provider)
+	out.APIURL = in.APIURL
+	out.TargetURL = in.TargetURL
+	return nil
+}
+
+// Convert_v1beta1_GitCommitStatus_To_v1alpha1_GitCommitStatus is an autogenerated conversion function.
+func Convert_v1beta1_GitCommitStatus_To_v1alpha1_GitCommitStatus(in *v1beta1.GitCommitStatus, out *GitCommitStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_GitCommitStatus_To_v1alpha1_GitCommitStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_GitStatus_To_v1beta1_GitStatus(in *GitStatus, out *v1beta1.GitStatus, s conversion.Scope) error {
 	out.Repo = in.Repo
 	out.Revision = in.Revision
@@ -628,6 +862,110 @@ func Convert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in *v1beta1.HelmStatus, o
 	return autoConvert_v1beta1_HelmStatus_To_v1alpha1_HelmStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in *HookStatus, out *v1beta1.HookStatus, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Commit = in.Commit
+	out.Result = in.Result
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus is an autogenerated conversion function.
+func Convert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in *HookStatus, out *v1beta1.HookStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_HookStatus_To_v1beta1_HookStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in *v1beta1.HookStatus, out *HookStatus, s conversion.Scope) error {
+	out.Phase = in.Phase
+	out.Group = in.Group
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	out.Commit = in.Commit
+	out.Result = in.Result
+	out.Message = in.Message
+	return nil
+}
+
+// Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus is an autogenerated conversion function.
+func Convert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in *v1beta1.HookStatus, out *HookStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_HookStatus_To_v1alpha1_HookStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_NotificationEndpoint_To_v1beta1_NotificationEndpoint(in *NotificationEndpoint, out *v1beta1.NotificationEndpoint, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.Format = configsync.NotificationFormat(in.Format)
+	out.Triggers = *(*[]configsync.NotificationTrigger)(unsafe.Pointer(&in.Triggers))
+	return nil
+}
+
+// Convert_v1alpha1_NotificationEndpoint_To_v1beta1_NotificationEndpoint is an autogenerated conversion function.
+func Convert_v1alpha1_NotificationEndpoint_To_v1beta1_NotificationEndpoint(in *NotificationEndpoint, out *v1beta1.NotificationEndpoint, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NotificationEndpoint_To_v1beta1_NotificationEndpoint(in, out, s)
+}
+
+func autoConvert_v1beta1_NotificationEndpoint_To_v1alpha1_NotificationEndpoint(in *v1beta1.NotificationEndpoint, out *NotificationEndpoint, s conversion.Scope) error {
+	out.Name = in.Name
+	out.URL = in.URL
+	out.Format = configsync.NotificationFormat(in.Format)
+	out.Triggers = *(*[]configsync.NotificationTrigger)(unsafe.Pointer(&in.Triggers))
+	return nil
+}
+
+// Convert_v1beta1_NotificationEndpoint_To_v1alpha1_NotificationEndpoint is an autogenerated conversion function.
+func Convert_v1beta1_NotificationEndpoint_To_v1alpha1_NotificationEndpoint(in *v1beta1.NotificationEndpoint, out *NotificationEndpoint, s conversion.Scope) error {
+	return autoConvert_v1beta1_NotificationEndpoint_To_v1alpha1_NotificationEndpoint(in, out, s)
+}
+
+func autoConvert_v1alpha1_ObjectMetrics_To_v1beta1_ObjectMetrics(in *ObjectMetrics, out *v1beta1.ObjectMetrics, s conversion.Scope) error {
+	out.Kinds = *(*[]v1beta1.ObjectMetricsKind)(unsafe.Pointer(&in.Kinds))
+	out.IncludeNamespace = in.IncludeNamespace
+	return nil
+}
+
+// Convert_v1alpha1_ObjectMetrics_To_v1beta1_ObjectMetrics is an autogenerated conversion function.
+func Convert_v1alpha1_ObjectMetrics_To_v1beta1_ObjectMetrics(in *ObjectMetrics, out *v1beta1.ObjectMetrics, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ObjectMetrics_To_v1beta1_ObjectMetrics(in, out, s)
+}
+
+func autoConvert_v1beta1_ObjectMetrics_To_v1alpha1_ObjectMetrics(in *v1beta1.ObjectMetrics, out *ObjectMetrics, s conversion.Scope) error {
+	out.Kinds = *(*[]ObjectMetricsKind)(unsafe.Pointer(&in.Kinds))
+	out.IncludeNamespace = in.IncludeNamespace
+	return nil
+}
+
+// Convert_v1beta1_ObjectMetrics_To_v1alpha1_ObjectMetrics is an autogenerated conversion function.
+func Convert_v1beta1_ObjectMetrics_To_v1alpha1_ObjectMetrics(in *v1beta1.ObjectMetrics, out *ObjectMetrics, s conversion.Scope) error {
+	return autoConvert_v1beta1_ObjectMetrics_To_v1alpha1_ObjectMetrics(in, out, s)
+}
+
+func autoConvert_v1alpha1_ObjectMetricsKind_To_v1beta1_ObjectMetricsKind(in *ObjectMetricsKind, out *v1beta1.ObjectMetricsKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1alpha1_ObjectMetricsKind_To_v1beta1_ObjectMetricsKind is an autogenerated conversion function.
+func Convert_v1alpha1_ObjectMetricsKind_To_v1beta1_ObjectMetricsKind(in *ObjectMetricsKind, out *v1beta1.ObjectMetricsKind, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ObjectMetricsKind_To_v1beta1_ObjectMetricsKind(in, out, s)
+}
+
+func autoConvert_v1beta1_ObjectMetricsKind_To_v1alpha1_ObjectMetricsKind(in *v1beta1.ObjectMetricsKind, out *ObjectMetricsKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1beta1_ObjectMetricsKind_To_v1alpha1_ObjectMetricsKind is an autogenerated conversion function.
+func Convert_v1beta1_ObjectMetricsKind_To_v1alpha1_ObjectMetricsKind(in *v1beta1.ObjectMetricsKind, out *ObjectMetricsKind, s conversion.Scope) error {
+	return autoConvert_v1beta1_ObjectMetricsKind_To_v1alpha1_ObjectMetricsKind(in, out, s)
+}
+
 func autoConvert_v1alpha1_Oci_To_v1beta1_Oci(in *Oci, out *v1beta1.Oci, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Dir = in.Dir
@@ -688,6 +1026,13 @@ func autoConvert_v1alpha1_OverrideSpec_To_v1beta1_OverrideSpec(in *OverrideSpec,
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]v1beta1.ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.LogFormat = configsync.LogFormat(in.LogFormat)
+	out.PrunePolicy = (*v1beta1.PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
+	out.SubstitutionConfigMapRef = (*v1beta1.ConfigMapReference)(unsafe.Pointer(in.SubstitutionConfigMapRef))
+	out.ObjectMetrics = (*v1beta1.ObjectMetrics)(unsafe.Pointer(in.ObjectMetrics))
+	out.Notifications = *(*[]v1beta1.NotificationEndpoint)(unsafe.Pointer(&in.Notifications))
+	out.AuditLog = (*v1beta1.AuditLog)(unsafe.Pointer(in.AuditLog))
+	out.PrometheusMetrics = (*v1beta1.PrometheusMetrics)(unsafe.Pointer(in.PrometheusMetrics))
 	return nil
 }
 
@@ -704,6 +1049,13 @@ func autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.Overr
 	out.APIServerTimeout = (*metav1.Duration)(unsafe.Pointer(in.APIServerTimeout))
 	out.EnableShellInRendering = (*bool)(unsafe.Pointer(in.EnableShellInRendering))
 	out.LogLevels = *(*[]ContainerLogLevelOverride)(unsafe.Pointer(&in.LogLevels))
+	out.LogFormat = configsync.LogFormat(in.LogFormat)
+	out.PrunePolicy = (*PrunePolicy)(unsafe.Pointer(in.PrunePolicy))
+	out.SubstitutionConfigMapRef = (*ConfigMapReference)(unsafe.Pointer(in.SubstitutionConfigMapRef))
+	out.ObjectMetrics = (*ObjectMetrics)(unsafe.Pointer(in.ObjectMetrics))
+	out.Notifications = *(*[]NotificationEndpoint)(unsafe.Pointer(&in.Notifications))
+	out.AuditLog = (*AuditLog)(unsafe.Pointer(in.AuditLog))
+	out.PrometheusMetrics = (*PrometheusMetrics)(unsafe.Pointer(in.PrometheusMetrics))
 	return nil
 }
 
@@ -712,6 +1064,74 @@ func Convert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in *v1beta1.OverrideS
 	return autoConvert_v1beta1_OverrideSpec_To_v1alpha1_OverrideSpec(in, out, s)
 }
 
+func autoConvert_v1alpha1_PrometheusMetrics_To_v1beta1_PrometheusMetrics(in *PrometheusMetrics, out *v1beta1.PrometheusMetrics, s conversion.Scope) error {
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1alpha1_PrometheusMetrics_To_v1beta1_PrometheusMetrics is an autogenerated conversion function.
+func Convert_v1alpha1_PrometheusMetrics_To_v1beta1_PrometheusMetrics(in *PrometheusMetrics, out *v1beta1.PrometheusMetrics, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrometheusMetrics_To_v1beta1_PrometheusMetrics(in, out, s)
+}
+
+func autoConvert_v1beta1_PrometheusMetrics_To_v1alpha1_PrometheusMetrics(in *v1beta1.PrometheusMetrics, out *PrometheusMetrics, s conversion.Scope) error {
+	out.Port = in.Port
+	return nil
+}
+
+// Convert_v1beta1_PrometheusMetrics_To_v1alpha1_PrometheusMetrics is an autogenerated conversion function.
+func Convert_v1beta1_PrometheusMetrics_To_v1alpha1_PrometheusMetrics(in *v1beta1.PrometheusMetrics, out *PrometheusMetrics, s conversion.Scope) error {
+	return autoConvert_v1beta1_PrometheusMetrics_To_v1alpha1_PrometheusMetrics(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in *PrunePolicy, out *v1beta1.PrunePolicy, s conversion.Scope) error {
+	out.MaxPruneCount = (*int64)(unsafe.Pointer(in.MaxPruneCount))
+	out.MaxPrunePercent = (*int64)(unsafe.Pointer(in.MaxPrunePercent))
+	out.ProtectedKinds = *(*[]v1beta1.PruneProtectedKind)(unsafe.Pointer(&in.ProtectedKinds))
+	out.ConfirmedCommit = in.ConfirmedCommit
+	return nil
+}
+
+// Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy is an autogenerated conversion function.
+func Convert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in *PrunePolicy, out *v1beta1.PrunePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrunePolicy_To_v1beta1_PrunePolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in *v1beta1.PrunePolicy, out *PrunePolicy, s conversion.Scope) error {
+	out.MaxPruneCount = (*int64)(unsafe.Pointer(in.MaxPruneCount))
+	out.MaxPrunePercent = (*int64)(unsafe.Pointer(in.MaxPrunePercent))
+	out.ProtectedKinds = *(*[]PruneProtectedKind)(unsafe.Pointer(&in.ProtectedKinds))
+	out.ConfirmedCommit = in.ConfirmedCommit
+	return nil
+}
+
+// Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy is an autogenerated conversion function.
+func Convert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in *v1beta1.PrunePolicy, out *PrunePolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PrunePolicy_To_v1alpha1_PrunePolicy(in, out, s)
+}
+
+func autoConvert_v1alpha1_PruneProtectedKind_To_v1beta1_PruneProtectedKind(in *PruneProtectedKind, out *v1beta1.PruneProtectedKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1alpha1_PruneProtectedKind_To_v1beta1_PruneProtectedKind is an autogenerated conversion function.
+func Convert_v1alpha1_PruneProtectedKind_To_v1beta1_PruneProtectedKind(in *PruneProtectedKind, out *v1beta1.PruneProtectedKind, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PruneProtectedKind_To_v1beta1_PruneProtectedKind(in, out, s)
+}
+
+func autoConvert_v1beta1_PruneProtectedKind_To_v1alpha1_PruneProtectedKind(in *v1beta1.PruneProtectedKind, out *PruneProtectedKind, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Kind = in.Kind
+	return nil
+}
+
+// Convert_v1beta1_PruneProtectedKind_To_v1alpha1_PruneProtectedKind is an autogenerated conversion function.
+func Convert_v1beta1_PruneProtectedKind_To_v1alpha1_PruneProtectedKind(in *v1beta1.PruneProtectedKind, out *PruneProtectedKind, s conversion.Scope) error {
+	return autoConvert_v1beta1_PruneProtectedKind_To_v1alpha1_PruneProtectedKind(in, out, s)
+}
+
 func autoConvert_v1alpha1_RenderingStatus_To_v1beta1_RenderingStatus(in *RenderingStatus, out *v1beta1.RenderingStatus, s conversion.Scope) error {
 	out.Git = (*v1beta1.GitStatus)(unsafe.Pointer(in.Git))
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
@@ -1098,6 +1518,7 @@ func autoConvert_v1alpha1_RootSyncOverrideSpec_To_v1beta1_RootSyncOverrideSpec(i
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
 	out.RoleRefs = *(*[]v1beta1.RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
+	out.ClusterLabelsSource = (*v1beta1.ClusterLabelsSource)(unsafe.Pointer(in.ClusterLabelsSource))
 	return nil
 }
 
@@ -1112,6 +1533,7 @@ func autoConvert_v1beta1_RootSyncOverrideSpec_To_v1alpha1_RootSyncOverrideSpec(i
 	}
 	out.NamespaceStrategy = configsync.NamespaceStrategy(in.NamespaceStrategy)
 	out.RoleRefs = *(*[]RootSyncRoleRef)(unsafe.Pointer(&in.RoleRefs))
+	out.ClusterLabelsSource = (*ClusterLabelsSource)(unsafe.Pointer(in.ClusterLabelsSource))
 	return nil
 }
 
@@ -1241,6 +1663,8 @@ func autoConvert_v1alpha1_SourceStatus_To_v1beta1_SourceStatus(in *SourceStatus,
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
+	out.CommitTime = (*metav1.Time)(unsafe.Pointer(in.CommitTime))
+	out.LastFetchTime = (*metav1.Time)(unsafe.Pointer(in.LastFetchTime))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
@@ -1257,6 +1681,8 @@ func autoConvert_v1beta1_SourceStatus_To_v1alpha1_SourceStatus(in *v1beta1.Sourc
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
+	out.CommitTime = (*metav1.Time)(unsafe.Pointer(in.CommitTime))
+	out.LastFetchTime = (*metav1.Time)(unsafe.Pointer(in.LastFetchTime))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
@@ -1315,9 +1741,11 @@ func autoConvert_v1alpha1_SyncStatus_To_v1beta1_SyncStatus(in *SyncStatus, out *
 	out.Oci = (*v1beta1.OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*v1beta1.HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
+	out.CommitTime = (*metav1.Time)(unsafe.Pointer(in.CommitTime))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]v1beta1.ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*v1beta1.ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Hooks = *(*[]v1beta1.HookStatus)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -1331,9 +1759,11 @@ func autoConvert_v1beta1_SyncStatus_To_v1alpha1_SyncStatus(in *v1beta1.SyncStatu
 	out.Oci = (*OciStatus)(unsafe.Pointer(in.Oci))
 	out.Helm = (*HelmStatus)(unsafe.Pointer(in.Helm))
 	out.Commit = in.Commit
+	out.CommitTime = (*metav1.Time)(unsafe.Pointer(in.CommitTime))
 	out.LastUpdate = in.LastUpdate
 	out.Errors = *(*[]ConfigSyncError)(unsafe.Pointer(&in.Errors))
 	out.ErrorSummary = (*ErrorSummary)(unsafe.Pointer(in.ErrorSummary))
+	out.Hooks = *(*[]HookStatus)(unsafe.Pointer(&in.Hooks))
 	return nil
 }
 
@@ -9,8 +9,78 @@ import (
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	configsync "kpt.dev/configsync/pkg/api/configsync"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(AuditLogFile)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogFile) DeepCopyInto(out *AuditLogFile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogFile.
+func (in *AuditLogFile) DeepCopy() *AuditLogFile {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLabelsSource) DeepCopyInto(out *ClusterLabelsSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLabelsSource.
+func (in *ClusterLabelsSource) DeepCopy() *ClusterLabelsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLabelsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSyncError) DeepCopyInto(out *ConfigSyncError) {
 	*out = *in
@@ -98,6 +168,11 @@ func (in *Git) DeepCopyInto(out *Git) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.CommitStatus != nil {
+		in, out := &in.CommitStatus, &out.CommitStatus
+		*out = new(GitCommitStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -111,6 +186,22 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommitStatus) DeepCopyInto(out *GitCommitStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommitStatus.
+func (in *GitCommitStatus) DeepCopy() *GitCommitStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommitStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitStatus) DeepCopyInto(out *GitStatus) {
 	*out = *in
@@ -214,6 +305,80 @@ func (in *HelmStatus) DeepCopy() *HelmStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationEndpoint) DeepCopyInto(out *NotificationEndpoint) {
+	*out = *in
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]configsync.NotificationTrigger, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEndpoint.
+func (in *NotificationEndpoint) DeepCopy() *NotificationEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetrics) DeepCopyInto(out *ObjectMetrics) {
+	*out = *in
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]ObjectMetricsKind, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetrics.
+func (in *ObjectMetrics) DeepCopy() *ObjectMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectMetricsKind) DeepCopyInto(out *ObjectMetricsKind) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectMetricsKind.
+func (in *ObjectMetricsKind) DeepCopy() *ObjectMetricsKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectMetricsKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Oci) DeepCopyInto(out *Oci) {
 	*out = *in
@@ -287,6 +452,38 @@ func (in *OverrideSpec) DeepCopyInto(out *OverrideSpec) {
 		*out = make([]ContainerLogLevelOverride, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrunePolicy != nil {
+		in, out := &in.PrunePolicy, &out.PrunePolicy
+		*out = new(PrunePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubstitutionConfigMapRef != nil {
+		in, out := &in.SubstitutionConfigMapRef, &out.SubstitutionConfigMapRef
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.ObjectMetrics != nil {
+		in, out := &in.ObjectMetrics, &out.ObjectMetrics
+		*out = new(ObjectMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusMetrics != nil {
+		in, out := &in.PrometheusMetrics, &out.PrometheusMetrics
+		*out = new(PrometheusMetrics)
+		**out = **in
+	}
 	return
 }
 
@@ -300,6 +497,69 @@ func (in *OverrideSpec) DeepCopy() *OverrideSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMetrics) DeepCopyInto(out *PrometheusMetrics) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMetrics.
+func (in *PrometheusMetrics) DeepCopy() *PrometheusMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrunePolicy) DeepCopyInto(out *PrunePolicy) {
+	*out = *in
+	if in.MaxPruneCount != nil {
+		in, out := &in.MaxPruneCount, &out.MaxPruneCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPrunePercent != nil {
+		in, out := &in.MaxPrunePercent, &out.MaxPrunePercent
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProtectedKinds != nil {
+		in, out := &in.ProtectedKinds, &out.ProtectedKinds
+		*out = make([]PruneProtectedKind, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrunePolicy.
+func (in *PrunePolicy) DeepCopy() *PrunePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PrunePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneProtectedKind) DeepCopyInto(out *PruneProtectedKind) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneProtectedKind.
+func (in *PruneProtectedKind) DeepCopy() *PruneProtectedKind {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneProtectedKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderingStatus) DeepCopyInto(out *RenderingStatus) {
 	*out = *in
@@ -639,6 +899,11 @@ func (in *RootSyncOverrideSpec) DeepCopyInto(out *RootSyncOverrideSpec) {
 		*out = make([]RootSyncRoleRef, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterLabelsSource != nil {
+		in, out := &in.ClusterLabelsSource, &out.ClusterLabelsSource
+		*out = new(ClusterLabelsSource)
+		**out = **in
+	}
 	return
 }
 
@@ -762,6 +1027,14 @@ func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 		*out = new(HelmStatus)
 		**out = **in
 	}
+	if in.CommitTime != nil {
+		in, out := &in.CommitTime, &out.CommitTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFetchTime != nil {
+		in, out := &in.LastFetchTime, &out.LastFetchTime
+		*out = (*in).DeepCopy()
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
@@ -825,6 +1098,10 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(HelmStatus)
 		**out = **in
 	}
+	if in.CommitTime != nil {
+		in, out := &in.CommitTime, &out.CommitTime
+		*out = (*in).DeepCopy()
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 	if in.Errors != nil {
 		in, out := &in.Errors, &out.Errors
@@ -838,6 +1115,11 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = new(ErrorSummary)
 		**out = **in
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/adopt"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCacheAdoptedObjects(t *testing.T) {
+	syncName := "root-sync"
+	syncNamespace := configmanagement.ControllerNamespace
+	declaredObj := k8sobjects.UnstructuredObject(kinds.Namespace(), core.Name("adopted-ns"),
+		core.Label("team", "shop"),
+		core.Annotation(metadata.SyncTokenAnnotationKey, "example-commit"),
+		metadata.WithManagementMode(metadata.ManagementEnabled))
+	hash, err := adopt.Hash(declaredObj)
+	require.NoError(t, err)
+	resourceID := core.GKNN(declaredObj)
+
+	testCases := []struct {
+		name              string
+		adopted           map[string]string
+		wantIgnoredHashes []string
+		wantAdopted       map[string]string
+	}{
+		{
+			name:              "declared state unchanged",
+			adopted:           map[string]string{resourceID: hash},
+			wantIgnoredHashes: []string{hash},
+			wantAdopted:       map[string]string{resourceID: hash},
+		},
+		{
+			name:        "declared state changed",
+			adopted:     map[string]string{resourceID: "previous-hash"},
+			wantAdopted: map[string]string{},
+		},
+		{
+			name:              "adopted object no longer declared",
+			adopted:           map[string]string{resourceID: hash, "_namespace_removed-ns": hash},
+			wantIgnoredHashes: []string{hash},
+			wantAdopted:       map[string]string{resourceID: hash},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := newInventoryUnstructured(syncName, syncNamespace)
+			require.NoError(t, adopt.SetAdoptedObjects(inv, tc.adopted))
+			liveObj := k8sobjects.NamespaceObject("adopted-ns", core.Label("team", "legacy"))
+			fakeClient := testingfake.NewClient(t, core.Scheme, inv, liveObj)
+			cs := &ClientSet{Client: fakeClient, Mapper: fakeClient.RESTMapper()}
+			s := NewSupervisor(cs, declared.RootScope, syncName, 5*time.Minute, declared.PrunePolicy{}).(*supervisor)
+
+			resources := &declared.Resources{}
+			_, err := resources.UpdateDeclared(context.Background(), []client.Object{declaredObj}, "example-commit")
+			require.NoError(t, err)
+			require.NoError(t, s.cacheAdoptedObjects(context.Background(), resources))
+
+			var ignoredHashes []string
+			for _, obj := range resources.IgnoredObjects() {
+				assert.Equal(t, "legacy", obj.GetLabels()["team"])
+				ignoredHashes = append(ignoredHashes, obj.GetAnnotations()[metadata.AdoptedHashAnnotationKey])
+			}
+			assert.Equal(t, tc.wantIgnoredHashes, ignoredHashes)
+
+			gotInv := newInventoryUnstructured(syncName, syncNamespace)
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(gotInv), gotInv))
+			adopted, adoptErr := adopt.AdoptedObjects(gotInv)
+			require.NoError(t, adoptErr)
+			assert.Equal(t, tc.wantAdopted, adopted)
+		})
+	}
+}
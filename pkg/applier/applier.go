@@ -21,6 +21,8 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,8 +30,11 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/adopt"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/applier/stats"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
@@ -39,8 +44,10 @@ import (
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/differ"
 	"kpt.dev/configsync/pkg/syncer/metrics"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util"
 	nomosutil "kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/log"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply"
 	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
@@ -51,6 +58,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// keyAction tags action group spans with their action.
+// Possible values: Apply, Prune, Delete, Wait, Inventory.
+var keyAction = attribute.Key("configsync.applier.action")
+
 var (
 	// maxRequestBytesStr defines the max request bytes on the etcd server.
 	// It is defined in https://github.com/etcd-io/etcd/blob/release-3.4/embed/config.go#L56
@@ -141,6 +152,11 @@ type supervisor struct {
 	syncNamespace string
 	// reconcileTimeout controls the reconcile and prune timeout
 	reconcileTimeout time.Duration
+	// prunePolicy limits which managed objects may be pruned, and how many
+	// may be pruned in a single sync
+	prunePolicy declared.PrunePolicy
+	// clock is used to time the actuation and reconciliation of objects
+	clock clock.PassiveClock
 
 	// execMux prevents concurrent Apply/Destroy calls
 	execMux sync.Mutex
@@ -152,7 +168,7 @@ var _ Supervisor = &supervisor{}
 
 // NewSupervisor constructs either a cluster-level or namespace-level Supervisor,
 // based on the specified scope.
-func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration) Supervisor {
+func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconcileTimeout time.Duration, prunePolicy declared.PrunePolicy) Supervisor {
 	syncKind := scope.SyncKind()
 	syncNamespace := scope.SyncNamespace()
 	invInfo := inventory.NewSingleObjectInfo(
@@ -174,6 +190,8 @@ func NewSupervisor(cs *ClientSet, scope declared.Scope, syncName string, reconci
 		syncName:         syncName,
 		syncNamespace:    syncNamespace,
 		reconcileTimeout: reconcileTimeout,
+		prunePolicy:      prunePolicy,
+		clock:            clock.RealClock{},
 	}
 	klog.V(4).Infof("%s Supervisor %s/%s is initialized", syncKind, syncNamespace, syncName)
 	return a
@@ -226,11 +244,15 @@ func (s *supervisor) processApplyEvent(ctx context.Context, e event.ApplyEvent,
 	case event.ApplySuccessful:
 		objectStatus.Actuation = actuation.ActuationSucceeded
 		handleMetrics(ctx, "update", e.Error)
+		// The declared object is recorded, since the object that cli-utils
+		// applies, with its owning-inventory annotation, isn't in the event.
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationApply, id, resourceMap[id], e.Error)
 		return nil
 
 	case event.ApplyFailed:
 		objectStatus.Actuation = actuation.ActuationFailed
 		handleMetrics(ctx, "update", e.Error)
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationApply, id, resourceMap[id], e.Error)
 		switch e.Error.(type) {
 		case *applyerror.UnknownTypeError:
 			unknownTypeResources[id] = struct{}{}
@@ -331,6 +353,7 @@ func (s *supervisor) processPruneEvent(ctx context.Context, e event.PruneEvent,
 	case event.PruneSuccessful:
 		objectStatus.Actuation = actuation.ActuationSucceeded
 		handleMetrics(ctx, "delete", e.Error)
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationDelete, id, nil, e.Error)
 
 		iObj, found := declaredResources.GetIgnored(id)
 		if found {
@@ -343,6 +366,7 @@ func (s *supervisor) processPruneEvent(ctx context.Context, e event.PruneEvent,
 	case event.PruneFailed:
 		objectStatus.Actuation = actuation.ActuationFailed
 		handleMetrics(ctx, "delete", e.Error)
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationDelete, id, nil, e.Error)
 		return PruneErrorForResource(e.Error, id)
 
 	case event.PruneSkipped:
@@ -375,11 +399,13 @@ func (s *supervisor) processDeleteEvent(ctx context.Context, e event.DeleteEvent
 	case event.DeleteSuccessful:
 		objectStatus.Actuation = actuation.ActuationSucceeded
 		handleMetrics(ctx, "delete", e.Error)
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationDelete, id, nil, e.Error)
 		return nil
 
 	case event.DeleteFailed:
 		objectStatus.Actuation = actuation.ActuationFailed
 		handleMetrics(ctx, "delete", e.Error)
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationDelete, id, nil, e.Error)
 		return DeleteErrorForResource(e.Error, id)
 
 	case event.DeleteSkipped:
@@ -465,6 +491,15 @@ func isNamespace(obj *unstructured.Unstructured) bool {
 	return obj.GetObjectKind().GroupVersionKind().GroupKind() == kinds.Namespace().GroupKind()
 }
 
+// auditLog returns the audit log of the writes, or nil if the writes are not
+// audited.
+func (s *supervisor) auditLog() *audit.Logger {
+	if s.clientSet == nil {
+		return nil
+	}
+	return s.clientSet.AuditLog
+}
+
 func handleMetrics(ctx context.Context, operation string, err error) {
 	// TODO capture the apply duration in the kpt apply library.
 	start := time.Now()
@@ -494,6 +529,7 @@ func (s *supervisor) checkInventoryObjectSize(ctx context.Context, c client.Clie
 
 // applyInner triggers a kpt live apply library call to apply a set of resources.
 func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), declaredResources *declared.Resources) (ObjectStatusMap, *stats.SyncStats) {
+	ctx = audit.WithCommit(ctx, declaredResources.Commit())
 	s.checkInventoryObjectSize(ctx, s.clientSet.Client)
 	isDestroy := false
 
@@ -506,6 +542,11 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		return objStatusMap, syncStats
 	}
 
+	if err := s.cacheAdoptedObjects(ctx, declaredResources); err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+
 	if len(declaredResources.IgnoredObjects()) > 0 {
 		klog.Infof("%v mutation-ignored objects: %v", len(declaredResources.IgnoredObjects()), core.GKNNs(declaredResources.IgnoredObjects()))
 	}
@@ -535,6 +576,11 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		return objStatusMap, syncStats
 	}
 
+	if err := s.enforcePrunePolicy(ctx, declaredResources.Commit(), resources); err != nil {
+		sendErrorEvent(err, eventHandler)
+		return objStatusMap, syncStats
+	}
+
 	unknownTypeResources := make(map[core.ID]struct{})
 	options := apply.ApplierOptions{
 		ServerSideOptions: common.ServerSideOptions{
@@ -569,6 +615,9 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 		resourceMap[idFrom(ObjMetaFromUnstructured(obj))] = obj
 	}
 
+	spans := newActionGroupSpans(ctx)
+	defer spans.endAll()
+	timer := newObjectTimer(s.clock)
 	events := s.clientSet.KptApplier.Run(ctx, s.invInfo, resources, options)
 	for e := range events {
 		switch e.Type {
@@ -578,6 +627,8 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			}
 		case event.ActionGroupType:
 			klog.Info(e.ActionGroupEvent)
+			spans.handle(e.ActionGroupEvent)
+			timer.handleActionGroup(e.ActionGroupEvent)
 		case event.ErrorType:
 			klog.Info(e.ErrorEvent)
 			err := e.ErrorEvent.Err
@@ -598,6 +649,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			if err := s.processWaitEvent(e.WaitEvent, syncStats.WaitEvent, objStatusMap, isDestroy); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
+			timer.observeReconcile(idFrom(e.WaitEvent.Identifier), objStatusMap)
 		case event.ApplyType:
 			if e.ApplyEvent.Error != nil {
 				klog.Info(e.ApplyEvent)
@@ -607,6 +659,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			if err := s.processApplyEvent(ctx, e.ApplyEvent, syncStats.ApplyEvent, objStatusMap, unknownTypeResources, resourceMap); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
+			timer.observeActuation(idFrom(e.ApplyEvent.Identifier), objStatusMap)
 		case event.PruneType:
 			if e.PruneEvent.Error != nil {
 				klog.Info(e.PruneEvent)
@@ -616,6 +669,7 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 			if err := s.processPruneEvent(ctx, e.PruneEvent, syncStats.PruneEvent, objStatusMap, declaredResources); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
+			timer.observeActuation(idFrom(e.PruneEvent.Identifier), objStatusMap)
 		default:
 			klog.Infof("Unhandled event (%s): %v", e.Type, e)
 		}
@@ -624,6 +678,42 @@ func (s *supervisor) applyInner(ctx context.Context, eventHandler func(Event), d
 	return objStatusMap, syncStats
 }
 
+// actionGroupSpans traces the action groups run by the kpt applier or
+// destroyer (e.g. apply, prune, wait), from their Started to Finished events.
+type actionGroupSpans struct {
+	ctx   context.Context
+	spans map[string]trace.Span
+}
+
+func newActionGroupSpans(ctx context.Context) *actionGroupSpans {
+	return &actionGroupSpans{
+		ctx:   ctx,
+		spans: make(map[string]trace.Span),
+	}
+}
+
+func (a *actionGroupSpans) handle(e event.ActionGroupEvent) {
+	switch e.Status {
+	case event.Started:
+		_, span := tracing.Start(a.ctx, e.GroupName, keyAction.String(e.Action.String()))
+		a.spans[e.GroupName] = span
+	case event.Finished:
+		if span, found := a.spans[e.GroupName]; found {
+			span.End()
+			delete(a.spans, e.GroupName)
+		}
+	}
+}
+
+// endAll ends the spans of the action groups that did not finish, for example
+// because the run was cancelled or timed out.
+func (a *actionGroupSpans) endAll() {
+	for name, span := range a.spans {
+		span.End()
+		delete(a.spans, name)
+	}
+}
+
 func sendErrorEvent(err error, eventHandler func(Event)) {
 	eventHandler(ErrorEvent{Error: wrapError(err)})
 }
@@ -659,6 +749,9 @@ func (s *supervisor) destroyInner(ctx context.Context, eventHandler func(Event))
 	// This allows for picking up CRD changes.
 	meta.MaybeResetRESTMapper(s.clientSet.Mapper)
 
+	spans := newActionGroupSpans(ctx)
+	defer spans.endAll()
+	timer := newObjectTimer(s.clock)
 	events := s.clientSet.KptDestroyer.Run(ctx, s.invInfo, options)
 	for e := range events {
 		switch e.Type {
@@ -668,6 +761,8 @@ func (s *supervisor) destroyInner(ctx context.Context, eventHandler func(Event))
 			}
 		case event.ActionGroupType:
 			klog.Info(e.ActionGroupEvent)
+			spans.handle(e.ActionGroupEvent)
+			timer.handleActionGroup(e.ActionGroupEvent)
 		case event.ErrorType:
 			klog.Info(e.ErrorEvent)
 			err := e.ErrorEvent.Err
@@ -688,6 +783,7 @@ func (s *supervisor) destroyInner(ctx context.Context, eventHandler func(Event))
 			if err := s.processWaitEvent(e.WaitEvent, syncStats.WaitEvent, objStatusMap, isDestroy); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
+			timer.observeReconcile(idFrom(e.WaitEvent.Identifier), objStatusMap)
 		case event.DeleteType:
 			if e.DeleteEvent.Error != nil {
 				klog.Info(e.DeleteEvent)
@@ -697,6 +793,7 @@ func (s *supervisor) destroyInner(ctx context.Context, eventHandler func(Event))
 			if err := s.processDeleteEvent(ctx, e.DeleteEvent, syncStats.DeleteEvent, objStatusMap); err != nil {
 				sendErrorEvent(err, eventHandler)
 			}
+			timer.observeActuation(idFrom(e.DeleteEvent.Identifier), objStatusMap)
 		default:
 			klog.Infof("Unhandled event (%s): %v", e.Type, e)
 		}
@@ -711,6 +808,8 @@ func (s *supervisor) Apply(ctx context.Context, eventHandler func(Event), resour
 	s.execMux.Lock()
 	defer s.execMux.Unlock()
 
+	ctx, span := tracing.Start(ctx, "Apply")
+	defer span.End()
 	return s.applyInner(ctx, eventHandler, resources)
 }
 
@@ -720,6 +819,8 @@ func (s *supervisor) Destroy(ctx context.Context, eventHandler func(Event)) (Obj
 	s.execMux.Lock()
 	defer s.execMux.Unlock()
 
+	ctx, span := tracing.Start(ctx, "Destroy")
+	defer span.End()
 	return s.destroyInner(ctx, eventHandler)
 }
 
@@ -802,7 +903,7 @@ func (s *supervisor) abandonObject(ctx context.Context, obj client.Object) error
 		}
 		return err
 	}
-	klog.Infof("Abandoning object: %s", core.IDOf(obj))
+	klog.InfoS("Abandoning object", log.KeyObject, core.IDOf(obj).String())
 	if metadata.HasConfigSyncMetadata(uObj) {
 		// Use minimal before & after objects to simplify DeepCopy and building
 		// the merge patch.
@@ -822,8 +923,15 @@ func (s *supervisor) abandonObject(ctx context.Context, obj client.Object) error
 		// Use merge-patch instead of server-side-apply, because we don't have
 		// the object's source of truth handy and don't want to take ownership
 		// of all the fields managed by other clients.
-		return s.clientSet.Client.Patch(ctx, toObj, client.MergeFrom(fromObj),
+		patch := client.MergeFrom(fromObj)
+		patchData, err := patch.Data(toObj)
+		if err != nil {
+			return err
+		}
+		err = s.clientSet.Client.Patch(ctx, toObj, patch,
 			client.FieldOwner(configsync.FieldManager))
+		s.auditLog().Record(ctx, m.ApplierController, audit.OperationPatch, core.IDOf(obj), patchData, err)
+		return err
 	}
 	return nil
 }
@@ -861,3 +969,80 @@ func (s *supervisor) cacheIgnoreMutationObjects(ctx context.Context, declaredRes
 
 	return nil
 }
+
+// cacheAdoptedObjects gets the current cluster state of any declared objects
+// adopted with their current state, and puts it in the Resources ignore objects
+// cache, so that the object is applied with its cluster state, like an object
+// with the ignore mutation annotation.
+// Objects whose declared state changed since they were adopted are removed
+// from the adopted objects of the inventory, so that their declared state is
+// applied.
+func (s *supervisor) cacheAdoptedObjects(ctx context.Context, declaredResources *declared.Resources) error {
+	inv := newInventoryUnstructured(s.syncName, s.syncNamespace)
+	if err := s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(inv), inv); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	adopted, err := adopt.AdoptedObjects(inv)
+	if err != nil {
+		return err
+	}
+	if len(adopted) == 0 {
+		return nil
+	}
+
+	var objsToUpdate []client.Object
+	remaining := make(map[string]string, len(adopted))
+	for _, obj := range declaredResources.DeclaredObjects() {
+		resourceID := core.GKNN(obj)
+		adoptedHash, found := adopted[resourceID]
+		if !found {
+			continue
+		}
+		id := core.IDOf(obj)
+		ignoreMutation := obj.GetAnnotations()[metadata.LifecycleMutationAnnotation] == metadata.IgnoreMutation
+		hash, err := adopt.Hash(obj)
+		if err != nil {
+			return err
+		}
+		if hash != adoptedHash {
+			klog.Infof("Declared state of adopted object %s changed, applying the declared state", resourceID)
+			if !ignoreMutation {
+				declaredResources.DeleteIgnored(id)
+			}
+			continue
+		}
+		remaining[resourceID] = adoptedHash
+		if _, found := declaredResources.GetIgnored(id); found {
+			continue
+		}
+		uObj := &unstructured.Unstructured{}
+		uObj.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+		err = s.clientSet.Client.Get(ctx, client.ObjectKeyFromObject(obj), uObj)
+		if apierrors.IsNotFound(err) {
+			delete(remaining, resourceID)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		// Apply the annotation with the object, so that it is removed by the
+		// applier once the declared state is applied.
+		core.SetAnnotation(uObj, metadata.AdoptedHashAnnotationKey, adoptedHash)
+		objsToUpdate = append(objsToUpdate, uObj)
+	}
+	declaredResources.UpdateIgnored(objsToUpdate...)
+
+	if len(remaining) == len(adopted) {
+		return nil
+	}
+	klog.Infof("%d of %d objects adopted with their current state are kept", len(remaining), len(adopted))
+	existing := inv.DeepCopy()
+	if err := adopt.SetAdoptedObjects(inv, remaining); err != nil {
+		return err
+	}
+	return s.clientSet.Client.Patch(ctx, inv, client.MergeFrom(existing),
+		client.FieldOwner(configsync.FieldManager))
+}
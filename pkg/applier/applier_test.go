@@ -15,9 +15,14 @@
 package applier
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,12 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	fakeclock "k8s.io/utils/clock/testing"
 	"kpt.dev/configsync/pkg/api/configmanagement"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
@@ -377,7 +384,9 @@ func TestApply(t *testing.T) {
 				Mapper:     fakeClient.RESTMapper(),
 				// TODO: Add tests to cover status mode
 			}
-			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, declared.PrunePolicy{})
+			// Stop the clock, so the objects take no time to actuate or reconcile.
+			applier.(*supervisor).clock = fakeclock.NewFakeClock(time.Now())
 
 			var errs status.MultiError
 			eventHandler := func(event Event) {
@@ -626,7 +635,7 @@ func TestApplyMutationIgnoredObjects(t *testing.T) {
 				}
 			}
 
-			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, declared.PrunePolicy{})
 
 			resources := &declared.Resources{}
 			_, err := resources.UpdateDeclared(context.Background(), tc.declaredObjs, "")
@@ -684,7 +693,7 @@ func TestNewSupervisor(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			s := NewSupervisor(nil, tc.scope, tc.syncName, 5*time.Minute)
+			s := NewSupervisor(nil, tc.scope, tc.syncName, 5*time.Minute, declared.PrunePolicy{})
 			ts := s.(*supervisor)
 			require.Equal(t, tc.wantInventoryPolicy, ts.policy)
 			require.Equal(t, tc.wantSyncKind, ts.syncKind)
@@ -757,7 +766,7 @@ func TestUpdateStatusMode(t *testing.T) {
 				StatusMode: tc.newStatusMode,
 			}
 
-			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute)
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, declared.PrunePolicy{})
 
 			err := applier.UpdateStatusMode(context.Background())
 			require.NoError(t, err)
@@ -1142,3 +1151,57 @@ func asUnstructuredSanitizedObj(o client.Object) *unstructured.Unstructured {
 
 	return uObj
 }
+
+func TestAuditLog(t *testing.T) {
+	dir := t.TempDir()
+	auditLog, err := audit.New(v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, audit.Options{
+		Dir:            dir,
+		ReconcilerName: "root-reconciler",
+		SyncKind:       configsync.RootSyncKind,
+		SyncNamespace:  configsync.ControllerNamespace,
+		SyncName:       configsync.RootSyncName,
+	})
+	require.NoError(t, err)
+	deploymentObj := newDeploymentObj()
+	deploymentObjID := core.IDOf(deploymentObj)
+	testObj := newTestObj("test-1")
+
+	ctx := audit.WithCommit(context.Background(), "abc123")
+	syncStats := stats.NewSyncStats()
+	objStatusMap := make(ObjectStatusMap)
+	s := supervisor{
+		clientSet: &ClientSet{AuditLog: auditLog},
+	}
+	resourceMap := map[core.ID]client.Object{deploymentObjID: deploymentObj}
+
+	err = s.processApplyEvent(ctx, formApplyEvent(event.ApplySuccessful, deploymentObj, nil).ApplyEvent, syncStats.ApplyEvent, objStatusMap, nil, resourceMap)
+	require.Nil(t, err)
+	err = s.processPruneEvent(ctx, formPruneEvent(event.PruneFailed, testObj, fmt.Errorf("test error")).PruneEvent, syncStats.PruneEvent, objStatusMap, &declared.Resources{})
+	require.NotNil(t, err)
+	// Pending events are not writes.
+	err = s.processPruneEvent(ctx, formPruneEvent(event.PrunePending, testObj, nil).PruneEvent, syncStats.PruneEvent, objStatusMap, &declared.Resources{})
+	require.Nil(t, err)
+
+	data, readErr := os.ReadFile(filepath.Join(dir, "root-reconciler.log"))
+	require.NoError(t, readErr)
+	result, verifyErr := audit.Verify(bytes.NewReader(data), "")
+	require.NoError(t, verifyErr)
+	require.Equal(t, audit.VerifyResult{Records: 2}, result)
+
+	var records []audit.Record
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		record := audit.Record{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	assert.Equal(t, audit.OperationApply, records[0].Operation)
+	assert.Equal(t, deploymentObjID.String(), records[0].Object)
+	assert.Equal(t, "abc123", records[0].Commit)
+	assert.NotEmpty(t, records[0].DeclaredSHA256)
+	assert.Equal(t, audit.ResultSuccess, records[0].Result)
+	assert.Equal(t, audit.OperationDelete, records[1].Operation)
+	assert.Equal(t, core.IDOf(testObj).String(), records[1].Object)
+	assert.Empty(t, records[1].DeclaredSHA256)
+	assert.Equal(t, audit.ResultFailure, records[1].Result)
+	assert.Equal(t, "test error", records[1].Error)
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/kubectl/pkg/cmd/util"
 	csinventory "kpt.dev/configsync/pkg/applier/inventory"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/metadata"
 	"sigs.k8s.io/cli-utils/pkg/apply"
@@ -54,6 +55,9 @@ type ClientSet struct {
 	Mapper       meta.RESTMapper
 	StatusMode   metadata.StatusMode
 	ApplySetID   string
+	// AuditLog records the writes made to the managed objects. Nil if the
+	// writes are not audited.
+	AuditLog *audit.Logger
 }
 
 // NewClientSet constructs a new ClientSet.
@@ -21,10 +21,12 @@ import (
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakeclock "k8s.io/utils/clock/testing"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/status"
 	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
@@ -255,7 +257,9 @@ func TestDestroy(t *testing.T) {
 				// TODO: Add tests to cover disabling objects
 				// TODO: Add tests to cover status mode
 			}
-			destroyer := NewSupervisor(cs, "test-namespace", "rs", 5*time.Minute)
+			destroyer := NewSupervisor(cs, "test-namespace", "rs", 5*time.Minute, declared.PrunePolicy{})
+			// Stop the clock, so the objects take no time to actuate or reconcile.
+			destroyer.(*supervisor).clock = fakeclock.NewFakeClock(time.Now())
 
 			var errs status.MultiError
 			eventHandler := func(event Event) {
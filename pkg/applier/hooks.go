@@ -0,0 +1,264 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/audit"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metadata"
+	m "kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/status"
+	syncerreconcile "kpt.dev/configsync/pkg/syncer/reconcile"
+	"kpt.dev/configsync/pkg/util/log"
+	kstatus "sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// HookRunning is the result of a hook that has not completed yet.
+	HookRunning = "Running"
+	// HookSucceeded is the result of a hook that completed successfully.
+	HookSucceeded = "Succeeded"
+	// HookFailed is the result of a hook that failed or timed out.
+	HookFailed = "Failed"
+)
+
+// hookPollInterval is how often the status of a running hook is checked.
+var hookPollInterval = 2 * time.Second
+
+// HookRunner runs the hooks of a source commit around the apply.
+type HookRunner interface {
+	// Run runs the hooks of the phase for the source commit one at a time, in
+	// order, and waits for each of them to complete. The remaining hooks of
+	// the phase are not run after a hook fails.
+	// A hook that already ran for the commit is not run again, so retrying a
+	// sync does not repeat its hooks. Deleting the hook object from the
+	// cluster runs it again.
+	// The hook status is sent to the statusHandler when each hook starts and
+	// when it completes.
+	Run(ctx context.Context, statusHandler func(v1beta1.HookStatus), phase metadata.HookPhase, commit string, hooks []client.Object) status.MultiError
+}
+
+// hookRunner is the default implementation of the HookRunner interface.
+// Each hook runs by creating the hook object, after deleting the object left
+// by the previous commit, and waiting until its kstatus is Current or Failed.
+type hookRunner struct {
+	client client.Client
+	// auditLog records the creates and deletes of the hook objects
+	auditLog *audit.Logger
+	// timeout is how long to wait for each hook to complete
+	timeout time.Duration
+}
+
+var _ HookRunner = &hookRunner{}
+
+// NewHookRunner constructs a HookRunner which waits up to the reconcile
+// timeout for each hook to complete.
+func NewHookRunner(cs *ClientSet, reconcileTimeout time.Duration) HookRunner {
+	return &hookRunner{
+		client:   cs.Client,
+		auditLog: cs.AuditLog,
+		timeout:  reconcileTimeout,
+	}
+}
+
+// Run implements HookRunner.
+func (r *hookRunner) Run(ctx context.Context, statusHandler func(v1beta1.HookStatus), phase metadata.HookPhase, commit string, hooks []client.Object) status.MultiError {
+	for _, hook := range hooks {
+		if !metadata.HasHookPhase(hook, phase) {
+			continue
+		}
+		gvk := hook.GetObjectKind().GroupVersionKind()
+		hookStatus := v1beta1.HookStatus{
+			Phase:     phase.String(),
+			Group:     gvk.Group,
+			Kind:      gvk.Kind,
+			Namespace: hook.GetNamespace(),
+			Name:      hook.GetName(),
+			Commit:    commit,
+		}
+		if err := r.run(audit.WithCommit(ctx, commit), statusHandler, hookStatus, hook); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run runs a single hook and waits for it to complete.
+func (r *hookRunner) run(ctx context.Context, statusHandler func(v1beta1.HookStatus), hookStatus v1beta1.HookStatus, hook client.Object) status.Error {
+	id := core.IDOf(hook)
+	obj, err := hookObject(hook)
+	if err != nil {
+		return err
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	getErr := r.client.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	switch {
+	case apierrors.IsNotFound(getErr):
+		klog.InfoS("Running hook", "phase", hookStatus.Phase, log.KeyObject, id.String(), log.KeyCommit, hookStatus.Commit)
+		if err := r.create(ctx, obj); err != nil {
+			return status.APIServerErrorWrap(err, obj)
+		}
+	case getErr != nil:
+		return status.APIServerErrorWrap(getErr, obj)
+	case core.GetAnnotation(live, metadata.SyncTokenAnnotationKey) == hookStatus.Commit:
+		klog.V(3).Infof("The %s hook %v already ran for commit %s", hookStatus.Phase, id, hookStatus.Commit)
+	default:
+		klog.InfoS("Running hook, replacing the run of a previous commit", "phase", hookStatus.Phase, log.KeyObject, id.String(),
+			log.KeyCommit, hookStatus.Commit, "previousCommit", core.GetAnnotation(live, metadata.SyncTokenAnnotationKey))
+		if err := r.replace(ctx, live, obj); err != nil {
+			return err
+		}
+	}
+
+	hookStatus.Result = HookRunning
+	statusHandler(hookStatus)
+	hookStatus.Result, hookStatus.Message = r.wait(ctx, obj)
+	statusHandler(hookStatus)
+	if hookStatus.Result != HookSucceeded {
+		return HookError(hookStatus, obj)
+	}
+	klog.InfoS("Hook succeeded", "phase", hookStatus.Phase, log.KeyObject, id.String(), log.KeyCommit, hookStatus.Commit)
+	return nil
+}
+
+// replace deletes the live hook object left by the previous commit, waits
+// until it is gone, and creates the hook object of the current commit.
+// Hook objects are replaced instead of updated, because the spec of a Job
+// is immutable and a completed Job never runs again.
+func (r *hookRunner) replace(ctx context.Context, live, obj *unstructured.Unstructured) status.Error {
+	err := r.client.Delete(ctx, live, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	r.auditLog.Record(ctx, m.ApplierController, audit.OperationDelete, core.IDOf(live), nil, err)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return status.APIServerErrorWrap(err, live)
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	err = wait.PollUntilContextCancel(ctx, hookPollInterval, true, func(ctx context.Context) (bool, error) {
+		err := r.client.Get(ctx, client.ObjectKeyFromObject(live), live.DeepCopy())
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return status.APIServerErrorWrap(fmt.Errorf("waiting for the previous run to be deleted: %w", err), live)
+	}
+	if err := r.create(ctx, obj); err != nil {
+		return status.APIServerErrorWrap(err, obj)
+	}
+	return nil
+}
+
+// create creates the hook object, and records it in the audit log.
+func (r *hookRunner) create(ctx context.Context, obj *unstructured.Unstructured) error {
+	body := obj.DeepCopy()
+	err := r.client.Create(ctx, obj, client.FieldOwner(configsync.FieldManager))
+	r.auditLog.Record(ctx, m.ApplierController, audit.OperationCreate, core.IDOf(obj), body, err)
+	return err
+}
+
+// wait waits until the hook object completes or the timeout expires, and
+// returns the result and the reason the hook failed, if it did.
+func (r *hookRunner) wait(ctx context.Context, obj *unstructured.Unstructured) (string, string) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	var result *kstatus.Result
+	live := &unstructured.Unstructured{}
+	err := wait.PollUntilContextCancel(ctx, hookPollInterval, true, func(ctx context.Context) (bool, error) {
+		live = &unstructured.Unstructured{}
+		live.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), live); err != nil {
+			// Retry until the timeout, in case the error is transient.
+			klog.Warningf("Failed to get the status of hook %v: %v", core.IDOf(obj), err)
+			return false, nil
+		}
+		var err error
+		result, err = kstatus.Compute(live)
+		if err != nil {
+			return false, err
+		}
+		return result.Status == kstatus.CurrentStatus || result.Status == kstatus.FailedStatus, nil
+	})
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return HookFailed, fmt.Sprintf("timed out after %v waiting for the hook to complete", r.timeout)
+	case err != nil:
+		return HookFailed, err.Error()
+	case result.Status == kstatus.FailedStatus:
+		if message := failedConditionMessage(live); message != "" {
+			return HookFailed, message
+		}
+		return HookFailed, result.Message
+	default:
+		return HookSucceeded, ""
+	}
+}
+
+// failedConditionMessage returns the message of the Failed condition of the
+// object, which explains why a Job failed better than its kstatus.
+func failedConditionMessage(obj *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != "Failed" || condition["status"] != "True" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return message
+	}
+	return ""
+}
+
+// hookObject returns the object to create for the hook.
+// The management metadata is removed, because the hook is not applied or
+// tracked in the inventory, so it must not be protected by the admission
+// webhook or adopted by another reconciler. The sync token annotation is kept
+// to identify the commit the hook ran for.
+func hookObject(hook client.Object) (*unstructured.Unstructured, status.Error) {
+	obj, err := syncerreconcile.AsUnstructuredSanitized(hook)
+	if err != nil {
+		return nil, err
+	}
+	core.RemoveAnnotations(obj, metadata.ManagementModeAnnotationKey, metadata.OwningInventoryKey, metadata.DeclaredFieldsKey)
+	core.RemoveLabels(obj, metadata.ApplySetPartOfLabel)
+	return obj, nil
+}
+
+// HookErrorCode is the error code for hooks that failed.
+const HookErrorCode = "2018"
+
+var hookErrorBuilder = status.NewErrorBuilder(HookErrorCode)
+
+// HookError reports that a hook failed.
+func HookError(hookStatus v1beta1.HookStatus, resource client.Object) status.Error {
+	return hookErrorBuilder.
+		Sprintf("%s hook failed for commit %s: %s", hookStatus.Phase, hookStatus.Commit, hookStatus.Message).
+		BuildWithResources(resource)
+}
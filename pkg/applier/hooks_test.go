@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func hookJob(name string, phase metadata.HookPhase, commit string, conditionType string) *unstructured.Unstructured {
+	u := k8sobjects.UnstructuredObject(kinds.Job(), core.Name(name), core.Namespace("shipping"),
+		core.Annotation(metadata.HookAnnotationKey, phase.String()),
+		core.Annotation(metadata.SyncTokenAnnotationKey, commit),
+		core.Annotation(metadata.ManagementModeAnnotationKey, metadata.ManagementEnabled.String()))
+	if conditionType != "" {
+		_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+			map[string]interface{}{"type": conditionType, "status": "True", "message": "Job has reached the specified backoff limit"},
+		}, "status", "conditions")
+	}
+	return u
+}
+
+func TestHookRunner(t *testing.T) {
+	hookPollInterval = time.Millisecond
+
+	testCases := []struct {
+		name       string
+		serverObjs []client.Object
+		hooks      []client.Object
+		wantErr    string
+		want       []v1beta1.HookStatus
+		wantToken  string
+	}{
+		{
+			name:       "hook already completed for the commit",
+			serverObjs: []client.Object{hookJob("migrate", metadata.PreSyncHook, "abc", "Complete")},
+			hooks: []client.Object{
+				hookJob("migrate", metadata.PreSyncHook, "abc", ""),
+				hookJob("smoke-test", metadata.PostSyncHook, "abc", ""),
+			},
+			want: []v1beta1.HookStatus{
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookRunning},
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookSucceeded},
+			},
+			wantToken: "abc",
+		},
+		{
+			name:       "hook failed for the commit",
+			serverObjs: []client.Object{hookJob("migrate", metadata.PreSyncHook, "abc", "Failed")},
+			hooks: []client.Object{
+				hookJob("migrate", metadata.PreSyncHook, "abc", ""),
+				hookJob("seed", metadata.PreSyncHook, "abc", ""),
+			},
+			wantErr: "KNV2018: pre-sync hook failed for commit abc: Job has reached the specified backoff limit",
+			want: []v1beta1.HookStatus{
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookRunning},
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookFailed,
+					Message: "Job has reached the specified backoff limit"},
+			},
+			wantToken: "abc",
+		},
+		{
+			name:       "hook of the previous commit is replaced",
+			serverObjs: []client.Object{hookJob("migrate", metadata.PreSyncHook, "old", "Complete")},
+			hooks:      []client.Object{hookJob("migrate", metadata.PreSyncHook, "abc", "")},
+			wantErr:    "KNV2018: pre-sync hook failed for commit abc: timed out after 10ms waiting for the hook to complete",
+			want: []v1beta1.HookStatus{
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookRunning},
+				{Phase: "pre-sync", Group: "batch", Kind: "Job", Namespace: "shipping", Name: "migrate", Commit: "abc", Result: HookFailed,
+					Message: "timed out after 10ms waiting for the hook to complete"},
+			},
+			wantToken: "abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme, tc.serverObjs...)
+			runner := NewHookRunner(&ClientSet{Client: fakeClient}, 10*time.Millisecond)
+			var got []v1beta1.HookStatus
+			err := runner.Run(context.Background(), func(s v1beta1.HookStatus) {
+				got = append(got, s)
+			}, metadata.PreSyncHook, "abc", tc.hooks)
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+
+			live := &unstructured.Unstructured{}
+			live.SetGroupVersionKind(kinds.Job())
+			require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "shipping", Name: "migrate"}, live))
+			assert.Equal(t, tc.wantToken, core.GetAnnotation(live, metadata.SyncTokenAnnotationKey))
+		})
+	}
+}
+
+func TestHookObject(t *testing.T) {
+	hook := hookJob("migrate", metadata.PreSyncHook, "abc", "")
+	core.SetLabel(hook, metadata.ApplySetPartOfLabel, "applyset-id")
+	core.SetAnnotation(hook, metadata.OwningInventoryKey, "inventory-id")
+
+	obj, err := hookObject(hook)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		metadata.HookAnnotationKey:      metadata.PreSyncHook.String(),
+		metadata.SyncTokenAnnotationKey: "abc",
+	}, obj.GetAnnotations())
+	assert.Empty(t, obj.GetLabels())
+}
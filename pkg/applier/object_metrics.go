@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/metrics"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+)
+
+// Operations of the per-object metrics.
+const (
+	objectOperationApply = "apply"
+	objectOperationPrune = "prune"
+	objectOperationWait  = "wait"
+)
+
+// ObjectMetricsOptions controls which objects have per-object metrics
+// recorded, to keep the cardinality of the metrics bounded.
+type ObjectMetricsOptions struct {
+	// GroupKinds is the allow-list of object types to record metrics for.
+	// If empty, no per-object metrics are recorded.
+	GroupKinds map[schema.GroupKind]struct{}
+	// IncludeNamespace records the namespace of the objects, in addition to
+	// their type.
+	IncludeNamespace bool
+}
+
+// Enabled returns true if metrics are recorded for any object type.
+func (o ObjectMetricsOptions) Enabled() bool {
+	return len(o.GroupKinds) > 0
+}
+
+// RecordMetrics records the outcome and latency of the actuation and
+// reconciliation of the objects with an allowed type.
+// Pending and unset statuses are not recorded, because the object was not
+// actuated or reconciled.
+func (m ObjectStatusMap) RecordMetrics(ctx context.Context, opts ObjectMetricsOptions) {
+	if !opts.Enabled() {
+		return
+	}
+	for id, objStatus := range m {
+		if objStatus == nil {
+			continue
+		}
+		if _, found := opts.GroupKinds[id.GroupKind]; !found {
+			continue
+		}
+		objectType := id.GroupKind.String()
+		var namespace string
+		if opts.IncludeNamespace {
+			namespace = id.Namespace
+		}
+		if objStatus.Actuation != "" && objStatus.Actuation != actuation.ActuationPending {
+			operation := objectOperationApply
+			if objStatus.Strategy == actuation.ActuationStrategyDelete {
+				operation = objectOperationPrune
+			}
+			recordObjectOperation(ctx, operation, objStatus.Actuation.String(), objectType, namespace, objStatus.ActuationDuration)
+		}
+		if objStatus.Reconcile != "" && objStatus.Reconcile != actuation.ReconcilePending {
+			recordObjectOperation(ctx, objectOperationWait, objStatus.Reconcile.String(), objectType, namespace, objStatus.ReconcileDuration)
+		}
+	}
+}
+
+func recordObjectOperation(ctx context.Context, operation, status, objectType, namespace string, duration time.Duration) {
+	status = strings.ToLower(status)
+	metrics.RecordObjectOperation(ctx, operation, status, objectType, namespace)
+	// Skipped operations are not timed.
+	if duration > 0 {
+		metrics.RecordObjectOperationDuration(ctx, operation, status, objectType, namespace, duration)
+	}
+}
+
+// objectTimer times the actuation and reconciliation of objects from the
+// events of the kpt applier or destroyer.
+//
+// The objects in an apply, prune or delete action group are actuated one at a
+// time, so the actuation of an object is timed from the previous actuation in
+// the group, or from the start of the group. The reconciliation of an object
+// is timed from its actuation.
+type objectTimer struct {
+	clock clock.PassiveClock
+	// last is the time of the last actuation event or action group start
+	last time.Time
+	// actuated is the time each object was actuated
+	actuated map[core.ID]time.Time
+}
+
+func newObjectTimer(c clock.PassiveClock) *objectTimer {
+	return &objectTimer{
+		clock:    c,
+		last:     c.Now(),
+		actuated: make(map[core.ID]time.Time),
+	}
+}
+
+func (t *objectTimer) handleActionGroup(e event.ActionGroupEvent) {
+	if e.Status == event.Started {
+		t.last = t.clock.Now()
+	}
+}
+
+// observeActuation sets the ActuationDuration of the object, if its actuation
+// succeeded or failed.
+func (t *objectTimer) observeActuation(id core.ID, objStatusMap ObjectStatusMap) {
+	objStatus := objStatusMap[id]
+	if objStatus == nil || objStatus.Actuation == actuation.ActuationPending {
+		return
+	}
+	now := t.clock.Now()
+	switch objStatus.Actuation {
+	case actuation.ActuationSucceeded, actuation.ActuationFailed:
+		objStatus.ActuationDuration = now.Sub(t.last)
+		t.actuated[id] = now
+	}
+	t.last = now
+}
+
+// observeReconcile sets the ReconcileDuration of the object, if it was
+// actuated and its reconciliation succeeded, failed or timed out.
+func (t *objectTimer) observeReconcile(id core.ID, objStatusMap ObjectStatusMap) {
+	objStatus := objStatusMap[id]
+	if objStatus == nil {
+		return
+	}
+	actuated, found := t.actuated[id]
+	if !found {
+		return
+	}
+	switch objStatus.Reconcile {
+	case actuation.ReconcileSucceeded, actuation.ReconcileFailed, actuation.ReconcileTimeout:
+		objStatus.ReconcileDuration = t.clock.Now().Sub(actuated)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakeclock "k8s.io/utils/clock/testing"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/testing/testmetrics"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	deploymentID = core.ID{
+		GroupKind: kinds.Deployment().GroupKind(),
+		ObjectKey: client.ObjectKey{Namespace: "shipping", Name: "api"},
+	}
+	configMapID = core.ID{
+		GroupKind: kinds.ConfigMap().GroupKind(),
+		ObjectKey: client.ObjectKey{Namespace: "shipping", Name: "config"},
+	}
+	roleID = core.ID{
+		GroupKind: kinds.Role().GroupKind(),
+		ObjectKey: client.ObjectKey{Namespace: "billing", Name: "admin"},
+	}
+)
+
+func TestObjectTimer(t *testing.T) {
+	clock := fakeclock.NewFakeClock(time.Now())
+	timer := newObjectTimer(clock)
+	objStatusMap := ObjectStatusMap{}
+
+	clock.Step(time.Minute)
+	timer.handleActionGroup(event.ActionGroupEvent{GroupName: "apply-0", Action: event.ApplyAction, Status: event.Started})
+
+	clock.Step(2 * time.Second)
+	objStatusMap[deploymentID] = &ObjectStatus{Strategy: actuation.ActuationStrategyApply, Actuation: actuation.ActuationSucceeded}
+	timer.observeActuation(deploymentID, objStatusMap)
+
+	clock.Step(time.Second)
+	objStatusMap[configMapID] = &ObjectStatus{Strategy: actuation.ActuationStrategyApply, Actuation: actuation.ActuationFailed}
+	timer.observeActuation(configMapID, objStatusMap)
+
+	clock.Step(time.Second)
+	objStatusMap[roleID] = &ObjectStatus{Strategy: actuation.ActuationStrategyApply, Actuation: actuation.ActuationSkipped}
+	timer.observeActuation(roleID, objStatusMap)
+
+	clock.Step(time.Minute)
+	timer.handleActionGroup(event.ActionGroupEvent{GroupName: "wait-0", Action: event.WaitAction, Status: event.Started})
+
+	clock.Step(5 * time.Second)
+	objStatusMap[deploymentID].Reconcile = actuation.ReconcileSucceeded
+	timer.observeReconcile(deploymentID, objStatusMap)
+	objStatusMap[roleID].Reconcile = actuation.ReconcileSkipped
+	timer.observeReconcile(roleID, objStatusMap)
+
+	want := ObjectStatusMap{
+		deploymentID: {
+			Strategy:          actuation.ActuationStrategyApply,
+			Actuation:         actuation.ActuationSucceeded,
+			Reconcile:         actuation.ReconcileSucceeded,
+			ActuationDuration: 2 * time.Second,
+			ReconcileDuration: time.Minute + 7*time.Second,
+		},
+		configMapID: {
+			Strategy:          actuation.ActuationStrategyApply,
+			Actuation:         actuation.ActuationFailed,
+			ActuationDuration: time.Second,
+		},
+		roleID: {
+			Strategy:  actuation.ActuationStrategyApply,
+			Actuation: actuation.ActuationSkipped,
+			Reconcile: actuation.ReconcileSkipped,
+		},
+	}
+	assert.Equal(t, want, objStatusMap)
+}
+
+func TestObjectStatusMapRecordMetrics(t *testing.T) {
+	objStatusMap := ObjectStatusMap{
+		deploymentID: {
+			Strategy:          actuation.ActuationStrategyApply,
+			Actuation:         actuation.ActuationSucceeded,
+			Reconcile:         actuation.ReconcileTimeout,
+			ActuationDuration: time.Second,
+			ReconcileDuration: time.Minute,
+		},
+		configMapID: {
+			Strategy:          actuation.ActuationStrategyDelete,
+			Actuation:         actuation.ActuationFailed,
+			Reconcile:         actuation.ReconcilePending,
+			ActuationDuration: time.Second,
+		},
+		roleID: {
+			Strategy:          actuation.ActuationStrategyApply,
+			Actuation:         actuation.ActuationSucceeded,
+			Reconcile:         actuation.ReconcileSucceeded,
+			ActuationDuration: time.Second,
+			ReconcileDuration: time.Second,
+		},
+	}
+	allowed := map[schema.GroupKind]struct{}{
+		kinds.Deployment().GroupKind(): {},
+		kinds.ConfigMap().GroupKind():  {},
+	}
+
+	testCases := []struct {
+		name          string
+		opts          ObjectMetricsOptions
+		wantNamespace bool
+		wantEmpty     bool
+	}{
+		{
+			name:      "disabled",
+			opts:      ObjectMetricsOptions{},
+			wantEmpty: true,
+		},
+		{
+			name: "allowed kinds",
+			opts: ObjectMetricsOptions{GroupKinds: allowed},
+		},
+		{
+			name:          "allowed kinds with namespace",
+			opts:          ObjectMetricsOptions{GroupKinds: allowed, IncludeNamespace: true},
+			wantNamespace: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := testmetrics.RegisterMetrics()
+			objStatusMap.RecordMetrics(context.Background(), tc.opts)
+
+			row := func(operation, status, objectType string) *testmetrics.Row {
+				attrs := []attribute.KeyValue{
+					metrics.KeyOperation.String(operation),
+					metrics.KeyStatus.String(status),
+					metrics.KeyObjectType.String(objectType),
+				}
+				if tc.wantNamespace {
+					attrs = append(attrs, metrics.KeyObjectNamespace.String("shipping"))
+				}
+				return &testmetrics.Row{Attributes: attrs, Value: 1}
+			}
+			var wantOperations, wantDurations []*testmetrics.Row
+			if !tc.wantEmpty {
+				wantOperations = []*testmetrics.Row{
+					row("apply", "succeeded", "Deployment.apps"),
+					row("wait", "timeout", "Deployment.apps"),
+					row("prune", "failed", "ConfigMap"),
+				}
+				// The ConfigMap prune is timed, but the reconcile is pending.
+				wantDurations = []*testmetrics.Row{
+					row("apply", "succeeded", "Deployment.apps"),
+					row("wait", "timeout", "Deployment.apps"),
+					row("prune", "failed", "ConfigMap"),
+				}
+			}
+			if diff := m.ValidateMetrics(metrics.ObjectOperationsName, wantOperations); diff != "" {
+				t.Error(diff)
+			}
+			if diff := m.ValidateMetrics(metrics.ObjectOperationDurationName, wantDurations); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/status"
+	nomosutil "kpt.dev/configsync/pkg/util"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// enforcePrunePolicy checks the objects that would be pruned by applying
+// objsToApply against the prune policy.
+//
+// Prune candidates with a protected GroupKind are removed from the inventory
+// and abandoned, so that the applier never deletes them. If the remaining
+// prune candidates exceed the prune limits, an error is returned and the apply
+// must not proceed.
+func (s *supervisor) enforcePrunePolicy(ctx context.Context, commit string, objsToApply []*unstructured.Unstructured) status.MultiError {
+	if s.prunePolicy.IsEmpty() {
+		return nil
+	}
+	inv, err := s.clientSet.InvClient.Get(ctx, s.invInfo, inventory.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		// Nothing is managed yet, so nothing can be pruned.
+		return nil
+	} else if err != nil {
+		return Error(err)
+	}
+	if inv == nil {
+		return nil
+	}
+
+	applySet := make(map[object.ObjMetadata]struct{}, len(objsToApply))
+	for _, obj := range objsToApply {
+		applySet[ObjMetaFromUnstructured(obj)] = struct{}{}
+	}
+	managed := inv.GetObjectRefs()
+	var protected []object.ObjMetadata
+	var prunes []core.ID
+	for _, ref := range managed {
+		if _, found := applySet[ref]; found {
+			continue
+		}
+		if s.prunePolicy.IsProtected(ref.GroupKind) {
+			protected = append(protected, ref)
+			continue
+		}
+		prunes = append(prunes, idFrom(ref))
+	}
+
+	if err := s.prunePolicy.CheckPruneLimits(commit, len(managed), prunes); err != nil {
+		klog.Warningf("Prune policy blocked the apply of commit %q: %d of %d managed objects would be pruned",
+			commit, len(prunes), len(managed))
+		return err
+	}
+	if len(protected) > 0 {
+		klog.Infof("%v objects to be abandoned instead of pruned: %v", len(protected), protected)
+		return s.handleProtectedObjects(ctx, protected)
+	}
+	return nil
+}
+
+// handleProtectedObjects removes the specified objects from the inventory, and
+// then abandons them, one by one, by removing the ConfigSync metadata.
+func (s *supervisor) handleProtectedObjects(ctx context.Context, refs []object.ObjMetadata) status.MultiError {
+	objs := make([]client.Object, 0, len(refs))
+	for _, ref := range refs {
+		obj := &unstructured.Unstructured{}
+		obj.SetName(ref.Name)
+		obj.SetNamespace(ref.Namespace)
+		mapping, err := s.clientSet.Mapper.RESTMapping(ref.GroupKind)
+		if err != nil {
+			if !meta.IsNoMatchError(err) {
+				return Error(err)
+			}
+			// The resource type no longer exists, so neither does the object.
+			// Only the GroupKind is needed to remove it from the inventory.
+			obj.SetGroupVersionKind(ref.GroupKind.WithVersion(""))
+		} else {
+			obj.SetGroupVersionKind(mapping.GroupVersionKind)
+		}
+		objs = append(objs, obj)
+	}
+
+	if err := s.removeFromInventory(ctx, objs); err != nil {
+		if nomosutil.IsRequestTooLargeError(err) {
+			return largeResourceGroupError(err, coreIDFromInventoryInfo(s.invInfo))
+		}
+		return Error(err)
+	}
+	var errs status.MultiError
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().Version == "" {
+			continue
+		}
+		id := core.IDOf(obj)
+		err := s.abandonObject(ctx, obj)
+		handleMetrics(ctx, "unmanage", err)
+		if err != nil {
+			err = fmt.Errorf("failed to remove the Config Sync metadata from %v (prune protected): %v", id, err)
+			klog.Warning(err)
+			errs = status.Append(errs, Error(err))
+		} else {
+			klog.V(4).Infof("removed the Config Sync metadata from %v (prune protected)", id)
+		}
+	}
+	return errs
+}
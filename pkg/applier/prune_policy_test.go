@@ -0,0 +1,160 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package applier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	testingfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyPrunePolicy(t *testing.T) {
+	syncScope := declared.Scope("test-namespace")
+	syncName := "rs"
+	commit := "example-commit"
+
+	deploymentObj := newDeploymentObj()
+	deploymentObjMeta := object.UnstructuredToObjMetadata(deploymentObj)
+	testObj1 := newTestObj("test-1")
+	testObj1Meta := object.UnstructuredToObjMetadata(testObj1)
+	testObj2 := newTestObj("test-2")
+	testObj2Meta := object.UnstructuredToObjMetadata(testObj2)
+
+	protectedObj := k8sobjects.RoleObject(core.Name("protected"), core.Namespace("test-namespace"),
+		core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(syncScope, syncName)),
+		core.Annotation(metadata.ResourceIDKey, "rbac.authorization.k8s.io_role_test-namespace_protected"),
+		core.Label(metadata.ManagedByKey, metadata.ManagedByValue))
+	protectedObjMeta := object.ObjMetadata{
+		GroupKind: kinds.Role().GroupKind(),
+		Namespace: "test-namespace",
+		Name:      "protected",
+	}
+
+	testCases := []struct {
+		name                string
+		policy              declared.PrunePolicy
+		inventoryObjs       object.ObjMetadataSet
+		serverObjs          []client.Object
+		expectedError       status.MultiError
+		expectedApply       bool
+		expectedInventory   object.ObjMetadataSet
+		expectedUnmanagedID *core.ID
+	}{
+		{
+			name:              "prunes within the limit",
+			policy:            declared.PrunePolicy{MaxPruneCount: 1},
+			inventoryObjs:     object.ObjMetadataSet{deploymentObjMeta, testObj1Meta},
+			expectedApply:     true,
+			expectedInventory: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta},
+		},
+		{
+			name:          "prunes exceed the count limit",
+			policy:        declared.PrunePolicy{MaxPruneCount: 1},
+			inventoryObjs: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+			expectedError: declared.PruneLimitExceededError(commit, 3,
+				[]core.ID{idFrom(testObj1Meta), idFrom(testObj2Meta)},
+				declared.PrunePolicy{MaxPruneCount: 1}),
+			expectedApply:     false,
+			expectedInventory: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+		},
+		{
+			name:          "prunes exceed the percent limit",
+			policy:        declared.PrunePolicy{MaxPrunePercent: 50},
+			inventoryObjs: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+			expectedError: declared.PruneLimitExceededError(commit, 3,
+				[]core.ID{idFrom(testObj1Meta), idFrom(testObj2Meta)},
+				declared.PrunePolicy{MaxPrunePercent: 50}),
+			expectedApply:     false,
+			expectedInventory: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+		},
+		{
+			name:              "prunes exceed the limit but the commit is confirmed",
+			policy:            declared.PrunePolicy{MaxPruneCount: 1, ConfirmedCommit: commit},
+			inventoryObjs:     object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+			expectedApply:     true,
+			expectedInventory: object.ObjMetadataSet{deploymentObjMeta, testObj1Meta, testObj2Meta},
+		},
+		{
+			name: "protected kinds are abandoned instead of pruned",
+			policy: declared.PrunePolicy{
+				ProtectedGroupKinds: map[schema.GroupKind]struct{}{kinds.Role().GroupKind(): {}},
+			},
+			inventoryObjs:     object.ObjMetadataSet{deploymentObjMeta, protectedObjMeta},
+			serverObjs:        []client.Object{protectedObj},
+			expectedApply:     true,
+			expectedInventory: object.ObjMetadataSet{deploymentObjMeta},
+			expectedUnmanagedID: &core.ID{
+				GroupKind: protectedObjMeta.GroupKind,
+				ObjectKey: client.ObjectKey{Namespace: protectedObjMeta.Namespace, Name: protectedObjMeta.Name},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := testingfake.NewClient(t, core.Scheme, tc.serverObjs...)
+			fakeKptApplier := newFakeKptApplier([]event.Event{})
+			invClient := inventory.NewFakeClient(tc.inventoryObjs)
+			cs := &ClientSet{
+				KptApplier: fakeKptApplier,
+				InvClient:  invClient,
+				Client:     fakeClient,
+				Mapper:     fakeClient.RESTMapper(),
+			}
+			applier := NewSupervisor(cs, syncScope, syncName, 5*time.Minute, tc.policy)
+
+			var errs status.MultiError
+			eventHandler := func(event Event) {
+				if errEvent, ok := event.(ErrorEvent); ok {
+					errs = status.Append(errs, errEvent.Error)
+				}
+			}
+
+			resources := &declared.Resources{}
+			_, err := resources.UpdateDeclared(context.Background(), []client.Object{deploymentObj}, commit)
+			require.NoError(t, err)
+
+			applier.Apply(context.Background(), eventHandler, resources)
+
+			testerrors.AssertEqual(t, tc.expectedError, errs)
+			assert.Equal(t, tc.expectedApply, fakeKptApplier.objsToApply != nil)
+			assert.ElementsMatch(t, tc.expectedInventory, invClient.Inv.GetObjectRefs())
+
+			if tc.expectedUnmanagedID != nil {
+				obj := k8sobjects.RoleObject()
+				err := fakeClient.Get(context.Background(), tc.expectedUnmanagedID.ObjectKey, obj)
+				require.NoError(t, err)
+				assert.False(t, metadata.HasConfigSyncMetadata(obj),
+					"expected Config Sync metadata to be removed from %s", tc.expectedUnmanagedID)
+			}
+		})
+	}
+}
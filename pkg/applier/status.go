@@ -17,6 +17,7 @@ package applier
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"kpt.dev/configsync/pkg/core"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
@@ -31,103 +32,57 @@ type ObjectStatus struct {
 	Actuation actuation.ActuationStatus
 	// Reconcile indicates whether reconciliation has been performed yet and how it went.
 	Reconcile actuation.ReconcileStatus
+	// ActuationDuration is how long the actuation took, if it succeeded or failed.
+	ActuationDuration time.Duration
+	// ReconcileDuration is how long the object took to reconcile after
+	// actuation, if it succeeded, failed or timed out.
+	ReconcileDuration time.Duration
 }
 
 // ObjectStatusMap is a map of object IDs to ObjectStatus.
 type ObjectStatusMap map[core.ID]*ObjectStatus
 
-// infofLogger is a subest of klog.Verbose to make testing ObjectStatusMap.Log
+// infoSLogger is a subset of klog.Verbose to make testing ObjectStatusMap.Log
 // easier.
-type infofLogger interface {
+type infoSLogger interface {
 	Enabled() bool
-	Infof(format string, args ...interface{})
+	InfoS(msg string, keysAndValues ...interface{})
 }
 
 // Log uses the specified logger to log object statuses.
-// This produces multiple log entries, if the logger is enabled.
+// This produces one structured log entry per strategy and stage, with the
+// total and the sorted object IDs of each status, if the logger is enabled.
 // Takes a minimal logger interface in order to make testing easier, but is
 // designed for use with a leveled klog, like klog.V(3)
-func (m ObjectStatusMap) Log(logger infofLogger) {
+func (m ObjectStatusMap) Log(logger infoSLogger) {
 	if !logger.Enabled() {
 		return
 	}
-
-	count := 0
-	var b strings.Builder
-	for i, status := range actuationStatuses {
-		if i > 0 {
-			b.WriteString(commaEscapedNewlineDelimiter)
-		}
-		ids := m.Filter(actuation.ActuationStrategyApply, status, "")
-		count += len(ids)
-		writeStatus(&b, status, ids)
-	}
-	if count == 0 {
-		logger.Infof("Apply Actuations (Total: %d)", count)
-	} else {
-		logger.Infof("Apply Actuations (Total: %d):\\n%s", count, b.String())
-	}
-
-	count = 0
-	b.Reset()
-	for i, status := range reconcileStatuses {
-		if i > 0 {
-			b.WriteString(commaEscapedNewlineDelimiter)
-		}
-		ids := m.Filter(actuation.ActuationStrategyApply, "", status)
-		count += len(ids)
-		writeStatus(&b, status, ids)
-	}
-	if count == 0 {
-		logger.Infof("Apply Reconciles (Total: %d)", count)
-	} else {
-		logger.Infof("Apply Reconciles (Total: %d):\\n%s", count, b.String())
-	}
-
-	count = 0
-	b.Reset()
-	for i, status := range actuationStatuses {
-		if i > 0 {
-			b.WriteString(commaEscapedNewlineDelimiter)
+	for _, strategy := range []actuation.ActuationStrategy{actuation.ActuationStrategyApply, actuation.ActuationStrategyDelete} {
+		count := 0
+		var keysAndValues []interface{}
+		for _, status := range actuationStatuses {
+			ids := m.Filter(strategy, status, "")
+			count += len(ids)
+			keysAndValues = append(keysAndValues, statusKey(status), sortedIDs(ids...))
 		}
-		ids := m.Filter(actuation.ActuationStrategyDelete, status, "")
-		count += len(ids)
-		writeStatus(&b, status, ids)
-	}
-	if count == 0 {
-		logger.Infof("Delete Actuations (Total: %d)", count)
-	} else {
-		logger.Infof("Delete Actuations (Total: %d):\\n%s", count, b.String())
-	}
+		logger.InfoS(fmt.Sprintf("%s actuations", strategy), append([]interface{}{"total", count}, keysAndValues...)...)
 
-	count = 0
-	b.Reset()
-	for i, status := range reconcileStatuses {
-		if i > 0 {
-			b.WriteString(commaEscapedNewlineDelimiter)
+		count = 0
+		keysAndValues = nil
+		for _, status := range reconcileStatuses {
+			ids := m.Filter(strategy, "", status)
+			count += len(ids)
+			keysAndValues = append(keysAndValues, statusKey(status), sortedIDs(ids...))
 		}
-		ids := m.Filter(actuation.ActuationStrategyDelete, "", status)
-		count += len(ids)
-		writeStatus(&b, status, ids)
-	}
-	if count == 0 {
-		logger.Infof("Delete Reconciles (Total: %d)", count)
-	} else {
-		logger.Infof("Delete Reconciles (Total: %d):\\n%s", count, b.String())
+		logger.InfoS(fmt.Sprintf("%s reconciles", strategy), append([]interface{}{"total", count}, keysAndValues...)...)
 	}
 }
 
-func writeStatus(b *strings.Builder, status interface{ String() string }, ids []core.ID) {
-	var err error
-	if len(ids) == 0 {
-		_, err = fmt.Fprintf(b, "%s (%d)", status, len(ids))
-	} else {
-		_, err = fmt.Fprintf(b, "%s (%d): [%s]", status, len(ids), joinIDs(commaSpaceDelimiter, ids...))
-	}
-	// Builder.Write never returns an error. So this should never happen.
-	if err != nil {
-		panic(fmt.Sprintf("Failed to write status: %v", err))
-	}
+// statusKey returns the log key of an actuation or reconcile status, like
+// "succeeded".
+func statusKey(status interface{ String() string }) string {
+	return strings.ToLower(status.String())
 }
 
 // Filter returns an unsorted list of IDs that satisfy the specified constraints.
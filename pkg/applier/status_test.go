@@ -17,6 +17,7 @@ package applier
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 
@@ -179,24 +180,10 @@ func TestObjectStatusMapLog(t *testing.T) {
 				},
 			},
 			expected: []string{
-				"Apply Actuations (Total: 1):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (1): [apps/namespaces/test-namespace/Deployment/random-name],\\n" +
-					"Failed (0)",
-				"Apply Reconciles (Total: 1):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (0),\\n" +
-					"Failed (1): [apps/namespaces/test-namespace/Deployment/random-name],\\n" +
-					"Timeout (0)",
-				"Delete Actuations (Total: 1):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (1): [configsync.test/namespaces/test-namespace/Test/random-name],\\n" +
-					"Failed (0)",
-				"Delete Reconciles (Total: 1):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (1): [configsync.test/namespaces/test-namespace/Test/random-name],\\n" +
-					"Failed (0),\\n" +
-					"Timeout (0)",
+				"Apply actuations total=1 skipped=[] succeeded=[apps/namespaces/test-namespace/Deployment/random-name] failed=[]",
+				"Apply reconciles total=1 skipped=[] succeeded=[] failed=[apps/namespaces/test-namespace/Deployment/random-name] timeout=[]",
+				"Delete actuations total=1 skipped=[] succeeded=[configsync.test/namespaces/test-namespace/Test/random-name] failed=[]",
+				"Delete reconciles total=1 skipped=[] succeeded=[configsync.test/namespaces/test-namespace/Test/random-name] failed=[] timeout=[]",
 			},
 		},
 		{
@@ -214,17 +201,10 @@ func TestObjectStatusMapLog(t *testing.T) {
 				},
 			},
 			expected: []string{
-				"Apply Actuations (Total: 0)",
-				"Apply Reconciles (Total: 0)",
-				"Delete Actuations (Total: 2):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (2): [apps/namespaces/test-namespace/Deployment/random-name, configsync.test/namespaces/test-namespace/Test/random-name],\\n" +
-					"Failed (0)",
-				"Delete Reconciles (Total: 2):\\n" +
-					"Skipped (0),\\n" +
-					"Succeeded (1): [configsync.test/namespaces/test-namespace/Test/random-name],\\n" +
-					"Failed (1): [apps/namespaces/test-namespace/Deployment/random-name],\\n" +
-					"Timeout (0)",
+				"Apply actuations total=0 skipped=[] succeeded=[] failed=[]",
+				"Apply reconciles total=0 skipped=[] succeeded=[] failed=[] timeout=[]",
+				"Delete actuations total=2 skipped=[] succeeded=[apps/namespaces/test-namespace/Deployment/random-name configsync.test/namespaces/test-namespace/Test/random-name] failed=[]",
+				"Delete reconciles total=2 skipped=[] succeeded=[configsync.test/namespaces/test-namespace/Test/random-name] failed=[apps/namespaces/test-namespace/Deployment/random-name] timeout=[]",
 			},
 		},
 	}
@@ -260,8 +240,13 @@ func (fl *fakeLogger) Enabled() bool {
 	return fl.enabled
 }
 
-func (fl *fakeLogger) Infof(format string, args ...interface{}) {
+func (fl *fakeLogger) InfoS(msg string, keysAndValues ...interface{}) {
 	fl.lock.Lock()
 	defer fl.lock.Unlock()
-	fl.Logs = append(fl.Logs, fmt.Sprintf(format, args...))
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], keysAndValues[i+1])
+	}
+	fl.Logs = append(fl.Logs, b.String())
 }
@@ -18,7 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
-	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -189,26 +188,18 @@ func stringsFromRefs(refs ...mutation.ResourceReference) []string {
 	return strs
 }
 
-const (
-	commaSpaceDelimiter          = ", "
-	commaEscapedNewlineDelimiter = ",\\n"
-)
-
-// joinIDs joins the object IDs (GKNN) using ResourceReference string format
-// and the specified delimiter.
+// sortedIDs returns the object IDs (GKNN) in ResourceReference string format,
+// sorted.
 //
 // ResourceReference string format is used because the normal ID string format,
-// includes commas and spaces that make it harder to parse in a list delimited
-// by commas.
+// includes commas and spaces that make it harder to parse in a list.
 //
-// This can be used to build depends-on annotations when commaDelimiter is used,
-// or for log messages with commaSpaceDelimiter or commaEscapedNewlineDelimiter.
-func joinIDs(delimiter string, ids ...core.ID) string {
-	if len(ids) == 0 {
-		return ""
+// Returns an empty list, instead of nil, so log messages show an empty list.
+func sortedIDs(ids ...core.ID) []string {
+	refStrs := stringsFromRefs(refsFromIDs(ids...)...)
+	if refStrs == nil {
+		return []string{}
 	}
-	refs := refsFromIDs(ids...)
-	refStrs := stringsFromRefs(refs...)
 	sort.Strings(refStrs)
-	return strings.Join(refStrs, delimiter)
+	return refStrs
 }
@@ -0,0 +1,303 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records an audit log of the writes that the reconciler makes
+// to the objects it manages, to a rotating file and/or an HTTP sink.
+//
+// Each record includes the hash of the previous record, and its own hash, so
+// that records which are modified, removed or reordered break the chain.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+)
+
+const (
+	// defaultMaxSizeMB is the default size in megabytes at which the audit
+	// log file is rotated.
+	defaultMaxSizeMB = 10
+	// defaultMaxBackups is the default number of rotated audit log files to
+	// keep.
+	defaultMaxBackups = 5
+)
+
+// Operation is the kind of write made to an object.
+type Operation string
+
+const (
+	// OperationApply is a server-side apply patch.
+	OperationApply Operation = "apply"
+	// OperationCreate is a create.
+	OperationCreate Operation = "create"
+	// OperationUpdate is an update (PUT) of the whole object.
+	OperationUpdate Operation = "update"
+	// OperationPatch is a strategic merge or JSON merge patch.
+	OperationPatch Operation = "patch"
+	// OperationDelete is a delete.
+	OperationDelete Operation = "delete"
+)
+
+const (
+	// ResultSuccess is the result of a write that succeeded.
+	ResultSuccess = "success"
+	// ResultFailure is the result of a write that failed.
+	ResultFailure = "failure"
+)
+
+// Record is an entry of the audit log.
+type Record struct {
+	// Sequence is the position of the record in the chain, starting at 1.
+	Sequence uint64 `json:"seq"`
+	// Timestamp is the time of the write.
+	Timestamp time.Time `json:"ts"`
+	// Cluster is the name of the cluster, if set.
+	Cluster string `json:"cluster,omitempty"`
+	// SyncKind is the kind of the RSync: RootSync or RepoSync.
+	SyncKind string `json:"syncKind"`
+	// SyncNamespace is the namespace of the RSync.
+	SyncNamespace string `json:"syncNamespace"`
+	// SyncName is the name of the RSync.
+	SyncName string `json:"syncName"`
+	// Controller is the component of the reconciler that made the write:
+	// applier or remediator.
+	Controller string `json:"controller"`
+	// Operation is the kind of write.
+	Operation Operation `json:"operation"`
+	// Object is the ID of the object, like "Deployment.apps, bookstore/web".
+	Object string `json:"object"`
+	// Commit is the source commit that the write synced, if known.
+	Commit string `json:"commit,omitempty"`
+	// DeclaredSHA256 is the hex SHA-256 of the write as Config Sync declared
+	// it: the applied, created or updated object as JSON, or the patch. Empty
+	// for deletes.
+	// For the applies of the applier, it is the declared object, not the
+	// request body, because the applier adds the owning-inventory annotation
+	// to the object before applying it.
+	DeclaredSHA256 string `json:"declaredSHA256,omitempty"`
+	// Result is the result of the write: success or failure.
+	Result string `json:"result"`
+	// Error is the error of a failed write.
+	Error string `json:"error,omitempty"`
+	// PrevHash is the Hash of the previous record, or empty for the first
+	// record of the chain.
+	PrevHash string `json:"prevHash"`
+	// Hash is the hex SHA-256 of the JSON of the record, without the Hash.
+	Hash string `json:"hash"`
+}
+
+// computeHash returns the hash of the record, computed with an empty Hash.
+func (r Record) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Logger writes an audit record for each write to the audit log file and
+// queues it for the sink.
+//
+// A nil Logger is valid, and ignores all writes.
+type Logger struct {
+	clock clock.PassiveClock
+	file  *rotatingFile
+	sink  *sink
+
+	cluster       string
+	syncKind      string
+	syncNamespace string
+	syncName      string
+
+	// mux serializes the records, so that they are chained in order.
+	mux      sync.Mutex
+	seq      uint64
+	lastHash string
+}
+
+// Options identify the reconciler whose writes are audited.
+type Options struct {
+	// Dir is the directory of the audit log file.
+	Dir string
+	// ReconcilerName is the name of the reconciler, which names the audit log
+	// file, so that reconcilers may share a volume.
+	ReconcilerName string
+	// ClusterName is the name of the cluster, if set.
+	ClusterName string
+	// SyncKind is the kind of the RSync: RootSync or RepoSync.
+	SyncKind string
+	// SyncNamespace is the namespace of the RSync.
+	SyncNamespace string
+	// SyncName is the name of the RSync.
+	SyncName string
+}
+
+// New constructs a Logger for the audit log of the RootSync or RepoSync.
+// Returns an error if the sink URL is invalid or the file can't be opened.
+//
+// The caller must call Run to send the records to the sink.
+func New(spec v1beta1.AuditLog, opts Options) (*Logger, error) {
+	l := &Logger{
+		clock:         clock.RealClock{},
+		cluster:       opts.ClusterName,
+		syncKind:      opts.SyncKind,
+		syncNamespace: opts.SyncNamespace,
+		syncName:      opts.SyncName,
+	}
+	if spec.SinkURL != "" {
+		u, err := url.Parse(spec.SinkURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit log sink URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("invalid audit log sink URL: scheme must be http or https")
+		}
+		l.sink = newSink(spec.SinkURL)
+	}
+	if spec.File != nil {
+		maxSizeMB := spec.File.MaxSizeMB
+		if maxSizeMB <= 0 {
+			maxSizeMB = defaultMaxSizeMB
+		}
+		maxBackups := spec.File.MaxBackups
+		if maxBackups <= 0 {
+			maxBackups = defaultMaxBackups
+		}
+		path := filepath.Join(opts.Dir, opts.ReconcilerName+".log")
+		last, err := lastRecord(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the audit log file: %w", err)
+		}
+		if last != nil {
+			// Continue the chain of the records written before the restart.
+			l.seq = last.Sequence
+			l.lastHash = last.Hash
+		}
+		l.file, err = openRotatingFile(path, int64(maxSizeMB)*1024*1024, maxBackups)
+		if err != nil {
+			return nil, fmt.Errorf("opening the audit log file: %w", err)
+		}
+	}
+	return l, nil
+}
+
+// Run sends the queued records to the sink until the context is cancelled.
+func (l *Logger) Run(ctx context.Context) {
+	if l == nil || l.sink == nil {
+		return
+	}
+	l.sink.run(ctx)
+}
+
+// Record writes an audit record of a write to the object, made by the
+// controller. body is the declared request body: a []byte patch, or an object
+// which is hashed as JSON. body is nil for deletes. err is the error of the write.
+// The commit is read from the context.
+func (l *Logger) Record(ctx context.Context, controller string, operation Operation, id core.ID, body interface{}, err error) {
+	if l == nil {
+		return
+	}
+	record := Record{
+		Cluster:        l.cluster,
+		SyncKind:       l.syncKind,
+		SyncNamespace:  l.syncNamespace,
+		SyncName:       l.syncName,
+		Controller:     controller,
+		Operation:      operation,
+		Object:         id.String(),
+		Commit:         CommitFrom(ctx),
+		DeclaredSHA256: declaredHash(body),
+		Result:         ResultSuccess,
+	}
+	if err != nil {
+		record.Result = ResultFailure
+		record.Error = err.Error()
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	record.Sequence = l.seq + 1
+	record.Timestamp = l.clock.Now().UTC()
+	record.PrevHash = l.lastHash
+	hash, hashErr := record.computeHash()
+	if hashErr != nil {
+		klog.Errorf("Failed to hash the audit record of %s %v: %v", operation, id, hashErr)
+		return
+	}
+	record.Hash = hash
+	line, jsonErr := json.Marshal(record)
+	if jsonErr != nil {
+		klog.Errorf("Failed to encode the audit record of %s %v: %v", operation, id, jsonErr)
+		return
+	}
+	if l.file != nil {
+		// The record is still chained and sent to the sink, so the missing
+		// record shows as a gap in the sequence of the file.
+		if writeErr := l.file.write(append(line, '\n')); writeErr != nil {
+			klog.Errorf("Failed to write the audit record of %s %v: %v", operation, id, writeErr)
+		}
+	}
+	l.seq = record.Sequence
+	l.lastHash = record.Hash
+	l.sink.enqueue(line)
+}
+
+// declaredHash returns the hex SHA-256 of the declared request body, or an
+// empty string if there is no body.
+func declaredHash(body interface{}) string {
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case []byte:
+		data = b
+	default:
+		var err error
+		data, err = json.Marshal(b)
+		if err != nil {
+			klog.Warningf("Failed to encode the audited request body: %v", err)
+			return ""
+		}
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type commitKey struct{}
+
+// WithCommit returns a context with the source commit, which is recorded in
+// the audit records of the writes made with the context.
+func WithCommit(ctx context.Context, commit string) context.Context {
+	return context.WithValue(ctx, commitKey{}, commit)
+}
+
+// CommitFrom returns the source commit of the context, or an empty string.
+func CommitFrom(ctx context.Context) string {
+	commit, _ := ctx.Value(commitKey{}).(string)
+	return commit
+}
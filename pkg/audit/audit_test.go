@@ -0,0 +1,322 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+	testingclock "k8s.io/utils/clock/testing"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+)
+
+const reconcilerName = "root-reconciler"
+
+var now = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+func newTestLogger(t *testing.T, spec v1beta1.AuditLog, dir string) *Logger {
+	t.Helper()
+	l, err := New(spec, Options{
+		Dir:            dir,
+		ReconcilerName: reconcilerName,
+		ClusterName:    "my-cluster",
+		SyncKind:       "RootSync",
+		SyncNamespace:  "config-management-system",
+		SyncName:       "root-sync",
+	})
+	require.NoError(t, err)
+	l.clock = testingclock.NewFakePassiveClock(now)
+	return l
+}
+
+func readRecords(t *testing.T, data []byte) []Record {
+	t.Helper()
+	var records []Record
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		record := Record{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	return records
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestRecord(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLogger(t, v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, dir)
+	deployment := k8sobjects.DeploymentObject(core.Namespace("bookstore"), core.Name("web"))
+	patch := []byte(`{"metadata":{"labels":null}}`)
+	ctx := WithCommit(context.Background(), "abc123")
+
+	l.Record(ctx, "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	l.Record(ctx, "applier", OperationPatch, core.IDOf(deployment), patch, nil)
+	l.Record(context.Background(), "remediator", OperationDelete, core.IDOf(deployment), nil, errors.New("forbidden"))
+
+	data, err := os.ReadFile(filepath.Join(dir, reconcilerName+".log"))
+	require.NoError(t, err)
+	result, err := Verify(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	assert.Equal(t, VerifyResult{Records: 3}, result)
+
+	deploymentJSON, err := json.Marshal(deployment)
+	require.NoError(t, err)
+	records := readRecords(t, data)
+	for i := range records {
+		assert.NotEmpty(t, records[i].Hash)
+		records[i].Hash = ""
+		records[i].PrevHash = ""
+	}
+	base := Record{
+		Timestamp:     now,
+		Cluster:       "my-cluster",
+		SyncKind:      "RootSync",
+		SyncNamespace: "config-management-system",
+		SyncName:      "root-sync",
+		Object:        "Deployment.apps, bookstore/web",
+	}
+	expected := []Record{base, base, base}
+	expected[0].Sequence = 1
+	expected[0].Controller = "applier"
+	expected[0].Operation = OperationApply
+	expected[0].Commit = "abc123"
+	expected[0].DeclaredSHA256 = sha256Hex(deploymentJSON)
+	expected[0].Result = ResultSuccess
+	expected[1].Sequence = 2
+	expected[1].Controller = "applier"
+	expected[1].Operation = OperationPatch
+	expected[1].Commit = "abc123"
+	expected[1].DeclaredSHA256 = sha256Hex(patch)
+	expected[1].Result = ResultSuccess
+	expected[2].Sequence = 3
+	expected[2].Controller = "remediator"
+	expected[2].Operation = OperationDelete
+	expected[2].Result = ResultFailure
+	expected[2].Error = "forbidden"
+	assert.Equal(t, expected, records)
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLogger(t, v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, dir)
+	deployment := k8sobjects.DeploymentObject(core.Namespace("bookstore"), core.Name("web"))
+	for i := 0; i < 3; i++ {
+		l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, reconcilerName+".log"))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+
+	testCases := []struct {
+		name        string
+		lines       []string
+		prevHash    string
+		expectedErr string
+	}{
+		{
+			name:  "valid chain",
+			lines: lines,
+		},
+		{
+			name:  "valid chain after rotated files were deleted",
+			lines: lines[1:],
+		},
+		{
+			name:        "modified record",
+			lines:       []string{lines[0], strings.Replace(lines[1], `"result":"success"`, `"result":"failure"`, 1), lines[2]},
+			expectedErr: "record 2: hash mismatch",
+		},
+		{
+			name:        "removed record",
+			lines:       []string{lines[0], lines[2]},
+			expectedErr: "record 3: expected record 2",
+		},
+		{
+			name:        "reordered records",
+			lines:       []string{lines[0], lines[2], lines[1]},
+			expectedErr: "record 3: expected record 2",
+		},
+		{
+			name:  "truncated record",
+			lines: []string{lines[0], `{"seq":2,"ts":`, lines[1], lines[2]},
+		},
+		{
+			name:        "record modified into invalid JSON",
+			lines:       []string{lines[0], lines[1][:len(lines[1])-1], lines[2]},
+			expectedErr: "record 3: expected record 2",
+		},
+		{
+			name:        "unexpected previous hash",
+			lines:       lines[1:],
+			prevHash:    "0000",
+			expectedErr: "record 2: previous hash mismatch",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Verify(strings.NewReader(strings.Join(tc.lines, "\n")), tc.prevHash)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRestartContinuesChain(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, reconcilerName+".log")
+	deployment := k8sobjects.DeploymentObject()
+
+	l := newTestLogger(t, v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, dir)
+	l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	// Simulate a record truncated by a killed reconciler.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":2,"ts":`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	l = newTestLogger(t, v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, dir)
+	l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, `{"seq":2,"ts":`, lines[1])
+	result, err := Verify(bytes.NewReader(data), "")
+	require.NoError(t, err)
+	assert.Equal(t, VerifyResult{Records: 2, TruncatedLines: []int{2}}, result)
+}
+
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, reconcilerName+".log")
+	deployment := k8sobjects.DeploymentObject()
+	l := newTestLogger(t, v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}}, dir)
+
+	// Measure the size of a record, to rotate the file every 2 records. The
+	// first record is smaller, since it has no previous hash.
+	l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(path))
+	l.seq = 0
+	l.lastHash = ""
+	l.file, err = openRotatingFile(path, 3*info.Size(), 2)
+	require.NoError(t, err)
+
+	for i := 0; i < 7; i++ {
+		l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	}
+
+	var all []byte
+	for _, p := range []string{backupPath(path, 2), backupPath(path, 1), path} {
+		data, err := os.ReadFile(p)
+		require.NoError(t, err)
+		all = append(all, data...)
+	}
+	assert.NoFileExists(t, backupPath(path, 3))
+	records := readRecords(t, all)
+	require.Len(t, records, 5)
+	assert.Equal(t, uint64(3), records[0].Sequence)
+	assert.Equal(t, uint64(7), records[4].Sequence)
+	result, err := Verify(bytes.NewReader(all), "")
+	require.NoError(t, err)
+	assert.Equal(t, VerifyResult{Records: 5}, result)
+
+	// The chain continues from the rotated file if the file is empty.
+	require.NoError(t, os.Rename(path, backupPath(path, 1)))
+	last, err := lastRecord(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), last.Sequence)
+}
+
+func TestSink(t *testing.T) {
+	var mux sync.Mutex
+	var bodies []string
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		bodies = append(bodies, string(body))
+	}))
+	defer server.Close()
+
+	l := newTestLogger(t, v1beta1.AuditLog{SinkURL: server.URL}, "")
+	l.sink.flushInterval = 10 * time.Millisecond
+	l.sink.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go l.Run(ctx)
+
+	deployment := k8sobjects.DeploymentObject()
+	for i := 0; i < 3; i++ {
+		l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	}
+
+	var received string
+	require.Eventually(t, func() bool {
+		mux.Lock()
+		defer mux.Unlock()
+		received = strings.Join(bodies, "")
+		return strings.Count(received, "\n") == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	result, err := Verify(strings.NewReader(received), "")
+	require.NoError(t, err)
+	assert.Equal(t, VerifyResult{Records: 3}, result)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(v1beta1.AuditLog{SinkURL: "ftp://example.com"}, Options{})
+	assert.ErrorContains(t, err, "scheme must be http or https")
+
+	var l *Logger
+	deployment := k8sobjects.DeploymentObject()
+	l.Record(context.Background(), "applier", OperationApply, core.IDOf(deployment), deployment, nil)
+	l.Run(context.Background())
+}
@@ -0,0 +1,229 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxRecordSize is the maximum size of a line of the audit log file.
+const maxRecordSize = 1024 * 1024
+
+// rotatingFile is a file that is rotated when it reaches its maximum size.
+// The rotated files are named after the file, with the suffixes .1 (the most
+// recent) to .<maxBackups> (the oldest).
+type rotatingFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens the file for appending, creating it if needed.
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	if f.size > 0 {
+		return f.terminateLastLine()
+	}
+	return nil
+}
+
+// terminateLastLine appends a newline if the file doesn't end with one, like
+// when the reconciler was killed while writing a record, so that the next
+// record starts on its own line.
+func (f *rotatingFile) terminateLastLine() error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, f.size-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+	n, err := f.file.Write([]byte{'\n'})
+	f.size += int64(n)
+	return err
+}
+
+// write appends the data to the file, after rotating the file if the data
+// would make it exceed its maximum size. Records are never split across
+// files.
+func (f *rotatingFile) write(data []byte) error {
+	if f.size > 0 && f.size+int64(len(data)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return fmt.Errorf("rotating %s: %w", f.path, err)
+		}
+	}
+	n, err := f.file.Write(data)
+	f.size += int64(n)
+	return err
+}
+
+// rotate renames the file to <path>.1, after shifting the previous rotated
+// files and removing the oldest one, and opens a new file.
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Remove(backupPath(f.path, f.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupPath(f.path, i), backupPath(f.path, i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(f.path, backupPath(f.path, 1)); err != nil {
+		return err
+	}
+	return f.open()
+}
+
+func backupPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
+
+// lastRecord returns the last record of the audit log file, or of the most
+// recent rotated file if the file is missing or empty. Returns nil if there
+// are no records.
+func lastRecord(path string) (*Record, error) {
+	for _, p := range []string{path, backupPath(path, 1)} {
+		file, err := os.Open(p)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		last, err := readLastRecord(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		if last != nil {
+			return last, nil
+		}
+	}
+	return nil, nil
+}
+
+func readLastRecord(r io.Reader) (*Record, error) {
+	var last *Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		record := &Record{}
+		if err := json.Unmarshal(line, record); err != nil {
+			// A record may be truncated if the reconciler was killed while
+			// writing it. The chain continues from the last complete record.
+			continue
+		}
+		last = record
+	}
+	return last, scanner.Err()
+}
+
+// VerifyResult is the result of Verify.
+type VerifyResult struct {
+	// Records is the number of records verified.
+	Records int
+	// TruncatedLines are the line numbers of the records that were truncated,
+	// like when the reconciler was killed while writing them. The chain
+	// continues from the last complete record, so they are skipped.
+	TruncatedLines []int
+}
+
+// Verify reads the records of an audit log, and returns an error if a record
+// was modified, or if a record is missing or out of order.
+// prevHash is the hash of the record before the first record read, or empty
+// to accept the chain of the first record read, like for a rotated file whose
+// previous files were deleted.
+// Lines that are not valid records are reported as truncated records, like
+// lastRecord skips them when the chain continues. A modified record that is
+// no longer valid JSON still breaks the chain of the next record.
+func Verify(r io.Reader, prevHash string) (VerifyResult, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	result := VerifyResult{}
+	var prev *Record
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		record := Record{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			result.TruncatedLines = append(result.TruncatedLines, lineNumber)
+			continue
+		}
+		hash, err := record.computeHash()
+		if err != nil {
+			return result, err
+		}
+		switch {
+		case hash != record.Hash:
+			return result, fmt.Errorf("record %d: hash mismatch: the record was modified", record.Sequence)
+		case prev != nil && record.Sequence != prev.Sequence+1:
+			return result, fmt.Errorf("record %d: expected record %d: a record is missing or out of order", record.Sequence, prev.Sequence+1)
+		case (prev != nil || prevHash != "") && record.PrevHash != prevHash:
+			return result, fmt.Errorf("record %d: previous hash mismatch: a record is missing or out of order", record.Sequence)
+		}
+		prevHash = record.Hash
+		prev = &record
+		result.Records++
+	}
+	return result, scanner.Err()
+}
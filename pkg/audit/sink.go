@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/util"
+)
+
+const (
+	// sinkQueueSize is the number of records buffered for the sink. Records
+	// are dropped when the queue is full.
+	sinkQueueSize = 1000
+	// sinkBatchSize is the maximum number of records POSTed in a request.
+	sinkBatchSize = 100
+	// sinkFlushInterval is how long records are buffered before they are
+	// POSTed, unless a batch is full.
+	sinkFlushInterval = 5 * time.Second
+	// sinkRequestTimeout is the timeout of each POST request.
+	sinkRequestTimeout = 10 * time.Second
+)
+
+// sink POSTs batches of records to an HTTP endpoint, as newline-delimited
+// JSON.
+type sink struct {
+	url           string
+	client        *http.Client
+	backoff       wait.Backoff
+	flushInterval time.Duration
+	queue         chan []byte
+}
+
+func newSink(url string) *sink {
+	return &sink{
+		url:           url,
+		client:        &http.Client{Timeout: sinkRequestTimeout},
+		backoff:       util.HTTPRetryBackoff(),
+		flushInterval: sinkFlushInterval,
+		queue:         make(chan []byte, sinkQueueSize),
+	}
+}
+
+// enqueue queues the record for the sink, or drops it if the queue is full.
+// The dropped record shows as a gap in the sequence of the records received
+// by the sink.
+func (s *sink) enqueue(record []byte) {
+	if s == nil {
+		return
+	}
+	select {
+	case s.queue <- record:
+	default:
+		klog.Warningf("Dropped audit record: the queue of the audit log sink is full")
+	}
+}
+
+// run POSTs the queued records until the context is cancelled.
+func (s *sink) run(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+	var batch [][]byte
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.send(ctx, batch); err != nil {
+			klog.Warningf("Failed to send %d audit records to the audit log sink: %v", len(batch), err)
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-s.queue:
+			batch = append(batch, record)
+			if len(batch) >= sinkBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send POSTs the batch of records, and retries with exponential backoff if
+// the request fails with a network error, a 429 or a 5xx response.
+func (s *sink) send(ctx context.Context, batch [][]byte) error {
+	var body bytes.Buffer
+	for _, record := range batch {
+		body.Write(record)
+		body.WriteByte('\n')
+	}
+	return util.PostWithRetry(ctx, s.client, s.backoff, "the request to the audit log sink", s.url, "application/x-ndjson", body.Bytes())
+}
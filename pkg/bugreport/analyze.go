@@ -0,0 +1,280 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bugreport
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/yaml"
+)
+
+// ProblemCategory is the category of a problem detected in a bug report.
+type ProblemCategory string
+
+const (
+	// CrashLoopProblem indicates a container which restarts repeatedly.
+	CrashLoopProblem = ProblemCategory("CrashLoop")
+	// ErrorCodeProblem indicates a KNV error reported by a RootSync or RepoSync,
+	// or logged by a container.
+	ErrorCodeProblem = ProblemCategory("ErrorCode")
+	// StaleCommitProblem indicates a RootSync or RepoSync which has not synced
+	// the latest commit of its source.
+	StaleCommitProblem = ProblemCategory("StaleCommit")
+	// ManagerConflictProblem indicates objects declared by more than one
+	// RootSync or RepoSync.
+	ManagerConflictProblem = ProblemCategory("ManagerConflict")
+)
+
+// crashLoopRestartThreshold is the number of restarts after which a container
+// is considered to be crashlooping.
+const crashLoopRestartThreshold = 3
+
+// knvCodePattern matches the KNV error codes in logs.
+var knvCodePattern = regexp.MustCompile(`KNV(\d{4})`)
+
+// knownErrorCodes describes the likely cause of well known KNV error codes.
+var knownErrorCodes = map[string]string{
+	status.UnknownKindErrorCode:            "a declared resource type is not installed on the cluster",
+	status.ManagementConflictErrorCode:     "an object is declared by more than one RootSync or RepoSync",
+	status.ActionableHydrationErrorCode:    "rendering the source failed because of an issue in the source",
+	status.SourceErrorCode:                 "fetching the source failed; check the source URL and credentials",
+	status.EmptySourceErrorCode:            "the source would prune too many objects",
+	applier.ApplierErrorCode:               "applying objects to the cluster failed",
+	status.InsufficientPermissionErrorCode: "the reconciler does not have permission to manage the declared objects",
+	status.InternalHydrationErrorCode:      "rendering the source failed",
+}
+
+// Problem is a problem detected in a bug report.
+type Problem struct {
+	// Category is the category of the problem.
+	Category ProblemCategory
+	// Path is the path of the file in the bug report which shows the problem.
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (p Problem) String() string {
+	return fmt.Sprintf("[%s] %s: %s", p.Category, p.Path, p.Message)
+}
+
+// AnalyzeFile detects problems in the bug report zip file with the specified
+// name.
+func AnalyzeFile(name string) ([]Problem, error) {
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bug report %s: %w", name, err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return Analyze(&r.Reader)
+}
+
+// Analyze detects problems in a bug report zip archive.
+//
+// The returned problems are sorted by category and path.
+func Analyze(r *zip.Reader) ([]Problem, error) {
+	var problems []Problem
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		// Bug reports created on Windows use backslashes as separators.
+		name := strings.ReplaceAll(f.Name, `\`, "/")
+		base := path.Base(name)
+		var analyze func(string, []byte) ([]Problem, error)
+		switch {
+		case base == "pods.yaml":
+			analyze = analyzePods
+		case strings.HasPrefix(base, configsync.RootSyncKind+"-") && strings.HasSuffix(base, ".yaml"),
+			strings.HasPrefix(base, configsync.RepoSyncKind+"-") && strings.HasSuffix(base, ".yaml"):
+			analyze = analyzeRSync
+		case strings.HasSuffix(base, ".log"):
+			analyze = analyzeLog
+		default:
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, err
+		}
+		found, err := analyze(name, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze %s: %w", name, err)
+		}
+		problems = append(problems, found...)
+	}
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Category != problems[j].Category {
+			return problems[i].Category < problems[j].Category
+		}
+		return problems[i].Path < problems[j].Path
+	})
+	return problems, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	return data, nil
+}
+
+// analyzePods detects crashlooping containers.
+func analyzePods(name string, data []byte) ([]Problem, error) {
+	podList := &corev1.PodList{}
+	if err := yaml.Unmarshal(data, podList); err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	for _, pod := range podList.Items {
+		component := "pod"
+		if _, found := pod.Labels[metadata.ReconcilerLabel]; found {
+			component = "reconciler pod"
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			crashLoop := cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff"
+			if !crashLoop && cs.RestartCount < crashLoopRestartThreshold {
+				continue
+			}
+			msg := fmt.Sprintf("container %q of %s %s/%s is crashlooping (%d restarts)",
+				cs.Name, component, pod.Namespace, pod.Name, cs.RestartCount)
+			if t := cs.LastTerminationState.Terminated; t != nil {
+				msg += fmt.Sprintf("; last terminated with reason %q and exit code %d", t.Reason, t.ExitCode)
+			}
+			problems = append(problems, Problem{Category: CrashLoopProblem, Path: name, Message: msg})
+		}
+	}
+	return problems, nil
+}
+
+// rsync is the subset of a RootSync or RepoSync that is analyzed.
+type rsync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            v1beta1.Status `json:"status,omitempty"`
+}
+
+// analyzeRSync detects errors and stale commits reported by a RootSync or
+// RepoSync.
+func analyzeRSync(name string, data []byte) ([]Problem, error) {
+	rs := &rsync{}
+	if err := yaml.Unmarshal(data, rs); err != nil {
+		return nil, err
+	}
+	id := fmt.Sprintf("%s %s/%s", rs.Kind, rs.Namespace, rs.Name)
+	var problems []Problem
+
+	sourceCommit := rs.Status.Source.Commit
+	if sourceCommit != "" && rs.Status.Sync.Commit != sourceCommit {
+		syncCommit := rs.Status.Sync.Commit
+		if syncCommit == "" {
+			syncCommit = "none"
+		}
+		problems = append(problems, Problem{
+			Category: StaleCommitProblem,
+			Path:     name,
+			Message: fmt.Sprintf("%s fetched commit %q but last synced commit %q",
+				id, sourceCommit, syncCommit),
+		})
+	}
+
+	stages := []struct {
+		stage  string
+		errors []v1beta1.ConfigSyncError
+	}{
+		{stage: "source", errors: rs.Status.Source.Errors},
+		{stage: "rendering", errors: rs.Status.Rendering.Errors},
+		{stage: "sync", errors: rs.Status.Sync.Errors},
+	}
+	for _, s := range stages {
+		for _, e := range s.errors {
+			code := strings.TrimPrefix(e.Code, "KNV")
+			problems = append(problems, Problem{
+				Category: errorCodeCategory(code),
+				Path:     name,
+				Message:  fmt.Sprintf("%s reports %s error %s", id, s.stage, describeErrorCode(code)),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// analyzeLog detects KNV error codes in a container log.
+func analyzeLog(name string, data []byte) ([]Problem, error) {
+	counts := make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		for _, match := range knvCodePattern.FindAllStringSubmatch(scanner.Text(), -1) {
+			counts[match[1]]++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	codes := make([]string, 0, len(counts))
+	for code := range counts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	var problems []Problem
+	for _, code := range codes {
+		problems = append(problems, Problem{
+			Category: errorCodeCategory(code),
+			Path:     name,
+			Message:  fmt.Sprintf("error %s logged %d times", describeErrorCode(code), counts[code]),
+		})
+	}
+	return problems, nil
+}
+
+func errorCodeCategory(code string) ProblemCategory {
+	if code == status.ManagementConflictErrorCode {
+		return ManagerConflictProblem
+	}
+	return ErrorCodeProblem
+}
+
+func describeErrorCode(code string) string {
+	if cause, found := knownErrorCodes[code]; found {
+		return fmt.Sprintf("KNV%s (%s)", code, cause)
+	}
+	return "KNV" + code
+}
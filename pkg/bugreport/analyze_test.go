@@ -0,0 +1,141 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bugreport
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPods = `apiVersion: v1
+kind: PodList
+items:
+- metadata:
+    name: root-reconciler-abc
+    namespace: config-management-system
+    labels:
+      configsync.gke.io/reconciler: root-reconciler
+  status:
+    containerStatuses:
+    - name: reconciler
+      restartCount: 7
+      state:
+        waiting:
+          reason: CrashLoopBackOff
+      lastState:
+        terminated:
+          reason: OOMKilled
+          exitCode: 137
+    - name: git-sync
+      restartCount: 0
+- metadata:
+    name: reconciler-manager-abc
+    namespace: config-management-system
+  status:
+    containerStatuses:
+    - name: reconciler-manager
+      restartCount: 1
+`
+
+const testRootSync = `apiVersion: configsync.gke.io/v1beta1
+kind: RootSync
+metadata:
+  name: root-sync
+  namespace: config-management-system
+status:
+  source:
+    commit: def456
+  sync:
+    commit: abc123
+    errors:
+    - code: "1060"
+      errorMessage: declared in another repository
+`
+
+const testHealthyRepoSync = `apiVersion: configsync.gke.io/v1beta1
+kind: RepoSync
+metadata:
+  name: repo-sync
+  namespace: bookstore
+status:
+  source:
+    commit: abc123
+  sync:
+    commit: abc123
+`
+
+const testLog = `2026-01-02T15:04:05.000000000Z E0102 KNV2004: failed to fetch the source
+2026-01-02T15:04:06.000000000Z E0102 KNV2004: failed to fetch the source
+2026-01-02T15:04:07.000000000Z E0102 KNV9999: something unexpected
+`
+
+func TestAnalyze(t *testing.T) {
+	files := map[string]string{
+		"bug_report_1/raw/ctx/namespaces/config-management-system/pods.yaml":                          testPods,
+		"bug_report_1/raw/ctx/namespaces/config-management-system/RootSync-root-sync.yaml":            testRootSync,
+		"bug_report_1/raw/ctx/namespaces/bookstore/RepoSync-repo-sync.yaml":                           testHealthyRepoSync,
+		"bug_report_1/raw/ctx/namespaces/config-management-system/root-reconciler-abc/reconciler.log": testLog,
+		"bug_report_1/processed/ctx/version.txt":                                                      "KNV1060 is ignored outside of logs",
+	}
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	problems, err := Analyze(r)
+	require.NoError(t, err)
+
+	want := []Problem{
+		{
+			Category: CrashLoopProblem,
+			Path:     "bug_report_1/raw/ctx/namespaces/config-management-system/pods.yaml",
+			Message: `container "reconciler" of reconciler pod config-management-system/root-reconciler-abc is crashlooping (7 restarts); ` +
+				`last terminated with reason "OOMKilled" and exit code 137`,
+		},
+		{
+			Category: ErrorCodeProblem,
+			Path:     "bug_report_1/raw/ctx/namespaces/config-management-system/root-reconciler-abc/reconciler.log",
+			Message:  "error KNV2004 (fetching the source failed; check the source URL and credentials) logged 2 times",
+		},
+		{
+			Category: ErrorCodeProblem,
+			Path:     "bug_report_1/raw/ctx/namespaces/config-management-system/root-reconciler-abc/reconciler.log",
+			Message:  "error KNV9999 logged 1 times",
+		},
+		{
+			Category: ManagerConflictProblem,
+			Path:     "bug_report_1/raw/ctx/namespaces/config-management-system/RootSync-root-sync.yaml",
+			Message: "RootSync config-management-system/root-sync reports sync error " +
+				"KNV1060 (an object is declared by more than one RootSync or RepoSync)",
+		},
+		{
+			Category: StaleCommitProblem,
+			Path:     "bug_report_1/raw/ctx/namespaces/config-management-system/RootSync-root-sync.yaml",
+			Message:  `RootSync config-management-system/root-sync fetched commit "def456" but last synced commit "abc123"`,
+		},
+	}
+	assert.Equal(t, want, problems)
+}
@@ -42,9 +42,11 @@ import (
 	"kpt.dev/configsync/cmd/nomos/version"
 	"kpt.dev/configsync/pkg/api/configmanagement"
 	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
 	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/metadata"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
@@ -70,6 +72,10 @@ type BugReporter struct {
 	enabled   map[Product]bool
 	util.ConfigManagementClient
 	k8sContext string
+	opts       Options
+	// reconcilers is the set of names of the reconcilers to collect, or nil
+	// to collect all reconcilers.
+	reconcilers map[string]struct{}
 	// report file
 	outFile       *os.File
 	writer        *zip.Writer
@@ -79,7 +85,7 @@ type BugReporter struct {
 }
 
 // New creates a new BugReport
-func New(ctx context.Context, c client.Client, cs *kubernetes.Clientset) (*BugReporter, error) {
+func New(ctx context.Context, c client.Client, cs *kubernetes.Clientset, opts Options) (*BugReporter, error) {
 	cm := &unstructured.Unstructured{}
 	cm.SetGroupVersionKind(schema.GroupVersionKind{
 		Group: configmanagement.GroupName,
@@ -117,6 +123,7 @@ func New(ctx context.Context, c client.Client, cs *kubernetes.Clientset) (*BugRe
 		clientSet:     cs,
 		cm:            cm,
 		k8sContext:    currentk8sContext,
+		opts:          opts,
 		ErrorList:     errorList,
 		WritingErrors: []error{},
 	}, nil
@@ -160,6 +167,8 @@ func (b *BugReporter) EnabledServices() map[Product]bool {
 func (b *BugReporter) FetchLogSources(ctx context.Context) []Readable {
 	var toBeLogged logSources
 
+	b.reconcilers = b.selectedReconcilers(ctx)
+
 	// for each namespace, generate a list of logSources
 	listOps := client.ListOptions{}
 	nsLabels := map[string]string{"configmanagement.gke.io/system": "true"}
@@ -229,7 +238,59 @@ func (b *BugReporter) logSourcesForNamespace(ctx context.Context, name string, l
 		return nil, wrap(err, "failed to list pods for namespace %v", name)
 	}
 
-	return assembleLogSources(*ns, *pods), nil
+	return assembleLogSources(*ns, b.selectPods(*pods), b.opts.sinceSeconds()), nil
+}
+
+// selectedReconcilers returns the set of names of the reconcilers of the
+// selected RootSyncs and RepoSyncs, or nil if all of them are selected.
+func (b *BugReporter) selectedReconcilers(ctx context.Context) map[string]struct{} {
+	if b.opts.selectsAllSyncs() {
+		return nil
+	}
+	reconcilers := make(map[string]struct{})
+	// Explicitly selected reconcilers are collected even if their RSync is gone.
+	for _, s := range b.opts.Syncs {
+		reconcilers[reconcilerName(s.Namespace, s.Name)] = struct{}{}
+	}
+	rootSyncs := &v1beta1.RootSyncList{}
+	if err := b.client.List(ctx, rootSyncs); err != nil {
+		b.ErrorList = append(b.ErrorList, fmt.Errorf("failed to list RootSyncs: %v", err))
+	}
+	for _, rs := range rootSyncs.Items {
+		if b.opts.selectsSync(rs.Namespace, rs.Name) {
+			reconcilers[reconcilerName(rs.Namespace, rs.Name)] = struct{}{}
+		}
+	}
+	repoSyncs := &v1beta1.RepoSyncList{}
+	if err := b.client.List(ctx, repoSyncs); err != nil {
+		b.ErrorList = append(b.ErrorList, fmt.Errorf("failed to list RepoSyncs: %v", err))
+	}
+	for _, rs := range repoSyncs.Items {
+		if b.opts.selectsSync(rs.Namespace, rs.Name) {
+			reconcilers[reconcilerName(rs.Namespace, rs.Name)] = struct{}{}
+		}
+	}
+	return reconcilers
+}
+
+// selectPods returns the pods which are not reconcilers, and the pods of the
+// selected reconcilers.
+func (b *BugReporter) selectPods(pods corev1.PodList) corev1.PodList {
+	if b.reconcilers == nil {
+		return pods
+	}
+	selected := pods.DeepCopy()
+	selected.Items = nil
+	for _, p := range pods.Items {
+		name, isReconciler := p.Labels[metadata.ReconcilerLabel]
+		if isReconciler {
+			if _, found := b.reconcilers[name]; !found {
+				continue
+			}
+		}
+		selected.Items = append(selected.Items, p)
+	}
+	return *selected
 }
 
 func (b *BugReporter) fetchNamespace(ctx context.Context, name string, nsLabels map[string]string) (*corev1.Namespace, error) {
@@ -261,14 +322,15 @@ func (b *BugReporter) listPods(ctx context.Context, ns corev1.Namespace, lOps cl
 	return pods, nil
 }
 
-func assembleLogSources(ns corev1.Namespace, pods corev1.PodList) logSources {
+func assembleLogSources(ns corev1.Namespace, pods corev1.PodList, sinceSeconds *int64) logSources {
 	var ls logSources
 	for _, p := range pods.Items {
 		for _, c := range p.Spec.Containers {
 			ls = append(ls, &logSource{
-				ns:   ns,
-				pod:  p,
-				cont: c,
+				ns:           ns,
+				pod:          p,
+				cont:         c,
+				sinceSeconds: sinceSeconds,
 			})
 		}
 	}
@@ -332,7 +394,7 @@ func (b *BugReporter) fetchConfigMaps(ctx context.Context) (rd []Readable) {
 			if strings.HasSuffix(cm.Name, "git-sync") {
 				for _, k := range redactKeys {
 					if _, ok := cm.Data[k]; ok {
-						cm.Data[k] = "<REDACTED>"
+						cm.Data[k] = RedactedValue
 					}
 				}
 			}
@@ -368,6 +430,9 @@ func (b *BugReporter) FetchResources(ctx context.Context) []Readable {
 
 	var namespacedResourceToReadables = func(u *unstructured.UnstructuredList, _ string) (r []Readable) {
 		for _, o := range u.Items {
+			if !b.selectsResource(o) {
+				continue
+			}
 			r = b.appendPrettyYaml(r, pathToNamespacedResource(o.GetNamespace(), o.GetKind(), o.GetName()), o)
 		}
 		return r
@@ -394,6 +459,18 @@ func (b *BugReporter) FetchResources(ctx context.Context) []Readable {
 	return rd
 }
 
+// selectsResource returns false for the RootSyncs, RepoSyncs and
+// ResourceGroups which are not selected. The ResourceGroup inventory of a
+// RootSync or RepoSync has the same namespace and name as the RSync.
+func (b *BugReporter) selectsResource(o unstructured.Unstructured) bool {
+	switch o.GetKind() {
+	case configsync.RootSyncKind, configsync.RepoSyncKind, v1alpha1.ResourceGroupKind:
+		return b.opts.selectsSync(o.GetNamespace(), o.GetName())
+	default:
+		return true
+	}
+}
+
 func pathToNamespacedResource(namespace, kind, name string) string {
 	return path.Join(Namespace, namespace, kind+"-"+name)
 }
@@ -416,6 +493,7 @@ func (b *BugReporter) FetchCMSystemPods(ctx context.Context) (rd []Readable) {
 		if err != nil {
 			b.ErrorList = append(b.ErrorList, fmt.Errorf("failed to list %s pods: %v", ns, err))
 		} else {
+			*podList = b.selectPods(*podList)
 			rd = b.appendPrettyYaml(rd, pathToNamespacePodList(ns), podList)
 		}
 	}
@@ -439,32 +517,14 @@ func (b *BugReporter) AddNomosStatusToZip(ctx context.Context) {
 	}()
 	if err != nil {
 		b.ErrorList = append(b.ErrorList, err)
-	} else if err = b.copyToZip(tmpFile, path.Join(Processed, b.k8sContext, "status.txt")); err != nil {
+	} else if err = b.writeReadableToZip(Readable{
+		Name:       path.Join(Processed, b.k8sContext, "status.txt"),
+		ReadCloser: tmpFile,
+	}); err != nil {
 		b.WritingErrors = append(b.WritingErrors, err)
 	}
 }
 
-// copyToZip copies the content from the input file to the zip file.
-func (b *BugReporter) copyToZip(inputFile *os.File, zipFileName string) error {
-	baseName := filepath.Base(b.name)
-	dirName := strings.TrimSuffix(baseName, filepath.Ext(baseName))
-	fileName := filepath.FromSlash(filepath.Join(dirName, zipFileName))
-	zipFile, err := b.writer.Create(fileName)
-	if err != nil {
-		e := fmt.Errorf("failed to create file %v inside zip: %v", fileName, err)
-		return e
-	}
-	if _, err = io.Copy(zipFile, inputFile); err != nil {
-		return fmt.Errorf("failed to copy file %s to zip %s: %v", inputFile.Name(), fileName, err)
-	}
-	if err = inputFile.Close(); err != nil {
-		klog.Errorf("failed to close file %s: %v", inputFile.Name(), err)
-	}
-
-	fmt.Println("Wrote file " + fileName)
-	return nil
-}
-
 // AddNomosVersionToZip writes `nomos version` to bugreport zip file
 func (b *BugReporter) AddNomosVersionToZip(ctx context.Context) {
 	if versionRc, err := version.GetVersionReadCloser(ctx); err != nil {
@@ -488,7 +548,33 @@ func getReportName() string {
 	return nameWithPath
 }
 
+// sanitize returns a ReadCloser which removes the log lines outside of the
+// collected time window, and redacts sensitive data, from the readable.
+func (b *BugReporter) sanitize(readable Readable) io.ReadCloser {
+	if b.opts.Until.IsZero() && b.opts.Redactor == nil {
+		return readable.ReadCloser
+	}
+	isLog := strings.HasSuffix(readable.Name, ".log")
+	until := b.opts.untilFilter()
+	return filterLines(readable.ReadCloser, func(line string) (string, bool) {
+		if isLog {
+			var keep bool
+			if line, keep = until(line); !keep {
+				return line, false
+			}
+		}
+		return b.opts.Redactor.RedactString(line), true
+	})
+}
+
 func (b *BugReporter) writeReadableToZip(readable Readable) error {
+	rc := b.sanitize(readable)
+	defer func() {
+		if err := rc.Close(); err != nil {
+			klog.Errorf("failed to close %s: %v", readable.Name, err)
+		}
+	}()
+
 	baseName := filepath.Base(b.name)
 	dirName := strings.TrimSuffix(baseName, filepath.Ext(baseName))
 	fileName := filepath.FromSlash(filepath.Join(dirName, readable.Name))
@@ -499,7 +585,7 @@ func (b *BugReporter) writeReadableToZip(readable Readable) error {
 	}
 
 	w := bufio.NewWriter(f)
-	_, err = w.ReadFrom(readable.ReadCloser)
+	_, err = w.ReadFrom(rc)
 	if err != nil {
 		e := fmt.Errorf("failed to write file %v to zip: %v", fileName, err)
 		return e
@@ -573,6 +659,11 @@ func (b *BugReporter) Open() (err error) {
 }
 
 func (b *BugReporter) appendPrettyYaml(rd []Readable, pathName string, object interface{}) []Readable {
+	object, err := b.opts.Redactor.RedactObject(object)
+	if err != nil {
+		b.ErrorList = append(b.ErrorList, fmt.Errorf("failed to redact resources %s: %v", pathName, err))
+		return rd
+	}
 	if data, err := yaml.Marshal(object); err != nil {
 		b.ErrorList = append(b.ErrorList, fmt.Errorf("invalid yaml response from resources %s: %v", pathName, err))
 	} else {
@@ -83,7 +83,7 @@ func TestAssembleLogSources(t *testing.T) {
 		test := test
 
 		t.Run(test.name, func(t *testing.T) {
-			outputs := assembleLogSources(test.ns, test.pods)
+			outputs := assembleLogSources(test.ns, test.pods, nil)
 
 			sort.Sort(outputs)
 			sort.Sort(test.expectedValues)
@@ -26,6 +26,8 @@ type logSource struct {
 	ns   v1.Namespace
 	pod  v1.Pod
 	cont v1.Container
+	// sinceSeconds limits the log to the specified number of seconds.
+	sinceSeconds *int64
 }
 
 func (l *logSource) pathName() string {
@@ -33,6 +35,6 @@ func (l *logSource) pathName() string {
 }
 
 func (l *logSource) fetchRcForLogSource(ctx context.Context, cs coreClient) (io.ReadCloser, error) {
-	options := v1.PodLogOptions{Timestamps: true, Container: l.cont.Name}
+	options := v1.PodLogOptions{Timestamps: true, Container: l.cont.Name, SinceSeconds: l.sinceSeconds}
 	return cs.CoreV1().Pods(l.ns.Name).GetLogs(l.pod.Name, &options).Stream(ctx)
 }
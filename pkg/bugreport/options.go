@@ -0,0 +1,124 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bugreport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/core"
+)
+
+// Options limits what a BugReporter collects, and how the collected data is
+// sanitized.
+type Options struct {
+	// Syncs limits the collected RootSyncs, RepoSyncs, ResourceGroups and
+	// reconciler logs to the specified RootSyncs and RepoSyncs.
+	// If both Syncs and Namespaces are empty, all of them are collected.
+	Syncs []types.NamespacedName
+	// Namespaces limits the collected RootSyncs, RepoSyncs, ResourceGroups and
+	// reconciler logs to the RootSyncs and RepoSyncs in the specified
+	// namespaces.
+	Namespaces []string
+	// Since limits the collected logs to the logs newer than this duration.
+	// Zero means no limit.
+	Since time.Duration
+	// Until limits the collected logs to the logs older than this time.
+	// Zero means no limit.
+	Until time.Time
+	// Redactor removes sensitive data from the collected files.
+	// Nil disables redaction.
+	Redactor *Redactor
+}
+
+// ParseSyncs parses a list of RootSyncs and RepoSyncs, each formatted as
+// `NAMESPACE/NAME`. RootSyncs are in the config-management-system namespace.
+func ParseSyncs(syncs []string) ([]types.NamespacedName, error) {
+	var result []types.NamespacedName
+	for _, s := range syncs {
+		ns, name, found := strings.Cut(s, "/")
+		if !found || ns == "" || name == "" {
+			return nil, fmt.Errorf("invalid sync %q: must be formatted as NAMESPACE/NAME", s)
+		}
+		result = append(result, types.NamespacedName{Namespace: ns, Name: name})
+	}
+	return result, nil
+}
+
+// selectsAllSyncs returns true if the options do not limit the collected
+// RootSyncs and RepoSyncs.
+func (o Options) selectsAllSyncs() bool {
+	return len(o.Syncs) == 0 && len(o.Namespaces) == 0
+}
+
+// selectsSync returns true if the RootSync or RepoSync with the specified
+// namespace and name is collected.
+func (o Options) selectsSync(namespace, name string) bool {
+	if o.selectsAllSyncs() {
+		return true
+	}
+	for _, ns := range o.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	for _, s := range o.Syncs {
+		if s.Namespace == namespace && s.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcilerName returns the name of the reconciler of the RootSync or
+// RepoSync with the specified namespace and name.
+func reconcilerName(namespace, name string) string {
+	if namespace == configmanagement.ControllerNamespace {
+		return core.RootReconcilerName(name)
+	}
+	return core.NsReconcilerName(namespace, name)
+}
+
+// sinceSeconds returns the value of the PodLogOptions.SinceSeconds field.
+func (o Options) sinceSeconds() *int64 {
+	if o.Since <= 0 {
+		return nil
+	}
+	seconds := int64(o.Since.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return &seconds
+}
+
+// untilFilter returns a lineFilter which drops the log lines with a timestamp
+// after Until. Lines without a timestamp, like the continuation lines of a
+// multi-line log entry, share the fate of the previous line.
+func (o Options) untilFilter() lineFilter {
+	keep := true
+	return func(line string) (string, bool) {
+		if o.Until.IsZero() {
+			return line, true
+		}
+		prefix, _, _ := strings.Cut(line, " ")
+		if ts, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			keep = !ts.After(o.Until)
+		}
+		return line, keep
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+// providerStatus is a commit status in the terms of this package, which each
+// provider maps to its own API.
+type providerStatus struct {
+	state       State
+	context     string
+	description string
+	targetURL   string
+}
+
+// provider builds the requests that post commit statuses to the API of a Git
+// provider.
+type provider interface {
+	// apiURL returns the base URL of the API.
+	apiURL() string
+	// newRequest returns the request that posts the commit status.
+	newRequest(ctx context.Context, commit string, s providerStatus, token string) (*http.Request, error)
+}
+
+// newProvider returns the provider for the repository. The API URL is derived
+// from the host of the repository if empty.
+func newProvider(name configsync.GitProvider, repo, apiURL string) (provider, error) {
+	scheme, host, path, err := parseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case configsync.GitProviderGitHub:
+		if apiURL == "" {
+			if host == "github.com" {
+				apiURL = "https://api.github.com"
+			} else {
+				apiURL = fmt.Sprintf("%s://%s/api/v3", scheme, host)
+			}
+		}
+		return &githubProvider{api: strings.TrimSuffix(apiURL, "/"), path: path}, nil
+	case configsync.GitProviderGitea:
+		if apiURL == "" {
+			apiURL = fmt.Sprintf("%s://%s/api/v1", scheme, host)
+		}
+		return &githubProvider{api: strings.TrimSuffix(apiURL, "/"), path: path, gitea: true}, nil
+	case configsync.GitProviderGitLab:
+		if apiURL == "" {
+			apiURL = fmt.Sprintf("%s://%s/api/v4", scheme, host)
+		}
+		return &gitlabProvider{api: strings.TrimSuffix(apiURL, "/"), path: path}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Git provider %q: must be %q, %q or %q",
+			name, configsync.GitProviderGitHub, configsync.GitProviderGitLab, configsync.GitProviderGitea)
+	}
+}
+
+// parseRepo returns the scheme of the API, the host and the path of the
+// repository, without the .git suffix. Supports URLs and scp-like SSH
+// addresses, like git@github.com:owner/repo.git. The scheme is https, unless
+// the repository is served over http.
+func parseRepo(repo string) (scheme, host, path string, err error) {
+	scheme = "https"
+	if strings.Contains(repo, "://") {
+		u, err := url.Parse(repo)
+		if err != nil {
+			return "", "", "", fmt.Errorf("invalid repo URL %q: %w", repo, err)
+		}
+		switch u.Scheme {
+		case "http":
+			scheme = "http"
+			host = u.Host
+		case "https":
+			host = u.Host
+		default:
+			// The SSH port doesn't serve the API.
+			host = u.Hostname()
+		}
+		path = u.Path
+	} else if at := strings.Index(repo, "@"); at >= 0 && strings.Contains(repo[at:], ":") {
+		hostPath := repo[at+1:]
+		colon := strings.Index(hostPath, ":")
+		host = hostPath[:colon]
+		path = hostPath[colon+1:]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || !strings.Contains(path, "/") {
+		return "", "", "", fmt.Errorf("invalid repo URL %q: must be the URL of a repository like https://<host>/<owner>/<repo>", repo)
+	}
+	return scheme, host, path, nil
+}
+
+// githubProvider posts commit statuses to the GitHub API, or to the
+// compatible Gitea API.
+type githubProvider struct {
+	api   string
+	path  string
+	gitea bool
+}
+
+type githubStatus struct {
+	State       State  `json:"state"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Context     string `json:"context"`
+}
+
+func (p *githubProvider) apiURL() string {
+	return p.api
+}
+
+func (p *githubProvider) newRequest(ctx context.Context, commit string, s providerStatus, token string) (*http.Request, error) {
+	body, err := json.Marshal(githubStatus{
+		State:       s.state,
+		TargetURL:   s.targetURL,
+		Description: s.description,
+		Context:     s.context,
+	})
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/statuses/%s", p.api, p.path, url.PathEscape(commit))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.gitea {
+		req.Header.Set("Authorization", "token "+token)
+	} else {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+// gitlabProvider posts commit statuses to the GitLab API.
+type gitlabProvider struct {
+	api  string
+	path string
+}
+
+type gitlabStatus struct {
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	TargetURL   string `json:"target_url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (p *gitlabProvider) apiURL() string {
+	return p.api
+}
+
+func (p *gitlabProvider) newRequest(ctx context.Context, commit string, s providerStatus, token string) (*http.Request, error) {
+	state := string(s.state)
+	if s.state == StateFailure {
+		state = "failed"
+	}
+	body, err := json.Marshal(gitlabStatus{
+		State:       state,
+		Name:        s.context,
+		TargetURL:   s.targetURL,
+		Description: s.description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// GitLab identifies the project by its URL-encoded path.
+	endpoint := fmt.Sprintf("%s/projects/%s/statuses/%s", p.api, url.PathEscape(p.path), url.PathEscape(commit))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", token)
+	return req, nil
+}
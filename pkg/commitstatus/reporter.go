@@ -0,0 +1,271 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package commitstatus reports whether each commit synced back to the Git
+// provider, as commit statuses keyed by the cluster name.
+package commitstatus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/util"
+)
+
+const (
+	// queueSize is the number of commit statuses buffered. Commit statuses are
+	// dropped when the queue is full.
+	queueSize = 10
+	// requestTimeout is the timeout of each request to the Git provider.
+	requestTimeout = 10 * time.Second
+	// maxDescriptionLength is the maximum length of a GitHub commit status
+	// description.
+	maxDescriptionLength = 140
+)
+
+// State is the state of a commit status.
+type State string
+
+const (
+	// StatePending indicates that the commit is being synced.
+	StatePending State = "pending"
+	// StateSuccess indicates that the commit synced without errors.
+	StateSuccess State = "success"
+	// StateFailure indicates that the commit failed to sync.
+	StateFailure State = "failure"
+)
+
+// Options configures a Reporter.
+type Options struct {
+	// Provider is the API of the Git provider.
+	Provider configsync.GitProvider
+	// APIURL is the base URL of the API of the Git provider.
+	// Derived from Repo if empty.
+	APIURL string
+	// TargetURL is the link of the commit statuses. Optional.
+	TargetURL string
+	// Token authenticates the requests to the Git provider with the token
+	// auth type.
+	Token string
+	// GithubApp authenticates the requests to GitHub with the githubapp auth
+	// type. Used instead of Token if not nil.
+	GithubApp *GithubApp
+
+	// Repo is the URL of the Git repository being synced.
+	Repo string
+	// ClusterName is the name of the cluster, which keys the commit statuses.
+	ClusterName string
+	// Scope is the scope of the RootSync or RepoSync.
+	Scope declared.Scope
+	// SyncName is the name of the RootSync or RepoSync.
+	SyncName string
+}
+
+// Reporter posts the commit statuses of the synced commits to the Git
+// provider in the background.
+//
+// A nil Reporter is valid, and ignores all commits.
+type Reporter struct {
+	provider    provider
+	tokens      tokenSource
+	client      *http.Client
+	backoff     wait.Backoff
+	context     string
+	targetURL   string
+	clusterName string
+
+	queue chan *commitStatus
+
+	mux sync.Mutex
+	// last is the last queued commit status.
+	last *commitStatus
+}
+
+// commitStatus is a commit status of a commit.
+type commitStatus struct {
+	commit      string
+	state       State
+	description string
+}
+
+// New constructs a Reporter. Returns an error if the repository URL cannot be
+// mapped to the API of the Git provider.
+//
+// Since the API URL of a RepoSync is set by the users of its namespace, the
+// Reporter of a RepoSync only connects to public IP addresses, so that the
+// Git token can't be sent to the Pods, Services or nodes of the cluster, or to
+// the metadata server.
+//
+// The caller must call Run to post the commit statuses.
+func New(opts Options) (*Reporter, error) {
+	client := &http.Client{Timeout: requestTimeout}
+	restricted := opts.Scope != declared.RootScope
+	if restricted {
+		client = util.PublicOnlyClient(client)
+	}
+	apiURL := opts.APIURL
+	if apiURL == "" && opts.GithubApp != nil {
+		apiURL = opts.GithubApp.BaseURL
+	}
+	p, err := newProvider(opts.Provider, opts.Repo, apiURL)
+	if err != nil {
+		return nil, err
+	}
+	if restricted {
+		u, err := url.Parse(p.apiURL())
+		if err != nil {
+			return nil, fmt.Errorf("invalid API URL %q: %w", p.apiURL(), err)
+		}
+		if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !util.IsPublicAddress(addr) {
+			return nil, fmt.Errorf("invalid API URL %q: the address of the API of a RepoSync must be public", p.apiURL())
+		}
+	}
+	var tokens tokenSource = staticToken(opts.Token)
+	if opts.GithubApp != nil {
+		tokens, err = newGithubAppTokenSource(*opts.GithubApp, p.apiURL(), client, clock.RealClock{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Reporter{
+		provider:    p,
+		tokens:      tokens,
+		client:      client,
+		backoff:     util.HTTPRetryBackoff(),
+		context:     contextName(opts.ClusterName, opts.Scope, opts.SyncName),
+		targetURL:   opts.TargetURL,
+		clusterName: opts.ClusterName,
+		queue:       make(chan *commitStatus, queueSize),
+	}, nil
+}
+
+// contextName returns the name of the commit statuses of a RootSync or
+// RepoSync, which is unique per cluster.
+func contextName(clusterName string, scope declared.Scope, syncName string) string {
+	name := "config-sync"
+	if clusterName != "" {
+		name += "/" + clusterName
+	}
+	if scope != declared.RootScope {
+		name += "/" + string(scope)
+	}
+	return name + "/" + syncName
+}
+
+// ReportPending reports that the commit is being synced.
+func (r *Reporter) ReportPending(commit string) {
+	if r == nil {
+		return
+	}
+	r.enqueue(&commitStatus{
+		commit:      commit,
+		state:       StatePending,
+		description: r.describe("Syncing"),
+	})
+}
+
+// ReportResult reports that the commit synced, or failed to sync with the
+// specified errors. Transient errors are ignored, since they are retried.
+func (r *Reporter) ReportResult(commit string, errs status.MultiError) {
+	if r == nil || status.AllTransientErrors(errs) {
+		return
+	}
+	cs := &commitStatus{
+		commit:      commit,
+		state:       StateSuccess,
+		description: r.describe("Synced"),
+	}
+	if errs != nil {
+		cs.state = StateFailure
+		cs.description = fmt.Sprintf("%s: %d errors", r.describe("Failed to sync"), len(errs.Errors()))
+	}
+	r.enqueue(cs)
+}
+
+func (r *Reporter) describe(action string) string {
+	if r.clusterName == "" {
+		return action
+	}
+	return fmt.Sprintf("%s to cluster %s", action, r.clusterName)
+}
+
+// enqueue queues the commit status, unless it is the same as the last one or
+// the commit is unknown.
+func (r *Reporter) enqueue(cs *commitStatus) {
+	if cs.commit == "" {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if r.last != nil && *r.last == *cs {
+		return
+	}
+	r.last = cs
+	select {
+	case r.queue <- cs:
+	default:
+		klog.Warningf("Dropped %s commit status of commit %s: the queue is full", cs.state, cs.commit)
+	}
+}
+
+// Run posts the queued commit statuses until the context is cancelled.
+func (r *Reporter) Run(ctx context.Context) {
+	if r == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case cs := <-r.queue:
+			if err := r.send(ctx, cs); err != nil {
+				klog.Warningf("Failed to post %s commit status of commit %s: %v", cs.state, cs.commit, err)
+			} else {
+				klog.V(3).Infof("Posted %s commit status of commit %s", cs.state, cs.commit)
+			}
+		}
+	}
+}
+
+// send posts the commit status, and retries with exponential backoff if the
+// request fails with a network error, a 429 or a 5xx response.
+func (r *Reporter) send(ctx context.Context, cs *commitStatus) error {
+	description := cs.description
+	if len(description) > maxDescriptionLength {
+		description = description[:maxDescriptionLength]
+	}
+	return util.SendWithRetry(ctx, r.client, r.backoff, fmt.Sprintf("the %s commit status of commit %s", cs.state, cs.commit), func(ctx context.Context) (*http.Request, error) {
+		token, err := r.tokens.Token(ctx)
+		if err != nil {
+			return nil, util.NewRetriableError(fmt.Errorf("getting token: %w", err))
+		}
+		return r.provider.newRequest(ctx, cs.commit, providerStatus{
+			state:       cs.state,
+			context:     r.context,
+			description: description,
+			targetURL:   r.targetURL,
+		}, token)
+	})
+}
@@ -0,0 +1,466 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitstatus
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clocktesting "k8s.io/utils/clock/testing"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// request is a request received by the testServer.
+type request struct {
+	path    string
+	headers http.Header
+	body    map[string]string
+}
+
+// testServer is a local stand-in for the API of a Git provider, which
+// responds with the queued status codes, then with 201.
+type testServer struct {
+	*httptest.Server
+	requests chan request
+	statuses chan int
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		requests: make(chan request, 10),
+		statuses: make(chan int, 10),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		req := request{path: r.URL.EscapedPath(), headers: r.Header}
+		if len(body) > 0 {
+			assert.NoError(t, json.Unmarshal(body, &req.body))
+		}
+		s.requests <- req
+		select {
+		case code := <-s.statuses:
+			w.WriteHeader(code)
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// next returns the next request, or fails after a timeout.
+func (s *testServer) next(t *testing.T) request {
+	t.Helper()
+	select {
+	case req := <-s.requests:
+		return req
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a commit status")
+		return request{}
+	}
+}
+
+// expectNone fails if a request is received within a short period.
+func (s *testServer) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case req := <-s.requests:
+		t.Fatalf("unexpected commit status: %+v", req)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func startReporter(t *testing.T, opts Options) *Reporter {
+	r, err := New(opts)
+	require.NoError(t, err)
+	r.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return r
+}
+
+func TestReporter_Providers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		provider    configsync.GitProvider
+		scope       declared.Scope
+		syncName    string
+		wantPath    string
+		wantHeaders map[string]string
+		wantBody    map[string]string
+	}{
+		{
+			name:     "github",
+			provider: configsync.GitProviderGitHub,
+			scope:    declared.RootScope,
+			syncName: configsync.RootSyncName,
+			wantPath: "/repos/org/repo/statuses/abc",
+			wantHeaders: map[string]string{
+				"Authorization": "Bearer secret",
+				"Accept":        "application/vnd.github+json",
+			},
+			wantBody: map[string]string{
+				"state":       "failure",
+				"context":     "config-sync/test-cluster/root-sync",
+				"description": "Failed to sync to cluster test-cluster: 1 errors",
+				"target_url":  "https://console.example.com",
+			},
+		},
+		{
+			name:     "gitea",
+			provider: configsync.GitProviderGitea,
+			scope:    declared.RootScope,
+			syncName: configsync.RootSyncName,
+			wantPath: "/repos/org/repo/statuses/abc",
+			wantHeaders: map[string]string{
+				"Authorization": "token secret",
+			},
+			wantBody: map[string]string{
+				"state":       "failure",
+				"context":     "config-sync/test-cluster/root-sync",
+				"description": "Failed to sync to cluster test-cluster: 1 errors",
+				"target_url":  "https://console.example.com",
+			},
+		},
+		{
+			name:     "gitlab",
+			provider: configsync.GitProviderGitLab,
+			scope:    declared.RootScope,
+			syncName: configsync.RootSyncName,
+			wantPath: "/projects/org%2Frepo/statuses/abc",
+			wantHeaders: map[string]string{
+				"PRIVATE-TOKEN": "secret",
+			},
+			wantBody: map[string]string{
+				"state":       "failed",
+				"name":        "config-sync/test-cluster/root-sync",
+				"description": "Failed to sync to cluster test-cluster: 1 errors",
+				"target_url":  "https://console.example.com",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			r := startReporter(t, Options{
+				Provider:    tc.provider,
+				APIURL:      server.URL,
+				TargetURL:   "https://console.example.com",
+				Token:       "secret",
+				Repo:        "https://git.example.com/org/repo.git",
+				ClusterName: "test-cluster",
+				Scope:       tc.scope,
+				SyncName:    tc.syncName,
+			})
+
+			r.ReportResult("abc", status.InternalError("a"))
+			got := server.next(t)
+			assert.Equal(t, tc.wantPath, got.path)
+			for k, v := range tc.wantHeaders {
+				assert.Equal(t, v, got.headers.Get(k), k)
+			}
+			assert.Equal(t, tc.wantBody, got.body)
+		})
+	}
+}
+
+func TestReporter_Report(t *testing.T) {
+	server := newTestServer(t)
+	r := startReporter(t, Options{
+		Provider: configsync.GitProviderGitHub,
+		APIURL:   server.URL,
+		Token:    "secret",
+		Repo:     "git@github.com:org/repo",
+		Scope:    declared.RootScope,
+		SyncName: configsync.RootSyncName,
+	})
+
+	r.ReportPending("abc")
+	got := server.next(t)
+	assert.Equal(t, "pending", got.body["state"])
+	assert.Equal(t, "Syncing", got.body["description"])
+	assert.Equal(t, "config-sync/root-sync", got.body["context"])
+
+	// The same commit status is only posted once.
+	r.ReportPending("abc")
+	// Transient errors are ignored.
+	r.ReportResult("abc", status.TransientError(errors.New("retry")))
+	// Unknown commits are ignored.
+	r.ReportResult("", nil)
+	server.expectNone(t)
+
+	r.ReportResult("abc", nil)
+	got = server.next(t)
+	assert.Equal(t, "success", got.body["state"])
+	assert.Equal(t, "Synced", got.body["description"])
+}
+
+func TestReporter_Retry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statuses     []int
+		wantRequests int
+	}{
+		{
+			name:         "retry server errors",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "don't retry client errors",
+			statuses:     []int{http.StatusUnprocessableEntity},
+			wantRequests: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			for _, code := range tc.statuses {
+				server.statuses <- code
+			}
+			r := startReporter(t, Options{
+				Provider: configsync.GitProviderGitHub,
+				APIURL:   server.URL,
+				Repo:     "https://github.com/org/repo",
+				Scope:    declared.RootScope,
+				SyncName: configsync.RootSyncName,
+			})
+
+			r.ReportResult("abc", nil)
+			for i := 0; i < tc.wantRequests; i++ {
+				assert.Equal(t, "/repos/org/repo/statuses/abc", server.next(t).path)
+			}
+			server.expectNone(t)
+		})
+	}
+}
+
+func TestReporter_RepoSyncPublicOnly(t *testing.T) {
+	server := newTestServer(t)
+	r := startReporter(t, Options{
+		Provider: configsync.GitProviderGitHub,
+		// The host name resolves to the loopback address of the test server.
+		APIURL:   strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+		Token:    "secret",
+		Repo:     "https://github.com/org/repo",
+		Scope:    "bookstore",
+		SyncName: "repo-sync",
+	})
+
+	r.ReportPending("abc")
+	server.expectNone(t)
+}
+
+func TestNew_RepoSyncAPIURL(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scope     declared.Scope
+		apiURL    string
+		wantError string
+	}{
+		{
+			name:   "private address of a RootSync",
+			scope:  declared.RootScope,
+			apiURL: "http://10.0.0.1/api/v3",
+		},
+		{
+			name:      "private address of a RepoSync",
+			scope:     "bookstore",
+			apiURL:    "http://10.0.0.1/api/v3",
+			wantError: `invalid API URL "http://10.0.0.1/api/v3": the address of the API of a RepoSync must be public`,
+		},
+		{
+			name:      "metadata server address of a RepoSync",
+			scope:     "bookstore",
+			apiURL:    "http://169.254.169.254",
+			wantError: `invalid API URL "http://169.254.169.254": the address of the API of a RepoSync must be public`,
+		},
+		{
+			name:   "host name of a RepoSync",
+			scope:  "bookstore",
+			apiURL: "https://github.example.com/api/v3",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(Options{
+				Provider: configsync.GitProviderGitHub,
+				APIURL:   tc.apiURL,
+				Repo:     "https://github.com/org/repo",
+				Scope:    tc.scope,
+				SyncName: "repo-sync",
+			})
+			if tc.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestContextName(t *testing.T) {
+	assert.Equal(t, "config-sync/root-sync", contextName("", declared.RootScope, configsync.RootSyncName))
+	assert.Equal(t, "config-sync/test-cluster/root-sync", contextName("test-cluster", declared.RootScope, configsync.RootSyncName))
+	assert.Equal(t, "config-sync/test-cluster/bookstore/repo-sync", contextName("test-cluster", "bookstore", "repo-sync"))
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.ReportPending("abc")
+	r.ReportResult("abc", nil)
+	r.Run(context.Background())
+}
+
+func TestNewProvider(t *testing.T) {
+	testCases := []struct {
+		name       string
+		provider   configsync.GitProvider
+		repo       string
+		wantAPIURL string
+		wantError  string
+	}{
+		{
+			name:       "github.com",
+			provider:   configsync.GitProviderGitHub,
+			repo:       "https://github.com/org/repo.git",
+			wantAPIURL: "https://api.github.com",
+		},
+		{
+			name:       "github enterprise over ssh",
+			provider:   configsync.GitProviderGitHub,
+			repo:       "ssh://git@github.example.com:2222/org/repo.git",
+			wantAPIURL: "https://github.example.com/api/v3",
+		},
+		{
+			name:       "gitlab scp-like address",
+			provider:   configsync.GitProviderGitLab,
+			repo:       "git@gitlab.example.com:group/subgroup/repo.git",
+			wantAPIURL: "https://gitlab.example.com/api/v4",
+		},
+		{
+			name:       "gitea over http",
+			provider:   configsync.GitProviderGitea,
+			repo:       "http://gitea.example.com:3000/org/repo",
+			wantAPIURL: "http://gitea.example.com:3000/api/v1",
+		},
+		{
+			name:      "repo without owner",
+			provider:  configsync.GitProviderGitHub,
+			repo:      "https://github.com/repo",
+			wantError: `invalid repo URL "https://github.com/repo": must be the URL of a repository like https://<host>/<owner>/<repo>`,
+		},
+		{
+			name:      "unsupported provider",
+			provider:  "bitbucket",
+			repo:      "https://bitbucket.org/org/repo",
+			wantError: `unsupported Git provider "bitbucket": must be "github", "gitlab" or "gitea"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := newProvider(tc.provider, tc.repo, "")
+			if tc.wantError != "" {
+				assert.EqualError(t, err, tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAPIURL, p.apiURL())
+		})
+	}
+}
+
+func TestGithubAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(now)
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var got struct {
+			Iss string `json:"iss"`
+			Iat int64  `json:"iat"`
+			Exp int64  `json:"exp"`
+		}
+		require.NoError(t, json.Unmarshal(claims, &got))
+		assert.Equal(t, "client-id", got.Iss)
+		assert.Equal(t, fakeClock.Now().Add(-time.Minute).Unix(), got.Iat)
+		assert.Equal(t, fakeClock.Now().Add(9*time.Minute).Unix(), got.Exp)
+		tokens++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token":"installation-token","expires_at":"2026-10-19T11:00:00Z"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	s, err := newGithubAppTokenSource(GithubApp{
+		PrivateKey:     keyPEM,
+		ClientID:       "client-id",
+		AppID:          "1",
+		InstallationID: "42",
+	}, server.URL, server.Client(), fakeClock)
+	require.NoError(t, err)
+
+	token, err := s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "installation-token", token)
+	assert.Equal(t, 1, tokens)
+
+	// The token is cached until shortly before it expires.
+	_, err = s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, tokens)
+
+	fakeClock.SetTime(now.Add(59 * time.Minute))
+	_, err = s.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, tokens)
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commitstatus
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	// jwtLifetime is the lifetime of the JWTs that authenticate as the GitHub
+	// App. GitHub accepts at most 10 minutes.
+	jwtLifetime = 9 * time.Minute
+	// clockSkew is subtracted from the issue time of the JWTs, to allow for
+	// clock drift between the reconciler and GitHub.
+	clockSkew = time.Minute
+	// tokenExpiryDelta is how long before their expiry installation tokens
+	// are refreshed.
+	tokenExpiryDelta = time.Minute
+)
+
+// GithubApp are the credentials of a GitHub App installation.
+type GithubApp struct {
+	// PrivateKey is the PEM-encoded private key of the GitHub App.
+	PrivateKey string
+	// ClientID is the client ID of the GitHub App. Preferred over AppID.
+	ClientID string
+	// AppID is the application ID of the GitHub App.
+	AppID string
+	// InstallationID is the ID of the installation of the GitHub App.
+	InstallationID string
+	// BaseURL is the base URL of the GitHub API. Optional.
+	BaseURL string
+}
+
+// tokenSource returns the token that authenticates the requests to the Git
+// provider.
+type tokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// staticToken is a token that never expires, like a personal access token.
+type staticToken string
+
+func (t staticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+// githubAppTokenSource returns installation tokens of a GitHub App, which
+// are cached until shortly before they expire.
+type githubAppTokenSource struct {
+	key            *rsa.PrivateKey
+	issuer         string
+	installationID string
+	apiURL         string
+	client         *http.Client
+	clock          clock.PassiveClock
+
+	mux    sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newGithubAppTokenSource(app GithubApp, apiURL string, client *http.Client, c clock.PassiveClock) (*githubAppTokenSource, error) {
+	key, err := parsePrivateKey(app.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: %w", err)
+	}
+	issuer := app.ClientID
+	if issuer == "" {
+		issuer = app.AppID
+	}
+	if issuer == "" {
+		return nil, errors.New("invalid GitHub App: the client ID or the application ID must be set")
+	}
+	if app.InstallationID == "" {
+		return nil, errors.New("invalid GitHub App: the installation ID must be set")
+	}
+	return &githubAppTokenSource{
+		key:            key,
+		issuer:         issuer,
+		installationID: app.InstallationID,
+		apiURL:         apiURL,
+		client:         client,
+		clock:          c,
+	}, nil
+}
+
+// parsePrivateKey parses a PEM-encoded PKCS#1 or PKCS#8 RSA private key.
+func parsePrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T: must be RSA", key)
+	}
+	return rsaKey, nil
+}
+
+// Token returns the cached installation token, or exchanges a new JWT for a
+// new installation token.
+func (s *githubAppTokenSource) Token(ctx context.Context) (string, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	now := s.clock.Now()
+	if s.token != "" && now.Before(s.expiry.Add(-tokenExpiryDelta)) {
+		return s.token, nil
+	}
+	jwt, err := s.jwt(now)
+	if err != nil {
+		return "", err
+	}
+	endpoint := fmt.Sprintf("%s/app/installations/%s/access_tokens", s.apiURL, s.installationID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response status %s from the GitHub App installation token endpoint", resp.Status)
+	}
+	var token struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("decoding GitHub App installation token: %w", err)
+	}
+	s.token = token.Token
+	s.expiry = token.ExpiresAt
+	return s.token, nil
+}
+
+// jwt returns a JWT signed with RS256, which authenticates as the GitHub App.
+func (s *githubAppTokenSource) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-clockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": s.issuer,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := strings.Join([]string{
+		base64.RawURLEncoding.EncodeToString(header),
+		base64.RawURLEncoding.EncodeToString(claims),
+	}, ".")
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("signing GitHub App JWT: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
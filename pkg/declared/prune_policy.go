@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package declared
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// PrunePolicy limits which managed objects may be pruned, and how many managed
+// objects may be pruned in a single sync.
+//
+// PrunePolicy generalizes the deletesAllNamespaces safeguard. The zero value
+// allows every prune.
+type PrunePolicy struct {
+	// MaxPruneCount is the maximum number of objects that may be pruned in a
+	// single sync. Zero means no limit.
+	MaxPruneCount int
+	// MaxPrunePercent is the maximum percentage of the managed objects that may
+	// be pruned in a single sync. Zero means no limit.
+	MaxPrunePercent int
+	// ProtectedGroupKinds is the set of GroupKinds that are never pruned.
+	ProtectedGroupKinds map[schema.GroupKind]struct{}
+	// ConfirmedCommit is the source commit which is allowed to exceed
+	// MaxPruneCount and MaxPrunePercent.
+	ConfirmedCommit string
+}
+
+// IsEmpty returns true if the policy allows every prune.
+func (p PrunePolicy) IsEmpty() bool {
+	return p.MaxPruneCount <= 0 && p.MaxPrunePercent <= 0 && len(p.ProtectedGroupKinds) == 0
+}
+
+// IsProtected returns true if objects with the specified GroupKind must never
+// be pruned.
+func (p PrunePolicy) IsProtected(gk schema.GroupKind) bool {
+	_, found := p.ProtectedGroupKinds[gk]
+	return found
+}
+
+// CheckPruneLimits returns an error if pruning the specified objects would
+// exceed the prune limits, unless the prune was confirmed for this commit.
+//
+// managedCount is the number of objects currently managed, including the
+// objects to prune.
+func (p PrunePolicy) CheckPruneLimits(commit string, managedCount int, prunes []core.ID) status.Error {
+	if len(prunes) == 0 {
+		return nil
+	}
+	if p.ConfirmedCommit != "" && p.ConfirmedCommit == commit {
+		return nil
+	}
+	exceedsCount := p.MaxPruneCount > 0 && len(prunes) > p.MaxPruneCount
+	exceedsPercent := p.MaxPrunePercent > 0 && managedCount > 0 &&
+		len(prunes)*100 > p.MaxPrunePercent*managedCount
+	if !exceedsCount && !exceedsPercent {
+		return nil
+	}
+	return PruneLimitExceededError(commit, managedCount, prunes, p)
+}
+
+// ParseGroupKinds parses a comma-separated list of GroupKinds, formatted as
+// `Kind.group`, with the group omitted for the core API group.
+func ParseGroupKinds(s string) map[schema.GroupKind]struct{} {
+	if s == "" {
+		return nil
+	}
+	gks := make(map[schema.GroupKind]struct{})
+	for _, str := range strings.Split(s, ",") {
+		str = strings.TrimSpace(str)
+		if str == "" {
+			continue
+		}
+		gks[schema.ParseGroupKind(str)] = struct{}{}
+	}
+	return gks
+}
+
+// PruneLimitExceededError represents a failsafe error we return to ensure we
+// don't prune more objects in a single sync than the prune policy allows.
+//
+// Users confirm that the prune is intended by setting
+// `spec.override.prunePolicy.confirmedCommit` to the commit being synced.
+func PruneLimitExceededError(commit string, managedCount int, prunes []core.ID, policy PrunePolicy) status.Error {
+	var limits []string
+	if policy.MaxPruneCount > 0 {
+		limits = append(limits, fmt.Sprintf("maxPruneCount: %d", policy.MaxPruneCount))
+	}
+	if policy.MaxPrunePercent > 0 {
+		limits = append(limits, fmt.Sprintf("maxPrunePercent: %d", policy.MaxPrunePercent))
+	}
+	ids := make([]string, len(prunes))
+	for i, id := range prunes {
+		ids[i] = id.String()
+	}
+	sort.Strings(ids)
+	return status.EmptySourceErrorBuilder.Sprintf(
+		"New commit %q would prune %d of %d managed objects, which exceeds the prune policy (%s): %v. "+
+			"If this is not a mistake, confirm the prune by setting spec.override.prunePolicy.confirmedCommit to %q.",
+		commit, len(prunes), managedCount, strings.Join(limits, ", "), ids, commit).Build()
+}
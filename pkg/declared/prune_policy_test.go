@@ -0,0 +1,135 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package declared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPrunePolicyCheckPruneLimits(t *testing.T) {
+	prunes := []core.ID{
+		{GroupKind: kinds.Role().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "a"}},
+		{GroupKind: kinds.Role().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "b"}},
+		{GroupKind: kinds.Role().GroupKind(), ObjectKey: client.ObjectKey{Namespace: "foo", Name: "c"}},
+	}
+
+	testCases := []struct {
+		name         string
+		policy       PrunePolicy
+		commit       string
+		managedCount int
+		prunes       []core.ID
+		want         status.Error
+	}{
+		{
+			name:         "no limits",
+			policy:       PrunePolicy{},
+			commit:       "abc",
+			managedCount: 3,
+			prunes:       prunes,
+		},
+		{
+			name:         "no prunes",
+			policy:       PrunePolicy{MaxPruneCount: 1},
+			commit:       "abc",
+			managedCount: 3,
+		},
+		{
+			name:         "count at limit",
+			policy:       PrunePolicy{MaxPruneCount: 3},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+		},
+		{
+			name:         "count exceeds limit",
+			policy:       PrunePolicy{MaxPruneCount: 2},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+			want:         PruneLimitExceededError("abc", 10, prunes, PrunePolicy{MaxPruneCount: 2}),
+		},
+		{
+			name:         "percent at limit",
+			policy:       PrunePolicy{MaxPrunePercent: 30},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+		},
+		{
+			name:         "percent exceeds limit",
+			policy:       PrunePolicy{MaxPrunePercent: 25},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+			want:         PruneLimitExceededError("abc", 10, prunes, PrunePolicy{MaxPrunePercent: 25}),
+		},
+		{
+			name:         "exceeds limit but confirmed",
+			policy:       PrunePolicy{MaxPruneCount: 1, ConfirmedCommit: "abc"},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+		},
+		{
+			name:         "exceeds limit and confirmed for another commit",
+			policy:       PrunePolicy{MaxPruneCount: 1, ConfirmedCommit: "def"},
+			commit:       "abc",
+			managedCount: 10,
+			prunes:       prunes,
+			want:         PruneLimitExceededError("abc", 10, prunes, PrunePolicy{MaxPruneCount: 1}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.policy.CheckPruneLimits(tc.commit, tc.managedCount, tc.prunes)
+			testerrors.AssertEqual(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseGroupKinds(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  map[schema.GroupKind]struct{}
+	}{
+		"empty": {
+			input: "",
+			want:  nil,
+		},
+		"core and non-core kinds": {
+			input: "Namespace, PersistentVolumeClaim,CustomResourceDefinition.apiextensions.k8s.io,",
+			want: map[schema.GroupKind]struct{}{
+				kinds.Namespace().GroupKind():    {},
+				{Kind: "PersistentVolumeClaim"}:  {},
+				kinds.CustomResourceDefinition(): {},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ParseGroupKinds(tc.input))
+		})
+	}
+}
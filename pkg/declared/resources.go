@@ -178,6 +178,13 @@ func (r *Resources) GetDeclared(id core.ID) (*unstructured.Unstructured, string,
 	return u.DeepCopy(), r.commit, found
 }
 
+// Commit returns the source commit in which the resources were declared.
+func (r *Resources) Commit() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.commit
+}
+
 // DeclaredUnstructureds returns all resource objects declared in the source,
 // along with the source commit.
 func (r *Resources) DeclaredUnstructureds() []*unstructured.Unstructured {
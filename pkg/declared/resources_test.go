@@ -22,8 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -211,20 +210,20 @@ func TestGVKSet(t *testing.T) {
 }
 
 func TestResources_InternalErrorMetricValidation(t *testing.T) {
-	m := testmetrics.RegisterMetrics(metrics.InternalErrorsView)
+	m := testmetrics.RegisterMetrics()
 	dr := Resources{}
 	if _, err := dr.UpdateDeclared(context.Background(), nilSet, "unused"); err != nil {
 		t.Fatal(err)
 	}
-	wantMetrics := []*view.Row{
+	wantMetrics := []*testmetrics.Row{
 		{
-			Data: &view.CountData{Value: 1},
-			Tags: []tag.Tag{
-				{Key: metrics.KeyInternalErrorSource, Value: "parser"},
+			Value: 1,
+			Attributes: []attribute.KeyValue{
+				metrics.KeyInternalErrorSource.String("parser"),
 			},
 		},
 	}
-	if diff := m.ValidateMetrics(metrics.InternalErrorsView, wantMetrics); diff != "" {
+	if diff := m.ValidateMetrics(metrics.InternalErrorsName, wantMetrics); diff != "" {
 		t.Error(diff)
 	}
 }
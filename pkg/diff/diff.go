@@ -20,6 +20,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/adopt"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/lifecycle"
@@ -121,7 +122,10 @@ func (d Diff) updateType(scope declared.Scope, syncName string) Operation {
 	// nomos vet error. Note that as-is, it is valid to declare something owned by
 	// another object, possible causing (and being surfaced as) a resource fight.
 	canManage := CanManage(scope, syncName, d.Actual, admissionv1.Update)
-	ignoreMutation := d.Actual.GetAnnotations()[metadata.LifecycleMutationAnnotation] == metadata.IgnoreMutation
+	// Objects adopted with their current state are treated as mutation-ignored
+	// until their declared state changes.
+	ignoreMutation := d.Actual.GetAnnotations()[metadata.LifecycleMutationAnnotation] == metadata.IgnoreMutation ||
+		adopt.IsAdoptedWithCurrentState(d.Declared, d.Actual)
 
 	if d.sameCSMetadata() {
 		// canManage must be true when CS metadata is the same between
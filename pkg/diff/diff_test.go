@@ -20,6 +20,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/adopt"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
@@ -39,6 +40,14 @@ const (
 )
 
 func TestDiffType(t *testing.T) {
+	adoptedHash, err := adopt.Hash(k8sobjects.RoleObject(
+		syncertest.ManagementEnabled,
+		core.Annotation("foo", "bar"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	testCases := []struct {
 		name     string
 		scope    declared.Scope
@@ -285,6 +294,31 @@ func TestDiffType(t *testing.T) {
 			),
 			want: UpdateCSMetadata,
 		},
+		// Adopted with current state path.
+		{
+			name: "adopted with current state, declared unchanged: update CS metadata",
+			declared: k8sobjects.RoleObject(
+				syncertest.ManagementEnabled,
+				core.Annotation("foo", "bar"),
+			),
+			actual: k8sobjects.RoleObject(
+				core.Annotation(metadata.AdoptedHashAnnotationKey, adoptedHash),
+				core.Annotation("foo", "qux"),
+			),
+			want: UpdateCSMetadata,
+		},
+		{
+			name: "adopted with current state, declared changed: update",
+			declared: k8sobjects.RoleObject(
+				syncertest.ManagementEnabled,
+				core.Annotation("foo", "baz"),
+			),
+			actual: k8sobjects.RoleObject(
+				core.Annotation(metadata.AdoptedHashAnnotationKey, adoptedHash),
+				core.Annotation("foo", "qux"),
+			),
+			want: Update,
+		},
 		// Actual + no declared paths.
 		{
 			name:   "actual + no declared, no meta: no-op",
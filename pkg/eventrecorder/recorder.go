@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eventrecorder records Kubernetes Events on the RootSync or RepoSync
+// of a reconciler, for the key transitions of the sync lifecycle.
+package eventrecorder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/declared"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons of the Events recorded on the RootSync or RepoSync.
+const (
+	// ReasonNewCommit is the reason of the Event recorded when a new source
+	// commit is fetched.
+	ReasonNewCommit = "NewCommit"
+	// ReasonRenderingSucceeded is the reason of the Event recorded when the
+	// rendering of a commit succeeds.
+	ReasonRenderingSucceeded = "RenderingSucceeded"
+	// ReasonRenderingFailed is the reason of the Event recorded when the
+	// rendering of a commit fails.
+	ReasonRenderingFailed = "RenderingFailed"
+	// ReasonApplyStarted is the reason of the Event recorded when the applier
+	// starts to apply a commit.
+	ReasonApplyStarted = "ApplyStarted"
+	// ReasonApplySucceeded is the reason of the Event recorded when the applier
+	// finishes applying a commit without errors.
+	ReasonApplySucceeded = "ApplySucceeded"
+	// ReasonApplyFailed is the reason of the Event recorded when the applier
+	// finishes applying a commit with errors.
+	ReasonApplyFailed = "ApplyFailed"
+	// ReasonPruned is the reason of the Event recorded when the applier prunes
+	// managed objects removed from the source.
+	ReasonPruned = "Pruned"
+	// ReasonManagementConflict is the reason of the Event recorded when a
+	// declared object is found to be managed by another reconciler.
+	ReasonManagementConflict = "ManagementConflict"
+	// ReasonDriftCorrected is the reason of the Event recorded when the
+	// remediator corrects a managed object that drifted from the source.
+	ReasonDriftCorrected = "DriftCorrected"
+)
+
+// Recorder records Events on the RootSync or RepoSync of the reconciler.
+//
+// A nil Recorder is valid and records nothing.
+type Recorder struct {
+	ctx      context.Context
+	recorder record.EventRecorder
+	reader   client.Reader
+
+	syncKind      string
+	syncName      string
+	syncNamespace string
+
+	// mux guards ref
+	mux sync.Mutex
+	// ref is the reference to the RootSync or RepoSync, including its UID,
+	// which is looked up before the first Event is recorded.
+	ref *corev1.ObjectReference
+}
+
+// New returns a Recorder which records Events on the specified RootSync or
+// RepoSync with the EventRecorder.
+// The reader is used to look up the UID of the RootSync or RepoSync, which is
+// required for the Events to be listed by `kubectl describe`.
+func New(ctx context.Context, recorder record.EventRecorder, reader client.Reader, scope declared.Scope, syncName string) *Recorder {
+	return &Recorder{
+		ctx:           ctx,
+		recorder:      recorder,
+		reader:        reader,
+		syncKind:      scope.SyncKind(),
+		syncName:      syncName,
+		syncNamespace: scope.SyncNamespace(),
+	}
+}
+
+// Normal records an Event of type Normal.
+func (r *Recorder) Normal(reason, messageFmt string, args ...interface{}) {
+	r.eventf(corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// Warning records an Event of type Warning.
+func (r *Recorder) Warning(reason, messageFmt string, args ...interface{}) {
+	r.eventf(corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+func (r *Recorder) eventf(eventType, reason, messageFmt string, args ...interface{}) {
+	if r == nil {
+		return
+	}
+	ref, err := r.reference()
+	if err != nil {
+		// Events are best effort. Don't block the sync.
+		klog.Warningf("Failed to record %s Event %s on %s %s/%s: %v",
+			eventType, reason, r.syncKind, r.syncNamespace, r.syncName, err)
+		return
+	}
+	r.recorder.Eventf(ref, eventType, reason, messageFmt, args...)
+}
+
+func (r *Recorder) reference() (*corev1.ObjectReference, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.ref != nil {
+		return r.ref, nil
+	}
+	rs := &metav1.PartialObjectMetadata{}
+	rs.SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(r.syncKind))
+	key := client.ObjectKey{Namespace: r.syncNamespace, Name: r.syncName}
+	if err := r.reader.Get(r.ctx, key, rs); err != nil {
+		return nil, fmt.Errorf("getting %s %s: %w", r.syncKind, key, err)
+	}
+	r.ref = &corev1.ObjectReference{
+		APIVersion: v1beta1.SchemeGroupVersion.String(),
+		Kind:       r.syncKind,
+		Namespace:  r.syncNamespace,
+		Name:       r.syncName,
+		UID:        rs.GetUID(),
+	}
+	return r.ref, nil
+}
+
+// NewEventRecorder returns an EventRecorder which sends the Events recorded by
+// the component to the API server, and a function to stop sending them.
+// Similar Events are aggregated and rate limited by the EventRecorder.
+func NewEventRecorder(cfg *rest.Config, component string) (record.EventRecorder, func(), error) {
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: clientset.CoreV1().Events(""),
+	})
+	recorder := broadcaster.NewRecorder(core.Scheme, corev1.EventSource{Component: component})
+	return recorder, broadcaster.Shutdown, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventrecorder_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecorder(t *testing.T) {
+	testCases := []struct {
+		name       string
+		scope      declared.Scope
+		syncName   string
+		objs       []client.Object
+		wantEvents []string
+	}{
+		{
+			name:     "RootSync",
+			scope:    declared.RootScope,
+			syncName: configsync.RootSyncName,
+			objs: []client.Object{
+				k8sobjects.RootSyncObjectV1Beta1(configsync.RootSyncName, core.UID("root-uid")),
+			},
+			wantEvents: []string{
+				"Normal NewCommit Fetched commit abc123 involvedObject{kind=RootSync,apiVersion=configsync.gke.io/v1beta1}",
+				"Warning ApplyFailed Failed to apply commit abc123 involvedObject{kind=RootSync,apiVersion=configsync.gke.io/v1beta1}",
+			},
+		},
+		{
+			name:     "RepoSync",
+			scope:    declared.Scope("shipping"),
+			syncName: configsync.RepoSyncName,
+			objs: []client.Object{
+				k8sobjects.RepoSyncObjectV1Beta1("shipping", configsync.RepoSyncName, core.UID("repo-uid")),
+			},
+			wantEvents: []string{
+				"Normal NewCommit Fetched commit abc123 involvedObject{kind=RepoSync,apiVersion=configsync.gke.io/v1beta1}",
+				"Warning ApplyFailed Failed to apply commit abc123 involvedObject{kind=RepoSync,apiVersion=configsync.gke.io/v1beta1}",
+			},
+		},
+		{
+			name:     "RootSync not found",
+			scope:    declared.RootScope,
+			syncName: configsync.RootSyncName,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRecorder := &record.FakeRecorder{
+				Events:        make(chan string, 10),
+				IncludeObject: true,
+			}
+			fakeClient := syncertestfake.NewClient(t, core.Scheme, tc.objs...)
+			r := eventrecorder.New(context.Background(), fakeRecorder, fakeClient, tc.scope, tc.syncName)
+
+			r.Normal(eventrecorder.ReasonNewCommit, "Fetched commit %s", "abc123")
+			r.Warning(eventrecorder.ReasonApplyFailed, "Failed to apply commit %s", "abc123")
+
+			close(fakeRecorder.Events)
+			var events []string
+			for e := range fakeRecorder.Events {
+				events = append(events, e)
+			}
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *eventrecorder.Recorder
+	// Must not panic
+	r.Normal(eventrecorder.ReasonNewCommit, "Fetched commit %s", "abc123")
+	r.Warning(eventrecorder.ReasonApplyFailed, "Failed to apply commit %s", "abc123")
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
+	"helm.sh/helm/v3/pkg/release"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+// The helm commands that Kustomize runs to inflate the charts declared in the
+// `helmCharts` field of a kustomization.
+const (
+	versionCommand  = "version"
+	pullCommand     = "pull"
+	templateCommand = "template"
+)
+
+// IsCommand checks if the arguments are one of the helm commands that
+// Kustomize runs to inflate charts.
+func IsCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case versionCommand, pullCommand, templateCommand:
+		return true
+	default:
+		return false
+	}
+}
+
+// RunCommand runs one of the helm commands that Kustomize runs to inflate
+// charts with the Helm SDK, and writes its output to stdout, like the helm
+// binary. Only the flags that Kustomize sets are supported.
+func RunCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	if !IsCommand(args) {
+		return fmt.Errorf("unsupported helm command: %s", strings.Join(args, " "))
+	}
+	switch args[0] {
+	case versionCommand:
+		_, err := fmt.Fprintln(stdout, chartutil.DefaultCapabilities.HelmVersion.Version)
+		return err
+	case pullCommand:
+		return runPull(args[1:], stdout)
+	default:
+		return runTemplate(ctx, args[1:], stdout)
+	}
+}
+
+// newRegistryClient returns the client of the OCI registries, with the
+// credentials of the Helm registry config.
+func newRegistryClient() (*registry.Client, error) {
+	client, err := registry.NewClient(
+		registry.ClientOptEnableCache(true),
+		registry.ClientOptWriter(os.Stderr),
+		registry.ClientOptCredentialsFile(helmSettings.RegistryConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the helm registry client: %w", err)
+	}
+	return client, nil
+}
+
+// runPull downloads a chart like `helm pull`.
+func runPull(args []string, stdout io.Writer) error {
+	registryClient, err := newRegistryClient()
+	if err != nil {
+		return err
+	}
+	pull := action.NewPullWithOpts(action.WithConfig(&action.Configuration{
+		RegistryClient: registryClient,
+		Log:            klog.V(4).Infof,
+	}))
+	pull.Settings = helmSettings
+
+	flags := pflag.NewFlagSet(pullCommand, pflag.ContinueOnError)
+	flags.BoolVar(&pull.Untar, "untar", false, "")
+	flags.StringVar(&pull.UntarDir, "untardir", ".", "")
+	flags.StringVar(&pull.RepoURL, "repo", "", "")
+	flags.StringVar(&pull.Version, "version", "", "")
+	flags.BoolVar(&pull.Devel, "devel", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("expected the chart to pull, got %q", flags.Args())
+	}
+	if pull.Version == "" && pull.Devel {
+		pull.Version = ">0.0.0-0"
+	}
+
+	out, err := pull.Run(flags.Arg(0))
+	if _, writeErr := io.WriteString(stdout, out); writeErr != nil && err == nil {
+		err = writeErr
+	}
+	return err
+}
+
+// runTemplate renders a local chart like `helm template`.
+func runTemplate(ctx context.Context, args []string, stdout io.Writer) error {
+	install := action.NewInstall(&action.Configuration{
+		Log: klog.V(4).Infof,
+	})
+	install.DryRun = true
+	install.DryRunOption = "client"
+	install.ClientOnly = true
+	// Skip the name check
+	install.Replace = true
+	install.ReleaseName = defaultReleaseName
+
+	var apiVersions []string
+	var kubeVersion string
+	var skipTests bool
+	valueOpts := &values.Options{}
+	flags := pflag.NewFlagSet(templateCommand, pflag.ContinueOnError)
+	flags.BoolVar(&install.GenerateName, "generate-name", false, "")
+	flags.StringVar(&install.NameTemplate, "name-template", "", "")
+	flags.StringVar(&install.Namespace, "namespace", configsync.DefaultHelmReleaseNamespace, "")
+	flags.StringArrayVarP(&valueOpts.ValueFiles, "values", "f", nil, "")
+	flags.StringArrayVar(&apiVersions, "api-versions", nil, "")
+	flags.StringVar(&kubeVersion, "kube-version", "", "")
+	flags.BoolVar(&install.IncludeCRDs, "include-crds", false, "")
+	flags.BoolVar(&skipTests, "skip-tests", false, "")
+	flags.BoolVar(&install.DisableHooks, "no-hooks", false, "")
+	flags.BoolVar(&install.Devel, "devel", false, "")
+	// Kustomize sets --debug to get the invalid output of a failed rendering
+	// on stderr, which is always included in the error.
+	flags.Bool("debug", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	install.APIVersions = chartutil.VersionSet(apiVersions)
+	if kubeVersion != "" {
+		parsed, err := chartutil.ParseKubeVersion(kubeVersion)
+		if err != nil {
+			return fmt.Errorf("invalid kube version %q: %w", kubeVersion, err)
+		}
+		install.KubeVersion = parsed
+	}
+
+	name, chartPath, err := install.NameAndChart(flags.Args())
+	if err != nil {
+		return err
+	}
+	install.ReleaseName = name
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return fmt.Errorf("failed to load the helm chart: %w", err)
+	}
+	if chrt.Metadata.Type != "" && chrt.Metadata.Type != "application" {
+		return fmt.Errorf("%s charts are not installable", chrt.Metadata.Type)
+	}
+	if req := chrt.Metadata.Dependencies; req != nil {
+		if err := action.CheckDependencies(chrt, req); err != nil {
+			return fmt.Errorf("the helm chart is missing dependencies: %w", err)
+		}
+	}
+	vals, err := valueOpts.MergeValues(getter.All(helmSettings))
+	if err != nil {
+		return fmt.Errorf("failed to read the helm values: %w", err)
+	}
+
+	rel, err := install.RunWithContext(ctx, chrt, vals)
+	if err != nil {
+		if rel != nil {
+			// Print the invalid rendering for the error message, like the
+			// helm binary with --debug.
+			fmt.Fprintln(os.Stderr, rel.Manifest)
+		}
+		return err
+	}
+	var manifests bytes.Buffer
+	fmt.Fprintln(&manifests, strings.TrimSpace(rel.Manifest))
+	if !install.DisableHooks {
+		for _, hook := range rel.Hooks {
+			if skipTests && isTestHook(hook) {
+				continue
+			}
+			fmt.Fprintf(&manifests, "---\n# Source: %s\n%s\n", hook.Path, hook.Manifest)
+		}
+	}
+	_, err = manifests.WriteTo(stdout)
+	return err
+}
+
+// isTestHook checks if the hook runs on `helm test`.
+func isTestHook(hook *release.Hook) bool {
+	for _, event := range hook.Events {
+		if event == release.HookTest {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/kmetrics"
+)
+
+// TestMain runs the test binary as the helm command of Kustomize when it is
+// run with a helm command, like the hydration-controller.
+func TestMain(m *testing.M) {
+	if IsCommand(os.Args[1:]) {
+		if err := RunCommand(context.Background(), os.Args[1:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestIsCommand(t *testing.T) {
+	assert.True(t, IsCommand([]string{"version", "-c", "--short"}))
+	assert.True(t, IsCommand([]string{"pull", "--untar", "my-chart"}))
+	assert.True(t, IsCommand([]string{"template", "my-release", "my-chart"}))
+	assert.False(t, IsCommand([]string{"install", "my-release", "my-chart"}))
+	assert.False(t, IsCommand([]string{"--v=5"}))
+	assert.False(t, IsCommand(nil))
+}
+
+func TestRunCommand(t *testing.T) {
+	helmSettings.RepositoryCache = filepath.Join(t.TempDir(), "repository")
+	helmSettings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	helmSettings.RegistryConfig = filepath.Join(t.TempDir(), "registry.json")
+	server := newTestRepo(t, "1.0.0", "1.1.0")
+	chartHome := t.TempDir()
+
+	stdout := &bytes.Buffer{}
+	require.NoError(t, RunCommand(context.Background(), []string{"version", "-c", "--short"}, stdout))
+	assert.Regexp(t, `^v3\.`, stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, RunCommand(context.Background(),
+		[]string{"pull", "--untar", "--untardir", chartHome, "--repo", server.URL, "my-chart", "--version", "1.0.0"}, stdout))
+	assert.FileExists(t, filepath.Join(chartHome, "my-chart", "Chart.yaml"))
+
+	valuesPath := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(valuesPath, []byte("color: red\n"), 0644))
+	stdout.Reset()
+	require.NoError(t, RunCommand(context.Background(),
+		[]string{"template", "my-release", filepath.Join(chartHome, "my-chart"), "--namespace", "my-namespace", "-f", valuesPath, "--skip-tests"}, stdout))
+	assert.Equal(t, `---
+# Source: my-chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-release-config
+  namespace: my-namespace
+data:
+  color: red
+`, stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, RunCommand(context.Background(),
+		[]string{"template", "--generate-name", filepath.Join(chartHome, "my-chart")}, stdout))
+	assert.Contains(t, stdout.String(), "name: release-name-config")
+	assert.Contains(t, stdout.String(), "namespace: default")
+	assert.Contains(t, stdout.String(), "name: release-name-test", "the test hook is rendered without --skip-tests")
+
+	err := RunCommand(context.Background(), []string{"template", "my-release", filepath.Join(chartHome, "missing")}, stdout)
+	assert.ErrorContains(t, err, "failed to load the helm chart")
+	err = RunCommand(context.Background(), []string{"install", "my-release", "my-chart"}, stdout)
+	assert.ErrorContains(t, err, "unsupported helm command")
+}
+
+func TestKustomizeHelmCharts(t *testing.T) {
+	helmSettings.RepositoryCache = filepath.Join(t.TempDir(), "repository")
+	helmSettings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	helmSettings.RegistryConfig = filepath.Join(t.TempDir(), "registry.json")
+	server := newTestRepo(t, "1.0.0")
+	defaultCommand := kmetrics.HelmCommand
+	t.Cleanup(func() { kmetrics.HelmCommand = defaultCommand })
+	kmetrics.HelmCommand = os.Args[0]
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(fmt.Sprintf(`helmCharts:
+- name: my-chart
+  repo: %s
+  version: 1.0.0
+  releaseName: my-release
+  namespace: my-namespace
+  valuesInline:
+    color: green
+`, server.URL)), 0644))
+
+	m, err := kmetrics.RunKustomizeBuild(context.Background(), false, dir)
+	require.NoError(t, err)
+	configMap := m.GetByIndex(0)
+	require.NotNil(t, configMap)
+	assert.Equal(t, "my-release-config", configMap.GetName())
+	assert.Equal(t, "my-namespace", configMap.GetNamespace())
+	data := configMap.GetDataMap()
+	assert.Equal(t, "green", data["color"])
+}
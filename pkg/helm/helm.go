@@ -17,8 +17,8 @@ package helm
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -27,10 +27,18 @@ import (
 	"github.com/GoogleContainerTools/kpt-functions-sdk/go/fn"
 	semverrange "github.com/Masterminds/semver/v3"
 	"golang.org/x/mod/semver"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/registry"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/auth"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/render"
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/yaml"
@@ -39,11 +47,23 @@ import (
 const (
 	// valuesFile is the name of the file created to override default chart values.
 	valuesFile = "chart-values.yaml"
+
+	// tmpRenderDir is the directory under the hydrate root where the chart is
+	// rendered, before it is moved to the directory of the chart version.
+	tmpRenderDir = "tmp-render"
+
+	// defaultReleaseName is the release name used by `helm template` when
+	// none is specified.
+	defaultReleaseName = "release-name"
 )
 
 var (
 	// helmCacheHome is the local filepath where helm writes local cache data
 	helmCacheHome = os.Getenv("HOME") + "/.cache/helm"
+
+	// helmSettings are the Helm settings read from the HELM_* environment
+	// variables, like the paths of the repository cache and registry config.
+	helmSettings = cli.New()
 )
 
 // Hydrator runs the helm hydration process.
@@ -67,49 +87,15 @@ type Hydrator struct {
 	CredentialProvider      auth.CredentialProvider
 }
 
-func (h *Hydrator) templateArgs(ctx context.Context, destDir string) ([]string, error) {
-	args := []string{"template"}
-	var err error
-
-	if h.ReleaseName != "" {
-		args = append(args, h.ReleaseName)
-	}
-	if h.isOCI() {
-		args = append(args, h.Repo+"/"+h.Chart)
-	} else {
-		args = append(args, h.Chart)
-		args = append(args, "--repo", h.Repo)
-		args, err = h.appendAuthArgs(ctx, args)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if h.Namespace != "" {
-		args = append(args, "--namespace", h.Namespace)
-	} else {
-		args = append(args, "--namespace", configsync.DefaultHelmReleaseNamespace)
-	}
-	if h.Version != "" {
-		args = append(args, "--version", h.Version)
-	}
-	args, err = h.appendValuesArgs(args)
-	if err != nil {
-		return nil, err
-	}
-	includeCRDs, _ := strconv.ParseBool(h.IncludeCRDs)
-	if includeCRDs {
-		args = append(args, "--include-crds")
-	}
-	args = append(args, "--output-dir", destDir)
-	return args, nil
-}
-
-func (h *Hydrator) appendValuesArgs(args []string) ([]string, error) {
+// valueOptions returns the values files, with the inline values last, so
+// that they override the values files.
+func (h *Hydrator) valueOptions() (*values.Options, error) {
+	opts := &values.Options{}
 	for _, vs := range h.ValuesFilePaths {
 		if vs == "" {
 			return nil, fmt.Errorf("received empty string as a values file path")
 		}
-		args = append(args, "--values", vs)
+		opts.ValueFiles = append(opts.ValueFiles, vs)
 	}
 
 	if len(h.ValuesYAML) != 0 {
@@ -119,11 +105,9 @@ func (h *Hydrator) appendValuesArgs(args []string) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		args = append(args, "--values", valuesPath)
-
+		opts.ValueFiles = append(opts.ValueFiles, valuesPath)
 	}
-
-	return args, nil
+	return opts, nil
 }
 
 func writeValuesPath(values []byte) (string, error) {
@@ -134,61 +118,150 @@ func writeValuesPath(values []byte) (string, error) {
 	return valuesPath, nil
 }
 
-func (h *Hydrator) registryLoginArgs(ctx context.Context) ([]string, error) {
-	args := []string{"registry", "login"}
-	args, err := h.appendAuthArgs(ctx, args)
+// newInstall returns the client-only install action which renders the chart
+// like `helm template`, authenticated to the repository or registry.
+func (h *Hydrator) newInstall(ctx context.Context) (*action.Install, error) {
+	creds, err := h.credentials(ctx)
 	if err != nil {
 		return nil, err
 	}
-	res := strings.Split(strings.TrimPrefix(h.Repo, "oci://"), "/")
-	args = append(args, "https://"+res[0])
-	return args, nil
+	transport, err := h.transport()
+	if err != nil {
+		return nil, err
+	}
+	registryOpts := []registry.ClientOption{
+		registry.ClientOptEnableCache(true),
+		registry.ClientOptWriter(os.Stderr),
+		registry.ClientOptCredentialsFile(helmSettings.RegistryConfig),
+		registry.ClientOptHTTPClient(&http.Client{Transport: transport}),
+	}
+	if creds != nil && h.isOCI() {
+		registryOpts = append(registryOpts, registry.ClientOptBasicAuth(creds.Username, creds.Password))
+	}
+	registryClient, err := registry.NewClient(registryOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the helm registry client: %w", err)
+	}
+
+	install := action.NewInstall(&action.Configuration{
+		RegistryClient: registryClient,
+		Log:            klog.V(4).Infof,
+	})
+	install.DryRun = true
+	install.DryRunOption = "client"
+	install.ClientOnly = true
+	// Skip the name check
+	install.Replace = true
+	install.ReleaseName = defaultReleaseName
+	if h.ReleaseName != "" {
+		install.ReleaseName = h.ReleaseName
+	}
+	install.Namespace = configsync.DefaultHelmReleaseNamespace
+	if h.Namespace != "" {
+		install.Namespace = h.Namespace
+	}
+	install.IncludeCRDs, _ = strconv.ParseBool(h.IncludeCRDs)
+	install.Version = h.Version
+	install.CaFile = h.CACertFilePath
+	if !h.isOCI() {
+		install.RepoURL = h.Repo
+		if creds != nil {
+			install.Username = creds.Username
+			install.Password = creds.Password
+		}
+	}
+	return install, nil
 }
 
-func (h *Hydrator) showChartArgs(ctx context.Context) ([]string, error) {
+// chartRef returns the reference of the chart to locate: the OCI reference of
+// the chart, or the name of the chart in the repository.
+func (h *Hydrator) chartRef() string {
 	if h.isOCI() {
-		return []string{"show", "chart", h.Repo + "/" + h.Chart, "--version", h.Version}, nil
+		return h.Repo + "/" + h.Chart
 	}
-	return h.appendAuthArgs(ctx, []string{"show", "chart", h.Chart, "--repo", h.Repo, "--version", h.Version})
+	return h.Chart
 }
 
-// figure out which version we are going to pull as it can be provided to us as a range (e.g. 1.0.0 - 1.6.5)
-func (h *Hydrator) getChartVersion(ctx context.Context) error {
-	// Use `helm show chart` to get chart info and parse the output to get the version number.
-	// This is not super convenient but seems to be the only option that will work with OCI.
-	// See available subcommands for OCI registries at https://helm.sh/docs/topics/registries/.
-	args, err := h.showChartArgs(ctx)
+// loadChart downloads and loads the chart version, which may be the latest
+// version in a range, and checks that it can be rendered.
+func (h *Hydrator) loadChart(install *action.Install) (*chart.Chart, error) {
+	chartPath, err := install.ChartPathOptions.LocateChart(h.chartRef(), helmSettings)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to pull the helm chart: %w", err)
 	}
-	out, err := h.helm(ctx, args...)
+	chrt, err := loader.Load(chartPath)
 	if err != nil {
-		return fmt.Errorf("getting helm chart version: %w", err)
-	}
-	var parsedOut map[string]interface{}
-	if err := yaml.Unmarshal(out, &parsedOut); err != nil {
-		return fmt.Errorf("failed to parse output of `helm show chart`: %w, stdout: %s", err, string(out))
-	}
-
-	version, ok := parsedOut["version"].(string)
-	if ok {
-		h.Version = version
-	} else {
-		return fmt.Errorf("failed to get version from output of `helm show chart`, stdout: %s", string(out))
+		return nil, fmt.Errorf("failed to load the helm chart: %w", err)
 	}
 
-	// we need to clear the local helm cache after running `helm show chart`,
-	// otherwise we can get an OOM error on autopilot clusters later during
-	// the rendering step
+	// we need to clear the local helm cache after pulling the chart, otherwise
+	// we can get an OOM error on autopilot clusters later during the
+	// rendering step
 	if err := os.RemoveAll(helmCacheHome); err != nil {
 		// we don't necessarily need to exit on error here, as it is possible that the later rendering
 		// step could still succeed, so we just log the error and continue
 		klog.Infof("failed to clear helm cache: %v", err)
 	}
-	klog.Infoln("using chart version: ", h.Version)
+
+	if chrt.Metadata.Type != "" && chrt.Metadata.Type != "application" {
+		return nil, fmt.Errorf("%s charts are not installable", chrt.Metadata.Type)
+	}
+	if chrt.Metadata.Deprecated {
+		klog.Warningf("helm chart %s is deprecated", h.chartRef())
+	}
+	if req := chrt.Metadata.Dependencies; req != nil {
+		if err := action.CheckDependencies(chrt, req); err != nil {
+			return nil, fmt.Errorf("the helm chart is missing dependencies: %w", err)
+		}
+	}
+	return chrt, nil
+}
+
+// render renders the chart into destDir like `helm template --output-dir`:
+// each template is written to its own file under the chart directory.
+func (h *Hydrator) render(ctx context.Context, install *action.Install, chrt *chart.Chart, destDir string) error {
+	valueOpts, err := h.valueOptions()
+	if err != nil {
+		return err
+	}
+	vals, err := valueOpts.MergeValues(getter.All(helmSettings))
+	if err != nil {
+		return fmt.Errorf("failed to read the helm values: %w", err)
+	}
+
+	install.OutputDir = destDir
+	rel, err := install.RunWithContext(ctx, chrt, vals)
+	if err != nil {
+		return err
+	}
+	// The hooks are not written by the install action, but `helm template`
+	// renders them like the other templates.
+	for _, hook := range rel.Hooks {
+		if err := appendToFile(destDir, hook.Path, hook.Manifest); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// appendToFile writes the rendered template to the file named after it,
+// appending to the file if it exists, like the install action.
+func appendToFile(outputDir, name, data string) error {
+	path := filepath.Join(outputDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "---\n# Source: %s\n%s\n", name, data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func (h *Hydrator) setDeployNamespace(destDir string) error {
 	if h.DeployNamespace == "" {
 		// do nothing
@@ -235,107 +308,96 @@ data:
 	return pkgReadWriter.Write(newNodes)
 }
 
-func (h *Hydrator) helm(ctx context.Context, args ...string) ([]byte, error) {
-	var allArgs []string
-	allArgs = append(allArgs, args...)
-	if h.CACertFilePath != "" {
-		allArgs = append(allArgs, "--ca-file", h.CACertFilePath)
-	}
-	out, err := exec.CommandContext(ctx, "helm", allArgs...).CombinedOutput()
-	if err != nil {
-		return out, fmt.Errorf("invoking helm: %s: %w", string(out), err)
-	}
-	return out, nil
-}
-
-func (h *Hydrator) registryLogin(ctx context.Context) error {
-	if h.Auth != configsync.AuthNone && h.isOCI() {
-		args, err := h.registryLoginArgs(ctx)
-		if err != nil {
-			return err
-		}
-		if _, err := h.helm(ctx, args...); err != nil {
-			return fmt.Errorf("failed to authenticate to helm registry: %w", err)
-		}
-	}
-	return nil
-}
-
-// HelmTemplate runs helm template with args
+// HelmTemplate renders the chart in-process with the Helm SDK, like
+// `helm template`, and points the destination symlink to the rendered
+// chart version.
 func (h *Hydrator) HelmTemplate(ctx context.Context) error {
-	var loggedIn bool
-
-	if isRange(h.Version) {
-		klog.Infof("version range %s detected, fetching chart version\n", h.Version)
-		if err := h.registryLogin(ctx); err != nil {
-			return err
-		}
-		loggedIn = true
-
-		if err := h.getChartVersion(ctx); err != nil {
-			return err
-		}
-	}
-
-	destDir := filepath.Join(h.HydrateRoot, h.Version)
 	linkPath := filepath.Join(h.HydrateRoot, h.Dest)
 	oldDir, err := filepath.EvalSymlinks(linkPath)
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to evaluate the symbolic path %q to the Helm chart: %w", linkPath, err)
 	}
 
-	// for "latest" tag, we always re-fetch and re-sync
-	if h.Version != "latest" && oldDir == destDir {
+	versionRange := isRange(h.Version)
+	if !versionRange && h.upToDate(oldDir) {
 		klog.Infof("no update required with the same helm chart version %q", h.Version)
 		return nil
 	}
 
-	if !loggedIn {
-		if err := h.registryLogin(ctx); err != nil {
-			return err
-		}
+	install, err := h.newInstall(ctx)
+	if err != nil {
+		return err
 	}
 
-	args, err := h.templateArgs(ctx, destDir)
+	upToDate := false
+	// Render into a temporary directory, so that a failed rendering doesn't
+	// leave partial files behind, and the files of a previous rendering of
+	// the "latest" tag are replaced.
+	renderDir := filepath.Join(h.HydrateRoot, tmpRenderDir)
+	err = render.Run(ctx, fmt.Sprintf("helm template %s", h.chartRef()), func() error {
+		chrt, err := h.loadChart(install)
+		if err != nil {
+			return err
+		}
+		if versionRange {
+			// figure out which version we pulled as it can be provided to us
+			// as a range (e.g. 1.0.0 - 1.6.5)
+			klog.Infof("using chart version %q for version range %q", chrt.Metadata.Version, h.Version)
+			h.Version = chrt.Metadata.Version
+			if upToDate = h.upToDate(oldDir); upToDate {
+				return nil
+			}
+		}
+		if err := os.RemoveAll(renderDir); err != nil {
+			return fmt.Errorf("failed to remove the previous rendering: %w", err)
+		}
+		if err := h.render(ctx, install, chrt, renderDir); err != nil {
+			return fmt.Errorf("rendering helm chart: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	out, err := h.helm(ctx, args...)
-	if err != nil {
-		return fmt.Errorf("rendering helm chart: %w", err)
+	if upToDate {
+		klog.Infof("no update required with the same helm chart version %q", h.Version)
+		return nil
 	}
 
 	// Create the repo/chart directory, in case the chart is empty.
-	if err := os.MkdirAll(filepath.Join(destDir, h.Chart), os.ModePerm); err != nil {
+	if err := os.MkdirAll(filepath.Join(renderDir, h.Chart), os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create chart directory: %w", err)
 	}
 
-	if err := h.setDeployNamespace(destDir); err != nil {
+	if err := h.setDeployNamespace(renderDir); err != nil {
 		return fmt.Errorf("failed to set the deploy namespace: %w", err)
 	}
 
-	klog.Infof("successfully rendered the helm chart: %s", string(out))
+	destDir := filepath.Join(h.HydrateRoot, h.Version)
+	if err := os.RemoveAll(destDir); err != nil {
+		return fmt.Errorf("failed to remove the previous rendering: %w", err)
+	}
+	if err := os.Rename(renderDir, destDir); err != nil {
+		return fmt.Errorf("failed to move the rendered chart: %w", err)
+	}
+
+	klog.Infof("successfully rendered the helm chart %s version %s", h.chartRef(), h.Version)
+	h.writeSourceMetadata(ctx)
+	if oldDir == destDir {
+		// The symlink of the "latest" tag already points to the new rendering.
+		return nil
+	}
 	return util.UpdateSymlink(h.HydrateRoot, linkPath, destDir, oldDir)
 }
 
-func (h *Hydrator) isOCI() bool {
-	return strings.HasPrefix(h.Repo, "oci://")
+// upToDate returns whether the chart version is already rendered. The
+// "latest" tag is always rendered again.
+func (h *Hydrator) upToDate(oldDir string) bool {
+	return h.Version != "latest" && oldDir == filepath.Join(h.HydrateRoot, h.Version)
 }
 
-func (h *Hydrator) appendAuthArgs(ctx context.Context, args []string) ([]string, error) {
-	switch h.Auth {
-	case configsync.AuthToken:
-		args = append(args, "--username", h.UserName)
-		args = append(args, "--password", h.Password)
-	case configsync.AuthGCPServiceAccount, configsync.AuthK8sServiceAccount, configsync.AuthGCENode:
-		token, err := auth.FetchToken(ctx, h.CredentialProvider)
-		if err != nil {
-			return nil, err
-		}
-		args = append(args, "--username", "oauth2accesstoken")
-		args = append(args, "--password", token.Value)
-	}
-	return args, nil
+func (h *Hydrator) isOCI() bool {
+	return strings.HasPrefix(h.Repo, "oci://")
 }
 
 // we determine if a version is a valid range by checking that (a) it is not
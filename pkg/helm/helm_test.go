@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/repo"
+)
+
+const (
+	testChartYAML = `apiVersion: v2
+name: my-chart
+version: %s
+`
+	testValuesYAML = `color: blue
+`
+	testConfigMapTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}-config
+  namespace: {{ .Release.Namespace }}
+data:
+  color: {{ .Values.color }}
+`
+	testHookTemplate = `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    helm.sh/hook: test
+spec:
+  containers:
+  - name: test
+    image: busybox
+`
+)
+
+// newTestRepo serves a chart repository with the given versions of my-chart.
+func newTestRepo(t *testing.T, versions ...string) *httptest.Server {
+	t.Helper()
+	repoDir := t.TempDir()
+	for _, version := range versions {
+		chartDir := filepath.Join(t.TempDir(), "my-chart")
+		require.NoError(t, os.MkdirAll(filepath.Join(chartDir, "templates"), os.ModePerm))
+		files := map[string]string{
+			"Chart.yaml":               testChartYAML,
+			"values.yaml":              testValuesYAML,
+			"templates/configmap.yaml": testConfigMapTemplate,
+			"templates/test-hook.yaml": testHookTemplate,
+		}
+		for name, content := range files {
+			if name == "Chart.yaml" {
+				content = fmt.Sprintf(content, version)
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(chartDir, name), []byte(content), 0644))
+		}
+		chrt, err := loader.LoadDir(chartDir)
+		require.NoError(t, err)
+		_, err = chartutil.Save(chrt, repoDir)
+		require.NoError(t, err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(repoDir)))
+	t.Cleanup(server.Close)
+	index, err := repo.IndexDirectory(repoDir, server.URL)
+	require.NoError(t, err)
+	require.NoError(t, index.WriteFile(filepath.Join(repoDir, "index.yaml"), 0644))
+	return server
+}
+
+func TestHelmTemplate(t *testing.T) {
+	helmCacheHome = t.TempDir()
+	helmSettings.RepositoryCache = filepath.Join(helmCacheHome, "repository")
+	helmSettings.RepositoryConfig = filepath.Join(t.TempDir(), "repositories.yaml")
+	helmSettings.RegistryConfig = filepath.Join(t.TempDir(), "registry.json")
+
+	server := newTestRepo(t, "1.0.0", "1.1.0", "2.0.0")
+	root := t.TempDir()
+	h := &Hydrator{
+		Chart:       "my-chart",
+		Repo:        server.URL,
+		Version:     "^1.0.0",
+		ReleaseName: "my-release",
+		Namespace:   "my-namespace",
+		ValuesYAML:  "color: red\n",
+		HydrateRoot: root,
+		Dest:        "my-chart",
+	}
+	require.NoError(t, h.HelmTemplate(context.Background()))
+	assert.Equal(t, "1.1.0", h.Version)
+
+	target, err := filepath.EvalSymlinks(filepath.Join(root, "my-chart"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "1.1.0"), target)
+
+	configMap, err := os.ReadFile(filepath.Join(target, "my-chart", "templates", "configmap.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, `---
+# Source: my-chart/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-release-config
+  namespace: my-namespace
+data:
+  color: red
+`, string(configMap))
+
+	hook, err := os.ReadFile(filepath.Join(target, "my-chart", "templates", "test-hook.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(hook), "name: my-release-test")
+
+	_, err = os.Stat(filepath.Join(root, tmpRenderDir))
+	assert.True(t, os.IsNotExist(err), "the temporary rendering directory must be moved")
+
+	// The resolved version is already rendered.
+	h.Version = "^1.0.0"
+	require.NoError(t, h.HelmTemplate(context.Background()))
+	assert.Equal(t, "1.1.0", h.Version)
+
+	// A new version replaces the previous rendering.
+	h.Version = "2.0.0"
+	require.NoError(t, h.HelmTemplate(context.Background()))
+	target, err = filepath.EvalSymlinks(filepath.Join(root, "my-chart"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "2.0.0"), target)
+	_, err = os.Stat(filepath.Join(root, "1.1.0"))
+	assert.True(t, os.IsNotExist(err), "the previous rendering must be removed")
+
+	h.Version = "3.0.0"
+	assert.ErrorContains(t, h.HelmTemplate(context.Background()), "failed to pull the helm chart")
+}
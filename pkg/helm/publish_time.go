@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/auth"
+	"kpt.dev/configsync/pkg/util/sourcemetadata"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// ociCreatedAnnotation is the annotation of the manifest of a chart
+	// pushed to an OCI registry with its publish time.
+	ociCreatedAnnotation = "org.opencontainers.image.created"
+	// maxIndexSize bounds the size of the index of a chart repository.
+	maxIndexSize = 64 << 20
+)
+
+// repoIndex is the subset of the index.yaml of a chart repository with the
+// publish times of the chart versions.
+type repoIndex struct {
+	Entries map[string][]struct {
+		Version string    `yaml:"version"`
+		Created time.Time `yaml:"created"`
+	} `yaml:"entries"`
+}
+
+// writeSourceMetadata records the publish time of the chart version as the
+// commit time. It is best effort, since the commit time is only used for
+// metrics and status: the chart is synced without it.
+func (h *Hydrator) writeSourceMetadata(ctx context.Context) {
+	metadata := sourcemetadata.Metadata{Commit: h.Version}
+	publishTime, err := h.publishTime(ctx)
+	if err != nil {
+		klog.Warningf("failed to get the publish time of the helm chart version %q: %v", h.Version, err)
+	} else if !publishTime.IsZero() {
+		metadata.CommitTime = &publishTime
+	}
+	if err := sourcemetadata.Write(h.HydrateRoot, metadata); err != nil {
+		klog.Warningf("failed to record the metadata of the helm chart version %q: %v", h.Version, err)
+	}
+}
+
+// publishTime returns the publish time of the chart version: the created
+// annotation of the chart manifest in an OCI registry, or the created time of
+// the version in the index of a chart repository. Returns the zero time if
+// the chart has no publish time.
+func (h *Hydrator) publishTime(ctx context.Context) (time.Time, error) {
+	creds, err := h.credentials(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	transport, err := h.transport()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if h.isOCI() {
+		return h.ociPublishTime(ctx, creds, transport)
+	}
+	return h.repoPublishTime(ctx, creds, transport)
+}
+
+func (h *Hydrator) ociPublishTime(ctx context.Context, creds *authn.Basic, transport http.RoundTripper) (time.Time, error) {
+	// The "+" of semver build metadata isn't allowed in OCI tags, so Helm
+	// pushes the chart versions with "_" instead.
+	tag := strings.ReplaceAll(h.Version, "+", "_")
+	ref, err := name.ParseReference(strings.TrimPrefix(h.Repo, "oci://") + "/" + h.Chart + ":" + tag)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var authenticator authn.Authenticator = authn.Anonymous
+	if creds != nil {
+		authenticator = creds
+	}
+	desc, err := remote.Get(ref, remote.WithContext(ctx), remote.WithAuth(authenticator), remote.WithTransport(transport))
+	if err != nil {
+		return time.Time{}, err
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return time.Time{}, err
+	}
+	created, found := manifest.Annotations[ociCreatedAnnotation]
+	if !found {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, created)
+}
+
+func (h *Hydrator) repoPublishTime(ctx context.Context, creds *authn.Basic, transport http.RoundTripper) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(h.Repo, "/")+"/index.yaml", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if creds != nil {
+		req.SetBasicAuth(creds.Username, creds.Password)
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("unexpected response status %s for the repository index", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize))
+	if err != nil {
+		return time.Time{}, err
+	}
+	index := repoIndex{}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse the repository index: %w", err)
+	}
+	for _, entry := range index.Entries[h.Chart] {
+		if entry.Version == h.Version {
+			return entry.Created, nil
+		}
+	}
+	return time.Time{}, nil
+}
+
+// credentials returns the username and password to authenticate to the
+// repository, or nil for no authentication.
+func (h *Hydrator) credentials(ctx context.Context) (*authn.Basic, error) {
+	switch h.Auth {
+	case configsync.AuthToken:
+		return &authn.Basic{Username: h.UserName, Password: h.Password}, nil
+	case configsync.AuthGCPServiceAccount, configsync.AuthK8sServiceAccount, configsync.AuthGCENode:
+		token, err := auth.FetchToken(ctx, h.CredentialProvider)
+		if err != nil {
+			return nil, err
+		}
+		return &authn.Basic{Username: "oauth2accesstoken", Password: token.Value}, nil
+	}
+	return nil, nil
+}
+
+// transport returns the HTTP transport to the repository, which trusts the
+// CA certificate, if set.
+func (h *Hydrator) transport() (http.RoundTripper, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if h.CACertFilePath == "" {
+		return transport, nil
+	}
+	caCert, err := os.ReadFile(h.CACertFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse the CA certificate %q", h.CACertFilePath)
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return transport, nil
+}
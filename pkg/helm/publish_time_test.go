@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+const testIndex = `apiVersion: v1
+entries:
+  my-chart:
+  - name: my-chart
+    version: 1.1.0
+    created: "2026-10-19T10:00:00.123456789Z"
+  - name: my-chart
+    version: 1.0.0
+    created: "2026-10-01T10:00:00Z"
+  other-chart:
+  - name: other-chart
+    version: 1.0.0
+    created: "2026-09-01T10:00:00Z"
+`
+
+func TestRepoPublishTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if r.URL.Path != "/charts/index.yaml" || !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testIndex))
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		version  string
+		expected time.Time
+	}{
+		{
+			name:     "published version",
+			version:  "1.0.0",
+			expected: time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "version not in the index",
+			version: "2.0.0",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &Hydrator{
+				Chart:    "my-chart",
+				Repo:     server.URL + "/charts/",
+				Version:  tc.version,
+				Auth:     configsync.AuthToken,
+				UserName: "user",
+				Password: "pass",
+			}
+			publishTime, err := h.publishTime(context.Background())
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(publishTime), "expected %v, got %v", tc.expected, publishTime)
+		})
+	}
+
+	h := &Hydrator{Chart: "my-chart", Repo: server.URL + "/missing", Version: "1.0.0"}
+	_, err := h.publishTime(context.Background())
+	assert.ErrorContains(t, err, "404 Not Found")
+}
@@ -0,0 +1,255 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+	cueerrors "cuelang.org/go/cue/errors"
+	"cuelang.org/go/cue/load"
+	"github.com/google/go-jsonnet"
+	"kpt.dev/configsync/pkg/util/render"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+const (
+	// Jsonnet is the name of the Jsonnet render tool.
+	Jsonnet = "jsonnet"
+	// Cue is the name of the CUE render tool.
+	Cue = "cue"
+
+	// JsonnetEntrypoint is the Jsonnet file rendered in the sync directory.
+	JsonnetEntrypoint = "main.jsonnet"
+	// cueFileExtension is the extension of the CUE files.
+	cueFileExtension = ".cue"
+)
+
+// jsonnetLibraryPaths are the library search paths of Jsonnet, relative to the
+// sync directory, following the jsonnet-bundler conventions.
+var jsonnetLibraryPaths = []string{"lib", "vendor"}
+
+// IsJsonnetEntrypoint checks if the file is the Jsonnet entrypoint.
+func IsJsonnetEntrypoint(filename string) bool {
+	return filename == JsonnetEntrypoint
+}
+
+// IsCueFile checks if the file is a CUE file.
+func IsCueFile(filename string) bool {
+	return filepath.Ext(filename) == cueFileExtension
+}
+
+// hasFile checks if any file directly under the directory matches.
+func hasFile(dir string, match func(filename string) bool) (bool, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return false, fmt.Errorf("unable to traverse the directory: %s: %w", dir, err)
+	}
+	for _, f := range files {
+		if !f.IsDir() && match(f.Name()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// needsJsonnet checks if there is a Jsonnet entrypoint under the directory.
+func needsJsonnet(dir string) (bool, error) {
+	return hasFile(dir, IsJsonnetEntrypoint)
+}
+
+// needsCue checks if there is a CUE file under the directory.
+func needsCue(dir string) (bool, error) {
+	return hasFile(dir, IsCueFile)
+}
+
+// jsonnetBuild evaluates the Jsonnet entrypoint in the input directory, and
+// writes each rendered object to its own file in the output directory.
+func jsonnetBuild(ctx context.Context, input, output string) HydrationError {
+	return languageBuild(ctx, Jsonnet, evaluateJsonnet, input, output)
+}
+
+// cueBuild exports the CUE package in the input directory as JSON, and writes
+// each rendered object to its own file in the output directory.
+func cueBuild(ctx context.Context, input, output string) HydrationError {
+	return languageBuild(ctx, Cue, evaluateCue, input, output)
+}
+
+// languageBuild evaluates the configuration language in the input directory,
+// extracts the Kubernetes objects from the JSON value, and writes each of
+// them to its own file in the output directory.
+// The evaluation runs with render.Run, and is abandoned when ctx is done.
+func languageBuild(ctx context.Context, tool string, evaluate func(dir string) ([]byte, error), input, output string) HydrationError {
+	if _, err := os.Stat(output); err == nil {
+		mustDeleteOutput(err, output)
+	}
+
+	fileMode := os.FileMode(0755)
+	if err := os.MkdirAll(output, fileMode); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+
+	var value []byte
+	err := render.Run(ctx, fmt.Sprintf("%s evaluation in %s", tool, input), func() error {
+		var evalErr error
+		value, evalErr = evaluate(input)
+		return evalErr
+	})
+	var nodes []*yaml.RNode
+	if err == nil {
+		nodes, err = decodeObjects(value)
+	}
+	if err != nil {
+		buildErr := fmt.Errorf("failed to evaluate %s in %s: %w", tool, input, err)
+		mustDeleteOutput(buildErr, output)
+		if ctx.Err() != nil {
+			return NewTransientError(buildErr)
+		}
+		return NewActionableError(buildErr)
+	}
+	if err := writeNodes(nodes, output); err != nil {
+		mustDeleteOutput(err, output)
+		return NewInternalError(err)
+	}
+	return nil
+}
+
+// evaluateJsonnet evaluates the Jsonnet entrypoint in dir, like
+// `jsonnet --jpath lib --jpath vendor main.jsonnet`, and returns the JSON
+// value.
+func evaluateJsonnet(dir string) ([]byte, error) {
+	jpaths := make([]string, len(jsonnetLibraryPaths))
+	for i, lib := range jsonnetLibraryPaths {
+		jpaths[i] = filepath.Join(dir, lib)
+	}
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{JPaths: jpaths})
+	value, err := vm.EvaluateFile(filepath.Join(dir, JsonnetEntrypoint))
+	if err != nil {
+		return nil, errors.New(strings.TrimSpace(err.Error()))
+	}
+	return []byte(value), nil
+}
+
+// evaluateCue exports the CUE package in dir, like `cue export --out json`,
+// and returns the JSON value.
+func evaluateCue(dir string) ([]byte, error) {
+	instances := load.Instances([]string{"."}, &load.Config{Dir: dir})
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("found %d CUE packages, want exactly one", len(instances))
+	}
+	if err := instances[0].Err; err != nil {
+		return nil, cueError(err)
+	}
+	value := cuecontext.New().BuildInstance(instances[0])
+	if err := value.Validate(cue.Concrete(true)); err != nil {
+		return nil, cueError(err)
+	}
+	b, err := value.MarshalJSON()
+	if err != nil {
+		return nil, cueError(err)
+	}
+	return b, nil
+}
+
+// cueError returns the CUE error with the details and positions of all its
+// errors, like the CUE command line prints them.
+func cueError(err error) error {
+	return errors.New(strings.TrimSpace(cueerrors.Details(err, nil)))
+}
+
+// decodeObjects returns the Kubernetes objects in the JSON value.
+func decodeObjects(b []byte) ([]*yaml.RNode, error) {
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, fmt.Errorf("unable to parse the output: %w", err)
+	}
+	objs, err := extractObjects(value, "")
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*yaml.RNode, len(objs))
+	for i, obj := range objs {
+		if nodes[i], err = yaml.FromMap(obj); err != nil {
+			return nil, fmt.Errorf("unable to encode the rendered object: %w", err)
+		}
+	}
+	return nodes, nil
+}
+
+// extractObjects returns the Kubernetes objects in the evaluated value, which
+// can be an object, a List, an array of values, or an object whose fields are
+// values. Fields are visited in sorted order so the output is stable.
+func extractObjects(value interface{}, field string) ([]map[string]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		var objs []map[string]interface{}
+		for i, item := range v {
+			itemObjs, err := extractObjects(item, fmt.Sprintf("%s[%d]", field, i))
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, itemObjs...)
+		}
+		return objs, nil
+	case map[string]interface{}:
+		if isObject(v) {
+			if items, found := v["items"]; found && strings.HasSuffix(v["kind"].(string), "List") {
+				return extractObjects(items, field+".items")
+			}
+			return []map[string]interface{}{v}, nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var objs []map[string]interface{}
+		for _, k := range keys {
+			fieldObjs, err := extractObjects(v[k], field+"."+k)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, fieldObjs...)
+		}
+		return objs, nil
+	case nil:
+		return nil, nil
+	default:
+		if field == "" {
+			return nil, errors.New("the output is not a Kubernetes object")
+		}
+		return nil, fmt.Errorf("the output field %s is not a Kubernetes object", strings.TrimPrefix(field, "."))
+	}
+}
+
+// isObject checks if the value has the apiVersion and kind of a Kubernetes
+// object.
+func isObject(v map[string]interface{}) bool {
+	apiVersion, ok := v["apiVersion"].(string)
+	if !ok || apiVersion == "" {
+		return false
+	}
+	kind, ok := v["kind"].(string)
+	return ok && kind != ""
+}
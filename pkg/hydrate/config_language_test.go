@@ -0,0 +1,232 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractObjects(t *testing.T) {
+	ns := map[string]interface{}{"apiVersion": "v1", "kind": "Namespace", "metadata": map[string]interface{}{"name": "a"}}
+	cm := map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]interface{}{"name": "b"}}
+
+	testCases := []struct {
+		name    string
+		value   string
+		want    []map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:  "single object",
+			value: `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "a"}}`,
+			want:  []map[string]interface{}{ns},
+		},
+		{
+			name:  "array",
+			value: `[{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "a"}}, {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}]`,
+			want:  []map[string]interface{}{ns, cm},
+		},
+		{
+			name:  "List",
+			value: `{"apiVersion": "v1", "kind": "List", "items": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}]}`,
+			want:  []map[string]interface{}{cm},
+		},
+		{
+			name:  "nested fields in sorted order",
+			value: `{"z": {"cm": {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}}, "a": {"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "a"}}, "empty": null}`,
+			want:  []map[string]interface{}{ns, cm},
+		},
+		{
+			name:    "scalar field",
+			value:   `{"objects": [{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "a"}}, "text"]}`,
+			wantErr: "the output field objects[1] is not a Kubernetes object",
+		},
+		{
+			name:    "scalar",
+			value:   `"text"`,
+			wantErr: "the output is not a Kubernetes object",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var value interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.value), &value))
+			got, err := extractObjects(value, "")
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLanguageBuild(t *testing.T) {
+	testCases := []struct {
+		name    string
+		build   func(ctx context.Context, input, output string) HydrationError
+		files   map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "Jsonnet with a library",
+			build: jsonnetBuild,
+			files: map[string]string{
+				JsonnetEntrypoint: `local cm = import 'configmap.libsonnet';
+{ configMaps: [cm('a'), cm('b')] }
+`,
+				"lib/configmap.libsonnet": `function(name) { apiVersion: 'v1', kind: 'ConfigMap', metadata: { name: name } }
+`,
+			},
+			want: map[string]string{
+				"v1_configmap_a.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n",
+				"v1_configmap_b.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: b\n",
+			},
+		},
+		{
+			name:  "Jsonnet error",
+			build: jsonnetBuild,
+			files: map[string]string{
+				JsonnetEntrypoint: "error 'undefined field: foo'\n",
+			},
+			wantErr: "RUNTIME ERROR: undefined field: foo",
+		},
+		{
+			name:  "CUE package",
+			build: cueBuild,
+			files: map[string]string{
+				"ns.cue": `package config
+
+namespace: {
+	apiVersion: "v1"
+	kind:       "Namespace"
+	metadata: name: _name
+}
+`,
+				"name.cue": `package config
+
+_name: "shipping"
+`,
+			},
+			want: map[string]string{
+				"v1_namespace_shipping.yaml": "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shipping\n",
+			},
+		},
+		{
+			name:  "CUE incomplete value",
+			build: cueBuild,
+			files: map[string]string{
+				"ns.cue": `package config
+
+namespace: {
+	apiVersion: "v1"
+	kind:       "Namespace"
+	metadata: name: string
+}
+`,
+			},
+			wantErr: "namespace.metadata.name: incomplete value string",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(input, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+			output := filepath.Join(t.TempDir(), "hydrated")
+
+			hydrationErr := tc.build(context.Background(), input, output)
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.IsType(t, ActionableError{}, hydrationErr)
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				assert.NoDirExists(t, output)
+				return
+			}
+			require.NoError(t, hydrationErr)
+			files, err := os.ReadDir(output)
+			require.NoError(t, err)
+			got := map[string]string{}
+			for _, f := range files {
+				b, err := os.ReadFile(filepath.Join(output, f.Name()))
+				require.NoError(t, err)
+				got[f.Name()] = string(b)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRenderTool(t *testing.T) {
+	testCases := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{
+			name:  "wet configs",
+			files: []string{"ns.yaml"},
+			want:  "",
+		},
+		{
+			name:  "Kustomization takes precedence",
+			files: []string{"kustomization.yaml", JsonnetEntrypoint, "config.cue"},
+			want:  Kustomize,
+		},
+		{
+			name:  "Jsonnet takes precedence over CUE",
+			files: []string{JsonnetEntrypoint, "config.cue"},
+			want:  Jsonnet,
+		},
+		{
+			name:  "CUE",
+			files: []string{"config.cue", "ns.yaml"},
+			want:  Cue,
+		},
+		{
+			name:  "nested Jsonnet entrypoint is not rendered",
+			files: []string{filepath.Join("sub", JsonnetEntrypoint)},
+			want:  "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, f := range tc.files {
+				path := filepath.Join(dir, f)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, nil, 0644))
+			}
+			got, err := renderTool(dir)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -23,14 +23,18 @@ import (
 	"path/filepath"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/log"
 )
 
 const (
@@ -45,6 +49,16 @@ const (
 	ReadyToRenderFile = "ready-to-render"
 )
 
+var (
+	// keyRenderTool tags render spans with the rendering tool.
+	// Possible values: kustomize, kpt, jsonnet, cue.
+	keyRenderTool = attribute.Key("configsync.render.tool")
+
+	// keyRenderCached tags render spans with whether the rendered configs
+	// were restored from the render cache.
+	keyRenderCached = attribute.Key("configsync.render.cached")
+)
+
 // Hydrator runs the hydration process.
 type Hydrator struct {
 	// DonePath is the absolute path to the done file under the /repo directory.
@@ -69,6 +83,19 @@ type Hydrator struct {
 	RehydratePeriod time.Duration
 	// ReconcilerName is the name of the reconciler.
 	ReconcilerName string
+	// SyncName is the name of the RootSync or RepoSync object.
+	SyncName string
+	// Scope is the scope of the RootSync or RepoSync object.
+	Scope declared.Scope
+	// RenderTimeout is the maximum duration of a single rendering.
+	// Rendering is not limited if zero.
+	RenderTimeout time.Duration
+	// KptFunctions are the allow-listed KRM function images that Kptfile
+	// pipelines can run, mapped to their local binaries.
+	KptFunctions KptFunctions
+	// RenderCacheSize is the maximum number of renderings cached on the
+	// hydrated volume. The render cache is disabled if zero.
+	RenderCacheSize int
 }
 
 // Run runs the hydration process periodically.
@@ -128,30 +155,85 @@ func (h *Hydrator) Run(ctx context.Context) {
 	}
 }
 
-// runHydrate runs `kustomize build` on the source configs.
+// runHydrate renders the source configs with the tool selected by renderTool.
 func (h *Hydrator) runHydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	newHydratedDir := h.HydratedRoot.Join(cmpath.RelativeOS(sourceCommit))
 	dest := newHydratedDir.Join(h.SyncDir).OSPath()
 
+	// Tag the render span with the RSync and commit, like the reconciler spans.
+	ctx := tracing.WithAttributes(context.Background(),
+		append(tracing.SyncAttributes(h.Scope, h.SyncName), tracing.KeyCommit.String(sourceCommit))...)
+	if h.RenderTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.RenderTimeout)
+		defer cancel()
+	}
 	osSyncPath := syncPath.OSPath()
-	if err := kustomizeBuild(osSyncPath, dest, true); err != nil {
-		return err
+	tool, hydrationErr := renderTool(osSyncPath)
+	if hydrationErr != nil {
+		return hydrationErr
+	}
+
+	renderCtx, span := tracing.Start(ctx, "Render", keyRenderTool.String(tool))
+	cache := h.renderCache()
+	var cacheKey string
+	restored := false
+	if cache.enabled() {
+		cacheKey = h.renderCacheKey(sourceCommit, tool)
+		var err error
+		restored, err = cache.restore(cacheKey, newHydratedDir.OSPath())
+		if err != nil {
+			klog.Warningf("Unable to restore the rendered configs for commit %s from the render cache: %v", sourceCommit, err)
+		}
+	}
+	span.SetAttributes(keyRenderCached.Bool(restored))
+	if restored {
+		klog.Infof("Restored the rendered configs of %s for commit %s from the render cache", osSyncPath, sourceCommit)
+	} else {
+		hydrationErr = h.render(renderCtx, tool, osSyncPath, dest)
+	}
+	tracing.End(span, hydrationErr)
+	if hydrationErr != nil {
+		return hydrationErr
 	}
 
 	newCommit, err := ComputeCommit(h.sourcePath())
 	if err != nil {
 		return NewTransientError(err)
 	} else if sourceCommit != newCommit {
-		return NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
+		return NewTransientError(fmt.Errorf("source commit changed while rendering, was %s, now %s. It will be retried in the next sync", sourceCommit, newCommit))
+	}
+
+	if cache.enabled() && !restored {
+		if err := cache.store(cacheKey, newHydratedDir.OSPath()); err != nil {
+			klog.Warningf("Unable to store the rendered configs for commit %s in the render cache: %v", sourceCommit, err)
+		}
 	}
 
 	if err := updateSymlink(h.HydratedRoot.OSPath(), h.HydratedLink, newHydratedDir.OSPath()); err != nil {
 		return NewInternalError(fmt.Errorf("unable to update the symbolic link to %s: %w", newHydratedDir.OSPath(), err))
 	}
-	klog.Infof("Successfully rendered %s for commit %s", osSyncPath, sourceCommit)
+	klog.InfoS("Successfully rendered the source", "syncPath", osSyncPath, log.KeyCommit, sourceCommit)
 	return nil
 }
 
+// render renders the source configs in the input directory with the tool, and
+// writes the rendered configs to the output directory.
+func (h *Hydrator) render(ctx context.Context, tool, input, output string) HydrationError {
+	switch tool {
+	case Kustomize:
+		return kustomizeBuild(ctx, input, output, true)
+	case Kpt:
+		return kptRender(ctx, input, output, h.KptFunctions)
+	case Jsonnet:
+		return jsonnetBuild(ctx, input, output)
+	case Cue:
+		return cueBuild(ctx, input, output)
+	default:
+		return NewInternalError(fmt.Errorf("no rendering tool for the source directory: %s", input))
+	}
+}
+
 // ComputeCommit returns the computed commit from given sourceDir, or error
 // if the sourceDir fails symbolic link evaluation
 func ComputeCommit(sourceDir cmpath.Absolute) (string, error) {
@@ -172,11 +254,11 @@ func (h *Hydrator) sourcePath() cmpath.Absolute {
 // hydrate renders the source git repo to hydrated configs.
 func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) HydrationError {
 	osSyncPath := syncPath.OSPath()
-	hydrate, err := needsKustomize(osSyncPath)
-	if err != nil {
-		return NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", osSyncPath, err))
+	tool, hydrationErr := renderTool(osSyncPath)
+	if hydrationErr != nil {
+		return hydrationErr
 	}
-	if !hydrate {
+	if tool == "" {
 		found, err := hasKustomizeSubdir(osSyncPath)
 		if err != nil {
 			return NewInternalError(err)
@@ -186,7 +268,7 @@ func (h *Hydrator) hydrate(sourceCommit string, syncPath cmpath.Absolute) Hydrat
 				"To fix, either add kustomization.yaml in the sync directory to trigger the rendering process, "+
 				"or remove kustomizaiton.yaml from all sub directories to skip rendering.", osSyncPath))
 		}
-		klog.V(5).Infof("no rendering is needed because of no Kustomization config file in the source configs with commit %s", sourceCommit)
+		klog.V(5).Infof("no rendering is needed because of no Kustomization config file, Kptfile pipeline, Jsonnet entrypoint or CUE file in the source configs with commit %s", sourceCommit)
 		if err := os.RemoveAll(h.HydratedRoot.OSPath()); err != nil {
 			return NewInternalError(err)
 		}
@@ -309,7 +391,7 @@ func ExtractCommit(filePath string) (string, error) {
 
 // exportError writes the error content to the error file.
 func exportError(commit, root, errorFile string, hydrationError HydrationError) error {
-	klog.Errorf("rendering error for commit %s: %v", commit, hydrationError)
+	klog.ErrorS(hydrationError, "Rendering error", log.KeyCommit, commit, log.KeyErrorCode, hydrationError.Code())
 	if _, err := os.Stat(root); os.IsNotExist(err) {
 		fileMode := os.FileMode(0755)
 		if err := os.Mkdir(root, fileMode); err != nil {
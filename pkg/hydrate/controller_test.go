@@ -205,7 +205,7 @@ func TestRunHydrate(t *testing.T) {
 		{
 			name:      "Run hydrate when source commit is changed",
 			commit:    differentCommit,
-			wantedErr: NewTransientError(fmt.Errorf("source commit changed while running Kustomize build, was %s, now %s. It will be retried in the next sync", originCommit, differentCommit)),
+			wantedErr: NewTransientError(fmt.Errorf("source commit changed while rendering, was %s, now %s. It will be retried in the next sync", originCommit, differentCommit)),
 		},
 	}
 
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	v1repo "kpt.dev/configsync/pkg/api/configmanagement/v1/repo"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+)
+
+// serverMetadataFields are the metadata fields populated by the API server.
+var serverMetadataFields = []string{
+	"uid",
+	"resourceVersion",
+	"generation",
+	"creationTimestamp",
+	"deletionTimestamp",
+	"deletionGracePeriodSeconds",
+	"managedFields",
+	"selfLink",
+	"ownerReferences",
+}
+
+// serverAnnotations are the annotations populated by clients and controllers
+// rather than declared by users.
+var serverAnnotations = []string{
+	corev1.LastAppliedConfigAnnotation,
+	"deployment.kubernetes.io/revision",
+	"deprecated.daemonset.template.generation",
+}
+
+// namespaceNameLabel is the label the API server sets on every Namespace.
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// IsExportable returns false for live objects which are created by
+// controllers, and so should not be declared in a repository.
+func IsExportable(u *unstructured.Unstructured) bool {
+	if len(u.GetOwnerReferences()) > 0 {
+		return false
+	}
+	switch u.GroupVersionKind().GroupKind() {
+	case kinds.ServiceAccount().GroupKind():
+		return u.GetName() != "default"
+	case kinds.ConfigMap().GroupKind():
+		return u.GetName() != "kube-root-ca.crt"
+	case kinds.Secret().GroupKind():
+		secretType, _, _ := unstructured.NestedString(u.Object, "type")
+		return secretType != string(corev1.SecretTypeServiceAccountToken)
+	}
+	return true
+}
+
+// CleanLiveObject removes the fields populated by the API server, controllers
+// and Config Sync from a live object, so that it can be declared in a
+// repository.
+func CleanLiveObject(u *unstructured.Unstructured) {
+	delete(u.Object, "status")
+	for _, field := range serverMetadataFields {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+
+	annotations := u.GetAnnotations()
+	for _, a := range serverAnnotations {
+		delete(annotations, a)
+	}
+	u.SetAnnotations(annotations)
+	metadata.RemoveConfigSyncMetadata(u)
+	labels := u.GetLabels()
+	delete(labels, metadata.ApplySetPartOfLabel)
+
+	switch u.GroupVersionKind().GroupKind() {
+	case kinds.Namespace().GroupKind():
+		delete(labels, namespaceNameLabel)
+		unstructured.RemoveNestedField(u.Object, "spec")
+	case kinds.Service().GroupKind():
+		// Headless Services declare their clusterIP.
+		if clusterIP, _, _ := unstructured.NestedString(u.Object, "spec", "clusterIP"); clusterIP != corev1.ClusterIPNone {
+			unstructured.RemoveNestedField(u.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(u.Object, "spec", "clusterIPs")
+		}
+	}
+	if len(labels) == 0 {
+		labels = nil
+	}
+	u.SetLabels(labels)
+	if len(u.GetAnnotations()) == 0 {
+		u.SetAnnotations(nil)
+	}
+}
+
+// ExportFileObjects returns the file objects of live cluster objects, with
+// the same file names as `nomos hydrate`. With the hierarchy source format,
+// cluster-scoped objects are placed in the cluster/ directory, and Namespaces
+// and namespaced objects in the namespaces/ directory.
+func ExportFileObjects(sourceFormat configsync.SourceFormat, extension string, objects []*unstructured.Unstructured) []ast.FileObject {
+	fileObjects := make([]ast.FileObject, len(objects))
+	for i, u := range objects {
+		CleanLiveObject(u)
+		fileObjects[i] = ast.NewFileObject(u, cmpath.RelativeSlash(""))
+	}
+	fileObjects = generateUniqueFileNames(extension, false, fileObjects...)
+	Clean(fileObjects)
+	if sourceFormat != configsync.SourceFormatHierarchy {
+		return fileObjects
+	}
+
+	for i, obj := range fileObjects {
+		var dir string
+		switch {
+		case obj.GetObjectKind().GroupVersionKind().GroupKind() == kinds.Namespace().GroupKind():
+			dir = path.Join(v1repo.NamespacesDir, obj.GetName())
+		case obj.GetNamespace() != "":
+			dir = v1repo.NamespacesDir
+		default:
+			dir = v1repo.ClusterDir
+		}
+		fileObjects[i].Relative = cmpath.RelativeSlash(path.Join(dir, obj.SlashPath()))
+	}
+	return fileObjects
+}
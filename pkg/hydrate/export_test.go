@@ -0,0 +1,246 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"sigs.k8s.io/yaml"
+)
+
+func mustParseLive(t *testing.T, content string) *unstructured.Unstructured {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	require.NoError(t, yaml.Unmarshal([]byte(content), &u.Object))
+	return u
+}
+
+func TestCleanLiveObject(t *testing.T) {
+	testCases := map[string]struct {
+		live string
+		want string
+	}{
+		"deployment": {
+			live: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  uid: 1234
+  resourceVersion: "42"
+  generation: 3
+  creationTimestamp: "2026-01-02T15:04:05Z"
+  managedFields:
+  - manager: kubectl
+  labels:
+    app: web
+    app.kubernetes.io/managed-by: configmanagement.gke.io
+    applyset.kubernetes.io/part-of: applyset-id
+  annotations:
+    deployment.kubernetes.io/revision: "3"
+    kubectl.kubernetes.io/last-applied-configuration: '{}'
+    configsync.gke.io/manager: ':root'
+    config.k8s.io/owning-inventory: config-management-system_root-sync
+spec:
+  replicas: 2
+status:
+  replicas: 2
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+  labels:
+    app: web
+spec:
+  replicas: 2
+`,
+		},
+		"namespace": {
+			live: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+  labels:
+    kubernetes.io/metadata.name: shop
+spec:
+  finalizers:
+  - kubernetes
+status:
+  phase: Active
+`,
+			want: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: shop
+`,
+		},
+		"service": {
+			live: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  clusterIP: 10.0.0.1
+  clusterIPs:
+  - 10.0.0.1
+  ports:
+  - port: 80
+`,
+			want: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  ports:
+  - port: 80
+`,
+		},
+		"headless service": {
+			live: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  clusterIP: None
+  clusterIPs:
+  - None
+`,
+			want: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+spec:
+  clusterIP: None
+  clusterIPs:
+  - None
+`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			u := mustParseLive(t, tc.live)
+			CleanLiveObject(u)
+			assert.Equal(t, mustParseLive(t, tc.want), u)
+		})
+	}
+}
+
+func TestIsExportable(t *testing.T) {
+	testCases := map[string]struct {
+		live string
+		want bool
+	}{
+		"owned object": {
+			live: `
+apiVersion: apps/v1
+kind: ReplicaSet
+metadata:
+  name: web-abc
+  namespace: shop
+  ownerReferences:
+  - apiVersion: apps/v1
+    kind: Deployment
+    name: web
+    uid: "1234"
+`,
+			want: false,
+		},
+		"default service account": {
+			live: `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: default
+  namespace: shop
+`,
+			want: false,
+		},
+		"service account token": {
+			live: `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-token
+  namespace: shop
+type: kubernetes.io/service-account-token
+`,
+			want: false,
+		},
+		"user config map": {
+			live: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  namespace: shop
+`,
+			want: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IsExportable(mustParseLive(t, tc.live)))
+		})
+	}
+}
+
+func TestExportFileObjects(t *testing.T) {
+	newObjects := func() []*unstructured.Unstructured {
+		return []*unstructured.Unstructured{
+			mustParseLive(t, "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: shop\n"),
+			mustParseLive(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n  namespace: shop\n"),
+			mustParseLive(t, "apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: viewer\n"),
+		}
+	}
+	testCases := map[configsync.SourceFormat][]string{
+		configsync.SourceFormatUnstructured: {
+			"namespace_shop.yaml",
+			"shop/configmap_web.yaml",
+			"clusterrole_viewer.yaml",
+		},
+		configsync.SourceFormatHierarchy: {
+			"namespaces/shop/namespace_shop.yaml",
+			"namespaces/shop/configmap_web.yaml",
+			"cluster/clusterrole_viewer.yaml",
+		},
+	}
+	for sourceFormat, want := range testCases {
+		t.Run(string(sourceFormat), func(t *testing.T) {
+			var got []string
+			for _, obj := range ExportFileObjects(sourceFormat, "yaml", newObjects()) {
+				got = append(got, obj.SlashPath())
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
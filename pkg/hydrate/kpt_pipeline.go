@@ -0,0 +1,495 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	// Kpt is the name of the Kptfile pipeline rendering tool.
+	Kpt = "kpt"
+	// Kptfile is the file name of the kpt package metadata.
+	Kptfile = "Kptfile"
+	// kptfileAPIVersion is the only supported apiVersion of the Kptfile.
+	kptfileAPIVersion = "kpt.dev/v1"
+	// defaultKptFunctionRegistry is the registry of the function images
+	// referenced by short names, like `set-namespace:v0.4`.
+	defaultKptFunctionRegistry = "gcr.io/kpt-fn/"
+)
+
+// kptfile is the subset of the kpt.dev/v1 Kptfile used for rendering.
+type kptfile struct {
+	APIVersion string       `json:"apiVersion,omitempty"`
+	Kind       string       `json:"kind,omitempty"`
+	Pipeline   *kptPipeline `json:"pipeline,omitempty"`
+}
+
+// kptPipeline is the list of KRM functions to run on a kpt package.
+type kptPipeline struct {
+	// Mutators run in order, each on the output of the previous one.
+	Mutators []kptFunction `json:"mutators,omitempty"`
+	// Validators run on the mutated resources and can not change them.
+	Validators []kptFunction `json:"validators,omitempty"`
+}
+
+// empty returns true if the pipeline has no functions.
+func (p *kptPipeline) empty() bool {
+	return p == nil || len(p.Mutators)+len(p.Validators) == 0
+}
+
+// kptFunction is a KRM function in a Kptfile pipeline.
+type kptFunction struct {
+	Name       string            `json:"name,omitempty"`
+	Image      string            `json:"image,omitempty"`
+	Exec       string            `json:"exec,omitempty"`
+	ConfigPath string            `json:"configPath,omitempty"`
+	ConfigMap  map[string]string `json:"configMap,omitempty"`
+	Selectors  []interface{}     `json:"selectors,omitempty"`
+	Exclude    []interface{}     `json:"exclude,omitempty"`
+}
+
+// String returns the name of the function for error messages.
+func (f kptFunction) String() string {
+	switch {
+	case f.Name != "":
+		return f.Name
+	case f.Image != "":
+		return f.Image
+	default:
+		return f.Exec
+	}
+}
+
+// KptFunctions maps the images of the allow-listed KRM functions to the local
+// binaries that implement them. Exec functions can only run these binaries.
+type KptFunctions map[string]string
+
+// ParseKptFunctions parses a comma-separated list of `image=path` pairs.
+func ParseKptFunctions(s string) (KptFunctions, error) {
+	fns := KptFunctions{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		image, binary, found := strings.Cut(entry, "=")
+		if !found || image == "" || binary == "" {
+			return nil, fmt.Errorf("invalid KRM function %q: must be in the form of image=path", entry)
+		}
+		if !filepath.IsAbs(binary) {
+			return nil, fmt.Errorf("invalid KRM function %q: the binary path must be absolute", entry)
+		}
+		fns[fullImageName(image)] = binary
+	}
+	return fns, nil
+}
+
+// binary returns the local binary of the image, and whether the image is
+// allow-listed. An image matches an entry with the same name and tag or
+// digest, or an entry with the same name and no tag or digest.
+func (f KptFunctions) binary(image string) (string, bool) {
+	image = fullImageName(image)
+	if binary, found := f[image]; found {
+		return binary, true
+	}
+	binary, found := f[untaggedImageName(image)]
+	return binary, found
+}
+
+// allows checks if the binary implements one of the allow-listed images.
+func (f KptFunctions) allows(binary string) bool {
+	binary = filepath.Clean(binary)
+	for _, allowed := range f {
+		if filepath.Clean(allowed) == binary {
+			return true
+		}
+	}
+	return false
+}
+
+// fullImageName prefixes short function image names with the default
+// registry, like kpt does.
+func fullImageName(image string) string {
+	if !strings.Contains(image, "/") {
+		return defaultKptFunctionRegistry + image
+	}
+	return image
+}
+
+// untaggedImageName strips the tag and digest from the image name.
+func untaggedImageName(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// readKptfile reads the Kptfile of the package in dir.
+// It returns nil if the directory does not have a Kptfile.
+func readKptfile(dir string) (*kptfile, error) {
+	path := filepath.Join(dir, Kptfile)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	kf := &kptfile{}
+	if err := sigsyaml.Unmarshal(b, kf); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	if kf.APIVersion != kptfileAPIVersion || kf.Kind != Kptfile {
+		return nil, fmt.Errorf("unsupported %s: want apiVersion %q and kind %q, got %q and %q",
+			path, kptfileAPIVersion, Kptfile, kf.APIVersion, kf.Kind)
+	}
+	return kf, nil
+}
+
+// needsKptRender checks if there is a Kptfile with a function pipeline under
+// the directory.
+func needsKptRender(dir string) (bool, error) {
+	kf, err := readKptfile(dir)
+	if err != nil {
+		return false, err
+	}
+	return kf != nil && !kf.Pipeline.empty(), nil
+}
+
+// HasKptPipeline checks if the file is a Kptfile with a function pipeline.
+func HasKptPipeline(path string) bool {
+	if filepath.Base(path) != Kptfile {
+		return false
+	}
+	found, err := needsKptRender(filepath.Dir(path))
+	if err != nil {
+		// An invalid Kptfile is reported by the hydration-controller.
+		return true
+	}
+	return found
+}
+
+// kptRender renders the kpt package in the input directory by running the
+// Kptfile pipelines of the package and its subpackages, and writes each
+// rendered object to its own file in the output directory.
+// The rendering is abandoned when ctx is done.
+func kptRender(ctx context.Context, input, output string, fns KptFunctions) HydrationError {
+	if _, err := os.Stat(output); err == nil {
+		mustDeleteOutput(err, output)
+	}
+
+	fileMode := os.FileMode(0755)
+	if err := os.MkdirAll(output, fileMode); err != nil {
+		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
+	}
+
+	r := &kptRenderer{root: input, functions: fns}
+	nodes, err := r.renderPackage(ctx, input)
+	if err != nil {
+		renderErr := fmt.Errorf("failed to render the kpt package in %s: %w", input, err)
+		mustDeleteOutput(renderErr, output)
+		if ctx.Err() != nil {
+			return NewTransientError(renderErr)
+		}
+		return NewActionableError(renderErr)
+	}
+	if err := writeNodes(nodes, output); err != nil {
+		mustDeleteOutput(err, output)
+		return NewInternalError(err)
+	}
+	return nil
+}
+
+// kptRenderer runs the Kptfile pipelines of a package hierarchy.
+type kptRenderer struct {
+	// root is the absolute path to the root package.
+	root string
+	// functions are the allow-listed KRM function images.
+	functions KptFunctions
+}
+
+// renderPackage renders the subpackages of the package in dir depth-first,
+// and then runs the package pipeline on the package resources together with
+// the rendered subpackage resources.
+func (r *kptRenderer) renderPackage(ctx context.Context, dir string) ([]*yaml.RNode, error) {
+	kf, err := readKptfile(dir)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := kio.LocalPackageReader{
+		PackagePath:         dir,
+		PackageFileName:     Kptfile,
+		ErrorIfNonResources: true,
+		FileSkipFunc:        isHiddenPath,
+	}.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the resources in %s: %w", dir, err)
+	}
+	subpackages, err := findSubpackages(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, subpackage := range subpackages {
+		subNodes, err := r.renderPackage(ctx, subpackage)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, subNodes...)
+	}
+	if kf == nil || kf.Pipeline.empty() {
+		return nodes, nil
+	}
+
+	for _, fn := range kf.Pipeline.Mutators {
+		nodes, err = r.runFunction(ctx, dir, fn, nodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, fn := range kf.Pipeline.Validators {
+		copies := make([]*yaml.RNode, len(nodes))
+		for i, node := range nodes {
+			copies[i] = node.Copy()
+		}
+		if _, err := r.runFunction(ctx, dir, fn, copies); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// findSubpackages returns the directories under dir that contain a Kptfile,
+// without descending into the subpackages.
+func findSubpackages(dir string) ([]string, error) {
+	var subpackages []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || path == dir {
+			return nil
+		}
+		if isHiddenPath(d.Name()) {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, Kptfile)); err == nil {
+			subpackages = append(subpackages, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the subpackages in %s: %w", dir, err)
+	}
+	return subpackages, nil
+}
+
+// isHiddenPath returns true if any element of the relative path starts with a
+// dot, like the `.git` directory.
+func isHiddenPath(relPath string) bool {
+	for _, elem := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if strings.HasPrefix(elem, ".") && elem != "." && elem != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// runFunction runs the KRM function on the nodes and returns its output.
+func (r *kptRenderer) runFunction(ctx context.Context, dir string, fn kptFunction, nodes []*yaml.RNode) ([]*yaml.RNode, error) {
+	if len(fn.Selectors) > 0 || len(fn.Exclude) > 0 {
+		return nil, fmt.Errorf("function %s: selectors and exclude are not supported", fn)
+	}
+	binary, args, err := r.command(fn)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", fn, err)
+	}
+	config, err := functionConfig(dir, fn)
+	if err != nil {
+		return nil, fmt.Errorf("function %s: %w", fn, err)
+	}
+
+	stderr := &bytes.Buffer{}
+	filter := &runtimeutil.FunctionFilter{
+		FunctionConfig: config,
+		GlobalScope:    true,
+		Run: func(reader io.Reader, writer io.Writer) error {
+			cmd := exec.CommandContext(ctx, binary, args...)
+			cmd.Stdin = reader
+			cmd.Stdout = writer
+			cmd.Stderr = stderr
+			cmd.Dir = dir
+			return cmd.Run()
+		},
+	}
+	klog.V(3).Infof("Running function %s in %s", fn, dir)
+	output, err := filter.Filter(nodes)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("function %s: %w", fn, ctxErr)
+		}
+		return nil, fmt.Errorf("function %s failed: %w%s", fn, err, functionDetails(filter.Results, stderr.String()))
+	}
+	return output, nil
+}
+
+// command returns the local binary and arguments of the function.
+//
+// Image functions must be in the allow-list. Exec functions must be a binary
+// name on the PATH or an absolute path of one of the allow-listed binaries, so
+// that a Kptfile can't run any other binary of the image, like a shell or helm,
+// which could run scripts from the source repository in the package directory.
+func (r *kptRenderer) command(fn kptFunction) (string, []string, error) {
+	switch {
+	case fn.Image != "" && fn.Exec != "":
+		return "", nil, errors.New("only one of image and exec can be set")
+	case fn.Image != "":
+		binary, found := r.functions.binary(fn.Image)
+		if !found {
+			return "", nil, fmt.Errorf("image %q is not in the allow-list of KRM functions", fn.Image)
+		}
+		return binary, nil, nil
+	case fn.Exec != "":
+		fields := strings.Fields(fn.Exec)
+		if len(fields) == 0 {
+			return "", nil, errors.New("exec must not be blank")
+		}
+		name := fields[0]
+		if strings.ContainsRune(name, filepath.Separator) && !filepath.IsAbs(name) {
+			return "", nil, fmt.Errorf("exec %q must be a binary name on the PATH or an absolute path", name)
+		}
+		binary, err := exec.LookPath(name)
+		if err != nil {
+			return "", nil, fmt.Errorf("exec %q is not available locally: %w", name, err)
+		}
+		if !r.functions.allows(binary) {
+			return "", nil, fmt.Errorf("exec %q is not in the allow-list of KRM functions", name)
+		}
+		return binary, fields[1:], nil
+	default:
+		return "", nil, errors.New("one of image and exec must be set")
+	}
+}
+
+// functionConfig returns the functionConfig of the function, either read from
+// the configPath file in the package, or built from the configMap.
+func functionConfig(dir string, fn kptFunction) (*yaml.RNode, error) {
+	switch {
+	case fn.ConfigPath != "" && fn.ConfigMap != nil:
+		return nil, errors.New("only one of configPath and configMap can be set")
+	case fn.ConfigPath != "":
+		path := filepath.Clean(fn.ConfigPath)
+		if filepath.IsAbs(path) || strings.HasPrefix(path, "..") {
+			return nil, fmt.Errorf("configPath %q must be a relative path within the package", fn.ConfigPath)
+		}
+		config, err := yaml.ReadFile(filepath.Join(dir, path))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read configPath %q: %w", fn.ConfigPath, err)
+		}
+		return config, nil
+	case fn.ConfigMap != nil:
+		data := map[string]interface{}{}
+		for k, v := range fn.ConfigMap {
+			data[k] = v
+		}
+		return yaml.FromMap(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name": "function-input",
+				"annotations": map[string]interface{}{
+					filters.LocalConfigAnnotation: "true",
+				},
+			},
+			"data": data,
+		})
+	default:
+		return nil, nil
+	}
+}
+
+// functionDetails returns the error results and the stderr of a failed
+// function, to be appended to the error message.
+func functionDetails(results *yaml.RNode, stderr string) string {
+	var details []string
+	if results != nil {
+		elements, err := results.Elements()
+		if err != nil {
+			klog.Warningf("unable to read the function results: %v", err)
+		}
+		for _, result := range elements {
+			severity, _ := result.GetString("severity")
+			if severity != "" && severity != "error" {
+				continue
+			}
+			if message, _ := result.GetString("message"); message != "" {
+				details = append(details, message)
+			}
+		}
+	}
+	if stderr = strings.TrimSpace(stderr); stderr != "" {
+		details = append(details, stderr)
+	}
+	if len(details) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(details, "; ")
+}
+
+// writeNodes writes each rendered node to its own file in the output
+// directory, named like the files written by writeResources.
+func writeNodes(nodes []*yaml.RNode, output string) error {
+	for _, node := range nodes {
+		if node.GetKind() == Kptfile {
+			continue
+		}
+		fileName := resourceFileName(resid.GvkFromNode(node), node.GetNamespace(), node.GetName())
+		buf := &bytes.Buffer{}
+		w := kio.ByteWriter{
+			Writer: buf,
+			ClearAnnotations: []string{
+				kioutil.PathAnnotation, kioutil.LegacyPathAnnotation,
+				kioutil.IdAnnotation, kioutil.LegacyIdAnnotation,
+			},
+		}
+		if err := w.Write([]*yaml.RNode{node}); err != nil {
+			return fmt.Errorf("unable to encode the rendered %s %s: %w", node.GetKind(), node.GetName(), err)
+		}
+		path := filepath.Join(output, fileName)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("unable to write the rendered file %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kptConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+data:
+  key: value
+`
+	// setProdNamespace is a KRM function that moves every resource to the
+	// prod namespace.
+	setProdNamespace = "#!/bin/sh\nsed 's/namespace: default/namespace: prod/'\n"
+	// failingFunction is a KRM function that always fails.
+	failingFunction = "#!/bin/sh\necho 'invalid resources' >&2\nexit 1\n"
+)
+
+func kptfileWithPipeline(pipeline string) string {
+	return "apiVersion: kpt.dev/v1\nkind: Kptfile\nmetadata:\n  name: pkg\n" + pipeline
+}
+
+func TestParseKptFunctions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   string
+		want    KptFunctions
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  KptFunctions{},
+		},
+		{
+			name:  "short and full names",
+			input: "set-namespace:v0.4=/fns/set-namespace, example.com/fns/validate=/fns/validate",
+			want: KptFunctions{
+				"gcr.io/kpt-fn/set-namespace:v0.4": "/fns/set-namespace",
+				"example.com/fns/validate":         "/fns/validate",
+			},
+		},
+		{
+			name:    "missing binary",
+			input:   "set-namespace:v0.4",
+			wantErr: true,
+		},
+		{
+			name:    "relative binary",
+			input:   "set-namespace:v0.4=fns/set-namespace",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseKptFunctions(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestKptFunctionsBinary(t *testing.T) {
+	fns := KptFunctions{
+		"gcr.io/kpt-fn/set-namespace:v0.4": "/fns/set-namespace-v0.4",
+		"example.com/fns/validate":         "/fns/validate",
+	}
+	testCases := []struct {
+		image     string
+		want      string
+		wantFound bool
+	}{
+		{image: "set-namespace:v0.4", want: "/fns/set-namespace-v0.4", wantFound: true},
+		{image: "gcr.io/kpt-fn/set-namespace:v0.4", want: "/fns/set-namespace-v0.4", wantFound: true},
+		{image: "set-namespace:v0.5"},
+		{image: "example.com/fns/validate:v1", want: "/fns/validate", wantFound: true},
+		{image: "example.com/fns/validate@sha256:abc", want: "/fns/validate", wantFound: true},
+		{image: "example.com/other/validate:v1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			got, found := fns.binary(tc.image)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestKptRender(t *testing.T) {
+	binDir := t.TempDir()
+	for name, content := range map[string]string{
+		"set-prod-namespace": setProdNamespace,
+		"failing-function":   failingFunction,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(binDir, name), []byte(content), 0755))
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	fns := KptFunctions{
+		"gcr.io/kpt-fn/set-namespace":     filepath.Join(binDir, "set-prod-namespace"),
+		"example.com/fn/failing-function": filepath.Join(binDir, "failing-function"),
+	}
+
+	testCases := []struct {
+		name      string
+		files     map[string]string
+		wantFiles map[string]string
+		wantErr   string
+	}{
+		{
+			name: "mutator from the allow-list",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - image: set-namespace:v0.4\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantFiles: map[string]string{
+				"prod_v1_configmap_cm.yaml": strings.Replace(kptConfigMap, "namespace: default", "namespace: prod", 1),
+			},
+		},
+		{
+			name: "exec mutator in the parent package renders the subpackage",
+			files: map[string]string{
+				Kptfile:             kptfileWithPipeline("pipeline:\n  mutators:\n  - exec: set-prod-namespace\n"),
+				"sub/" + Kptfile:    kptfileWithPipeline(""),
+				"sub/cm.yaml":       kptConfigMap,
+				"sub/README.md":     "not a resource",
+				".hidden/skip.yaml": "not: a resource",
+			},
+			wantFiles: map[string]string{
+				"prod_v1_configmap_cm.yaml": strings.Replace(kptConfigMap, "namespace: default", "namespace: prod", 1),
+			},
+		},
+		{
+			name: "validators do not mutate",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  validators:\n  - exec: set-prod-namespace\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantFiles: map[string]string{
+				"default_v1_configmap_cm.yaml": kptConfigMap,
+			},
+		},
+		{
+			name: "image not in the allow-list",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - image: apply-setters:v0.2\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: `function apply-setters:v0.2: image "apply-setters:v0.2" is not in the allow-list of KRM functions`,
+		},
+		{
+			name: "exec binary in the source repository",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - exec: ./bin/fn\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: `function ./bin/fn: exec "./bin/fn" must be a binary name on the PATH or an absolute path`,
+		},
+		{
+			name: "exec binary not in the allow-list",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - exec: sh fn.sh\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: `function sh fn.sh: exec "sh" is not in the allow-list of KRM functions`,
+		},
+		{
+			name: "blank exec",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - name: blank\n    exec: \" \"\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: "function blank: exec must not be blank",
+		},
+		{
+			name: "failing function",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  validators:\n  - name: validate\n    exec: failing-function\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: "function validate failed: exit status 1: invalid resources",
+		},
+		{
+			name: "unsupported selectors",
+			files: map[string]string{
+				Kptfile:   kptfileWithPipeline("pipeline:\n  mutators:\n  - exec: set-prod-namespace\n    selectors:\n    - kind: ConfigMap\n"),
+				"cm.yaml": kptConfigMap,
+			},
+			wantErr: "function set-prod-namespace: selectors and exclude are not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := t.TempDir()
+			for name, content := range tc.files {
+				path := filepath.Join(input, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+			}
+			output := filepath.Join(t.TempDir(), "hydrated")
+
+			hydrationErr := kptRender(context.Background(), input, output, fns)
+			if tc.wantErr != "" {
+				require.Error(t, hydrationErr)
+				assert.IsType(t, ActionableError{}, hydrationErr)
+				assert.Contains(t, hydrationErr.Error(), tc.wantErr)
+				assert.NoDirExists(t, output)
+				return
+			}
+			require.NoError(t, hydrationErr)
+
+			entries, err := os.ReadDir(output)
+			require.NoError(t, err)
+			var gotNames, wantNames []string
+			for _, e := range entries {
+				gotNames = append(gotNames, e.Name())
+			}
+			for name, content := range tc.wantFiles {
+				wantNames = append(wantNames, name)
+				got, err := os.ReadFile(filepath.Join(output, name))
+				require.NoError(t, err)
+				assert.Equal(t, content, string(got))
+			}
+			sort.Strings(wantNames)
+			assert.Equal(t, wantNames, gotNames)
+		})
+	}
+}
+
+func TestNeedsKptRender(t *testing.T) {
+	testCases := []struct {
+		name    string
+		kptfile string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "no Kptfile",
+		},
+		{
+			name:    "Kptfile without pipeline",
+			kptfile: kptfileWithPipeline(""),
+		},
+		{
+			name:    "Kptfile with pipeline",
+			kptfile: kptfileWithPipeline("pipeline:\n  mutators:\n  - image: set-namespace:v0.4\n"),
+			want:    true,
+		},
+		{
+			name:    "unsupported Kptfile",
+			kptfile: "apiVersion: kpt.dev/v1alpha1\nkind: Kptfile\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.kptfile != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, Kptfile), []byte(tc.kptfile), 0644))
+			}
+			got, err := needsKptRender(dir)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/version"
+)
+
+const (
+	// RenderCacheDir is the name of the render cache directory under the
+	// hydrated root.
+	RenderCacheDir = ".render-cache"
+	// tmpRenderCachePrefix is the prefix of the cache entries being stored.
+	tmpRenderCachePrefix = "tmp-"
+)
+
+// renderCache stores the rendered configs on the hydrated volume, so that a
+// previously rendered commit is restored without rendering it again.
+//
+// The cached files are hard links to the rendered files, which are never
+// modified in place, so caching the current rendering takes no extra space.
+type renderCache struct {
+	// dir is the absolute path to the cache directory.
+	dir string
+	// size is the maximum number of cached renderings.
+	// The cache is disabled if zero.
+	size int
+}
+
+// renderCache returns the render cache of the hydrator.
+func (h *Hydrator) renderCache() *renderCache {
+	return &renderCache{
+		dir:  filepath.Join(h.HydratedRoot.OSPath(), RenderCacheDir),
+		size: h.RenderCacheSize,
+	}
+}
+
+// renderCacheKey returns the cache key of rendering the source commit with
+// the tool, which covers every input that can change the rendered configs.
+// Every render tool, including Helm, runs in-process, so the version of the
+// hydration-controller covers the versions of the tools.
+func (h *Hydrator) renderCacheKey(sourceCommit, tool string) string {
+	fns := make([]string, 0, len(h.KptFunctions))
+	for image, binary := range h.KptFunctions {
+		fns = append(fns, image+"="+binary)
+	}
+	sort.Strings(fns)
+
+	hash := sha256.New()
+	for _, input := range []string{
+		"commit=" + sourceCommit,
+		"syncDir=" + h.SyncDir.SlashPath(),
+		"tool=" + tool,
+		"version=" + version.VERSION,
+		"kptFunctions=" + strings.Join(fns, ","),
+	} {
+		// The inputs never contain new lines, so they can't be confused.
+		_, _ = io.WriteString(hash, input+"\n")
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// enabled returns true if the render cache is enabled.
+func (c *renderCache) enabled() bool {
+	return c.size > 0
+}
+
+// restore replaces the dest directory with the cached rendering of the key.
+// It returns false if the key is not cached.
+func (c *renderCache) restore(key, dest string) (bool, error) {
+	if !c.enabled() {
+		return false, nil
+	}
+	entry := filepath.Join(c.dir, key)
+	if _, err := os.Stat(entry); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to check the render cache entry %s: %w", entry, err)
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return false, fmt.Errorf("unable to remove directory %s: %w", dest, err)
+	}
+	if err := linkTree(entry, dest); err != nil {
+		if rmErr := os.RemoveAll(dest); rmErr != nil {
+			klog.Warningf("unable to remove directory %s: %v", dest, rmErr)
+		}
+		return false, fmt.Errorf("unable to restore the render cache entry %s: %w", entry, err)
+	}
+	// Keep the recently used entries when pruning.
+	now := time.Now()
+	if err := os.Chtimes(entry, now, now); err != nil {
+		klog.Warningf("unable to update the modification time of the render cache entry %s: %v", entry, err)
+	}
+	return true, nil
+}
+
+// store caches the rendering in the src directory under the key, and prunes
+// the least recently used entries beyond the cache size.
+func (c *renderCache) store(key, src string) error {
+	if !c.enabled() {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, os.FileMode(0755)); err != nil {
+		return fmt.Errorf("unable to make directory: %s: %w", c.dir, err)
+	}
+	entry := filepath.Join(c.dir, key)
+	if _, err := os.Stat(entry); err == nil {
+		return nil
+	}
+	tmpEntry, err := os.MkdirTemp(c.dir, tmpRenderCachePrefix)
+	if err != nil {
+		return fmt.Errorf("unable to create a temporary render cache entry: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpEntry); err != nil {
+			klog.Warningf("unable to remove the temporary render cache entry %s: %v", tmpEntry, err)
+		}
+	}()
+	if err := linkTree(src, tmpEntry); err != nil {
+		return fmt.Errorf("unable to cache the rendering in %s: %w", src, err)
+	}
+	if err := os.Rename(tmpEntry, entry); err != nil {
+		return fmt.Errorf("unable to rename %s to %s: %w", tmpEntry, entry, err)
+	}
+	return c.prune()
+}
+
+// prune removes the least recently used entries beyond the cache size, and
+// the temporary entries left behind by an interrupted store.
+func (c *renderCache) prune() error {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read the render cache directory %s: %w", c.dir, err)
+	}
+	var entries []fs.FileInfo
+	for _, f := range files {
+		info, err := f.Info()
+		if err != nil {
+			return fmt.Errorf("unable to read the render cache entry %s: %w", f.Name(), err)
+		}
+		if strings.HasPrefix(f.Name(), tmpRenderCachePrefix) {
+			// Keep the entries being stored right now.
+			if time.Since(info.ModTime()) > time.Hour {
+				if err := os.RemoveAll(filepath.Join(c.dir, f.Name())); err != nil {
+					return fmt.Errorf("unable to remove the render cache entry %s: %w", f.Name(), err)
+				}
+			}
+			continue
+		}
+		entries = append(entries, info)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime().After(entries[j].ModTime())
+	})
+	for i := c.size; i < len(entries); i++ {
+		klog.V(3).Infof("Pruning the render cache entry %s", entries[i].Name())
+		if err := os.RemoveAll(filepath.Join(c.dir, entries[i].Name())); err != nil {
+			return fmt.Errorf("unable to remove the render cache entry %s: %w", entries[i].Name(), err)
+		}
+	}
+	return nil
+}
+
+// linkTree recreates the directory tree of src under dest, hard linking the
+// regular files, and copying them if they can't be linked.
+func linkTree(src, dest string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, os.FileMode(0755))
+		case d.Type().IsRegular():
+			if err := os.Link(path, target); err == nil {
+				return nil
+			}
+			return copyFile(path, target)
+		default:
+			// The renderers only write directories and regular files.
+			return fmt.Errorf("unexpected file type %s of %s", d.Type(), path)
+		}
+	})
+}
+
+// copyFile copies the regular file src to dest.
+func copyFile(src, dest string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dest, b, 0644)
+}
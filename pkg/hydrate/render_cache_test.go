@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
+)
+
+func writeTree(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func readTree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel] = string(b)
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestRenderCache(t *testing.T) {
+	root := t.TempDir()
+	cache := &renderCache{dir: filepath.Join(root, RenderCacheDir), size: 2}
+	rendered := map[string]string{"sync/ns.yaml": "kind: Namespace"}
+
+	src := filepath.Join(root, "commit-a")
+	writeTree(t, src, rendered)
+	require.NoError(t, cache.store("a", src))
+
+	// The rendering can be removed once it is cached.
+	require.NoError(t, os.RemoveAll(src))
+	dest := filepath.Join(root, "commit-a")
+	restored, err := cache.restore("a", dest)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	assert.Equal(t, rendered, readTree(t, dest))
+
+	restored, err = cache.restore("unknown", filepath.Join(root, "unknown"))
+	require.NoError(t, err)
+	assert.False(t, restored)
+	assert.NoDirExists(t, filepath.Join(root, "unknown"))
+
+	// Storing more entries than the cache size prunes the least recently used.
+	old := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(cache.dir, "a"), old, old))
+	require.NoError(t, cache.store("b", dest))
+	_, err = cache.restore("a", dest)
+	require.NoError(t, err)
+	require.NoError(t, cache.store("c", dest))
+	assert.DirExists(t, filepath.Join(cache.dir, "a"))
+	assert.NoDirExists(t, filepath.Join(cache.dir, "b"))
+	assert.DirExists(t, filepath.Join(cache.dir, "c"))
+}
+
+func TestRenderCacheDisabled(t *testing.T) {
+	root := t.TempDir()
+	cache := &renderCache{dir: filepath.Join(root, RenderCacheDir)}
+	writeTree(t, filepath.Join(root, "src"), map[string]string{"ns.yaml": "kind: Namespace"})
+
+	require.NoError(t, cache.store("a", filepath.Join(root, "src")))
+	assert.NoDirExists(t, cache.dir)
+	restored, err := cache.restore("a", filepath.Join(root, "dest"))
+	require.NoError(t, err)
+	assert.False(t, restored)
+}
+
+func TestRunHydrateWithRenderCache(t *testing.T) {
+	sourceRoot := t.TempDir()
+	commitDir := filepath.Join(sourceRoot, originCommit)
+	writeTree(t, commitDir, map[string]string{
+		"kustomization.yaml": "namespace: test-ns\nresources:\n- cm.yaml\n",
+		"cm.yaml":            "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n",
+	})
+	require.NoError(t, os.Symlink(commitDir, filepath.Join(sourceRoot, "rev")))
+
+	hydratedRoot := t.TempDir()
+	hydrator := &Hydrator{
+		SourceRoot:      cmpath.Absolute(sourceRoot),
+		HydratedRoot:    cmpath.Absolute(hydratedRoot),
+		SourceLink:      "rev",
+		HydratedLink:    "rev",
+		RenderCacheSize: 1,
+	}
+	syncPath := cmpath.Absolute(commitDir)
+	require.NoError(t, hydrator.runHydrate(originCommit, syncPath))
+	want := readTree(t, filepath.Join(hydratedRoot, originCommit))
+	assert.Contains(t, want, "test-ns_v1_configmap_cm.yaml")
+
+	// Break the source configs to make sure the second run restores the
+	// rendering from the cache instead of rendering again.
+	writeTree(t, commitDir, map[string]string{"kustomization.yaml": "resources:\n- missing.yaml\n"})
+	require.NoError(t, os.RemoveAll(filepath.Join(hydratedRoot, originCommit)))
+	require.NoError(t, hydrator.runHydrate(originCommit, syncPath))
+	assert.Equal(t, want, readTree(t, filepath.Join(hydratedRoot, originCommit)))
+
+	// A different rendering input misses the cache.
+	hydrator.KptFunctions = KptFunctions{"gcr.io/kpt-fn/set-namespace": "/fns/set-namespace"}
+	assert.Error(t, hydrator.runHydrate(originCommit, syncPath))
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/kinds"
+	"sigs.k8s.io/yaml"
+)
+
+// ReadSubstitutionFile reads the substitution variables from a file holding a
+// ConfigMap, like the one referenced by spec.override.substitutionConfigMapRef
+// of a RootSync or RepoSync, so that `nomos hydrate` substitutes the same
+// variables as the reconciler.
+func ReadSubstitutionFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading substitution file: %w", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := yaml.UnmarshalStrict(content, cm); err != nil {
+		return nil, fmt.Errorf("parsing substitution file %q: %w", path, err)
+	}
+	if cm.GroupVersionKind() != kinds.ConfigMap() {
+		return nil, fmt.Errorf("substitution file %q must hold a single %s, found %s",
+			path, kinds.ConfigMap().Kind, cm.GroupVersionKind())
+	}
+	if cm.Data == nil {
+		// An empty ConfigMap still enables the built-in variables.
+		return map[string]string{}, nil
+	}
+	return cm.Data, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSubstitutionFile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "ConfigMap with data",
+			content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-vars
+data:
+  REGION: us-central1
+  DOMAIN: example.com
+`,
+			want: map[string]string{"REGION": "us-central1", "DOMAIN": "example.com"},
+		},
+		{
+			name: "ConfigMap without data",
+			content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cluster-vars
+`,
+			want: map[string]string{},
+		},
+		{
+			name: "not a ConfigMap",
+			content: `apiVersion: v1
+kind: Secret
+metadata:
+  name: cluster-vars
+`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "vars.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0644))
+			got, err := ReadSubstitutionFile(path)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
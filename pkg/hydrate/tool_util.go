@@ -47,16 +47,21 @@ import (
 	"kpt.dev/configsync/pkg/vet"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/kyaml/resid"
 )
 
 const (
 	// HelmVersion is the recommended version of Helm for hydration.
 	HelmVersion = "v3.15.3-gke.6"
-	// KustomizeVersion is the recommended version of Kustomize for hydration.
+	// KustomizeVersion is the version of the Kustomize binary shipped in the
+	// nomos image. Config Sync renders Kustomizations in-process.
 	KustomizeVersion = "v5.4.2-gke.0"
 	// Helm is the binary name of the installed Helm.
-	Helm = "helm"
-	// Kustomize is the binary name of the installed Kustomize.
+	// Kustomize runs it to inflate the charts in the `helmCharts` field of
+	// the nomos CLI.
+	Helm = kmetrics.DefaultHelmCommand
+	// Kustomize is the binary name of the Kustomize shipped in the nomos image.
 	Kustomize = "kustomize"
 
 	maxRetries = 5
@@ -81,6 +86,38 @@ func needsKustomize(dir string) (bool, error) {
 	return false, nil
 }
 
+// renderTool returns the tool that renders the source configs in the
+// directory, or an empty string if no rendering is needed.
+// A Kustomization config file takes precedence over a Kptfile pipeline, which
+// takes precedence over a Jsonnet entrypoint and then CUE files.
+func renderTool(dir string) (string, HydrationError) {
+	found, err := needsKustomize(dir)
+	if err != nil {
+		return "", NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", dir, err))
+	} else if found {
+		return Kustomize, nil
+	}
+	found, err = needsKptRender(dir)
+	if err != nil {
+		return "", NewActionableError(fmt.Errorf("unable to check if the Kptfile in the source directory %s has a pipeline: %w", dir, err))
+	} else if found {
+		return Kpt, nil
+	}
+	found, err = needsJsonnet(dir)
+	if err != nil {
+		return "", NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", dir, err))
+	} else if found {
+		return Jsonnet, nil
+	}
+	found, err = needsCue(dir)
+	if err != nil {
+		return "", NewInternalError(fmt.Errorf("unable to check if rendering is needed for the source directory: %s: %w", dir, err))
+	} else if found {
+		return Cue, nil
+	}
+	return "", nil
+}
+
 // HasKustomization checks if the file is a Kustomize configuration file.
 func HasKustomization(filename string) bool {
 	for _, kustomization := range validKustomizationFiles {
@@ -132,18 +169,10 @@ func mustDeleteOutput(err error, output string) {
 	klog.Fatalf("Attempted to delete the output directory %s for %d times, but all failed. Exiting now...", output, retries)
 }
 
-// kustomizeBuild runs the 'kustomize build' command to render the configs.
-func kustomizeBuild(input, output string, sendMetrics bool) HydrationError {
-	// The `--enable-alpha-plugins` and `--enable-exec` flags are to support rendering
-	// Helm charts using the Helm inflation function.
-	// The `--enable-helm` flag is to enable use of the Helm chart inflator generator.
-	// We decided to enable all the flags so that both the Helm plugin and Helm
-	// inflation function are supported. This provides us with a fallback plan
-	// if the new Helm inflation function is having issues.
-	// It has no side-effect if no Helm chart in the DRY configs.
-	// The `--load-restrictor` flag is to allow files to be loaded from outside the root directory
-	args := []string{"--enable-alpha-plugins", "--enable-exec", "--enable-helm", "--load-restrictor=LoadRestrictionsNone", "--output", output}
-
+// kustomizeBuild renders the configs in-process with Kustomize, and writes
+// each rendered object to its own file in the output directory.
+// The rendering is abandoned when ctx is done.
+func kustomizeBuild(ctx context.Context, input, output string, sendMetrics bool) HydrationError {
 	if _, err := os.Stat(output); err == nil {
 		mustDeleteOutput(err, output)
 	}
@@ -153,17 +182,46 @@ func kustomizeBuild(input, output string, sendMetrics bool) HydrationError {
 		return NewInternalError(fmt.Errorf("unable to make directory: %s: %w", output, err))
 	}
 
-	// run kustomize build with the wrapper library
-	out, err := kmetrics.RunKustomizeBuild(context.Background(), sendMetrics, input, args...)
+	m, err := kmetrics.RunKustomizeBuild(ctx, sendMetrics, input)
 	if err != nil {
-		kustomizeErr := fmt.Errorf("failed to run kustomize build in %s, stdout: %s: %w", input, out, err)
+		kustomizeErr := fmt.Errorf("failed to run kustomize build in %s: %w", input, err)
 		mustDeleteOutput(kustomizeErr, output)
 		return NewActionableError(kustomizeErr)
 	}
+	if err := writeResources(m, output); err != nil {
+		mustDeleteOutput(err, output)
+		return NewInternalError(err)
+	}
+	return nil
+}
 
+// writeResources writes each resource to its own file in the output
+// directory, named after its namespace, GroupVersionKind and name, like
+// `kustomize build --output`.
+func writeResources(m resmap.ResMap, output string) error {
+	for _, res := range m.Resources() {
+		fileName := resourceFileName(res.GetGvk(), res.GetNamespace(), res.GetName())
+		b, err := res.AsYAML()
+		if err != nil {
+			return fmt.Errorf("unable to encode the rendered %s %s: %w", res.GetGvk().Kind, res.GetName(), err)
+		}
+		path := filepath.Join(output, fileName)
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			return fmt.Errorf("unable to write the rendered file %s: %w", path, err)
+		}
+	}
 	return nil
 }
 
+// resourceFileName returns the name of the file of a rendered resource.
+func resourceFileName(gvk resid.Gvk, namespace, name string) string {
+	fileName := strings.ToLower(gvk.StringWoEmptyField()) + "_" + strings.ToLower(name) + ".yaml"
+	if namespace != "" {
+		fileName = strings.ToLower(namespace) + "_" + fileName
+	}
+	return fileName
+}
+
 // validateTool checks if the hydration tool is installed and if the installed
 // version meets the required version.
 func validateTool(tool, version, requiredVersion string) error {
@@ -203,17 +261,6 @@ func getVersion(tool string) (string, error) {
 	return version, nil
 }
 
-func validateKustomize() error {
-	version, err := getVersion(Kustomize)
-	if err != nil {
-		return fmt.Errorf("Kustomization file is detected, but Kustomize is not installed: %v. Please install Kustomize and re-run the command.", err)
-	}
-	if err := validateTool(Kustomize, version, KustomizeVersion); err != nil {
-		fmt.Printf("WARNING: %v\n", err)
-	}
-	return nil
-}
-
 func validateHelm() error {
 	version, err := getVersion(Helm)
 	if err != nil {
@@ -227,15 +274,12 @@ func validateHelm() error {
 	return nil
 }
 
-// ValidateAndRunKustomize validates if the Kustomize and Helm binaries are supported.
-// If supported, it copies the source configs to a temp directory, run 'kustomize build',
-// save the output to another temp directory, and return the output path for further
-// parsing and validation.
+// ValidateAndRunKustomize validates if the Helm binary is supported.
+// If supported, it renders the source configs in-process with Kustomize,
+// saves the output to a temp directory, and returns the output path for
+// further parsing and validation.
 func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
 	var output cmpath.Absolute
-	if err := validateKustomize(); err != nil {
-		return output, err
-	}
 	if err := validateHelm(); err != nil {
 		return output, err
 	}
@@ -247,7 +291,7 @@ func ValidateAndRunKustomize(sourcePath string) (cmpath.Absolute, error) {
 		return output, err
 	}
 
-	if err := kustomizeBuild(sourcePath, tmpHydratedDir, false); err != nil {
+	if err := kustomizeBuild(context.Background(), sourcePath, tmpHydratedDir, false); err != nil {
 		return output, fmt.Errorf("unable to render the source configs in %s: %w", sourcePath, err)
 	}
 
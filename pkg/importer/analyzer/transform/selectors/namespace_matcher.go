@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectors
+
+import (
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceMatcher matches Namespaces against the labels, annotations and name
+// patterns of a NamespaceSelector.
+type NamespaceMatcher struct {
+	labels       labels.Selector
+	annotations  labels.Selector
+	namePatterns []string
+}
+
+// NewNamespaceMatcher returns the NamespaceMatcher of the given
+// NamespaceSelector, or an error if its selectors are invalid or it does not
+// select anything.
+func NewNamespaceMatcher(nss *v1.NamespaceSelector) (*NamespaceMatcher, status.Error) {
+	labelSelector, err := metav1.LabelSelectorAsSelector(&nss.Spec.Selector)
+	if err != nil {
+		return nil, InvalidSelectorError(nss, err)
+	}
+	m := &NamespaceMatcher{labels: labelSelector}
+	if nss.Spec.AnnotationSelector != nil {
+		m.annotations, err = metav1.LabelSelectorAsSelector(nss.Spec.AnnotationSelector)
+		if err != nil {
+			return nil, InvalidSelectorError(nss, err)
+		}
+	}
+	for _, pattern := range nss.Spec.NamePatterns {
+		// Match the pattern once to validate it.
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, InvalidSelectorError(nss, err)
+		}
+		m.namePatterns = append(m.namePatterns, pattern)
+	}
+	if m.Empty() {
+		return nil, EmptySelectorError(nss)
+	}
+	return m, nil
+}
+
+// Empty returns true if the matcher has no requirements, so it would select
+// all Namespaces.
+func (m *NamespaceMatcher) Empty() bool {
+	return m.labels.Empty() &&
+		(m.annotations == nil || m.annotations.Empty()) &&
+		len(m.namePatterns) == 0
+}
+
+// Matches returns true if the Namespace matches all the requirements.
+func (m *NamespaceMatcher) Matches(ns client.Object) bool {
+	if !m.labels.Matches(labels.Set(ns.GetLabels())) {
+		return false
+	}
+	if m.annotations != nil && !m.annotations.Matches(labels.Set(ns.GetAnnotations())) {
+		return false
+	}
+	if len(m.namePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range m.namePatterns {
+		// The patterns are validated when building the matcher.
+		if matched, _ := path.Match(pattern, ns.GetName()); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+)
+
+func TestNamespaceMatcher(t *testing.T) {
+	testCases := []struct {
+		name      string
+		spec      v1.NamespaceSelectorSpec
+		wantErr   bool
+		matches   []core.MetaMutator
+		unmatches []core.MetaMutator
+	}{
+		{
+			name:    "empty selector",
+			spec:    v1.NamespaceSelectorSpec{},
+			wantErr: true,
+		},
+		{
+			name:    "empty annotation selector",
+			spec:    v1.NamespaceSelectorSpec{AnnotationSelector: &metav1.LabelSelector{}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid name pattern",
+			spec:    v1.NamespaceSelectorSpec{NamePatterns: []string{"team-["}},
+			wantErr: true,
+		},
+		{
+			name: "labels",
+			spec: v1.NamespaceSelectorSpec{
+				Selector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+			},
+			matches:   []core.MetaMutator{core.Name("a"), core.Label("env", "dev")},
+			unmatches: []core.MetaMutator{core.Name("a"), core.Annotation("env", "dev")},
+		},
+		{
+			name: "annotation expression",
+			spec: v1.NamespaceSelectorSpec{
+				AnnotationSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpExists},
+					},
+				},
+			},
+			matches:   []core.MetaMutator{core.Name("a"), core.Annotation("team", "payments")},
+			unmatches: []core.MetaMutator{core.Name("a"), core.Label("team", "payments")},
+		},
+		{
+			name: "name patterns",
+			spec: v1.NamespaceSelectorSpec{
+				NamePatterns: []string{"team-*", "shared"},
+			},
+			matches:   []core.MetaMutator{core.Name("team-payments")},
+			unmatches: []core.MetaMutator{core.Name("payments-team")},
+		},
+		{
+			name: "all requirements must match",
+			spec: v1.NamespaceSelectorSpec{
+				Selector:     metav1.LabelSelector{MatchLabels: map[string]string{"env": "dev"}},
+				NamePatterns: []string{"team-*"},
+			},
+			matches:   []core.MetaMutator{core.Name("team-a"), core.Label("env", "dev")},
+			unmatches: []core.MetaMutator{core.Name("team-a"), core.Label("env", "prod")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nss := k8sobjects.NamespaceSelectorObject(core.Name("nss"))
+			nss.Spec = tc.spec
+			m, err := NewNamespaceMatcher(nss)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, m.Matches(k8sobjects.NamespaceObject("", tc.matches...)))
+			assert.False(t, m.Matches(k8sobjects.NamespaceObject("", tc.unmatches...)))
+		})
+	}
+}
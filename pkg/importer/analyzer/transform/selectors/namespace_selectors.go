@@ -41,6 +41,20 @@ func ObjectNotInNamespaceSelectorSubdirectory(resource client.Object, selector c
 		BuildWithResources(selector, resource)
 }
 
+// NamespaceTemplateErrorCode is the error code for NamespaceTemplateError.
+const NamespaceTemplateErrorCode = "1072"
+
+var namespaceTemplateErrorBuilder = status.NewErrorBuilder(NamespaceTemplateErrorCode)
+
+// NamespaceTemplateError reports that an object with the namespace-template
+// annotation cannot be instantiated into a Namespace.
+func NamespaceTemplateError(resource client.Object, reason string) status.Error {
+	return namespaceTemplateErrorBuilder.
+		Sprintf("Config %q with the annotation %q is an invalid Namespace template: %s",
+			resource.GetName(), metadata.NamespaceTemplateAnnotationKey, reason).
+		BuildWithResources(resource)
+}
+
 // ListNamespaceErrorCode is the error code for ListNamespaceError used in NamespaceSelector
 const ListNamespaceErrorCode = "2017"
 
@@ -63,6 +77,15 @@ func UnsupportedNamespaceSelectorModeError(nsSelector client.Object) status.Erro
 		BuildWithResources(nsSelector)
 }
 
+// UnsupportedNamespaceSelectorFieldsError reports that a NamespaceSelector
+// matches annotations or name patterns with the hierarchy source format.
+func UnsupportedNamespaceSelectorFieldsError(nsSelector client.Object) status.Error {
+	return invalidSelectorError.Sprintf("NamespaceSelector MUST NOT use `spec.annotationSelector` or `spec.namePatterns` with the %s source format."+
+		" To fix, either switch to the %s source format, or select the Namespaces with `spec.selector`.",
+		configsync.SourceFormatHierarchy, configsync.SourceFormatUnstructured).
+		BuildWithResources(nsSelector)
+}
+
 // UnknownNamespaceSelectorModeError reports that a NamespaceSelector mode is unknown.
 func UnknownNamespaceSelectorModeError(nsSelector client.Object) status.Error {
 	return invalidSelectorError.Sprintf("Unknown mode defined in NamespaceSelector."+
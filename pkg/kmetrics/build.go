@@ -0,0 +1,176 @@
+/*
+Copyright 2021 Google LLC.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmetrics
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"kpt.dev/configsync/pkg/util/render"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// DefaultHelmCommand is the Helm binary used by Kustomize by default to
+// inflate the charts declared in the `helmCharts` field.
+const DefaultHelmCommand = "helm"
+
+// HelmCommand is the command used by Kustomize to inflate the charts declared
+// in the `helmCharts` field. The hydration-controller sets it to its own
+// binary, which inflates charts with the Helm SDK.
+var HelmCommand = DefaultHelmCommand
+
+// RunKustomizeBuild renders the kustomization in inputDir in-process, with the
+// same options as `kustomize build --enable-alpha-plugins --enable-exec
+// --enable-helm --load-restrictor=LoadRestrictionsNone`.
+//
+// The argument sendMetrics determines whether to send metrics about kustomize
+// to Google Cloud.
+//
+// A failed build returns a *BuildError with the location of the error.
+//
+// The build is abandoned when ctx is done, so callers can cap the time spent
+// rendering with a context deadline. An abandoned build keeps running until it
+// completes, and the next build waits for it. If it doesn't complete shortly
+// after, the process exits, so that it doesn't block every later build.
+//
+// By default, we would like to enable the `buildMetadata.originAnnotations`.
+// If the kustomization file does not include it already, it is added to the
+// in-memory copy of the kustomization file, leaving the file on disk
+// untouched.
+func RunKustomizeBuild(ctx context.Context, sendMetrics bool, inputDir string) (resmap.ResMap, error) {
+	inputDir, err := filepath.Abs(inputDir)
+	if err != nil {
+		return nil, err
+	}
+	b, kustPath := readKustomizeFileBytes(inputDir)
+	if b == nil {
+		return nil, fmt.Errorf("unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory %s", inputDir)
+	}
+
+	fSys := filesys.MakeFsOnDisk()
+	// A parse error here is very unpleasant and confusing. We will get a
+	// better error message from the build below if we ignore it here.
+	if withOrigin, err := withOriginAnnotations(b); err == nil && withOrigin != nil {
+		fSys = overlayFileSystem{FileSystem: fSys, path: kustPath, content: withOrigin}
+	}
+
+	return runKustomizeBuild(ctx, sendMetrics, inputDir, fSys)
+}
+
+// buildOptions returns the krusty options matching the flags used by
+// Config Sync when it ran the kustomize binary.
+func buildOptions() *krusty.Options {
+	opts := krusty.MakeDefaultOptions()
+	// The kustomize CLI sorts the resources in the legacy order by default.
+	opts.Reorder = krusty.ReorderOptionLegacy
+	opts.LoadRestrictions = types.LoadRestrictionsNone
+	opts.PluginConfig = types.EnabledPluginConfig(types.BploUseStaticallyLinked)
+	opts.PluginConfig.FnpLoadingOptions.EnableExec = true
+	opts.PluginConfig.HelmConfig.Command = HelmCommand
+	return opts
+}
+
+// withOriginAnnotations returns the kustomization file contents with the
+// `originAnnotations` build metadata, or nil if it is already enabled.
+func withOriginAnnotations(b []byte) ([]byte, error) {
+	kt := &types.Kustomization{}
+	if err := yaml.Unmarshal(b, kt); err != nil {
+		return nil, err
+	}
+	for _, opt := range kt.BuildMetadata {
+		if opt == types.OriginAnnotations {
+			return nil, nil
+		}
+	}
+	node, err := yaml.Parse(string(b))
+	if err != nil {
+		return nil, err
+	}
+	buildMetadata, err := node.Pipe(yaml.LookupCreate(yaml.SequenceNode, "buildMetadata"))
+	if err != nil {
+		return nil, err
+	}
+	if err := buildMetadata.PipeE(yaml.Append(yaml.NewScalarRNode(types.OriginAnnotations).YNode())); err != nil {
+		return nil, err
+	}
+	out, err := node.String()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// overlayFileSystem is a file system which overrides the contents of a single
+// file.
+type overlayFileSystem struct {
+	filesys.FileSystem
+	path    string
+	content []byte
+}
+
+// ReadFile implements filesys.FileSystem.
+func (fs overlayFileSystem) ReadFile(path string) ([]byte, error) {
+	if filepath.Clean(path) == fs.path {
+		return fs.content, nil
+	}
+	return fs.FileSystem.ReadFile(path)
+}
+
+// runKustomizeBuild renders the kustomization and also records measurements
+// about kustomize usage via OpenTelemetry. This assumes that there is already
+// an OTLP metrics exporter that is sending to a collector.
+//
+// The build runs with render.Run, so it waits for any previous rendering to
+// complete, including a build abandoned after its context was done.
+func runKustomizeBuild(ctx context.Context, sendMetrics bool, inputDir string, fSys filesys.FileSystem) (resmap.ResMap, error) {
+	kt, err := readKustomizeFile(inputDir)
+	if kt != nil && err == nil {
+		fieldMetrics, fieldErr := kustomizeFieldUsage(kt, inputDir)
+		if fieldErr == nil && fieldMetrics != nil && sendMetrics {
+			// Send field count metrics to the otel-collector
+			RecordKustomizeFieldCountData(ctx, fieldMetrics)
+		}
+	}
+
+	var m resmap.ResMap
+	var executionTime time.Duration
+	err = render.Run(ctx, fmt.Sprintf("kustomize build in %s", inputDir), func() error {
+		start := time.Now()
+		var buildErr error
+		m, buildErr = krusty.MakeKustomizer(buildOptions()).Run(fSys, inputDir)
+		executionTime = time.Since(start)
+		if buildErr != nil {
+			return newBuildError(inputDir, buildErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sendMetrics {
+		// Send execution time and resource count metrics to the otel-collector
+		RecordKustomizeResourceCount(ctx, m.Size())
+		RecordKustomizeExecutionTime(ctx, float64(executionTime.Nanoseconds()))
+	}
+	return m, nil
+}
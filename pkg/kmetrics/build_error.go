@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kmetrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// recursedPathPattern matches the directory of a base or component that
+	// Kustomize adds to the errors found while building it.
+	recursedPathPattern = regexp.MustCompile(`(?:recursed accumulation of|recursed merging from|couldn't make target for) path '([^']*)'`)
+
+	// resourcePattern matches the resource that Kustomize adds to the errors
+	// found while loading it, relative to its kustomization.
+	resourcePattern = regexp.MustCompile(`accumulating resources from '([^']*)'`)
+)
+
+// BuildError is the error of a Kustomize build, with the location where it
+// was found.
+type BuildError struct {
+	// Kustomization is the path of the kustomization file in which the error
+	// was found, relative to the built directory.
+	Kustomization string
+	// Resource is the path of the resource which failed to load, relative to
+	// the built directory, or its URL if it is remote. It is empty if the error
+	// is not about a resource.
+	Resource string
+	// Err is the error returned by Kustomize.
+	Err error
+}
+
+// Error implements error.
+func (e *BuildError) Error() string {
+	if e.Resource == "" {
+		return fmt.Sprintf("%s: %v", e.Kustomization, e.Err)
+	}
+	return fmt.Sprintf("%s: resource %s: %v", e.Kustomization, e.Resource, e.Err)
+}
+
+// Unwrap returns the error returned by Kustomize.
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
+
+// newBuildError locates the error of building the kustomization in inputDir.
+// Kustomize errors are not typed, but their messages include the directory of
+// each base it recursed into, and the resource it failed to load, if any.
+func newBuildError(inputDir string, err error) *BuildError {
+	root := inputDir
+	// The paths of the bases in the errors have their symlinks evaluated.
+	if evaluated, evalErr := filepath.EvalSymlinks(inputDir); evalErr == nil {
+		root = evaluated
+	}
+	msg := err.Error()
+	_, kustPath := readKustomizeFileBytes(root)
+	dir, rest := root, msg
+	// A base without a kustomization fails before it is recursed into, so the
+	// error is found in the kustomization which references it.
+	matches := recursedPathPattern.FindAllStringSubmatchIndex(msg, -1)
+	for i := len(matches) - 1; i >= 0; i-- {
+		base := msg[matches[i][2]:matches[i][3]]
+		if _, path := readKustomizeFileBytes(base); path != "" {
+			dir, kustPath, rest = base, path, msg[matches[i][1]:]
+			break
+		}
+	}
+
+	e := &BuildError{Kustomization: relativePath(root, dir), Err: err}
+	if kustPath != "" {
+		e.Kustomization = relativePath(root, kustPath)
+	}
+	if matches := resourcePattern.FindAllStringSubmatch(rest, -1); len(matches) > 0 {
+		resource := matches[len(matches)-1][1]
+		if isRemoteResource(resource) {
+			e.Resource = resource
+		} else {
+			e.Resource = relativePath(root, filepath.Join(dir, resource))
+		}
+	}
+	return e
+}
+
+// relativePath returns the path relative to root, or the path itself if it is
+// not under root.
+func relativePath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+// isRemoteResource checks if the resource is a remote URL, like a Git
+// repository or an HTTP file, rather than a local path.
+func isRemoteResource(resource string) bool {
+	return strings.Contains(resource, "://") ||
+		strings.HasPrefix(resource, "git@") ||
+		strings.Contains(resource, "?ref=")
+}
@@ -18,15 +18,17 @@ package kmetrics
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunKustomizeBuild(t *testing.T) {
 	testCases := map[string]struct {
 		inputDir    string
-		flags       []string
 		expected    string
 		expectedErr string
 	}{
@@ -49,7 +51,7 @@ spec:
 		},
 		"missing kustomization": {
 			inputDir:    "./testdata/missingkustomization",
-			expectedErr: "Error: unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
+			expectedErr: "unable to find one of 'kustomization.yaml', 'kustomization.yml' or 'Kustomization' in directory",
 		},
 		"complex": {
 			inputDir:    "./testdata/complex",
@@ -61,11 +63,11 @@ spec:
 		},
 		"invalid kustomization": {
 			inputDir:    "./testdata/invalidkustomization",
-			expectedErr: "Error: invalid Kustomization: json: cannot unmarshal string into Go struct field Kustomization.resources of type []string",
+			expectedErr: "invalid Kustomization: json: cannot unmarshal string into Go struct field Kustomization.resources of type []string",
 		},
 		"multiple kustomization files": {
 			inputDir:    "./testdata/multiplekustomizationfiles",
-			expectedErr: "Error: Found multiple kustomization files",
+			expectedErr: "Found multiple kustomization files",
 		},
 		"with generator": {
 			inputDir: "./testdata/withgenerator",
@@ -127,12 +129,16 @@ spec:
 
 	for tn, tc := range testCases {
 		t.Run(tn, func(t *testing.T) {
-			out, err := RunKustomizeBuild(context.Background(), false, tc.inputDir, tc.flags...)
+			m, err := RunKustomizeBuild(context.Background(), false, tc.inputDir)
 			if tc.expectedErr == "" {
 				if !assert.NoError(t, err) {
 					t.FailNow()
 				}
-				if !assert.Equal(t, tc.expected, out) {
+				out, err := m.AsYaml()
+				if !assert.NoError(t, err) {
+					t.FailNow()
+				}
+				if !assert.Equal(t, tc.expected, string(out)) {
 					t.FailNow()
 				}
 			} else {
@@ -146,3 +152,63 @@ spec:
 		})
 	}
 }
+
+func TestRunKustomizeBuildCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := RunKustomizeBuild(ctx, false, "./testdata/simple")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunKustomizeBuildKeepsKustomization(t *testing.T) {
+	before, err := os.ReadFile("./testdata/simple/kustomization.yaml")
+	assert.NoError(t, err)
+	_, err = RunKustomizeBuild(context.Background(), false, "./testdata/simple")
+	assert.NoError(t, err)
+	after, err := os.ReadFile("./testdata/simple/kustomization.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestRunKustomizeBuildErrorLocation(t *testing.T) {
+	linkDir := t.TempDir()
+	absComplex, err := filepath.Abs("./testdata/complex")
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink(absComplex, filepath.Join(linkDir, "rev")))
+
+	testCases := map[string]struct {
+		inputDir              string
+		expectedKustomization string
+		expectedResource      string
+	}{
+		"missing resource in base": {
+			inputDir:              "./testdata/complex",
+			expectedKustomization: "base/kustomization.yaml",
+			expectedResource:      "base/deployment.yaml",
+		},
+		"missing resource in base through a symlink": {
+			inputDir:              filepath.Join(linkDir, "rev"),
+			expectedKustomization: "base/kustomization.yaml",
+			expectedResource:      "base/deployment.yaml",
+		},
+		"missing kustomization in base": {
+			inputDir:              "./testdata/missingkustomizationinbase",
+			expectedKustomization: "overlay/kustomization.yaml",
+			expectedResource:      "base",
+		},
+		"invalid kustomization": {
+			inputDir:              "./testdata/invalidkustomization",
+			expectedKustomization: "kustomization.yaml",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := RunKustomizeBuild(context.Background(), false, tc.inputDir)
+			var buildErr *BuildError
+			require.ErrorAs(t, err, &buildErr)
+			assert.Equal(t, tc.expectedKustomization, buildErr.Kustomization)
+			assert.Equal(t, tc.expectedResource, buildErr.Resource)
+		})
+	}
+}
@@ -17,67 +17,77 @@ limitations under the License.
 package kmetrics
 
 import (
-	"go.opencensus.io/stats"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// meter creates the kustomize usage instruments with the global MeterProvider.
+var meter = otel.Meter("kpt.dev/configsync/pkg/kmetrics")
+
+const (
+	unitMilliseconds  = "ms"
+	unitDimensionless = "1"
 )
 
 var (
 	// KustomizeFieldCount is the number of times a particular field is used
-	KustomizeFieldCount = stats.Int64(
+	KustomizeFieldCount, _ = meter.Int64Gauge(
 		"kustomize_field_count",
-		"The number of times a particular field is used in the kustomization files",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of times a particular field is used in the kustomization files"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeDeprecatingFields is the usage of fields that may become deprecated
-	KustomizeDeprecatingFields = stats.Int64(
+	KustomizeDeprecatingFields, _ = meter.Int64Gauge(
 		"kustomize_deprecating_field_count",
-		"The usage of fields that may become deprecated",
-		stats.UnitDimensionless)
+		metric.WithDescription("The usage of fields that may become deprecated"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeSimplification is the usage of simplification transformers
-	KustomizeSimplification = stats.Int64(
+	KustomizeSimplification, _ = meter.Int64Gauge(
 		"kustomize_simplification_adoption_count",
-		"The usage of simplification transformers images, replicas, and replacements",
-		stats.UnitDimensionless)
+		metric.WithDescription("The usage of simplification transformers images, replicas, and replacements"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeK8sMetadata is the usage of builtin transformers
-	KustomizeK8sMetadata = stats.Int64(
+	KustomizeK8sMetadata, _ = meter.Int64Gauge(
 		"kustomize_builtin_transformers",
-		"The usage of builtin transformers related to kubernetes object metadata",
-		stats.UnitDimensionless)
+		metric.WithDescription("The usage of builtin transformers related to kubernetes object metadata"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeHelmMetrics is the usage of helm
-	KustomizeHelmMetrics = stats.Int64(
+	KustomizeHelmMetrics, _ = meter.Int64Gauge(
 		"kustomize_helm_inflator_count",
-		"The usage of helm in kustomize, whether by the builtin fields or the custom function",
-		stats.UnitDimensionless)
+		metric.WithDescription("The usage of helm in kustomize, whether by the builtin fields or the custom function"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeBaseCount is the number of remote and local bases
-	KustomizeBaseCount = stats.Int64(
+	KustomizeBaseCount, _ = meter.Int64Gauge(
 		"kustomize_base_count",
-		"The number of remote and local bases",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of remote and local bases"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizePatchCount is the number of patches
-	KustomizePatchCount = stats.Int64(
+	KustomizePatchCount, _ = meter.Int64Gauge(
 		"kustomize_patch_count",
-		"The number of patches in the fields `patches`, `patchesStrategicMerge`, and `patchesJson6902`",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of patches in the fields `patches`, `patchesStrategicMerge`, and `patchesJson6902`"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeTopTierMetrics is the usage of high level metrics
-	KustomizeTopTierMetrics = stats.Int64(
+	KustomizeTopTierMetrics, _ = meter.Int64Gauge(
 		"kustomize_ordered_top_tier_metrics",
-		"Usage of Resources, Generators, SecretGenerator, ConfigMapGenerator, Transformers, and Validators",
-		stats.UnitDimensionless)
+		metric.WithDescription("Usage of Resources, Generators, SecretGenerator, ConfigMapGenerator, Transformers, and Validators"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeResourceCount is the number of resources outputted by `kustomize build`
-	KustomizeResourceCount = stats.Int64(
+	KustomizeResourceCount, _ = meter.Int64Gauge(
 		"kustomize_resource_count",
-		"The number of resources outputted by `kustomize build`",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of resources outputted by `kustomize build`"),
+		metric.WithUnit(unitDimensionless))
 
 	// KustomizeExecutionTime is the execution time of `kustomize build`
-	KustomizeExecutionTime = stats.Float64(
+	KustomizeExecutionTime, _ = meter.Float64Histogram(
 		"kustomize_build_latency",
-		"Kustomize build latency",
-		stats.UnitMilliseconds)
+		metric.WithDescription("Execution time of `kustomize build`"),
+		metric.WithUnit(unitMilliseconds),
+		metric.WithExplicitBucketBoundaries(0, 10, 20, 40, 80, 160, 320, 640, 1280, 2560, 5120, 10240))
 )
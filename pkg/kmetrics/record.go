@@ -19,20 +19,20 @@ package kmetrics
 import (
 	"context"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"k8s.io/klog/v2"
 )
 
 var (
-	keyFieldName, _              = tag.NewKey("field_name")
-	keyDeprecatingField, _       = tag.NewKey("deprecating_field")
-	keySimplificationAdoption, _ = tag.NewKey("simplification_field")
-	keyK8sMetadata, _            = tag.NewKey("k8s_metadata_transformer")
-	keyHelmMetrics, _            = tag.NewKey("helm_inflator")
-	keyBaseCount, _              = tag.NewKey("base_source")
-	keyPatchCount, _             = tag.NewKey("patch_field")
-	keyTopTierCount, _           = tag.NewKey("top_tier_field")
+	keyFieldName              = attribute.Key("field_name")
+	keyDeprecatingField       = attribute.Key("deprecating_field")
+	keySimplificationAdoption = attribute.Key("simplification_field")
+	keyK8sMetadata            = attribute.Key("k8s_metadata_transformer")
+	keyHelmMetrics            = attribute.Key("helm_inflator")
+	keyBaseCount              = attribute.Key("base_source")
+	keyPatchCount             = attribute.Key("patch_field")
+	keyTopTierCount           = attribute.Key("top_tier_field")
 )
 
 // RecordKustomizeFieldCountData records all data relevant to the kustomization's field counts
@@ -47,85 +47,76 @@ func RecordKustomizeFieldCountData(ctx context.Context, fieldCountData *Kustomiz
 	recordKustomizeTopTierMetrics(ctx, fieldCountData.TopTierCount)
 }
 
-func record(ctx context.Context, ms ...stats.Measurement) {
-	stats.Record(ctx, ms...)
+func record(ctx context.Context, gauge metric.Int64Gauge, name string, value int64, attrs ...attribute.KeyValue) {
+	gauge.Record(ctx, value, metric.WithAttributes(attrs...))
 	if klog.V(5).Enabled() {
-		for _, m := range ms {
-			klog.Infof("Metric recorded: { \"Name\": %q, \"Value\": %#v, \"Tags\": %s }", m.Measure().Name(), m.Value(), tag.FromContext(ctx))
-		}
+		set := attribute.NewSet(attrs...)
+		klog.Infof("Metric recorded: { \"Name\": %q, \"Value\": %#v, \"Tags\": %s }", name, value, set.Encoded(attribute.DefaultEncoder()))
 	}
 }
 
-// RecordKustomizeResourceCount produces measurement for KustomizeResourceCount view
+// RecordKustomizeResourceCount produces measurement for KustomizeResourceCount metric
 func RecordKustomizeResourceCount(ctx context.Context, resourceCount int) {
-	record(ctx, KustomizeResourceCount.M(int64(resourceCount)))
+	record(ctx, KustomizeResourceCount, "kustomize_resource_count", int64(resourceCount))
 }
 
-// RecordKustomizeExecutionTime produces measurement for KustomizeExecutionTime view
+// RecordKustomizeExecutionTime produces measurement for KustomizeExecutionTime metric
 func RecordKustomizeExecutionTime(ctx context.Context, executionTime float64) {
-	record(ctx, KustomizeExecutionTime.M(executionTime))
+	KustomizeExecutionTime.Record(ctx, executionTime)
 }
 
-// recordKustomizeFieldCount produces measurement for KustomizeFieldCount view
+// recordKustomizeFieldCount produces measurement for KustomizeFieldCount metric
 func recordKustomizeFieldCount(ctx context.Context, fieldCount map[string]int) {
 	for field, count := range fieldCount {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyFieldName, field))
-		record(tagCtx, KustomizeFieldCount.M(int64(count)))
+		record(ctx, KustomizeFieldCount, "kustomize_field_count", int64(count), keyFieldName.String(field))
 	}
 }
 
-// recordKustomizeDeprecatingFields produces measurement for KustomizeDeprecatingMetrics view
+// recordKustomizeDeprecatingFields produces measurement for KustomizeDeprecatingMetrics metric
 func recordKustomizeDeprecatingFields(ctx context.Context, deprecationMetrics map[string]int) {
 	for field, count := range deprecationMetrics {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyDeprecatingField, field))
-		record(tagCtx, KustomizeDeprecatingFields.M(int64(count)))
+		record(ctx, KustomizeDeprecatingFields, "kustomize_deprecating_field_count", int64(count), keyDeprecatingField.String(field))
 	}
 }
 
-// recordKustomizeSimplification produces measurement for KustomizeSimplification view
+// recordKustomizeSimplification produces measurement for KustomizeSimplification metric
 func recordKustomizeSimplification(ctx context.Context, simplMetrics map[string]int) {
 	for field, count := range simplMetrics {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keySimplificationAdoption, field))
-		record(tagCtx, KustomizeSimplification.M(int64(count)))
+		record(ctx, KustomizeSimplification, "kustomize_simplification_adoption_count", int64(count), keySimplificationAdoption.String(field))
 	}
 }
 
-// recordKustomizeK8sMetadata produces measurement for KustomizeK8sMetadata view
+// recordKustomizeK8sMetadata produces measurement for KustomizeK8sMetadata metric
 func recordKustomizeK8sMetadata(ctx context.Context, k8sMetadata map[string]int) {
 	for field, count := range k8sMetadata {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyK8sMetadata, field))
-		record(tagCtx, KustomizeK8sMetadata.M(int64(count)))
+		record(ctx, KustomizeK8sMetadata, "kustomize_builtin_transformers", int64(count), keyK8sMetadata.String(field))
 	}
 }
 
-// recordKustomizeHelmMetrics produces measurement for KustomizeHelmMetrics view
+// recordKustomizeHelmMetrics produces measurement for KustomizeHelmMetrics metric
 func recordKustomizeHelmMetrics(ctx context.Context, helmMetrics map[string]int) {
 	for helmInflator, count := range helmMetrics {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyHelmMetrics, helmInflator))
-		record(tagCtx, KustomizeHelmMetrics.M(int64(count)))
+		record(ctx, KustomizeHelmMetrics, "kustomize_helm_inflator_count", int64(count), keyHelmMetrics.String(helmInflator))
 	}
 }
 
-// recordKustomizeBaseCount produces measurement for KustomizeBaseCount view
+// recordKustomizeBaseCount produces measurement for KustomizeBaseCount metric
 func recordKustomizeBaseCount(ctx context.Context, baseCount map[string]int) {
 	for baseSource, count := range baseCount {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyBaseCount, baseSource))
-		record(tagCtx, KustomizeBaseCount.M(int64(count)))
+		record(ctx, KustomizeBaseCount, "kustomize_base_count", int64(count), keyBaseCount.String(baseSource))
 	}
 }
 
-// recordKustomizePatchCount produces measurement for KustomizePatchCount view
+// recordKustomizePatchCount produces measurement for KustomizePatchCount metric
 func recordKustomizePatchCount(ctx context.Context, patchCount map[string]int) {
 	for patchType, count := range patchCount {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyPatchCount, patchType))
-		record(tagCtx, KustomizePatchCount.M(int64(count)))
+		record(ctx, KustomizePatchCount, "kustomize_patch_count", int64(count), keyPatchCount.String(patchType))
 	}
 }
 
-// recordKustomizeTopTierMetrics produces measurement for KustomizeTopTierMetrics view
+// recordKustomizeTopTierMetrics produces measurement for KustomizeTopTierMetrics metric
 func recordKustomizeTopTierMetrics(ctx context.Context, topTierCount map[string]int) {
 	for field, count := range topTierCount {
-		tagCtx, _ := tag.New(ctx, tag.Upsert(keyTopTierCount, field))
-		record(tagCtx, KustomizeTopTierMetrics.M(int64(count)))
+		record(ctx, KustomizeTopTierMetrics, "kustomize_ordered_top_tier_metrics", int64(count), keyTopTierCount.String(field))
 	}
 }
@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metadata
+
+import (
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HookPhase is the type used to identify value enums to use with the hook
+// annotation.
+type HookPhase string
+
+// String returns the string value of the HookPhase.
+// Implements the Stringer interface.
+func (p HookPhase) String() string {
+	return string(p)
+}
+
+const (
+	// HookAnnotationKey is the annotation key set on Jobs in the source to run
+	// them as hooks around the apply of each commit, instead of applying them
+	// with the other resources.
+	// This annotation is set by Config Sync users on a managed resource.
+	HookAnnotationKey = configsync.ConfigSyncPrefix + "hook"
+	// PreSyncHook runs the hook before the apply. The apply is blocked until
+	// the pre-sync hooks complete, and skipped if any of them fails.
+	PreSyncHook HookPhase = "pre-sync"
+	// PostSyncHook runs the hook after the apply succeeds.
+	PostSyncHook HookPhase = "post-sync"
+	// SyncFailHook runs the hook after a pre-sync hook or the apply fails.
+	SyncFailHook HookPhase = "sync-fail"
+)
+
+// HookPhases are the valid values of the hook annotation, in the order they
+// run.
+var HookPhases = []HookPhase{PreSyncHook, PostSyncHook, SyncFailHook}
+
+// IsHook returns true if the object has the hook annotation.
+func IsHook(obj client.Object) bool {
+	_, found := obj.GetAnnotations()[HookAnnotationKey]
+	return found
+}
+
+// HasHookPhase returns true if the hook annotation is set to the specified
+// phase. Returns false if not set.
+func HasHookPhase(obj client.Object, phase HookPhase) bool {
+	return core.GetAnnotation(obj, HookAnnotationKey) == phase.String()
+}
@@ -94,6 +94,24 @@ const (
 	// This annotation is set by Config Sync users on a managed resource.
 	ClusterNameSelectorAnnotationKey = configsync.ConfigSyncPrefix + "cluster-name-selector"
 
+	// NamespaceTemplateAnnotationKey is the annotation key set on ConfigSync-managed
+	// resources with a namespace-selector annotation, to substitute the
+	// `${namespace.*}` placeholders in each copy with the selected Namespace's
+	// name, labels and annotations.
+	// This annotation is set by Config Sync users on a managed resource.
+	NamespaceTemplateAnnotationKey = configsync.ConfigSyncPrefix + "namespace-template"
+
+	// NamespaceTemplateEnabled is the value of NamespaceTemplateAnnotationKey
+	// to enable the substitution.
+	NamespaceTemplateEnabled = "enabled"
+
+	// SyncWaveAnnotationKey is the annotation key set on ConfigSync-managed
+	// resources to apply them in waves. Each wave is applied and reconciled
+	// before the next higher wave, and pruned in reverse order.
+	// The value must be an integer.
+	// This annotation is set by Config Sync users on a managed resource.
+	SyncWaveAnnotationKey = configsync.ConfigSyncPrefix + "sync-wave"
+
 	// ResourceIDKey is the annotation that indicates the resource's GKNN.
 	// This annotation is set by Config  on a managed resource.
 	ResourceIDKey = configsync.ConfigSyncPrefix + "resource-id"
@@ -138,6 +156,17 @@ const (
 	// When the value is set to "disabled", the ResourceGroup controller
 	// ignores the ResourceGroup CR.
 	StatusModeAnnotationKey = configsync.ConfigSyncPrefix + "status"
+
+	// AdoptedHashAnnotationKey annotates an object adopted by `nomos adopt`
+	// with its current cluster state. The value is the hash of the declared
+	// object at adoption time. The reconciler keeps the cluster state of the
+	// object until the declared object no longer matches the hash.
+	AdoptedHashAnnotationKey = configsync.ConfigSyncPrefix + "adopted-hash"
+
+	// AdoptedObjectsAnnotationKey annotates a ResourceGroup inventory with the
+	// objects adopted with their current cluster state. The value is a JSON
+	// object mapping the resource ID of each object to its adopted hash.
+	AdoptedObjectsAnnotationKey = configsync.ConfigSyncPrefix + "adopted-objects"
 )
 
 // Lifecycle annotations
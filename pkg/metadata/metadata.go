@@ -53,6 +53,9 @@ var sourceAnnotations = map[string]bool{
 	NamespaceSelectorAnnotationKey:         true,
 	LegacyClusterSelectorAnnotationKey:     true,
 	ClusterNameSelectorAnnotationKey:       true,
+	NamespaceTemplateAnnotationKey:         true,
+	SyncWaveAnnotationKey:                  true,
+	HookAnnotationKey:                      true,
 	ManagementModeAnnotationKey:            true,
 	LifecycleMutationAnnotation:            true,
 	DeletionPropagationPolicyAnnotationKey: true,
@@ -14,7 +14,10 @@
 
 package metrics
 
-import "go.opencensus.io/stats"
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
 
 const (
 	// APICallDurationName is the name of API duration metric
@@ -45,93 +48,164 @@ const (
 	ResourceConflictsName = "resource_conflicts_total"
 	// InternalErrorsName is the name of internal error count metric
 	InternalErrorsName = "internal_errors_total"
+	// ObjectOperationsName is the name of per-object operations count metric
+	ObjectOperationsName = "object_operations_total"
+	// ObjectOperationDurationName is the name of per-object operation duration metric
+	ObjectOperationDurationName = "object_operation_duration_seconds"
+	// CommitSyncLatencyName is the name of commit-to-sync latency metric
+	CommitSyncLatencyName = "commit_sync_latency_seconds"
+	// SourceStalenessName is the name of source staleness metric
+	SourceStalenessName = "source_staleness_seconds"
+)
+
+// meter creates the instruments of Config Sync.
+// It is the meter of the global MeterProvider, so the instruments record to
+// the MeterProvider set by RegisterOTelExporter, and to a no-op MeterProvider
+// until then.
+var meter = otel.Meter("kpt.dev/configsync/pkg/metrics")
+
+// distributionBounds defines the bounds for a histogram distribution meansuring short durations.
+var distributionBounds = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// longDistributionBounds defines the bounds for a histogram distribution meansuring long durations.
+var longDistributionBounds = []float64{1, 5, 10, 30, 60, 300, 600, 1200, 1800, 3600, 5400}
+
+// objectDistributionBounds defines the bounds for a histogram distribution
+// measuring the durations of operations on a single object, which range from
+// a fast apply to a slow reconcile.
+var objectDistributionBounds = []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60, 300, 600}
+
+// commitDistributionBounds defines the bounds for a histogram distribution
+// measuring the durations from a source commit to its sync, which range from
+// seconds to days.
+var commitDistributionBounds = []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 14400, 43200, 86400, 259200}
+
+const (
+	unitSeconds       = "s"
+	unitDimensionless = "1"
 )
 
 var (
 	// APICallDuration metric measures the latency of API server calls.
-	APICallDuration = stats.Float64(
+	APICallDuration, _ = meter.Float64Histogram(
 		APICallDurationName,
-		"The duration of API server calls in seconds",
-		stats.UnitSeconds)
+		metric.WithDescription("The latency distribution of API server calls"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(distributionBounds...))
 
 	// ReconcilerErrors metric measures the number of errors in the reconciler.
-	ReconcilerErrors = stats.Int64(
+	ReconcilerErrors, _ = meter.Int64Gauge(
 		ReconcilerErrorsName,
-		"The number of errors in the reconciler",
-		stats.UnitDimensionless)
+		metric.WithDescription("The current number of errors in the RootSync and RepoSync reconcilers"),
+		metric.WithUnit(unitDimensionless))
 
 	// PipelineError metric measures the error by components when syncing a commit.
 	// Definition here must exactly match the definition in the resource-group
 	// controller, or the Prometheus exporter will error. b/247516388
 	// https://github.com/GoogleContainerTools/kpt-resource-group/blob/main/controllers/metrics/metrics.go#L88
-	PipelineError = stats.Int64(
+	PipelineError, _ = meter.Int64Gauge(
 		PipelineErrorName,
-		"A boolean value indicates if error happened at readiness stage when syncing a commit",
-		stats.UnitDimensionless)
+		metric.WithDescription("A boolean value indicates if error happened from different stages when syncing a commit"),
+		metric.WithUnit(unitDimensionless))
 
 	// ReconcileDuration metric measures the latency of reconcile events.
-	ReconcileDuration = stats.Float64(
+	ReconcileDuration, _ = meter.Float64Histogram(
 		ReconcileDurationName,
-		"The duration of reconcile events in seconds",
-		stats.UnitSeconds)
+		metric.WithDescription("The latency distribution of RootSync and RepoSync reconcile events"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(distributionBounds...))
 
 	// ParserDuration metric measures the latency of the parse-apply-watch loop.
-	ParserDuration = stats.Float64(
+	ParserDuration, _ = meter.Float64Histogram(
 		ParserDurationName,
-		"The duration of the parse-apply-watch loop in seconds",
-		stats.UnitSeconds)
+		metric.WithDescription("The latency distribution of the parse-apply-watch loop"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(longDistributionBounds...))
 
 	// LastSync metric measures the timestamp of the latest Git sync.
-	LastSync = stats.Int64(
+	LastSync, _ = meter.Int64Gauge(
 		LastSyncName,
-		"The timestamp of the most recent sync from Git",
-		stats.UnitDimensionless)
+		metric.WithDescription("The timestamp of the most recent sync from Git"),
+		metric.WithUnit(unitDimensionless))
 
 	// DeclaredResources metric measures the number of declared resources parsed from Git.
-	DeclaredResources = stats.Int64(
+	DeclaredResources, _ = meter.Int64Gauge(
 		DeclaredResourcesName,
-		"The number of declared resources parsed from Git",
-		stats.UnitDimensionless)
+		metric.WithDescription("The current number of declared resources parsed from Git"),
+		metric.WithUnit(unitDimensionless))
 
 	// ApplyOperations metric measures the number of applier apply events.
-	ApplyOperations = stats.Int64(
+	ApplyOperations, _ = meter.Int64Counter(
 		ApplyOperationsName,
-		"The number of operations that have been performed to sync resources to source of truth",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of operations that have been performed to sync resources to source of truth"),
+		metric.WithUnit(unitDimensionless))
 
 	// ApplyDuration metric measures the latency of applier apply events.
-	ApplyDuration = stats.Float64(
+	ApplyDuration, _ = meter.Float64Histogram(
 		ApplyDurationName,
-		"The duration of applier events in seconds",
-		stats.UnitSeconds)
+		metric.WithDescription("The latency distribution of applier resource sync events"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(longDistributionBounds...))
 
 	// ResourceFights metric measures the number of resource fights.
-	ResourceFights = stats.Int64(
+	ResourceFights, _ = meter.Int64Counter(
 		ResourceFightsName,
-		"The number of resources that are being synced too frequently",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of resources that are being synced too frequently"),
+		metric.WithUnit(unitDimensionless))
 
 	// RemediateDuration metric measures the latency of remediator reconciliation events.
-	RemediateDuration = stats.Float64(
+	RemediateDuration, _ = meter.Float64Histogram(
 		RemediateDurationName,
-		"The duration of remediator reconciliation events",
-		stats.UnitSeconds)
+		metric.WithDescription("The latency distribution of remediator reconciliation events"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(distributionBounds...))
 
 	// LastApply metric measures the timestamp of the most recent applier apply event.
-	LastApply = stats.Int64(
+	LastApply, _ = meter.Int64Gauge(
 		LastApplyName,
-		"The timestamp of the most recent applier event",
-		stats.UnitDimensionless)
+		metric.WithDescription("The timestamp of the most recent applier resource sync event"),
+		metric.WithUnit(unitDimensionless))
 
 	// ResourceConflicts metric measures the number of resource conflicts.
-	ResourceConflicts = stats.Int64(
+	ResourceConflicts, _ = meter.Int64Counter(
 		ResourceConflictsName,
-		"The number of resource conflicts resulting from a mismatch between the cached resources and cluster resources",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of resource conflicts resulting from a mismatch between the cached resources and cluster resources"),
+		metric.WithUnit(unitDimensionless))
 
 	// InternalErrors metric measures the number of unexpected internal errors triggered by defensive checks in Config Sync.
-	InternalErrors = stats.Int64(
+	InternalErrors, _ = meter.Int64Counter(
 		InternalErrorsName,
-		"The number of internal errors triggered by Config Sync",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of internal errors triggered by Config Sync"),
+		metric.WithUnit(unitDimensionless))
+
+	// ObjectOperations metric measures the number of apply, prune and wait
+	// operations on objects, by object type.
+	ObjectOperations, _ = meter.Int64Counter(
+		ObjectOperationsName,
+		metric.WithDescription("The total number of apply, prune and wait operations performed by the applier on objects of the allowed types"),
+		metric.WithUnit(unitDimensionless))
+
+	// ObjectOperationDuration metric measures the latency of apply, prune and
+	// wait operations on objects, by object type.
+	ObjectOperationDuration, _ = meter.Float64Histogram(
+		ObjectOperationDurationName,
+		metric.WithDescription("The latency distribution of apply, prune and wait operations performed by the applier on objects of the allowed types"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(objectDistributionBounds...))
+
+	// CommitSyncLatency metric measures the latency from the time of a source
+	// commit to its first successful sync.
+	CommitSyncLatency, _ = meter.Float64Histogram(
+		CommitSyncLatencyName,
+		metric.WithDescription("The latency distribution from the time of a source commit to its first successful sync"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(commitDistributionBounds...))
+
+	// SourceStaleness metric measures the time since the source was last
+	// fetched successfully.
+	SourceStaleness, _ = meter.Float64ObservableGauge(
+		SourceStalenessName,
+		metric.WithDescription("The time since the source was last fetched successfully"),
+		metric.WithUnit(unitSeconds),
+		metric.WithFloat64Callback(observeSourceStaleness))
 )
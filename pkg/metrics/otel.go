@@ -39,11 +39,23 @@ const (
 func CollectorConfigGooglecloudYAML() (string, error) {
 	cfg := map[string]interface{}{
 		"receivers": map[string]interface{}{
-			"opencensus": map[string]interface{}{
-				"endpoint": "0.0.0.0:55678",
+			"otlp": map[string]interface{}{
+				"protocols": map[string]interface{}{
+					"grpc": map[string]interface{}{
+						"endpoint": "0.0.0.0:4317",
+					},
+					"http": map[string]interface{}{
+						"endpoint": "0.0.0.0:4318",
+					},
+				},
 			},
 		},
 		"exporters": map[string]interface{}{
+			// debug: Only log a summary of the received spans. Spans can be
+			// exported to a tracing backend with the otel-collector-custom ConfigMap.
+			"debug": map[string]interface{}{
+				"verbosity": "basic",
+			},
 			"prometheus": map[string]interface{}{
 				"endpoint":  "0.0.0.0:8675",
 				"namespace": "config_sync",
@@ -53,7 +65,13 @@ func CollectorConfigGooglecloudYAML() (string, error) {
 			},
 			"googlecloud": map[string]interface{}{
 				"metric": map[string]interface{}{
+					// The prefix is kept from when the metrics were recorded with
+					// OpenCensus, so that existing dashboards and alerts keep working.
 					"prefix": "custom.googleapis.com/opencensus/config_sync/",
+					// instrumentation_library_labels: Skip the 'instrumentation_version'
+					// and 'instrumentation_source' labels, which OpenCensus metrics
+					// did not have.
+					"instrumentation_library_labels": false,
 					// The exporter would always fail at sending metric descriptor. Skipping
 					// creation of metric descriptors until the error from upstream is resolved
 					// The metric streaming data is not affected
@@ -106,8 +124,8 @@ func CollectorConfigGooglecloudYAML() (string, error) {
 		},
 		"processors": map[string]interface{}{
 			"batch": nil,
-			// resourcedetection: This processor is needed to correctly mirror resource
-			// labels from OpenCensus to OpenTelemetry. We also want to keep this same
+			// resourcedetection: This processor populates the resource attributes
+			// used by the Google Cloud exporters. We also want to keep this same
 			// processor in Otel Agent configuration as the resource labels are added from
 			// there
 			"resourcedetection": map[string]interface{}{
@@ -214,22 +232,28 @@ func CollectorConfigGooglecloudYAML() (string, error) {
 			"pipelines": map[string]interface{}{
 				// metrics/cloudmonitoring: pipeline for Cloud Monitoring backend
 				"metrics/cloudmonitoring": map[string]interface{}{
-					"receivers":  []string{"opencensus"},
+					"receivers":  []string{"otlp"},
 					"processors": []string{"batch", "filter/cloudmonitoring", "metricstransform/cloudmonitoring", "resourcedetection"},
 					"exporters":  []string{"googlecloud"},
 				},
 				// metrics/prometheus: pipeline for Prometheus backend
 				"metrics/prometheus": map[string]interface{}{
-					"receivers":  []string{"opencensus"},
+					"receivers":  []string{"otlp"},
 					"processors": []string{"batch"},
 					"exporters":  []string{"prometheus"},
 				},
 				// metrics/kubernetes: pipeline for Cloud Monarch backend
 				"metrics/kubernetes": map[string]interface{}{
-					"receivers":  []string{"opencensus"},
+					"receivers":  []string{"otlp"},
 					"processors": []string{"batch", "filter/kubernetes", "metricstransform/kubernetes", "resourcedetection"},
 					"exporters":  []string{"googlecloud/kubernetes"},
 				},
+				// traces: pipeline for the spans of the sync pipeline
+				"traces": map[string]interface{}{
+					"receivers":  []string{"otlp"},
+					"processors": []string{"batch"},
+					"exporters":  []string{"debug"},
+				},
 			},
 		},
 	}
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	// OTLPProtocolGRPC is the OTLP protocol value to export over gRPC.
+	OTLPProtocolGRPC = "grpc"
+	// OTLPProtocolHTTP is the OTLP protocol value to export over HTTP.
+	OTLPProtocolHTTP = "http/protobuf"
+
+	// OTLPSignalMetrics is the signal of the OTLP metrics exporter, in the
+	// names of its environment variables.
+	OTLPSignalMetrics = "METRICS"
+	// OTLPSignalTraces is the signal of the OTLP trace exporter, in the names
+	// of its environment variables.
+	OTLPSignalTraces = "TRACES"
+)
+
+// OTLPExporterOptions are the options of an OTLP exporter which Config Sync
+// sets, on top of the standard OTEL_EXPORTER_OTLP_* environment variables read
+// by the exporter itself.
+type OTLPExporterOptions struct {
+	// Protocol is OTLPProtocolGRPC or OTLPProtocolHTTP.
+	Protocol string
+	// Insecure is whether to export without TLS, to the otel-agent sidecar
+	// which listens on localhost. It is false if OTEL_EXPORTER_OTLP_INSECURE
+	// is set, so the exporter uses its value.
+	Insecure bool
+}
+
+// OTLPExporterOptionsFromEnv returns the options of the OTLP exporter of the
+// signal. The protocol is set by OTEL_EXPORTER_OTLP_<signal>_PROTOCOL or
+// OTEL_EXPORTER_OTLP_PROTOCOL, and defaults to grpc.
+func OTLPExporterOptionsFromEnv(signal string) (OTLPExporterOptions, error) {
+	_, insecureSet := lookupOTLPEnv(signal, "INSECURE")
+	protocol, found := lookupOTLPEnv(signal, "PROTOCOL")
+	if !found {
+		protocol = OTLPProtocolGRPC
+	}
+	if protocol != OTLPProtocolGRPC && protocol != OTLPProtocolHTTP {
+		return OTLPExporterOptions{}, fmt.Errorf("unsupported OTLP protocol %q: must be %q or %q", protocol, OTLPProtocolGRPC, OTLPProtocolHTTP)
+	}
+	return OTLPExporterOptions{
+		Protocol: protocol,
+		Insecure: !insecureSet,
+	}, nil
+}
+
+// lookupOTLPEnv returns the value of the OTEL_EXPORTER_OTLP_<signal>_<name>
+// environment variable, or else of OTEL_EXPORTER_OTLP_<name>.
+func lookupOTLPEnv(signal, name string) (string, bool) {
+	if value, found := os.LookupEnv("OTEL_EXPORTER_OTLP_" + signal + "_" + name); found {
+		return value, true
+	}
+	return os.LookupEnv("OTEL_EXPORTER_OTLP_" + name)
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// PrometheusMetricsPort is the default port of the /metrics endpoint, which
+// serves the metrics in the Prometheus format. It's the default port of the
+// OpenTelemetry Prometheus exporter.
+const PrometheusMetricsPort = 9464
+
+// PrometheusMetricsPortName is the name of the container port of the /metrics
+// endpoint, which is scraped by the Config Sync PodMonitors.
+const PrometheusMetricsPortName = "prom-metrics"
+
+// prometheusNamespace is the prefix of the metric names, which matches the
+// namespace of the prometheus exporter of the otel-collector, so that the
+// metrics have the same names whether they are scraped from the otel-collector
+// or from the Config Sync containers.
+const prometheusNamespace = "config_sync"
+
+// NewPrometheusReader creates a reader of the recorded metrics, which exposes
+// them in the Prometheus format with the controller-runtime metrics registry.
+// The registry also has the process and Go runtime collectors, and the
+// metrics of the controller-runtime controllers, workqueues and clients.
+//
+// Pass the reader to RegisterOTelExporter. The registry is served on /metrics
+// by the metrics server of the controller-runtime manager.
+func NewPrometheusReader() (sdkmetric.Reader, error) {
+	return otelprom.New(
+		otelprom.WithRegisterer(ctrlmetrics.Registry),
+		otelprom.WithNamespace(prometheusNamespace),
+		// The metric names already end with their unit and _total, like the
+		// names exported by the otel-collector with the
+		// pkg.translator.prometheus.NormalizeName feature gate disabled.
+		otelprom.WithoutUnits(),
+		otelprom.WithoutCounterSuffixes(),
+		otelprom.WithoutScopeInfo())
+}
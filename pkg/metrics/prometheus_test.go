@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestNewPrometheusReader(t *testing.T) {
+	reader, err := NewPrometheusReader()
+	require.NoError(t, err)
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() {
+		require.NoError(t, mp.Shutdown(context.Background()))
+	}()
+	meter := mp.Meter("kpt.dev/configsync/pkg/metrics")
+
+	ctx := context.Background()
+	errors, err := meter.Int64Gauge(ReconcilerErrorsName, metric.WithUnit(unitDimensionless))
+	require.NoError(t, err)
+	errors.Record(ctx, 2)
+	operations, err := meter.Int64Counter(ApplyOperationsName, metric.WithUnit(unitDimensionless))
+	require.NoError(t, err)
+	operations.Add(ctx, 1)
+	duration, err := meter.Float64Histogram(ApplyDurationName, metric.WithUnit(unitSeconds))
+	require.NoError(t, err)
+	duration.Record(ctx, 0.5)
+
+	families, err := ctrlmetrics.Registry.Gather()
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	// The names match the names exported by the otel-collector, without the
+	// unit and _total suffixes added by the Prometheus exporter.
+	assert.True(t, names["config_sync_reconciler_errors"], "gauge")
+	assert.True(t, names["config_sync_apply_operations_total"], "counter")
+	assert.True(t, names["config_sync_apply_duration_seconds"], "histogram")
+	assert.False(t, names["config_sync_reconciler_errors_ratio"])
+	assert.False(t, names["config_sync_apply_operations_total_total"])
+}
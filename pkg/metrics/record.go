@@ -17,35 +17,47 @@ package metrics
 import (
 	"context"
 	"os"
+	"sync/atomic"
 	"time"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/status"
 )
 
-func record(ctx context.Context, ms ...stats.Measurement) {
-	stats.Record(ctx, ms...)
+func logRecord(name string, value interface{}, attrs []attribute.KeyValue) {
 	if klog.V(5).Enabled() {
-		for _, m := range ms {
-			klog.Infof("Metric recorded: { \"Name\": %q, \"Value\": %#v, \"Tags\": %s }", m.Measure().Name(), m.Value(), tag.FromContext(ctx))
-		}
+		set := attribute.NewSet(attrs...)
+		klog.Infof("Metric recorded: { \"Name\": %q, \"Value\": %#v, \"Tags\": %s }", name, value, set.Encoded(attribute.DefaultEncoder()))
 	}
 }
 
-// RecordAPICallDuration produces a measurement for the APICallDuration view.
+func recordDuration(ctx context.Context, histogram metric.Float64Histogram, name string, seconds float64, attrs ...attribute.KeyValue) {
+	histogram.Record(ctx, seconds, metric.WithAttributes(attrs...))
+	logRecord(name, seconds, attrs)
+}
+
+func recordLastValue(ctx context.Context, gauge metric.Int64Gauge, name string, value int64, attrs ...attribute.KeyValue) {
+	gauge.Record(ctx, value, metric.WithAttributes(attrs...))
+	logRecord(name, value, attrs)
+}
+
+func recordCount(ctx context.Context, counter metric.Int64Counter, name string, attrs ...attribute.KeyValue) {
+	counter.Add(ctx, 1, metric.WithAttributes(attrs...))
+	logRecord(name, int64(1), attrs)
+}
+
+// RecordAPICallDuration produces a measurement for the APICallDuration metric.
 func RecordAPICallDuration(ctx context.Context, operation, status string, startTime time.Time) {
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyOperation, operation),
-		tag.Upsert(KeyStatus, status))
-	measurement := APICallDuration.M(time.Since(startTime).Seconds())
-	record(tagCtx, measurement)
+	recordDuration(ctx, APICallDuration, APICallDurationName, time.Since(startTime).Seconds(),
+		KeyOperation.String(operation),
+		KeyStatus.String(status))
 }
 
-// RecordReconcilerErrors produces a measurement for the ReconcilerErrors view.
+// RecordReconcilerErrors produces a measurement for the ReconcilerErrors metric.
 func RecordReconcilerErrors(ctx context.Context, component string, errs []v1beta1.ConfigSyncError) {
 	errorCountByClass := status.CountErrorByClass(errs)
 	var supportedErrorClasses = []string{"1xxx", "2xxx", "9xxx"}
@@ -54,119 +66,154 @@ func RecordReconcilerErrors(ctx context.Context, component string, errs []v1beta
 		if v, ok := errorCountByClass[errorclass]; ok {
 			errorCount = v
 		}
-		tagCtx, _ := tag.New(ctx,
-			tag.Upsert(KeyComponent, component),
-			tag.Upsert(KeyErrorClass, errorclass),
-		)
-		measurement := ReconcilerErrors.M(errorCount)
-		record(tagCtx, measurement)
+		recordLastValue(ctx, ReconcilerErrors, ReconcilerErrorsName, errorCount,
+			KeyComponent.String(component),
+			KeyErrorClass.String(errorclass))
 	}
 }
 
-// RecordPipelineError produces a measurement for the PipelineError view
+// RecordPipelineError produces a measurement for the PipelineError metric
 func RecordPipelineError(ctx context.Context, reconcilerType, component string, errLen int) {
 	reconcilerName := os.Getenv(reconcilermanager.ReconcilerNameKey)
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyName, reconcilerName),
-		tag.Upsert(KeyReconcilerType, reconcilerType),
-		tag.Upsert(KeyComponent, component))
+	var value int64
 	if errLen > 0 {
-		record(tagCtx, PipelineError.M(1))
-	} else {
-		record(tagCtx, PipelineError.M(0))
+		value = 1
 	}
+	recordLastValue(ctx, PipelineError, PipelineErrorName, value,
+		KeyName.String(reconcilerName),
+		KeyReconcilerType.String(reconcilerType),
+		KeyComponent.String(component))
 }
 
-// RecordReconcileDuration produces a measurement for the ReconcileDuration view.
+// RecordReconcileDuration produces a measurement for the ReconcileDuration metric.
 func RecordReconcileDuration(ctx context.Context, status string, startTime time.Time) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyStatus, status))
-	measurement := ReconcileDuration.M(time.Since(startTime).Seconds())
-	record(tagCtx, measurement)
+	recordDuration(ctx, ReconcileDuration, ReconcileDurationName, time.Since(startTime).Seconds(),
+		KeyStatus.String(status))
 }
 
-// RecordParserDuration produces a measurement for the ParserDuration view.
+// RecordParserDuration produces a measurement for the ParserDuration metric.
 func RecordParserDuration(ctx context.Context, trigger, source, status string, startTime time.Time) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyStatus, status), tag.Upsert(KeyTrigger, trigger), tag.Upsert(KeyParserSource, source))
-	measurement := ParserDuration.M(time.Since(startTime).Seconds())
-	record(tagCtx, measurement)
+	recordDuration(ctx, ParserDuration, ParserDurationName, time.Since(startTime).Seconds(),
+		KeyStatus.String(status),
+		KeyTrigger.String(trigger),
+		KeyParserSource.String(source))
 }
 
-// RecordLastSync produces a measurement for the LastSync view.
+// RecordLastSync produces a measurement for the LastSync metric.
 func RecordLastSync(ctx context.Context, status, commit string, timestamp time.Time) {
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyStatus, status),
-		tag.Upsert(KeyCommit, commit))
-	measurement := LastSync.M(timestamp.Unix())
-	record(tagCtx, measurement)
+	recordLastValue(ctx, LastSync, LastSyncName, timestamp.Unix(),
+		KeyStatus.String(status),
+		KeyCommit.String(commit))
 }
 
-// RecordDeclaredResources produces a measurement for the DeclaredResources view.
+// RecordDeclaredResources produces a measurement for the DeclaredResources metric.
 func RecordDeclaredResources(ctx context.Context, commit string, numResources int) {
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyCommit, commit))
-	measurement := DeclaredResources.M(int64(numResources))
-	record(tagCtx, measurement)
+	recordLastValue(ctx, DeclaredResources, DeclaredResourcesName, int64(numResources),
+		KeyCommit.String(commit))
 }
 
-// RecordApplyOperation produces a measurement for the ApplyOperations view.
+// RecordApplyOperation produces a measurement for the ApplyOperations metric.
 func RecordApplyOperation(ctx context.Context, controller, operation, status string) {
-	tagCtx, _ := tag.New(ctx,
-		//tag.Upsert(KeyName, GetResourceLabels()),
-		tag.Upsert(KeyOperation, operation),
-		tag.Upsert(KeyController, controller),
-		tag.Upsert(KeyStatus, status))
-	measurement := ApplyOperations.M(1)
-	record(tagCtx, measurement)
+	recordCount(ctx, ApplyOperations, ApplyOperationsName,
+		KeyOperation.String(operation),
+		KeyController.String(controller),
+		KeyStatus.String(status))
 }
 
-// RecordApplyDuration produces measurements for the ApplyDuration and LastApplyTimestamp views.
+// RecordApplyDuration produces measurements for the ApplyDuration and LastApply metrics.
 func RecordApplyDuration(ctx context.Context, status, commit string, startTime time.Time) {
 	if commit == "" {
 		// TODO: Remove default value when otel-collector supports empty tag values correctly.
 		commit = CommitNone
 	}
 	now := time.Now()
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyStatus, status),
-		tag.Upsert(KeyCommit, commit),
-	)
-	durationMeasurement := ApplyDuration.M(now.Sub(startTime).Seconds())
-	lastApplyMeasurement := LastApply.M(now.Unix())
-	record(tagCtx, durationMeasurement, lastApplyMeasurement)
+	attrs := []attribute.KeyValue{
+		KeyStatus.String(status),
+		KeyCommit.String(commit),
+	}
+	recordDuration(ctx, ApplyDuration, ApplyDurationName, now.Sub(startTime).Seconds(), attrs...)
+	recordLastValue(ctx, LastApply, LastApplyName, now.Unix(), attrs...)
 }
 
-// RecordResourceFight produces measurements for the ResourceFights view.
+// RecordResourceFight produces measurements for the ResourceFights metric.
 func RecordResourceFight(ctx context.Context, _ string) {
-	//tagCtx, _ := tag.New(ctx,
-	//tag.Upsert(KeyName, GetResourceLabels()),
-	//tag.Upsert(KeyOperation, operation),
-	//)
-	measurement := ResourceFights.M(1)
-	record(ctx, measurement)
+	recordCount(ctx, ResourceFights, ResourceFightsName)
 }
 
-// RecordRemediateDuration produces measurements for the RemediateDuration view.
+// RecordRemediateDuration produces measurements for the RemediateDuration metric.
 func RecordRemediateDuration(ctx context.Context, status string, startTime time.Time) {
-	tagCtx, _ := tag.New(ctx,
-		tag.Upsert(KeyStatus, status),
-	)
-	measurement := RemediateDuration.M(time.Since(startTime).Seconds())
-	record(tagCtx, measurement)
+	recordDuration(ctx, RemediateDuration, RemediateDurationName, time.Since(startTime).Seconds(),
+		KeyStatus.String(status))
 }
 
-// RecordResourceConflict produces measurements for the ResourceConflicts view.
+// RecordResourceConflict produces measurements for the ResourceConflicts metric.
 func RecordResourceConflict(ctx context.Context, commit string) {
-	tagCtx, _ := tag.New(ctx,
-		// tag.Upsert(KeyName, GetResourceLabels()),
-		tag.Upsert(KeyCommit, commit),
-	)
-	measurement := ResourceConflicts.M(1)
-	record(tagCtx, measurement)
+	recordCount(ctx, ResourceConflicts, ResourceConflictsName,
+		KeyCommit.String(commit))
 }
 
-// RecordInternalError produces measurements for the InternalErrors view.
+// RecordInternalError produces measurements for the InternalErrors metric.
 func RecordInternalError(ctx context.Context, source string) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyInternalErrorSource, source))
-	measurement := InternalErrors.M(1)
-	record(tagCtx, measurement)
+	recordCount(ctx, InternalErrors, InternalErrorsName,
+		KeyInternalErrorSource.String(source))
+}
+
+// RecordObjectOperation produces measurements for the ObjectOperations metric.
+// The namespace is only recorded if not empty.
+func RecordObjectOperation(ctx context.Context, operation, status, objectType, namespace string) {
+	recordCount(ctx, ObjectOperations, ObjectOperationsName,
+		objectAttributes(operation, status, objectType, namespace)...)
+}
+
+// RecordObjectOperationDuration produces measurements for the
+// ObjectOperationDuration metric.
+// The namespace is only recorded if not empty.
+func RecordObjectOperationDuration(ctx context.Context, operation, status, objectType, namespace string, duration time.Duration) {
+	recordDuration(ctx, ObjectOperationDuration, ObjectOperationDurationName, duration.Seconds(),
+		objectAttributes(operation, status, objectType, namespace)...)
+}
+
+// RecordCommitSyncLatency produces a measurement for the CommitSyncLatency
+// metric.
+func RecordCommitSyncLatency(ctx context.Context, latency time.Duration) {
+	if latency < 0 {
+		// The commit time is ahead of the clock of the reconciler.
+		latency = 0
+	}
+	recordDuration(ctx, CommitSyncLatency, CommitSyncLatencyName, latency.Seconds())
+}
+
+// lastFetchTime is the time of the last successful fetch of the source, in
+// Unix nanoseconds, or 0 until the source is fetched.
+var lastFetchTime atomic.Int64
+
+// RecordSourceFetch records the time of a successful fetch of the source,
+// from which the SourceStaleness metric is observed.
+func RecordSourceFetch(timestamp time.Time) {
+	lastFetchTime.Store(timestamp.UnixNano())
+}
+
+// observeSourceStaleness observes the SourceStaleness metric when the metrics
+// are collected, once the source has been fetched.
+func observeSourceStaleness(_ context.Context, o metric.Float64Observer) error {
+	last := lastFetchTime.Load()
+	if last == 0 {
+		return nil
+	}
+	staleness := time.Since(time.Unix(0, last)).Seconds()
+	o.Observe(staleness)
+	logRecord(SourceStalenessName, staleness, nil)
+	return nil
+}
+
+func objectAttributes(operation, status, objectType, namespace string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		KeyOperation.String(operation),
+		KeyStatus.String(status),
+		KeyObjectType.String(objectType),
+	}
+	if namespace != "" {
+		attrs = append(attrs, KeyObjectNamespace.String(namespace))
+	}
+	return attrs
 }
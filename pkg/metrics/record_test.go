@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestSourceStaleness(t *testing.T) {
+	defer lastFetchTime.Store(0)
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	defer func() {
+		require.NoError(t, mp.Shutdown(context.Background()))
+	}()
+	_, err := mp.Meter("kpt.dev/configsync/pkg/metrics").Float64ObservableGauge(SourceStalenessName,
+		metric.WithFloat64Callback(observeSourceStaleness))
+	require.NoError(t, err)
+
+	collect := func() []metricdata.DataPoint[float64] {
+		rm := metricdata.ResourceMetrics{}
+		require.NoError(t, reader.Collect(context.Background(), &rm))
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if m.Name == SourceStalenessName {
+					return m.Data.(metricdata.Gauge[float64]).DataPoints
+				}
+			}
+		}
+		return nil
+	}
+
+	// Not observed until the source is fetched.
+	assert.Empty(t, collect())
+
+	RecordSourceFetch(time.Now().Add(-time.Minute))
+	points := collect()
+	require.Len(t, points, 1)
+	assert.GreaterOrEqual(t, points[0].Value, 60.0)
+	assert.Less(t, points[0].Value, 120.0)
+}
@@ -15,53 +15,75 @@
 package metrics
 
 import (
-	"os"
+	"context"
+	"time"
 
-	"contrib.go.opencensus.io/exporter/ocagent"
-	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
-// RegisterOCAgentExporter creates the OC Agent metrics exporter.
-func RegisterOCAgentExporter(containerName string) (*ocagent.Exporter, error) {
-	// Add the k8s.container.name resource label so that the google cloud monitoring
-	// and monarch metrics exporters will use the k8s_container resource type
-	err := os.Setenv(
-		"OC_RESOURCE_LABELS",
-		"k8s.container.name=\""+containerName+"\"")
+// exportInterval is how often the recorded metrics are exported.
+const exportInterval = 10 * time.Second
+
+// RegisterOTelExporter creates the OTLP metrics exporter, and sets the global
+// MeterProvider to export the recorded metrics with it.
+//
+// The exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment
+// variables. The protocol is set by OTEL_EXPORTER_OTLP_METRICS_PROTOCOL or
+// OTEL_EXPORTER_OTLP_PROTOCOL, and defaults to grpc. Unless
+// OTEL_EXPORTER_OTLP_INSECURE is set, metrics are exported without TLS to the
+// otel-agent sidecar, which listens on localhost.
+//
+// The metrics are also read by the additional readers, like the reader created
+// by NewPrometheusReader.
+//
+// The caller must shut down the returned MeterProvider to flush the metrics
+// before exiting.
+func RegisterOTelExporter(ctx context.Context, containerName string, readers ...sdkmetric.Reader) (*sdkmetric.MeterProvider, error) {
+	exporter, err := newOTLPExporter(ctx)
 	if err != nil {
 		return nil, err
 	}
-	oce, err := ocagent.NewExporter(
-		ocagent.WithInsecure(),
-	)
+	// Add the k8s.container.name resource attribute so that the google cloud
+	// monitoring and monarch metrics exporters will use the k8s_container
+	// resource type
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(attribute.String("k8s.container.name", containerName)))
 	if err != nil {
 		return nil, err
 	}
-
-	view.RegisterExporter(oce)
-	return oce, nil
-}
-
-// RegisterReconcilerManagerMetricsViews registers the views so that recorded metrics can be exported in the reconciler manager.
-func RegisterReconcilerManagerMetricsViews() error {
-	return view.Register(ReconcileDurationView)
+	opts := []sdkmetric.Option{
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(exportInterval))),
+	}
+	for _, reader := range readers {
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	mp := sdkmetric.NewMeterProvider(opts...)
+	otel.SetMeterProvider(mp)
+	return mp, nil
 }
 
-// RegisterReconcilerMetricsViews registers the views so that recorded metrics can be exported in the reconcilers.
-func RegisterReconcilerMetricsViews() error {
-	return view.Register(
-		APICallDurationView,
-		ReconcilerErrorsView,
-		ParserDurationView,
-		LastApplyTimestampView,
-		LastSyncTimestampView,
-		DeclaredResourcesView,
-		ApplyOperationsView,
-		ApplyDurationView,
-		ResourceFightsView,
-		RemediateDurationView,
-		ResourceConflictsView,
-		InternalErrorsView,
-		PipelineErrorView,
-	)
+func newOTLPExporter(ctx context.Context) (sdkmetric.Exporter, error) {
+	exporterOpts, err := OTLPExporterOptionsFromEnv(OTLPSignalMetrics)
+	if err != nil {
+		return nil, err
+	}
+	if exporterOpts.Protocol == OTLPProtocolHTTP {
+		var opts []otlpmetrichttp.Option
+		if exporterOpts.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	}
+	var opts []otlpmetricgrpc.Option
+	if exporterOpts.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	return otlpmetricgrpc.New(ctx, opts...)
 }
@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+)
+
+func TestNewOTLPExporter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		wantType interface{}
+		wantErr  string
+	}{
+		{
+			name:     "gRPC by default",
+			wantType: &otlpmetricgrpc.Exporter{},
+		},
+		{
+			name:     "HTTP from the OTLP protocol",
+			env:      map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": OTLPProtocolHTTP},
+			wantType: &otlpmetrichttp.Exporter{},
+		},
+		{
+			name: "metrics protocol overrides the OTLP protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":         OTLPProtocolHTTP,
+				"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL": OTLPProtocolGRPC,
+			},
+			wantType: &otlpmetricgrpc.Exporter{},
+		},
+		{
+			name:    "unsupported protocol",
+			env:     map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": "http/json"},
+			wantErr: `unsupported OTLP protocol "http/json": must be "grpc" or "http/protobuf"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			exporter, err := newOTLPExporter(context.Background())
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tc.wantType, exporter)
+			require.NoError(t, exporter.Shutdown(context.Background()))
+		})
+	}
+}
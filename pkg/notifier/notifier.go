@@ -0,0 +1,312 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notifier POSTs notifications of sync results to the HTTP endpoints
+// configured on a RootSync or RepoSync.
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier/stats"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/util"
+)
+
+const (
+	// queueSize is the number of notifications buffered per endpoint.
+	// Notifications are dropped when the queue is full.
+	queueSize = 10
+	// requestTimeout is the timeout of each POST request.
+	requestTimeout = 10 * time.Second
+	// rateLimit and rateBurst limit how often each endpoint is notified, to
+	// avoid flooding it while the errors of a commit are flapping.
+	rateLimit = rate.Limit(1.0 / 10) // one notification per 10s
+	rateBurst = 5
+)
+
+// Result is the result of a sync attempt.
+type Result struct {
+	// Commit is the source commit of the sync attempt.
+	Commit string
+	// Errs are the errors of the sync attempt, if it failed.
+	Errs status.MultiError
+	// Stats are the stats of the apply, if the sync attempt reached the apply.
+	Stats *stats.SyncStats
+}
+
+// Notifier notifies the configured endpoints when a commit syncs, fails to
+// sync, or when the errors of a failing commit change.
+//
+// A nil Notifier is valid, and ignores all results.
+type Notifier struct {
+	endpoints []*endpoint
+	client    *http.Client
+	clock     clock.PassiveClock
+	backoff   wait.Backoff
+
+	clusterName   string
+	syncKind      string
+	syncName      string
+	syncNamespace string
+
+	mux sync.Mutex
+	// last is the result of the last notified sync attempt.
+	last *lastResult
+}
+
+type lastResult struct {
+	commit string
+	errs   []string
+}
+
+type endpoint struct {
+	v1beta1.NotificationEndpoint
+	triggers map[configsync.NotificationTrigger]bool
+	queue    chan *Notification
+	limiter  *rate.Limiter
+}
+
+// New constructs a Notifier for the specified endpoints of the RootSync or
+// RepoSync. Returns an error if an endpoint is invalid.
+//
+// Since the endpoints of a RepoSync are set by the users of its namespace, the
+// Notifier of a RepoSync only connects to public IP addresses, so that it
+// can't be used to make requests to the Pods, Services or nodes of the
+// cluster, or to the metadata server.
+//
+// The caller must call Run to send the notifications.
+func New(endpoints []v1beta1.NotificationEndpoint, clusterName string, scope declared.Scope, syncName string) (*Notifier, error) {
+	n := &Notifier{
+		client:        &http.Client{Timeout: requestTimeout},
+		clock:         clock.RealClock{},
+		backoff:       util.HTTPRetryBackoff(),
+		clusterName:   clusterName,
+		syncKind:      scope.SyncKind(),
+		syncName:      syncName,
+		syncNamespace: scope.SyncNamespace(),
+	}
+	restricted := scope != declared.RootScope
+	if restricted {
+		n.client = util.PublicOnlyClient(n.client)
+	}
+	for _, e := range endpoints {
+		if e.Name == "" {
+			return nil, fmt.Errorf("notification endpoint with URL %q has no name", e.URL)
+		}
+		u, err := url.Parse(e.URL)
+		if err != nil {
+			return nil, fmt.Errorf("notification endpoint %q has an invalid URL: %w", e.Name, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("notification endpoint %q has an invalid URL: scheme must be http or https", e.Name)
+		}
+		if addr, err := netip.ParseAddr(u.Hostname()); err == nil && restricted && !util.IsPublicAddress(addr) {
+			return nil, fmt.Errorf("notification endpoint %q has an invalid URL: the address of a RepoSync endpoint must be public", e.Name)
+		}
+		switch e.Format {
+		case "":
+			e.Format = configsync.NotificationFormatGeneric
+		case configsync.NotificationFormatGeneric, configsync.NotificationFormatSlack, configsync.NotificationFormatTeams:
+		default:
+			return nil, fmt.Errorf("notification endpoint %q has an unsupported format %q", e.Name, e.Format)
+		}
+		triggers := e.Triggers
+		if len(triggers) == 0 {
+			triggers = []configsync.NotificationTrigger{
+				configsync.NotificationTriggerSynced,
+				configsync.NotificationTriggerFailed,
+				configsync.NotificationTriggerErrorsChanged,
+			}
+		}
+		ep := &endpoint{
+			NotificationEndpoint: e,
+			triggers:             map[configsync.NotificationTrigger]bool{},
+			queue:                make(chan *Notification, queueSize),
+			limiter:              rate.NewLimiter(rateLimit, rateBurst),
+		}
+		for _, t := range triggers {
+			ep.triggers[t] = true
+		}
+		n.endpoints = append(n.endpoints, ep)
+	}
+	return n, nil
+}
+
+// Run sends the queued notifications to the endpoints until the context is
+// cancelled.
+func (n *Notifier) Run(ctx context.Context) {
+	if n == nil {
+		return
+	}
+	var wg sync.WaitGroup
+	for _, ep := range n.endpoints {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			n.runEndpoint(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+}
+
+func (n *Notifier) runEndpoint(ctx context.Context, ep *endpoint) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-ep.queue:
+			if err := ep.limiter.Wait(ctx); err != nil {
+				return
+			}
+			if err := n.send(ctx, ep, notification); err != nil {
+				klog.Warningf("Failed to notify endpoint %q of %s commit %s: %v",
+					ep.Name, notification.Trigger, notification.Commit, err)
+			}
+		}
+	}
+}
+
+// SetSynced records that the commit synced without errors before the
+// Notifier was constructed, like before the reconciler restarted, so that the
+// commit is only notified again if its result changes.
+func (n *Notifier) SetSynced(commit string) {
+	if n == nil {
+		return
+	}
+	n.mux.Lock()
+	defer n.mux.Unlock()
+	if n.last == nil {
+		n.last = &lastResult{commit: commit}
+	}
+}
+
+// Notify compares the result of a sync attempt with the last result, and
+// queues a notification for the endpoints configured with the matching
+// trigger. Results with only transient errors are ignored, since they are
+// retried.
+func (n *Notifier) Notify(result Result) {
+	if n == nil || status.AllTransientErrors(result.Errs) {
+		return
+	}
+	errs := errorMessages(result.Errs)
+
+	n.mux.Lock()
+	trigger, changed := nextTrigger(n.last, result.Commit, errs)
+	n.last = &lastResult{commit: result.Commit, errs: errs}
+	n.mux.Unlock()
+
+	if !changed {
+		return
+	}
+	notification := n.newNotification(trigger, result)
+	for _, ep := range n.endpoints {
+		if !ep.triggers[trigger] {
+			continue
+		}
+		select {
+		case ep.queue <- notification:
+		default:
+			klog.Warningf("Dropped notification of %s commit %s: the queue of endpoint %q is full",
+				trigger, result.Commit, ep.Name)
+		}
+	}
+}
+
+// nextTrigger returns the trigger of a sync attempt, and whether its result
+// changed since the last notified sync attempt.
+func nextTrigger(last *lastResult, commit string, errs []string) (configsync.NotificationTrigger, bool) {
+	switch {
+	case last != nil && last.commit == commit && equalErrors(last.errs, errs):
+		return "", false
+	case len(errs) == 0:
+		return configsync.NotificationTriggerSynced, true
+	case last == nil || last.commit != commit || len(last.errs) == 0:
+		return configsync.NotificationTriggerFailed, true
+	default:
+		return configsync.NotificationTriggerErrorsChanged, true
+	}
+}
+
+func errorMessages(errs status.MultiError) []string {
+	if errs == nil {
+		return nil
+	}
+	var msgs []string
+	for _, err := range errs.Errors() {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+func equalErrors(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *Notifier) newNotification(trigger configsync.NotificationTrigger, result Result) *Notification {
+	cses := status.ToCSE(result.Errs)
+	errorSummary := &v1beta1.ErrorSummary{
+		TotalCount:                len(cses),
+		ErrorCountAfterTruncation: len(cses),
+	}
+	if len(cses) > maxErrors {
+		cses = cses[:maxErrors]
+		errorSummary.Truncated = true
+		errorSummary.ErrorCountAfterTruncation = maxErrors
+	}
+	return &Notification{
+		Trigger:       trigger,
+		Cluster:       n.clusterName,
+		SyncKind:      n.syncKind,
+		SyncName:      n.syncName,
+		SyncNamespace: n.syncNamespace,
+		Commit:        result.Commit,
+		ErrorSummary:  errorSummary,
+		Errors:        cses,
+		SyncStats:     newSyncStats(result.Stats),
+		Timestamp:     metav1.Time{Time: n.clock.Now()},
+	}
+}
+
+// send POSTs the notification to the endpoint, and retries with exponential
+// backoff if the request fails with a network error, a 429 or a 5xx response.
+func (n *Notifier) send(ctx context.Context, ep *endpoint, notification *Notification) error {
+	body, err := encode(ep.Format, notification)
+	if err != nil {
+		return err
+	}
+	return util.PostWithRetry(ctx, n.client, n.backoff, fmt.Sprintf("the notification of endpoint %q", ep.Name), ep.URL, "application/json", body)
+}
@@ -0,0 +1,381 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier/stats"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+)
+
+// testServer is a local stand-in for a notification endpoint, which responds
+// with the queued status codes, then with 200.
+type testServer struct {
+	*httptest.Server
+	requests chan []byte
+	statuses chan int
+}
+
+func newTestServer(t *testing.T) *testServer {
+	s := &testServer{
+		requests: make(chan []byte, 10),
+		statuses: make(chan int, 10),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading request body: %v", err)
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		s.requests <- body
+		select {
+		case code := <-s.statuses:
+			w.WriteHeader(code)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// next returns the body of the next request, or fails after a timeout.
+func (s *testServer) next(t *testing.T) []byte {
+	t.Helper()
+	select {
+	case body := <-s.requests:
+		return body
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a notification")
+		return nil
+	}
+}
+
+// expectNone fails if a request is received within a short period.
+func (s *testServer) expectNone(t *testing.T) {
+	t.Helper()
+	select {
+	case body := <-s.requests:
+		t.Fatalf("unexpected notification: %s", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func startNotifier(t *testing.T, endpoints ...v1beta1.NotificationEndpoint) *Notifier {
+	return startScopedNotifier(t, declared.RootScope, endpoints...)
+}
+
+func startScopedNotifier(t *testing.T, scope declared.Scope, endpoints ...v1beta1.NotificationEndpoint) *Notifier {
+	n, err := New(endpoints, "test-cluster", scope, configsync.RootSyncName)
+	require.NoError(t, err)
+	n.backoff = wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		n.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return n
+}
+
+func decode(t *testing.T, body []byte) *Notification {
+	t.Helper()
+	n := &Notification{}
+	require.NoError(t, json.Unmarshal(body, n))
+	return n
+}
+
+func TestNotifier_Notify(t *testing.T) {
+	server := newTestServer(t)
+	n := startNotifier(t, v1beta1.NotificationEndpoint{Name: "generic", URL: server.URL})
+
+	errA := status.InternalError("a")
+	errB := status.InternalError("b")
+
+	n.Notify(Result{Commit: "abc", Errs: status.Wrap(errA)})
+	got := decode(t, server.next(t))
+	assert.Equal(t, configsync.NotificationTriggerFailed, got.Trigger)
+	assert.Equal(t, "test-cluster", got.Cluster)
+	assert.Equal(t, "RootSync", got.SyncKind)
+	assert.Equal(t, configsync.RootSyncName, got.SyncName)
+	assert.Equal(t, configsync.ControllerNamespace, got.SyncNamespace)
+	assert.Equal(t, "abc", got.Commit)
+	assert.Equal(t, &v1beta1.ErrorSummary{TotalCount: 1, ErrorCountAfterTruncation: 1}, got.ErrorSummary)
+	assert.Equal(t, status.ToCSE(errA), got.Errors)
+
+	// The same result is only notified once.
+	n.Notify(Result{Commit: "abc", Errs: status.Wrap(errA)})
+	// Transient errors are ignored.
+	n.Notify(Result{Commit: "abc", Errs: status.TransientError(errors.New("retry"))})
+	server.expectNone(t)
+
+	n.Notify(Result{Commit: "abc", Errs: status.Wrap(errA, errB)})
+	got = decode(t, server.next(t))
+	assert.Equal(t, configsync.NotificationTriggerErrorsChanged, got.Trigger)
+	assert.Equal(t, 2, got.ErrorSummary.TotalCount)
+
+	syncStats := stats.NewSyncStats().
+		WithApplyEvents(event.ApplySuccessful, 3).
+		WithPruneEvents(event.PruneSuccessful, 1)
+	n.Notify(Result{Commit: "def", Stats: syncStats})
+	got = decode(t, server.next(t))
+	assert.Equal(t, configsync.NotificationTriggerSynced, got.Trigger)
+	assert.Equal(t, "def", got.Commit)
+	assert.Equal(t, &v1beta1.ErrorSummary{}, got.ErrorSummary)
+	assert.Empty(t, got.Errors)
+	assert.Equal(t, &SyncStats{
+		Apply: map[string]uint64{"Successful": 3},
+		Prune: map[string]uint64{"Successful": 1},
+	}, got.SyncStats)
+}
+
+func TestNotifier_Triggers(t *testing.T) {
+	server := newTestServer(t)
+	n := startNotifier(t, v1beta1.NotificationEndpoint{
+		Name:     "failures",
+		URL:      server.URL,
+		Triggers: []configsync.NotificationTrigger{configsync.NotificationTriggerFailed},
+	})
+
+	n.Notify(Result{Commit: "abc"})
+	server.expectNone(t)
+
+	n.Notify(Result{Commit: "def", Errs: status.InternalError("a")})
+	got := decode(t, server.next(t))
+	assert.Equal(t, configsync.NotificationTriggerFailed, got.Trigger)
+	assert.Equal(t, "def", got.Commit)
+}
+
+func TestNotifier_SetSynced(t *testing.T) {
+	server := newTestServer(t)
+	n := startNotifier(t, v1beta1.NotificationEndpoint{Name: "generic", URL: server.URL})
+	n.SetSynced("abc")
+
+	n.Notify(Result{Commit: "abc"})
+	server.expectNone(t)
+
+	n.Notify(Result{Commit: "abc", Errs: status.InternalError("a")})
+	got := decode(t, server.next(t))
+	assert.Equal(t, configsync.NotificationTriggerFailed, got.Trigger)
+}
+
+func TestNotifier_RepoSyncPublicOnly(t *testing.T) {
+	server := newTestServer(t)
+	n := startScopedNotifier(t, declared.Scope("bookstore"), v1beta1.NotificationEndpoint{
+		Name: "local",
+		// The host name resolves to the loopback address of the test server.
+		URL: strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+	})
+
+	n.Notify(Result{Commit: "abc"})
+	server.expectNone(t)
+}
+
+func TestNotifier_Retry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		statuses     []int
+		wantRequests int
+	}{
+		{
+			name:         "retry server errors",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+			wantRequests: 3,
+		},
+		{
+			name:         "don't retry client errors",
+			statuses:     []int{http.StatusBadRequest},
+			wantRequests: 1,
+		},
+		{
+			name:         "give up after the backoff steps",
+			statuses:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantRequests: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestServer(t)
+			for _, code := range tc.statuses {
+				server.statuses <- code
+			}
+			n := startNotifier(t, v1beta1.NotificationEndpoint{Name: "generic", URL: server.URL})
+
+			n.Notify(Result{Commit: "abc"})
+			for i := 0; i < tc.wantRequests; i++ {
+				got := decode(t, server.next(t))
+				assert.Equal(t, "abc", got.Commit)
+			}
+			server.expectNone(t)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name      string
+		scope     declared.Scope
+		endpoint  v1beta1.NotificationEndpoint
+		wantError string
+	}{
+		{
+			name:     "valid endpoint",
+			endpoint: v1beta1.NotificationEndpoint{Name: "slack", URL: "https://hooks.example.com/abc", Format: configsync.NotificationFormatSlack},
+		},
+		{
+			name:      "missing name",
+			endpoint:  v1beta1.NotificationEndpoint{URL: "https://hooks.example.com/abc"},
+			wantError: `notification endpoint with URL "https://hooks.example.com/abc" has no name`,
+		},
+		{
+			name:      "unsupported scheme",
+			endpoint:  v1beta1.NotificationEndpoint{Name: "ftp", URL: "ftp://hooks.example.com/abc"},
+			wantError: `notification endpoint "ftp" has an invalid URL: scheme must be http or https`,
+		},
+		{
+			name:     "private address of a RootSync endpoint",
+			endpoint: v1beta1.NotificationEndpoint{Name: "internal", URL: "http://10.0.0.1:8080/hook"},
+		},
+		{
+			name:      "private address of a RepoSync endpoint",
+			scope:     declared.Scope("bookstore"),
+			endpoint:  v1beta1.NotificationEndpoint{Name: "internal", URL: "http://10.0.0.1:8080/hook"},
+			wantError: `notification endpoint "internal" has an invalid URL: the address of a RepoSync endpoint must be public`,
+		},
+		{
+			name:      "metadata server address of a RepoSync endpoint",
+			scope:     declared.Scope("bookstore"),
+			endpoint:  v1beta1.NotificationEndpoint{Name: "metadata", URL: "http://[::ffff:169.254.169.254]/"},
+			wantError: `notification endpoint "metadata" has an invalid URL: the address of a RepoSync endpoint must be public`,
+		},
+		{
+			name:     "host name of a RepoSync endpoint",
+			scope:    declared.Scope("bookstore"),
+			endpoint: v1beta1.NotificationEndpoint{Name: "slack", URL: "https://hooks.example.com/abc"},
+		},
+		{
+			name:      "unsupported format",
+			endpoint:  v1beta1.NotificationEndpoint{Name: "email", URL: "https://hooks.example.com/abc", Format: "email"},
+			wantError: `notification endpoint "email" has an unsupported format "email"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scope := tc.scope
+			if scope == "" {
+				scope = declared.RootScope
+			}
+			_, err := New([]v1beta1.NotificationEndpoint{tc.endpoint}, "", scope, configsync.RootSyncName)
+			if tc.wantError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestNilNotifier(t *testing.T) {
+	var n *Notifier
+	n.Notify(Result{Commit: "abc"})
+	n.Run(context.Background())
+}
+
+func TestEncode(t *testing.T) {
+	failed := &Notification{
+		Trigger:       configsync.NotificationTriggerFailed,
+		Cluster:       "test-cluster",
+		SyncKind:      "RepoSync",
+		SyncName:      "repo-sync",
+		SyncNamespace: "bookstore",
+		Commit:        "abc",
+		ErrorSummary:  &v1beta1.ErrorSummary{TotalCount: 4, ErrorCountAfterTruncation: 4},
+		Errors: []v1beta1.ConfigSyncError{
+			{ErrorMessage: "KNV1: one\n\nFor more information, see https://g.co/cloud/acm-errors#knv1"},
+			{ErrorMessage: "KNV2: two"},
+			{ErrorMessage: "KNV3: three"},
+			{ErrorMessage: "KNV4: four"},
+		},
+	}
+	synced := &Notification{
+		Trigger:       configsync.NotificationTriggerSynced,
+		SyncKind:      "RootSync",
+		SyncName:      "root-sync",
+		SyncNamespace: configsync.ControllerNamespace,
+		Commit:        "def",
+		ErrorSummary:  &v1beta1.ErrorSummary{},
+		SyncStats:     newSyncStats(stats.NewSyncStats().WithApplyEvents(event.ApplySuccessful, 2)),
+	}
+
+	testCases := []struct {
+		name         string
+		format       configsync.NotificationFormat
+		notification *Notification
+		want         string
+	}{
+		{
+			name:         "slack failed",
+			format:       configsync.NotificationFormatSlack,
+			notification: failed,
+			want:         `{"text":"*RepoSync bookstore/repo-sync on cluster test-cluster failed to sync commit abc*\n4 errors:\n- KNV1: one\n- KNV2: two\n- KNV3: three\n- and 1 more"}`,
+		},
+		{
+			name:         "slack synced",
+			format:       configsync.NotificationFormatSlack,
+			notification: synced,
+			want:         `{"text":"*RootSync config-management-system/root-sync synced commit def*\nApplyEvents: 2 (Successful: 2)"}`,
+		},
+		{
+			name:         "teams synced",
+			format:       configsync.NotificationFormatTeams,
+			notification: synced,
+			want:         `{"@type":"MessageCard","@context":"https://schema.org/extensions","themeColor":"2EB886","summary":"RootSync config-management-system/root-sync synced commit def","title":"RootSync config-management-system/root-sync synced commit def","text":"ApplyEvents: 2 (Successful: 2)"}`,
+		},
+		{
+			name:         "generic synced",
+			format:       configsync.NotificationFormatGeneric,
+			notification: synced,
+			want:         `{"trigger":"Synced","syncKind":"RootSync","syncName":"root-sync","syncNamespace":"config-management-system","commit":"def","errorSummary":{},"syncStats":{"apply":{"Successful":2}},"timestamp":null}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := encode(tc.format, tc.notification)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier/stats"
+)
+
+const (
+	// maxErrors is the maximum number of errors included in a notification.
+	maxErrors = 10
+	// maxMessageErrors is the maximum number of errors included in the text
+	// of a Slack or Teams message.
+	maxMessageErrors = 3
+)
+
+// Notification is the payload POSTed to the endpoints with the generic
+// format.
+type Notification struct {
+	// Trigger is the sync result being notified.
+	Trigger configsync.NotificationTrigger `json:"trigger"`
+	// Cluster is the name of the cluster, if known.
+	Cluster string `json:"cluster,omitempty"`
+	// SyncKind is the kind of the RSync: RootSync or RepoSync.
+	SyncKind string `json:"syncKind"`
+	// SyncName is the name of the RSync.
+	SyncName string `json:"syncName"`
+	// SyncNamespace is the namespace of the RSync.
+	SyncNamespace string `json:"syncNamespace"`
+	// Commit is the source commit of the sync attempt.
+	Commit string `json:"commit"`
+	// ErrorSummary summarizes the errors of the sync attempt.
+	ErrorSummary *v1beta1.ErrorSummary `json:"errorSummary"`
+	// Errors are the errors of the sync attempt, truncated to the first 10.
+	Errors []v1beta1.ConfigSyncError `json:"errors,omitempty"`
+	// SyncStats are the stats of the apply, if the sync attempt reached the
+	// apply.
+	SyncStats *SyncStats `json:"syncStats,omitempty"`
+	// Timestamp is when the result of the sync attempt was notified.
+	Timestamp metav1.Time `json:"timestamp"`
+}
+
+// SyncStats are the number of objects per apply, prune, delete and wait
+// status.
+type SyncStats struct {
+	Apply       map[string]uint64 `json:"apply,omitempty"`
+	Prune       map[string]uint64 `json:"prune,omitempty"`
+	Delete      map[string]uint64 `json:"delete,omitempty"`
+	Wait        map[string]uint64 `json:"wait,omitempty"`
+	ErrorEvents uint64            `json:"errorEvents,omitempty"`
+	// summary is the human readable summary of the stats, used by the Slack
+	// and Teams messages.
+	summary string
+}
+
+func newSyncStats(s *stats.SyncStats) *SyncStats {
+	if s.Empty() {
+		return nil
+	}
+	result := &SyncStats{
+		ErrorEvents: s.ErrorTypeEvents,
+		summary:     s.String(),
+	}
+	if !s.ApplyEvent.Empty() {
+		result.Apply = map[string]uint64{}
+		for k, v := range s.ApplyEvent.EventByOp {
+			result.Apply[k.String()] = v
+		}
+	}
+	if !s.PruneEvent.Empty() {
+		result.Prune = map[string]uint64{}
+		for k, v := range s.PruneEvent.EventByOp {
+			result.Prune[k.String()] = v
+		}
+	}
+	if !s.DeleteEvent.Empty() {
+		result.Delete = map[string]uint64{}
+		for k, v := range s.DeleteEvent.EventByOp {
+			result.Delete[k.String()] = v
+		}
+	}
+	if !s.WaitEvent.Empty() {
+		result.Wait = map[string]uint64{}
+		for k, v := range s.WaitEvent.EventByOp {
+			result.Wait[k.String()] = v
+		}
+	}
+	return result
+}
+
+// slackMessage is the payload of a Slack incoming webhook.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// teamsMessageCard is the payload of a Microsoft Teams incoming webhook.
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	ThemeColor string `json:"themeColor"`
+	Summary    string `json:"summary"`
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+}
+
+// encode encodes the notification in the format of the endpoint.
+func encode(format configsync.NotificationFormat, n *Notification) ([]byte, error) {
+	switch format {
+	case configsync.NotificationFormatSlack:
+		return json.Marshal(slackMessage{
+			Text: fmt.Sprintf("*%s*\n%s", n.title(), n.text()),
+		})
+	case configsync.NotificationFormatTeams:
+		color := "2EB886" // green
+		if n.Trigger != configsync.NotificationTriggerSynced {
+			color = "A30200" // red
+		}
+		return json.Marshal(teamsMessageCard{
+			Type:       "MessageCard",
+			Context:    "https://schema.org/extensions",
+			ThemeColor: color,
+			Summary:    n.title(),
+			Title:      n.title(),
+			// Teams renders the text as markdown, which needs blank lines
+			// between paragraphs.
+			Text: strings.ReplaceAll(n.text(), "\n", "\n\n"),
+		})
+	default:
+		return json.Marshal(n)
+	}
+}
+
+// title returns a one-line description of the notification.
+func (n *Notification) title() string {
+	sync := fmt.Sprintf("%s %s/%s", n.SyncKind, n.SyncNamespace, n.SyncName)
+	if n.Cluster != "" {
+		sync = fmt.Sprintf("%s on cluster %s", sync, n.Cluster)
+	}
+	switch n.Trigger {
+	case configsync.NotificationTriggerSynced:
+		return fmt.Sprintf("%s synced commit %s", sync, n.Commit)
+	case configsync.NotificationTriggerFailed:
+		return fmt.Sprintf("%s failed to sync commit %s", sync, n.Commit)
+	default:
+		return fmt.Sprintf("%s errors changed for commit %s", sync, n.Commit)
+	}
+}
+
+// text returns the details of the notification: the sync stats and the first
+// few errors.
+func (n *Notification) text() string {
+	var lines []string
+	if n.SyncStats != nil {
+		lines = append(lines, n.SyncStats.summary)
+	}
+	if n.ErrorSummary.TotalCount > 0 {
+		lines = append(lines, fmt.Sprintf("%d errors:", n.ErrorSummary.TotalCount))
+		for i, err := range n.Errors {
+			if i == maxMessageErrors {
+				lines = append(lines, fmt.Sprintf("- and %d more", n.ErrorSummary.TotalCount-maxMessageErrors))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("- %s", firstLine(err.ErrorMessage)))
+		}
+	}
+	if len(lines) == 0 {
+		return "No changes."
+	}
+	return strings.Join(lines, "\n")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/sourcemetadata"
 )
 
 const (
@@ -90,9 +91,27 @@ func (f *Fetcher) FetchPackage(ctx context.Context, imageName, ociRoot, rev stri
 	}
 
 	klog.Infof("pulled image digest %q", imageDigestHash)
+	writeSourceMetadata(image, ociRoot, imageDigestHash.Hex)
 	return util.UpdateSymlink(ociRoot, linkPath, destDir, oldDir)
 }
 
+// writeSourceMetadata records the creation time of the image as the commit
+// time. It is best effort, since the commit time is only used for metrics
+// and status: the image is synced without it.
+func writeSourceMetadata(image v1.Image, ociRoot, digest string) {
+	metadata := sourcemetadata.Metadata{Commit: digest}
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		klog.Warningf("failed to read the config of the image %q: %v", digest, err)
+	} else if created := configFile.Created.Time; created.Unix() > 0 {
+		// Reproducible builds, like ko, set the creation time to the epoch.
+		metadata.CommitTime = &created
+	}
+	if err := sourcemetadata.Write(ociRoot, metadata); err != nil {
+		klog.Warningf("failed to record the metadata of the image %q: %v", digest, err)
+	}
+}
+
 // PullImage pulls image from source using provided options for auth credentials
 func PullImage(imageName string, options ...remote.Option) (v1.Image, error) {
 	ref, err := name.ParseReference(imageName)
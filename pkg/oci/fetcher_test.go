@@ -15,9 +15,18 @@
 package oci
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/util/sourcemetadata"
 )
 
 func TestHasDigest(t *testing.T) {
@@ -48,3 +57,33 @@ func TestHasDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteSourceMetadata(t *testing.T) {
+	created := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	testCases := map[string]struct {
+		created  time.Time
+		expected time.Time
+	}{
+		"creation time": {
+			created:  created,
+			expected: created,
+		},
+		"reproducible build": {
+			created: time.Unix(0, 0),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			image, err := mutate.CreatedAt(empty.Image, v1.Time{Time: tc.created})
+			require.NoError(t, err)
+			root := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(root, "abc123"), 0755))
+			require.NoError(t, os.Symlink(filepath.Join(root, "abc123"), filepath.Join(root, "rev")))
+
+			writeSourceMetadata(image, root, "abc123")
+			commitTime, err := sourcemetadata.CommitTime(configsync.OciSource, filepath.Join(root, "rev"), "abc123")
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(commitTime), "expected %v, got %v", tc.expected, commitTime)
+		})
+	}
+}
@@ -61,9 +61,11 @@ type cacheForCommit struct {
 // file cache.
 func (c *cacheForCommit) UpdateParseResult(objs []ast.FileObject, parserErrs status.MultiError, now metav1.Time) {
 	knownScopeObjs, unknownScopeObjs := splitObjects(objs)
+	hooks, objsToApply := splitHooks(knownScopeObjs)
 	c.parse = &parseResult{
 		objsSkipped:    unknownScopeObjs,
-		objsToApply:    knownScopeObjs,
+		objsToApply:    objsToApply,
+		hooks:          hooks,
 		parserErrs:     parserErrs,
 		lastUpdateTime: now,
 	}
@@ -88,6 +90,20 @@ func splitObjects(objs []ast.FileObject) ([]ast.FileObject, []ast.FileObject) {
 	return knownScopeObjs, unknownScopeObjs
 }
 
+// splitHooks splits `objs` into two groups: the hooks, which run around the
+// apply instead of being sent to the applier, and the other objects.
+func splitHooks(objs []ast.FileObject) ([]ast.FileObject, []ast.FileObject) {
+	var hooks, others []ast.FileObject
+	for _, obj := range objs {
+		if metadata.IsHook(obj) {
+			hooks = append(hooks, obj)
+		} else {
+			others = append(others, obj)
+		}
+	}
+	return hooks, others
+}
+
 type parseResult struct {
 	// objsSkipped contains the objects which will not be sent to the applier to apply.
 	// For example, the objects whose scope is unknown will not be sent to the applier since
@@ -97,6 +113,10 @@ type parseResult struct {
 	// objsToApply contains the objects which will be sent to the applier to apply.
 	objsToApply []ast.FileObject
 
+	// hooks contains the hook objects, which are run around the apply by the
+	// Updater instead of being sent to the applier.
+	hooks []ast.FileObject
+
 	// parserErrs includes the parser errors.
 	parserErrs status.MultiError
 
@@ -19,6 +19,7 @@ import (
 
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/parse/events"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/status"
 )
@@ -29,14 +30,18 @@ type EventHandler struct {
 	Context           context.Context
 	Reconciler        Reconciler
 	NSControllerState *namespacecontroller.State
+	// ClusterLabelsState is the state of the cluster label controller, if the
+	// cluster labels are read from a cluster-local object.
+	ClusterLabelsState *clusterlabelcontroller.State
 }
 
 // NewEventHandler builds an EventHandler
-func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *namespacecontroller.State) *EventHandler {
+func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *namespacecontroller.State, clusterLabelsState *clusterlabelcontroller.State) *EventHandler {
 	return &EventHandler{
-		Context:           ctx,
-		Reconciler:        r,
-		NSControllerState: nsControllerState,
+		Context:            ctx,
+		Reconciler:         r,
+		NSControllerState:  nsControllerState,
+		ClusterLabelsState: clusterLabelsState,
 	}
 }
 
@@ -45,6 +50,7 @@ func NewEventHandler(ctx context.Context, r Reconciler, nsControllerState *names
 // - SyncEventType          - Sync from the cache, priming the cache from disk, if necessary.
 // - StatusUpdateEventType  - Update the RSync status with status from the Remediator & NSController.
 // - NamespaceSyncEventType - Sync from the cache, if the NSController requested one.
+// - ClusterLabelsSyncEventType - Sync from the cache, if the cluster labels changed.
 // - RetrySyncEventType     - Sync from the cache, if one of the following cases is detected:
 //   - Remediator or Reconciler reported a management conflict
 //   - Reconciler requested a retry due to error
@@ -86,6 +92,14 @@ func (s *EventHandler) Handle(event events.Event) events.Result {
 		}
 		runResult = runFn(ctx, triggerNamespaceUpdate)
 
+	case events.ClusterLabelsSyncEventType:
+		// FullSync if the cluster label controller detected a change.
+		if !s.ClusterLabelsState.ScheduleSync() {
+			// No RunFunc call
+			break
+		}
+		runResult = runFn(ctx, triggerClusterLabelsUpdate)
+
 	case events.RetrySyncEventType:
 		// Retry if there was an error, conflict, or any watches need to be updated.
 		var trigger string
@@ -24,6 +24,7 @@
 // Events and their Publisher:
 // - SyncEvent             - ResetOnRunAttemptPublisher (SyncPeriod)
 // - NamespaceResyncEvent  - TimeDelayPublisher (NamespaceControllerPeriod)
+// - ClusterLabelsSyncEvent - TimeDelayPublisher (ClusterLabelsControllerPeriod)
 // - RetrySyncEvent        - RetrySyncPublisher (RetryBackoff)
 // - StatusEvent           - TimeDelayPublisher (StatusUpdatePeriod)
 //
@@ -33,6 +33,9 @@ const (
 	// NamespaceSyncEventType is the EventType for a sync triggered by an
 	// update to a selected namespace.
 	NamespaceSyncEventType EventType = "NamespaceSyncEvent"
+	// ClusterLabelsSyncEventType is the EventType for a sync triggered by an
+	// update to the cluster labels matched by ClusterSelectors.
+	ClusterLabelsSyncEventType EventType = "ClusterLabelsSyncEvent"
 	// RetrySyncEventType is the EventType for a sync triggered by an error
 	// during a previous sync attempt.
 	RetrySyncEventType EventType = "RetrySyncEvent"
@@ -18,8 +18,11 @@ import (
 	"time"
 
 	"k8s.io/utils/clock"
+	"kpt.dev/configsync/pkg/commitstatus"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/notifier"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -66,11 +69,30 @@ type Options struct {
 	// WebhookEnabled indicates whether the Webhook is currently enabled
 	WebhookEnabled bool
 
+	// Variables are the values of the `${VAR}` placeholders substituted in the
+	// declared objects. Substitution is disabled when nil.
+	Variables map[string]string
+
 	// DeclaredResources is the set of valid source objects, managed by the
 	// Updater and shared with the Parser & Remediator.
 	// This is used by the Parser to validate that CRDs can only be removed from
 	// the source when all of its CRs are removed as well.
 	DeclaredResources *declared.Resources
+
+	// EventRecorder records Events on the RootSync or RepoSync when a new
+	// commit is fetched and when rendering succeeds or fails.
+	// No Events are recorded if nil.
+	EventRecorder *eventrecorder.Recorder
+
+	// Notifier notifies the configured HTTP endpoints when a commit syncs,
+	// fails to sync, or when the errors of a failing commit change.
+	// No notifications are sent if nil.
+	Notifier *notifier.Notifier
+
+	// CommitStatus posts the commit statuses of the synced commits to the Git
+	// provider.
+	// No commit statuses are posted if nil.
+	CommitStatus *commitstatus.Reporter
 }
 
 // ReconcilerOptions holds configuration for the reconciler.
@@ -82,7 +82,9 @@ func (p *repoSyncStatusClient) setSourceStatusWithRetries(ctx context.Context, n
 	reposync.SetSyncing(&rs, continueSyncing, "Source", "Source", newStatus.Commit, errorSource, rs.Status.Source.ErrorSummary, newStatus.LastUpdate)
 
 	// Avoid unnecessary status updates.
-	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
+	// The last fetch time is refreshed periodically, so its updates aren't ignored.
+	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) &&
+		currentRS.Status.Source.LastFetchTime.Equal(rs.Status.Source.LastFetchTime) {
 		klog.V(5).Infof("Skipping source status update for RepoSync %s/%s", rs.Namespace, rs.Name)
 		return nil
 	}
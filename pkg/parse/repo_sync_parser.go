@@ -22,6 +22,7 @@ import (
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 	utildiscovery "kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
 )
@@ -63,11 +64,14 @@ func (p *repoSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 		AllowAPICall:             false,
 		DynamicNSSelectorEnabled: false,
 		WebhookEnabled:           opts.WebhookEnabled,
+		Variables:                opts.Variables,
 		FieldManager:             configsync.FieldManager,
 	}
 	options = OptionsForScope(options, opts.Scope)
 
-	objs, err = validate.Unstructured(ctx, opts.Client, objs, options)
+	validateCtx, span := tracing.Start(ctx, "Validate")
+	objs, err = validate.Unstructured(validateCtx, opts.Client, objs, options)
+	tracing.End(span, err)
 
 	if status.HasBlockingErrors(err) {
 		return nil, err
@@ -21,8 +21,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -695,7 +694,7 @@ func TestRootReconciler_ParseAndUpdate(t *testing.T) {
 						files:    files,
 					},
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 			}
 			opts := &Options{
 				Clock:             fakeClock,
@@ -942,7 +941,7 @@ func TestRootReconciler_Parse_DeclaredFields(t *testing.T) {
 				cache: cacheForCommit{
 					source: &sourceState{},
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1230,7 +1229,7 @@ func TestRootReconciler_Parse_Discovery(t *testing.T) {
 				cache: cacheForCommit{
 					source: &sourceState{},
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1289,7 +1288,7 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 		name            string
 		parseErrors     status.MultiError
 		expectedError   status.MultiError
-		expectedMetrics []*view.Row
+		expectedMetrics []*testmetrics.Row
 	}{
 		{
 			name: "single reconciler error in source component",
@@ -1299,10 +1298,10 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 			expectedError: status.Wrap(
 				status.SourceError.Sprintf("source error").Build(),
 			),
-			expectedMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
+			expectedMetrics: []*testmetrics.Row{
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("9xxx")}},
 			},
 		},
 		{
@@ -1315,10 +1314,10 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 				status.SourceError.Sprintf("source error").Build(),
 				status.InternalError("internal error"),
 			),
-			expectedMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
+			expectedMetrics: []*testmetrics.Row{
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("9xxx")}},
 			},
 		},
 	}
@@ -1326,7 +1325,7 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			require.Error(t, tc.parseErrors)
-			m := testmetrics.RegisterMetrics(metrics.ReconcilerErrorsView)
+			m := testmetrics.RegisterMetrics()
 			fakeConfigParser := &fsfake.ConfigParser{
 				Outputs: []fsfake.ParserOutputs{
 					// One Parse call, with errors
@@ -1343,7 +1342,7 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 				cache: cacheForCommit{
 					source: &sourceState{},
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1388,7 +1387,7 @@ func TestRootReconciler_Parse_SourceErrorMetricValidation(t *testing.T) {
 			errs := reconciler.parse(ctx, trigger)
 			testerrors.AssertEqual(t, tc.expectedError, errs, "expected parse errors to match")
 
-			if diff := m.ValidateMetrics(metrics.ReconcilerErrorsView, tc.expectedMetrics); diff != "" {
+			if diff := m.ValidateMetrics(metrics.ReconcilerErrorsName, tc.expectedMetrics); diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -1400,7 +1399,7 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 		name            string
 		applyErrors     []status.Error
 		expectedError   status.MultiError
-		expectedMetrics []*view.Row
+		expectedMetrics []*testmetrics.Row
 	}{
 		{
 			name: "single reconciler error in sync component",
@@ -1408,13 +1407,13 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 				applier.Error(errors.New("sync error")),
 			},
 			expectedError: applier.Error(errors.New("sync error")),
-			expectedMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
+			expectedMetrics: []*testmetrics.Row{
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("9xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("9xxx")}},
 			},
 		},
 		{
@@ -1427,20 +1426,20 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 				applier.Error(errors.New("sync error")),
 				status.InternalError("internal error"),
 			),
-			expectedMetrics: []*view.Row{
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "source"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
-				{Data: &view.LastValueData{Value: 0}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "1xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "2xxx"}}},
-				{Data: &view.LastValueData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyComponent, Value: "sync"}, {Key: metrics.KeyErrorClass, Value: "9xxx"}}},
+			expectedMetrics: []*testmetrics.Row{
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("source"), metrics.KeyErrorClass.String("9xxx")}},
+				{Value: 0, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("1xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("2xxx")}},
+				{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyComponent.String("sync"), metrics.KeyErrorClass.String("9xxx")}},
 			},
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			m := testmetrics.RegisterMetrics(metrics.ReconcilerErrorsView)
+			m := testmetrics.RegisterMetrics()
 			fakeConfigParser := &fsfake.ConfigParser{
 				Outputs: []fsfake.ParserOutputs{
 					{}, // One Parse call, no errors
@@ -1456,7 +1455,7 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 				cache: cacheForCommit{
 					source: &sourceState{},
 				},
-				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+				syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 			}
 			opts := &Options{
 				Clock:             clock.RealClock{}, // TODO: Test with fake clock
@@ -1503,7 +1502,7 @@ func TestRootReconciler_Update_ApplierErrorMetricValidation(t *testing.T) {
 			errs = reconciler.update(ctx, trigger)
 			testerrors.AssertEqual(t, tc.expectedError, errs, "expected update errors to match")
 
-			if diff := m.ValidateMetrics(metrics.ReconcilerErrorsView, tc.expectedMetrics); diff != "" {
+			if diff := m.ValidateMetrics(metrics.ReconcilerErrorsName, tc.expectedMetrics); diff != "" {
 				t.Error(diff)
 			}
 		})
@@ -32,6 +32,7 @@ import (
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
 	"kpt.dev/configsync/pkg/rootsync"
 	"kpt.dev/configsync/pkg/status"
@@ -66,6 +67,12 @@ type RootOptions struct {
 	// Only Root reconciler may have Namespace Controller state because
 	// RepoSync can't manage NamespaceSelectors.
 	NSControllerState *namespacecontroller.State
+
+	// ClusterLabelsState stores the cluster labels read from a cluster-local
+	// object by the cluster label controller, and whether it schedules a sync
+	// event for the reconciler thread because they changed.
+	// Nil if ClusterSelectors only match the declared Cluster objects.
+	ClusterLabelsState *clusterlabelcontroller.State
 }
 
 type rootSyncStatusClient struct {
@@ -104,7 +111,9 @@ func (p *rootSyncStatusClient) setSourceStatusWithRetries(ctx context.Context, n
 	rootsync.SetSyncing(&rs, continueSyncing, "Source", "Source", newStatus.Commit, errorSource, rs.Status.Source.ErrorSummary, newStatus.LastUpdate)
 
 	// Avoid unnecessary status updates.
-	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) {
+	// The last fetch time is refreshed periodically, so its updates aren't ignored.
+	if !currentRS.Status.Source.LastUpdate.IsZero() && cmp.Equal(currentRS.Status, rs.Status, compare.IgnoreTimestampUpdates) &&
+		currentRS.Status.Source.LastFetchTime.Equal(rs.Status.Source.LastFetchTime) {
 		klog.V(5).Infof("Skipping source status update for RootSync %s/%s", rs.Namespace, rs.Name)
 		return nil
 	}
@@ -173,9 +182,27 @@ func setSourceStatusFields(source *v1beta1.SourceStatus, newStatus *SourceStatus
 	}
 	source.Errors = cse[0 : len(cse)/denominator]
 	source.ErrorSummary = errorSummary
+	source.CommitTime = optionalTime(newStatus.CommitTime)
+	source.LastFetchTime = optionalTime(newStatus.LastFetchTime)
 	source.LastUpdate = newStatus.LastUpdate
 }
 
+// optionalTime returns a pointer to the time, or nil if the time is zero.
+func optionalTime(t metav1.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return t.DeepCopy()
+}
+
+// timeValue returns the time, or the zero time if nil.
+func timeValue(t *metav1.Time) metav1.Time {
+	if t == nil {
+		return metav1.Time{}
+	}
+	return *t.DeepCopy()
+}
+
 func (p *rootSyncStatusClient) SetImageToSyncAnnotation(ctx context.Context, commit string) status.Error {
 	opts := p.options
 	rs := &v1beta1.RootSync{}
@@ -431,12 +458,14 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 
 	return &ReconcilerStatus{
 		SourceStatus: &SourceStatus{
-			Spec:   sourceSpec,
-			Commit: rsyncStatus.Source.Commit,
+			Spec:       sourceSpec,
+			Commit:     rsyncStatus.Source.Commit,
+			CommitTime: timeValue(rsyncStatus.Source.CommitTime),
 			// Can't parse errors.
 			// Errors will be reset the next time the reconciler updates the status.
-			Errs:       nil,
-			LastUpdate: rsyncStatus.Source.LastUpdate,
+			Errs:          nil,
+			LastFetchTime: timeValue(rsyncStatus.Source.LastFetchTime),
+			LastUpdate:    rsyncStatus.Source.LastUpdate,
 		},
 		RenderingStatus: &RenderingStatus{
 			Spec:    renderSpec,
@@ -451,14 +480,17 @@ func reconcilerStatusFromRSyncStatus(rsyncStatus v1beta1.Status, sourceType conf
 			RequiresRendering: false,
 		},
 		SyncStatus: &SyncStatus{
-			Spec:    syncSpec,
-			Syncing: syncing,
-			Commit:  rsyncStatus.Sync.Commit,
+			Spec:       syncSpec,
+			Syncing:    syncing,
+			Commit:     rsyncStatus.Sync.Commit,
+			CommitTime: timeValue(rsyncStatus.Sync.CommitTime),
 			// Can't parse errors.
 			// Errors will be reset the next time the reconciler updates the status.
 			Errs:       nil,
+			Hooks:      rsyncStatus.Sync.Hooks,
 			LastUpdate: rsyncStatus.Sync.LastUpdate,
 		},
+		LastSyncedCommit: rsyncStatus.LastSyncedCommit,
 	}
 }
 
@@ -541,10 +573,12 @@ func (p *rootSyncStatusClient) setSyncStatusWithRetries(ctx context.Context, new
 func setSyncStatusFields(syncStatus *v1beta1.Status, newStatus *SyncStatus, denominator int) {
 	cse := status.ToCSE(newStatus.Errs)
 	syncStatus.Sync.Commit = newStatus.Commit
+	syncStatus.Sync.CommitTime = optionalTime(newStatus.CommitTime)
 	syncStatus.Sync.Git = syncStatus.Source.Git
 	syncStatus.Sync.Oci = syncStatus.Source.Oci
 	syncStatus.Sync.Helm = syncStatus.Source.Helm
 	setSyncStatusErrors(syncStatus, cse, denominator)
+	syncStatus.Sync.Hooks = newStatus.Hooks
 	syncStatus.Sync.LastUpdate = newStatus.LastUpdate
 }
 
@@ -16,6 +16,7 @@ package parse
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/elliotchance/orderedmap/v2"
@@ -33,6 +34,7 @@ import (
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util/discovery"
 	"kpt.dev/configsync/pkg/validate"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -84,18 +86,30 @@ func (p *rootSyncParser) ParseSource(ctx context.Context, state *sourceState) ([
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
 		NSControllerState:        opts.NSControllerState,
 		WebhookEnabled:           opts.WebhookEnabled,
+		Variables:                opts.Variables,
 		FieldManager:             configsync.FieldManager,
 	}
+	if opts.ClusterLabelsState != nil {
+		clusterLabels, loaded := opts.ClusterLabelsState.Labels()
+		if !loaded {
+			// Parsing without the live labels would unselect the objects
+			// selected by them, so wait for the cluster label controller.
+			return nil, status.TransientError(errors.New("waiting for the cluster labels to be loaded"))
+		}
+		options.ClusterLabels = clusterLabels
+	}
 	options = OptionsForScope(options, opts.Scope)
 
+	validateCtx, span := tracing.Start(ctx, "Validate")
 	if opts.SourceFormat == configsync.SourceFormatUnstructured {
 		if opts.NamespaceStrategy == configsync.NamespaceStrategyImplicit {
 			options.Visitors = append(options.Visitors, p.addImplicitNamespaces)
 		}
-		objs, err = validate.Unstructured(ctx, opts.Client, objs, options)
+		objs, err = validate.Unstructured(validateCtx, opts.Client, objs, options)
 	} else {
 		objs, err = validate.Hierarchical(objs, options)
 	}
+	tracing.End(span, err)
 
 	if status.HasBlockingErrors(err) {
 		return nil, err
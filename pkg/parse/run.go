@@ -20,19 +20,26 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/hydrate"
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/notifier"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 	"kpt.dev/configsync/pkg/util"
+	"kpt.dev/configsync/pkg/util/log"
+	"kpt.dev/configsync/pkg/util/sourcemetadata"
 	webhookconfiguration "kpt.dev/configsync/pkg/webhook/configuration"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -46,14 +53,20 @@ import (
 // It turns out, the trigger string values are used as a ParserDuration metric label.
 // So we changed the variable names to make sense, without breaking customer metrics.
 const (
-	triggerFullSync           = "resync"
-	triggerSync               = "reimport"
-	triggerRetry              = "retry"
-	triggerManagementConflict = "managementConflict"
-	triggerWatchUpdate        = "watchUpdate"
-	triggerNamespaceUpdate    = "namespaceEvent"
+	triggerFullSync            = "resync"
+	triggerSync                = "reimport"
+	triggerRetry               = "retry"
+	triggerManagementConflict  = "managementConflict"
+	triggerWatchUpdate         = "watchUpdate"
+	triggerNamespaceUpdate     = "namespaceEvent"
+	triggerClusterLabelsUpdate = "clusterLabelsEvent"
 )
 
+// fetchTimeRefreshPeriod is how often the last fetch time of the source status
+// is refreshed, while the source is fetched successfully and the commit
+// doesn't change.
+const fetchTimeRefreshPeriod = 5 * time.Minute
+
 const (
 	// RenderingInProgress means that the configs are still being rendered by Config Sync.
 	RenderingInProgress string = "Rendering is still in progress"
@@ -102,6 +115,11 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 	state := r.ReconcilerState()
 	startTime := nowMeta(opts.Clock)
 
+	// Tag the spans of every stage with the RSync, and later the commit.
+	ctx = tracing.WithAttributes(ctx, tracing.SyncAttributes(opts.Options.Scope, opts.Options.SyncName)...)
+	ctx, span := tracing.Start(ctx, "Reconcile")
+	defer span.End()
+
 	// Initialize ReconcilerStatus from RSync status
 	if state.status == nil {
 		klog.V(3).Infof("Initializing reconciler status from %s status", opts.Options.Scope.SyncKind())
@@ -111,6 +129,15 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 			return result
 		}
 		state.status = reconcilerStatus
+		if !reconcilerStatus.SyncStatus.Syncing {
+			// Don't record the commit-to-sync latency of the commit synced
+			// before the reconciler restarted.
+			state.syncedCommit = reconcilerStatus.SyncStatus.Commit
+			if reconcilerStatus.SyncStatus.Commit != "" && reconcilerStatus.SyncStatus.Commit == reconcilerStatus.LastSyncedCommit {
+				// Don't notify again that the commit synced without errors.
+				opts.Notifier.SetSynced(reconcilerStatus.SyncStatus.Commit)
+			}
+		}
 	}
 
 	// Perform full-sync, if required
@@ -119,22 +146,33 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 	}
 
 	klog.Infof("Starting sync attempt (trigger: %s)", trigger)
+	span.SetAttributes(tracing.KeyTrigger.String(trigger))
 
 	switch trigger {
-	case triggerFullSync, triggerManagementConflict, triggerNamespaceUpdate:
+	case triggerFullSync, triggerManagementConflict, triggerNamespaceUpdate, triggerClusterLabelsUpdate:
 		// Force parsing and updating, but skip fetch, render, and read unless required.
 		state.RecordFullSyncStart(startTime)
 	}
 
-	newSourceStatus, syncPath, errs := r.fetch(ctx)
+	fetchCtx, fetchSpan := tracing.Start(ctx, "Fetch")
+	newSourceStatus, syncPath, errs := r.fetch(fetchCtx)
+	fetchSpan.SetAttributes(tracing.KeyCommit.String(newSourceStatus.Commit))
+	tracing.End(fetchSpan, errs)
 	if errs != nil {
 		state.RecordFailure(opts.Clock, errs)
+		r.notify(newSourceStatus.Commit, errs, nil)
 		return result
 	}
+	span.SetAttributes(tracing.KeyCommit.String(newSourceStatus.Commit))
+	ctx = tracing.WithAttributes(ctx, tracing.KeyCommit.String(newSourceStatus.Commit))
 
 	if opts.RenderingEnabled {
-		if errs := r.render(ctx, newSourceStatus); errs != nil {
+		renderCtx, renderSpan := tracing.Start(ctx, "Render")
+		errs := r.render(renderCtx, newSourceStatus)
+		tracing.End(renderSpan, errs)
+		if errs != nil {
 			state.RecordFailure(opts.Clock, errs)
+			r.notify(newSourceStatus.Commit, errs, nil)
 			return result
 		}
 	}
@@ -146,8 +184,12 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 
 	// rendering is done, starts to read the source or hydrated configs.
 	oldSyncPath := state.cache.source.syncPath
-	if errs := r.read(ctx, trigger, newSourceStatus, syncPath); errs != nil {
+	readCtx, readSpan := tracing.Start(ctx, "Read")
+	errs = r.read(readCtx, trigger, newSourceStatus, syncPath)
+	tracing.End(readSpan, errs)
+	if errs != nil {
 		state.RecordFailure(opts.Clock, errs)
+		r.notify(newSourceStatus.Commit, errs, nil)
 		return result
 	}
 
@@ -167,11 +209,14 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 		return result
 	}
 
-	parseErrs := r.parse(ctx, trigger)
+	parseCtx, parseSpan := tracing.Start(ctx, "Parse")
+	parseErrs := r.parse(parseCtx, trigger)
+	tracing.End(parseSpan, parseErrs)
 	// Fail if there are any blocking errors.
 	// Otherwise, continue to sync objects with known scope.
 	if status.HasBlockingErrors(parseErrs) {
 		state.RecordFailure(opts.Clock, parseErrs)
+		r.notify(newSourceStatus.Commit, parseErrs, nil)
 		return result
 	}
 
@@ -185,19 +230,54 @@ func (r *reconciler) Reconcile(ctx context.Context, trigger string) ReconcileRes
 		}
 	}
 
-	updateErrs := r.update(ctx, trigger)
+	updateCtx, updateSpan := tracing.Start(ctx, "Update")
+	updateErrs := r.update(updateCtx, trigger)
+	tracing.End(updateSpan, updateErrs)
 	// Fail if there are any update errors or non-blocking parse errors.
 	if parseErrs != nil || updateErrs != nil {
-		state.RecordFailure(opts.Clock, status.Append(parseErrs, updateErrs))
+		errs := status.Append(parseErrs, updateErrs)
+		state.RecordFailure(opts.Clock, errs)
+		r.notify(newSourceStatus.Commit, errs, opts.SyncStats())
 		return result
 	}
 
 	// Only checkpoint the state after *everything* succeeded, including status update.
 	state.RecordSyncSuccess(opts.Clock)
+	r.recordCommitSyncLatency(ctx)
+	r.notify(newSourceStatus.Commit, nil, opts.SyncStats())
 	result.Success = true
 	return result
 }
 
+// recordCommitSyncLatency records the latency from the time of the synced
+// commit to its first successful sync, if the commit time is known.
+func (r *reconciler) recordCommitSyncLatency(ctx context.Context) {
+	opts := r.Options()
+	state := r.ReconcilerState()
+	commit := state.cache.source.commit
+	if commit == state.syncedCommit {
+		return
+	}
+	state.syncedCommit = commit
+	commitTime := state.status.commitTime(commit)
+	if commitTime.IsZero() {
+		return
+	}
+	metrics.RecordCommitSyncLatency(ctx, opts.Clock.Since(commitTime.Time))
+}
+
+// notify notifies the configured endpoints and the Git provider of the result
+// of a sync attempt.
+func (r *reconciler) notify(commit string, errs status.MultiError, syncStats *stats.SyncStats) {
+	opts := r.Options()
+	opts.Notifier.Notify(notifier.Result{
+		Commit: commit,
+		Errs:   errs,
+		Stats:  syncStats,
+	})
+	opts.CommitStatus.ReportResult(commit, errs)
+}
+
 // fetch waits for the *-sync sidecars to fetch the source manifests to the
 // shared source volume.
 // Updates the RSync status (source status and syncing condition).
@@ -211,6 +291,15 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 	// pull the source commit and directory with retries within 5 minutes.
 	newSourceStatus.Commit, syncPath, newSourceStatus.Errs = hydrate.SourceCommitAndSyncPathWithRetry(
 		util.SourceRetryBackoff, opts.SourceType, opts.SourceDir, opts.SyncDir, opts.ReconcilerName)
+	if newSourceStatus.Errs == nil {
+		newSourceStatus.LastFetchTime = nowMeta(opts.Clock)
+		newSourceStatus.CommitTime = r.commitTime(newSourceStatus.Commit)
+		metrics.RecordSourceFetch(newSourceStatus.LastFetchTime.Time)
+	} else if state.status.SourceStatus != nil {
+		// Keep the last successful fetch time, which shows how stale the
+		// source is.
+		newSourceStatus.LastFetchTime = state.status.SourceStatus.LastFetchTime
+	}
 
 	// Add pre-sync annotations to the object.
 	// If updating the object fails, it's likely due to a signature verification error
@@ -232,10 +321,12 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 	// Generate source spec from Reconciler config
 	newSourceStatus.Spec = SourceSpecFromFileSource(opts.FileSource, opts.SourceType, newSourceStatus.Commit)
 
+	newCommit := state.status.SourceStatus == nil || newSourceStatus.Commit != state.status.SourceStatus.Commit
+
 	// Only update the source status if there are errors or the commit changed.
 	// Otherwise, parsing errors may be overwritten.
 	// TODO: Decouple fetch & parse stages to use different status fields
-	if newSourceStatus.Errs != nil || state.status.SourceStatus == nil || newSourceStatus.Commit != state.status.SourceStatus.Commit {
+	if newSourceStatus.Errs != nil || newCommit {
 		newSourceStatus.LastUpdate = nowMeta(opts.Clock)
 		if state.status.needToSetSourceStatus(newSourceStatus) {
 			klog.V(3).Info("Updating source status (after fetch)")
@@ -248,12 +339,56 @@ func (r *reconciler) fetch(ctx context.Context) (*SourceStatus, cmpath.Absolute,
 		if newSourceStatus.Errs != nil {
 			return newSourceStatus, syncPath, newSourceStatus.Errs
 		}
+	} else if state.status.needToRefreshFetchTimes(newSourceStatus, fetchTimeRefreshPeriod) {
+		// Refresh the fetch times of the source status of the same commit,
+		// keeping the errors of the parse stage.
+		refreshedStatus := *state.status.SourceStatus
+		refreshedStatus.CommitTime = newSourceStatus.CommitTime
+		refreshedStatus.LastFetchTime = newSourceStatus.LastFetchTime
+		klog.V(3).Info("Updating source status (fetch times)")
+		if statusErr := r.syncStatusClient.SetSourceStatus(ctx, &refreshedStatus); statusErr != nil {
+			return newSourceStatus, syncPath, statusErr
+		}
+		state.status.SourceStatus = &refreshedStatus
+	}
+
+	if newCommit {
+		klog.InfoS("Fetched new source commit", log.KeyCommit, newSourceStatus.Commit)
+		opts.Options.EventRecorder.Normal(eventrecorder.ReasonNewCommit, "Fetched new source commit %s", newSourceStatus.Commit)
+		opts.CommitStatus.ReportPending(newSourceStatus.Commit)
 	}
 
 	// Fetch successful
 	return newSourceStatus, syncPath, nil
 }
 
+// commitTime returns the time of the fetched commit, or the zero time if it
+// is unknown. The time is only read from the source once it is known.
+func (r *reconciler) commitTime(commit string) metav1.Time {
+	if commitTime := r.ReconcilerState().status.commitTime(commit); !commitTime.IsZero() {
+		return commitTime
+	}
+	opts := r.Options()
+	state := r.ReconcilerState()
+	commitTime, err := sourcemetadata.CommitTime(opts.SourceType, opts.SourceDir.OSPath(), commit)
+	if err != nil {
+		// Warn once per commit, since the time is read on every sync attempt
+		// until it is known.
+		if state.commitTimeFailedCommit != commit {
+			klog.Warningf("Failed to read the time of the source commit %s, so status.source.commitTime is not set: %v", commit, err)
+			state.commitTimeFailedCommit = commit
+		} else {
+			klog.V(3).Infof("Failed to read the time of the source commit %s: %v", commit, err)
+		}
+		return metav1.Time{}
+	}
+	if commitTime.IsZero() {
+		return metav1.Time{}
+	}
+	// The status only has a precision of seconds.
+	return metav1.NewTime(commitTime.Truncate(time.Second))
+}
+
 // render waits for the hydration-controller sidecar to render the source
 // manifests on the shared source volume.
 // Updates the RSync status (rendering status and syncing condition).
@@ -273,6 +408,7 @@ func (r *reconciler) render(ctx context.Context, sourceStatus *SourceStatus) sta
 	doneFilePath := opts.RepoRoot.Join(cmpath.RelativeSlash(hydrate.DoneFile)).OSPath()
 	renderedCommit, err := hydrate.ExtractCommit(doneFilePath)
 	if err != nil {
+		state.recordRenderWait(ctx, opts.Clock)
 		newRenderStatus.Message = RenderingFailed
 		newRenderStatus.LastUpdate = nowMeta(opts.Clock)
 		newRenderStatus.Errs = status.InternalHydrationError(err, RenderingFailed)
@@ -280,6 +416,7 @@ func (r *reconciler) render(ctx context.Context, sourceStatus *SourceStatus) sta
 		if statusErr := r.syncStatusClient.SetRenderingStatus(ctx, state.status.RenderingStatus, newRenderStatus); statusErr != nil {
 			return status.Append(newRenderStatus.Errs, statusErr)
 		}
+		r.recordRenderingEvent(state.status.RenderingStatus, newRenderStatus)
 		state.status.RenderingStatus = newRenderStatus
 		return newRenderStatus.Errs
 	}
@@ -291,13 +428,14 @@ func (r *reconciler) render(ctx context.Context, sourceStatus *SourceStatus) sta
 			return statusErr
 		}
 		state.status.RenderingStatus = newRenderStatus
-		state.RecordRenderInProgress()
+		state.RecordRenderInProgress(opts.Clock)
 		// Transient error will be logged as info, instead of error,
 		// but still trigger retry with backoff.
 		return status.TransientError(errors.New(RenderingInProgress))
 	}
 
 	// Render successful
+	state.recordRenderWait(ctx, opts.Clock)
 	return nil
 }
 
@@ -328,6 +466,7 @@ func (r *reconciler) read(ctx context.Context, trigger string, sourceStatus *Sou
 		// Return both errors
 		return status.Append(newRenderStatus.Errs, statusErr)
 	}
+	r.recordRenderingEvent(state.status.RenderingStatus, newRenderStatus)
 	state.status.RenderingStatus = newRenderStatus
 	if newRenderStatus.Errs != nil {
 		return newRenderStatus.Errs
@@ -338,6 +477,7 @@ func (r *reconciler) read(ctx context.Context, trigger string, sourceStatus *Sou
 	// and we don't want the source status flapping back and forth.
 	if newSourceStatus.Errs != nil {
 		newSourceStatus.LastUpdate = nowMeta(opts.Clock)
+		state.status.setFetchTimes(newSourceStatus)
 		if state.status.needToSetSourceStatus(newSourceStatus) {
 			klog.V(3).Info("Updating source status (after read)")
 			if statusErr := r.syncStatusClient.SetSourceStatus(ctx, newSourceStatus); statusErr != nil {
@@ -352,6 +492,22 @@ func (r *reconciler) read(ctx context.Context, trigger string, sourceStatus *Sou
 	return nil
 }
 
+// recordRenderingEvent records an Event when the rendering of a commit
+// succeeds or fails, unless the previous rendering status had the same commit
+// and result.
+func (r *reconciler) recordRenderingEvent(oldStatus, newStatus *RenderingStatus) {
+	if oldStatus != nil && oldStatus.Commit == newStatus.Commit && oldStatus.Message == newStatus.Message {
+		return
+	}
+	recorder := r.Options().Options.EventRecorder
+	switch newStatus.Message {
+	case RenderingSucceeded:
+		recorder.Normal(eventrecorder.ReasonRenderingSucceeded, "Rendered commit %s", newStatus.Commit)
+	case RenderingFailed:
+		recorder.Warning(eventrecorder.ReasonRenderingFailed, "Failed to render commit %s: %v", newStatus.Commit, newStatus.Errs)
+	}
+}
+
 // parseHydrationState reads from the file path which the hydration-controller
 // container writes to. It checks if the hydrated files are ready and returns
 // a renderingStatus.
@@ -430,7 +586,7 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 	if !opts.RenderingEnabled {
 		// Check if any kustomization Files exist
 		for _, fi := range srcState.files {
-			if hydrate.HasKustomization(path.Base(fi.OSPath())) {
+			if hydrate.HasKustomization(path.Base(fi.OSPath())) || requiresRendering(srcState.syncPath, fi) {
 				// Source of truth requires hydration, but the hydration-controller is not running
 				newRenderStatus.Message = RenderingRequired
 				newRenderStatus.RequiresRendering = true
@@ -450,6 +606,17 @@ func (r *reconciler) readFromSource(ctx context.Context, trigger string, srcStat
 	return newRenderStatus, newSourceStatus
 }
 
+// requiresRendering checks if the file directly under the sync directory is a
+// Kptfile with a pipeline, a Jsonnet entrypoint or a CUE file, which the
+// hydration-controller renders.
+func requiresRendering(syncPath cmpath.Absolute, file cmpath.Absolute) bool {
+	if path.Dir(file.OSPath()) != syncPath.OSPath() {
+		return false
+	}
+	filename := path.Base(file.OSPath())
+	return hydrate.IsJsonnetEntrypoint(filename) || hydrate.IsCueFile(filename) || hydrate.HasKptPipeline(file.OSPath())
+}
+
 // parse objects from the source files and perform scope validation.
 // If any objects have unknown scope, parse will return non-blocking errors.
 func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiError {
@@ -484,6 +651,7 @@ func (r *reconciler) parse(ctx context.Context, trigger string) status.MultiErro
 		Errs:       parseErrs,
 		LastUpdate: nowMeta(opts.Clock),
 	}
+	state.status.setFetchTimes(newSourceStatus)
 	if state.status.needToSetSourceStatus(newSourceStatus) {
 		klog.V(3).Info("Updating source status (after parse)")
 		if statusErr := r.syncStatusClient.SetSourceStatus(ctx, newSourceStatus); statusErr != nil {
@@ -522,7 +690,9 @@ func (r *reconciler) update(ctx context.Context, trigger string) status.MultiErr
 		Spec:       state.status.SourceStatus.Spec,
 		Syncing:    false,
 		Commit:     state.cache.source.commit,
+		CommitTime: state.status.commitTime(state.cache.source.commit),
 		Errs:       syncErrs,
+		Hooks:      opts.HookStatuses(),
 		LastUpdate: nowMeta(opts.Clock),
 	}
 	if statusErr := r.setSyncStatus(ctx, syncStatus); statusErr != nil {
@@ -573,7 +743,9 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 					Spec:       state.status.SourceStatus.Spec,
 					Syncing:    true,
 					Commit:     state.cache.source.commit,
+					CommitTime: state.status.commitTime(state.cache.source.commit),
 					Errs:       state.SyncErrors(),
+					Hooks:      opts.HookStatuses(),
 					LastUpdate: nowMeta(opts.Clock),
 				}
 				if err := r.setSyncStatus(ctx, syncStatus); err != nil {
@@ -591,7 +763,7 @@ func (r *reconciler) startAsyncStatusUpdates(ctx context.Context) <-chan struct{
 // shared directory between reconciler and hydration-controller to inform the
 // hydration-controller to proceed rendering
 func unblockHydration(commit, signalFile string) status.Error {
-	klog.Infof("signaling the hydration-controller that commit %s is ready to render", commit)
+	klog.InfoS("Signaling the hydration-controller that the commit is ready to render", log.KeyCommit, commit)
 	// Overwrite the commit in the given signal file
 	file, err := os.OpenFile(signalFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 	if err != nil {
@@ -643,7 +815,9 @@ func (r *reconciler) UpdateSyncStatus(ctx context.Context) error {
 		Spec:       state.status.SyncStatus.Spec,
 		Syncing:    false,
 		Commit:     state.status.SyncStatus.Commit,
+		CommitTime: state.status.SyncStatus.CommitTime,
 		Errs:       state.SyncErrors(),
+		Hooks:      opts.HookStatuses(),
 		LastUpdate: nowMeta(opts.Clock),
 	}
 	return r.setSyncStatus(ctx, syncStatus)
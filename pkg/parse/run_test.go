@@ -67,7 +67,7 @@ func newRootReconciler(t *testing.T, clock clock.Clock, fakeClient client.Client
 		t.Fatal(err)
 	}
 	state := &ReconcilerState{
-		syncErrorCache: NewSyncErrorCache(conflict.NewHandler(), fight.NewHandler()),
+		syncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
 	}
 	opts := &Options{
 		Clock:             clock,
@@ -259,9 +259,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -317,9 +318,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -456,9 +458,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -506,9 +509,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -560,8 +564,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:     sourceCommit,
-					LastUpdate: fakeMetaTime,
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
 					Errors: status.ToCSE(
 						status.ResourceErrorBuilder.Wrap(
 							fmt.Errorf("missing field %q", "metadata.name")).
@@ -621,8 +626,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:     sourceCommit,
-					LastUpdate: fakeMetaTime,
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
 					Errors: status.ToCSE(
 						status.ResourceErrorBuilder.Wrap(
 							fmt.Errorf("missing field %q", "metadata.name")).
@@ -678,8 +684,9 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:     sourceCommit,
-					LastUpdate: fakeMetaTime,
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
 					Errors: status.ToCSE(
 						status.UnknownObjectKindError(
 							k8sobjects.Unstructured(kinds.Anvil(), core.Name("deploy"),
@@ -746,9 +753,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -804,9 +812,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -864,9 +873,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -918,9 +928,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
@@ -997,9 +1008,10 @@ func TestReconciler_Reconcile(t *testing.T) {
 						Repo:   fileSource.SourceRepo,
 						Branch: fileSource.SourceBranch,
 					},
-					Commit:       sourceCommit,
-					LastUpdate:   fakeMetaTime,
-					ErrorSummary: &v1beta1.ErrorSummary{},
+					Commit:        sourceCommit,
+					LastFetchTime: &fakeMetaTime,
+					LastUpdate:    fakeMetaTime,
+					ErrorSummary:  &v1beta1.ErrorSummary{},
 				}
 				rs.Status.Status.Rendering = v1beta1.RenderingStatus{
 					Git: &v1beta1.GitStatus{
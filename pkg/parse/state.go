@@ -15,6 +15,7 @@
 package parse
 
 import (
+	"context"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,6 +23,7 @@ import (
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/tracing"
 )
 
 const invalidSyncPath = ""
@@ -55,6 +57,18 @@ type ReconcilerState struct {
 
 	// lastFullSyncTime is the last time a full reconciler attempt was started.
 	lastFullSyncTime metav1.Time
+
+	// syncedCommit is the last commit synced successfully, whose
+	// commit-to-sync latency was recorded.
+	syncedCommit string
+
+	// commitTimeFailedCommit is the last commit whose time failed to be
+	// read, to only warn once per commit.
+	commitTimeFailedCommit string
+
+	// renderWaitStartTime is when a reconcile attempt first found the
+	// rendering in progress, or zero if it is not in progress.
+	renderWaitStartTime time.Time
 }
 
 type checkpoint struct {
@@ -104,14 +118,29 @@ func (s *ReconcilerState) RecordSyncSuccess(c clock.Clock) {
 
 // RecordRenderInProgress is called when waiting for rendering status. It resets
 // the cacheForCommit, which tells the next reconcile attempt to re-parse from
-// source.
-func (s *ReconcilerState) RecordRenderInProgress() {
+// source, and records when the wait started, if it is a new wait.
+func (s *ReconcilerState) RecordRenderInProgress(c clock.Clock) {
 	klog.Info("Rendering in progress")
+	if s.renderWaitStartTime.IsZero() {
+		s.renderWaitStartTime = c.Now()
+	}
 	// TODO: track render status lastUpdateTime & lastTransitionTime
 	// TODO: update parserResultUpToDate() to trigger parsing when syncPath changes, to avoid needing to reset the cache to trigger parsing when render is successful
 	s.cache = cacheForCommit{}
 }
 
+// recordRenderWait is called when the rendering completed or failed. If
+// previous reconcile attempts waited for the rendering, it records a
+// RenderWait span from the first of them, since each attempt only waits
+// until it finds the rendering in progress.
+func (s *ReconcilerState) recordRenderWait(ctx context.Context, c clock.Clock) {
+	if s.renderWaitStartTime.IsZero() {
+		return
+	}
+	tracing.Record(ctx, "RenderWait", s.renderWaitStartTime, c.Now())
+	s.renderWaitStartTime = time.Time{}
+}
+
 // RecordFailure is called when a sync attempt errors. It invalidates the
 // checkpoint, requests a retry, and logs the errors. Does not reset the
 // cacheForCommit.
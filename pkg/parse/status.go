@@ -16,9 +16,12 @@ package parse
 
 import (
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/status"
 )
 
@@ -32,15 +35,20 @@ type ReconcilerStatus struct {
 
 	// SyncStatus tracks info from the `Status.Sync` field of a RepoSync/RootSync.
 	SyncStatus *SyncStatus
+
+	// LastSyncedCommit is the `Status.LastSyncedCommit` field of a
+	// RepoSync/RootSync: the last commit which synced without errors.
+	LastSyncedCommit string
 }
 
 // DeepCopy returns a deep copy of the receiver.
 // Warning: Go errors are not copy-able. So this isn't a true deep-copy.
 func (s *ReconcilerStatus) DeepCopy() *ReconcilerStatus {
 	return &ReconcilerStatus{
-		SourceStatus:    s.SourceStatus.DeepCopy(),
-		RenderingStatus: s.RenderingStatus.DeepCopy(),
-		SyncStatus:      s.SyncStatus.DeepCopy(),
+		SourceStatus:     s.SourceStatus.DeepCopy(),
+		RenderingStatus:  s.RenderingStatus.DeepCopy(),
+		SyncStatus:       s.SyncStatus.DeepCopy(),
+		LastSyncedCommit: s.LastSyncedCommit,
 	}
 }
 
@@ -82,6 +90,40 @@ func (s *ReconcilerStatus) needToSetSyncStatus(newStatus *SyncStatus) bool {
 	return !s.SyncStatus.Equals(newStatus)
 }
 
+// setFetchTimes copies the commit time and the last fetch time of the current
+// source status to the new source status of the same commit, since only the
+// fetch stage reads them.
+func (s *ReconcilerStatus) setFetchTimes(newStatus *SourceStatus) {
+	if s.SourceStatus == nil || s.SourceStatus.Commit != newStatus.Commit {
+		return
+	}
+	newStatus.CommitTime = s.SourceStatus.CommitTime
+	newStatus.LastFetchTime = s.SourceStatus.LastFetchTime
+}
+
+// needToRefreshFetchTimes returns true if the source status of the same
+// commit should be updated with the fetch times of the new source status:
+// when the commit time changed, or the last fetch time is at least the refresh
+// period newer.
+func (s *ReconcilerStatus) needToRefreshFetchTimes(newStatus *SourceStatus, refreshPeriod time.Duration) bool {
+	if s.SourceStatus == nil {
+		return false
+	}
+	if !s.SourceStatus.CommitTime.Equal(&newStatus.CommitTime) {
+		return true
+	}
+	return newStatus.LastFetchTime.Sub(s.SourceStatus.LastFetchTime.Time) >= refreshPeriod
+}
+
+// commitTime returns the commit time of the source status, if it is the
+// status of the commit, or else the zero time.
+func (s *ReconcilerStatus) commitTime(commit string) metav1.Time {
+	if s.SourceStatus == nil || s.SourceStatus.Commit != commit {
+		return metav1.Time{}
+	}
+	return s.SourceStatus.CommitTime
+}
+
 // SourceSpec is a representation of the source specification that is
 // cached and stored in the RSync status for each stage in the pipeline.
 //
@@ -198,10 +240,14 @@ type SourceStatus struct {
 	// Spec represents the source specification that this status corresponds to.
 	// The spec is stored in the status so we can distinguish if the status
 	// reflects the latest spec or not.
-	Spec       SourceSpec
-	Commit     string
+	Spec   SourceSpec
+	Commit string
+	// CommitTime is the time of the commit, or zero if unknown.
+	CommitTime metav1.Time
 	Errs       status.MultiError
-	LastUpdate metav1.Time
+	// LastFetchTime is the last time the source was fetched successfully.
+	LastFetchTime metav1.Time
+	LastUpdate    metav1.Time
 }
 
 // DeepCopy returns a deep copy of the receiver.
@@ -211,19 +257,22 @@ func (gs *SourceStatus) DeepCopy() *SourceStatus {
 		return nil
 	}
 	return &SourceStatus{
-		Commit:     gs.Commit,
-		Errs:       gs.Errs,
-		LastUpdate: *gs.LastUpdate.DeepCopy(),
+		Commit:        gs.Commit,
+		CommitTime:    *gs.CommitTime.DeepCopy(),
+		Errs:          gs.Errs,
+		LastFetchTime: *gs.LastFetchTime.DeepCopy(),
+		LastUpdate:    *gs.LastUpdate.DeepCopy(),
 	}
 }
 
 // Equals returns true if the specified SourceStatus equals this
-// SourceStatus, excluding the LastUpdate timestamp.
+// SourceStatus, excluding the LastFetchTime and LastUpdate timestamps.
 func (gs *SourceStatus) Equals(other *SourceStatus) bool {
 	if gs == nil {
 		return other == nil
 	}
 	return gs.Commit == other.Commit &&
+		gs.CommitTime.Equal(&other.CommitTime) &&
 		status.DeepEqual(gs.Errs, other.Errs) &&
 		isSourceSpecEqual(gs.Spec, other.Spec)
 }
@@ -275,10 +324,13 @@ type SyncStatus struct {
 	// Spec represents the source specification that this status corresponds to.
 	// The spec is stored in the status so we can distinguish if the status
 	// reflects the latest spec or not.
-	Spec       SourceSpec
-	Syncing    bool
-	Commit     string
+	Spec    SourceSpec
+	Syncing bool
+	Commit  string
+	// CommitTime is the time of the commit, or zero if unknown.
+	CommitTime metav1.Time
 	Errs       status.MultiError
+	Hooks      []v1beta1.HookStatus
 	LastUpdate metav1.Time
 }
 
@@ -288,10 +340,17 @@ func (ss *SyncStatus) DeepCopy() *SyncStatus {
 	if ss == nil {
 		return nil
 	}
+	var hooks []v1beta1.HookStatus
+	if ss.Hooks != nil {
+		hooks = make([]v1beta1.HookStatus, len(ss.Hooks))
+		copy(hooks, ss.Hooks)
+	}
 	return &SyncStatus{
 		Syncing:    ss.Syncing,
 		Commit:     ss.Commit,
+		CommitTime: *ss.CommitTime.DeepCopy(),
 		Errs:       ss.Errs,
+		Hooks:      hooks,
 		LastUpdate: *ss.LastUpdate.DeepCopy(),
 	}
 }
@@ -304,7 +363,9 @@ func (ss *SyncStatus) Equals(other *SyncStatus) bool {
 	}
 	return ss.Syncing == other.Syncing &&
 		ss.Commit == other.Commit &&
+		ss.CommitTime.Equal(&other.CommitTime) &&
 		status.DeepEqual(ss.Errs, other.Errs) &&
+		equality.Semantic.DeepEqual(ss.Hooks, other.Hooks) &&
 		isSourceSpecEqual(ss.Spec, other.Spec)
 }
 
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcilerStatus_FetchTimes(t *testing.T) {
+	commitTime := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	fetchTime := metav1.NewTime(commitTime.Add(time.Hour))
+	status := &ReconcilerStatus{
+		SourceStatus: &SourceStatus{
+			Commit:        "abc",
+			CommitTime:    commitTime,
+			LastFetchTime: fetchTime,
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		newStatus           *SourceStatus
+		expectedRefresh     bool
+		expectedCommitTime  metav1.Time
+		expectedLastFetched metav1.Time
+	}{
+		{
+			name: "same commit fetched recently",
+			newStatus: &SourceStatus{
+				Commit:        "abc",
+				CommitTime:    commitTime,
+				LastFetchTime: metav1.NewTime(fetchTime.Add(time.Minute)),
+			},
+			expectedRefresh:     false,
+			expectedCommitTime:  commitTime,
+			expectedLastFetched: fetchTime,
+		},
+		{
+			name: "same commit fetched after the refresh period",
+			newStatus: &SourceStatus{
+				Commit:        "abc",
+				CommitTime:    commitTime,
+				LastFetchTime: metav1.NewTime(fetchTime.Add(fetchTimeRefreshPeriod)),
+			},
+			expectedRefresh:     true,
+			expectedCommitTime:  commitTime,
+			expectedLastFetched: fetchTime,
+		},
+		{
+			name: "same commit with a newly known commit time",
+			newStatus: &SourceStatus{
+				Commit:        "abc",
+				CommitTime:    metav1.NewTime(commitTime.Add(time.Second)),
+				LastFetchTime: fetchTime,
+			},
+			expectedRefresh:     true,
+			expectedCommitTime:  commitTime,
+			expectedLastFetched: fetchTime,
+		},
+		{
+			name: "different commit",
+			newStatus: &SourceStatus{
+				Commit: "def",
+			},
+			expectedRefresh: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedRefresh, status.needToRefreshFetchTimes(tc.newStatus, fetchTimeRefreshPeriod))
+			newStatus := &SourceStatus{Commit: tc.newStatus.Commit}
+			status.setFetchTimes(newStatus)
+			assert.Equal(t, tc.expectedCommitTime, newStatus.CommitTime)
+			assert.Equal(t, tc.expectedLastFetched, newStatus.LastFetchTime)
+			assert.Equal(t, tc.expectedCommitTime, status.commitTime(tc.newStatus.Commit))
+		})
+	}
+}
@@ -17,6 +17,7 @@ package parse
 import (
 	"sync"
 
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/status"
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
@@ -34,6 +35,7 @@ type SyncErrorCache struct {
 	validationErrs status.MultiError
 	applyErrs      status.MultiError
 	watchErrs      status.MultiError
+	hookErrs       map[metadata.HookPhase]status.MultiError
 }
 
 // NewSyncErrorCache constructs a new SyncErrorCache with shared handlers
@@ -70,6 +72,9 @@ func (s *SyncErrorCache) Errors() status.MultiError {
 	errs = status.Append(errs, s.validationErrs)
 	errs = status.Append(errs, s.applyErrs)
 	errs = status.Append(errs, s.watchErrs)
+	for _, phase := range metadata.HookPhases {
+		errs = status.Append(errs, s.hookErrs[phase])
+	}
 	return errs
 }
 
@@ -101,3 +106,20 @@ func (s *SyncErrorCache) SetWatchErrs(errs status.MultiError) {
 	defer s.statusMux.Unlock()
 	s.watchErrs = errs
 }
+
+// SetHookErrs replaces the cached errors of the hooks of the phase.
+func (s *SyncErrorCache) SetHookErrs(phase metadata.HookPhase, errs status.MultiError) {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	if s.hookErrs == nil {
+		s.hookErrs = make(map[metadata.HookPhase]status.MultiError)
+	}
+	s.hookErrs[phase] = errs
+}
+
+// ResetHookErrs deletes all cached hook errors.
+func (s *SyncErrorCache) ResetHookErrs() {
+	s.statusMux.Lock()
+	defer s.statusMux.Unlock()
+	s.hookErrs = nil
+}
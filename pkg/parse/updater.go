@@ -21,13 +21,18 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
+	"kpt.dev/configsync/pkg/applier/stats"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/importer/filesystem"
+	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/remediator"
 	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/util/log"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -47,8 +52,28 @@ type Updater struct {
 	// sub-components running in parallel. This allows batching updates and
 	// pushing them asynchronously.
 	SyncErrorCache *SyncErrorCache
+	// HookRunner runs the hooks of each commit around the apply.
+	// Hooks are not run if nil.
+	HookRunner applier.HookRunner
+	// ObjectMetrics controls which objects have their apply, prune and wait
+	// outcomes and latencies recorded as metrics.
+	ObjectMetrics applier.ObjectMetricsOptions
+	// EventRecorder records Events on the RootSync or RepoSync when the
+	// applier starts and finishes applying a commit.
+	// No Events are recorded if nil.
+	EventRecorder *eventrecorder.Recorder
 
 	updateMux sync.RWMutex
+	// syncStats are the stats of the apply of the last Update, or nil if the
+	// last Update did not apply. Guarded by updateMux.
+	syncStats *stats.SyncStats
+
+	hookMux sync.RWMutex
+	// hookCommit is the source commit the hookStatuses were run for.
+	hookCommit string
+	// hookStatuses are the statuses of the hooks run for hookCommit, in the
+	// order they ran.
+	hookStatuses []v1beta1.HookStatus
 }
 
 func (u *Updater) needToUpdateWatch() bool {
@@ -72,13 +97,36 @@ func (u *Updater) Remediating() bool {
 	return u.Remediator.Remediating()
 }
 
+// HookStatuses returns the statuses of the hooks run for the latest commit.
+func (u *Updater) HookStatuses() []v1beta1.HookStatus {
+	u.hookMux.RLock()
+	defer u.hookMux.RUnlock()
+	if len(u.hookStatuses) == 0 {
+		return nil
+	}
+	hookStatuses := make([]v1beta1.HookStatus, len(u.hookStatuses))
+	copy(hookStatuses, u.hookStatuses)
+	return hookStatuses
+}
+
+// SyncStats returns the stats of the apply of the last Update, or nil if the
+// last Update did not apply.
+func (u *Updater) SyncStats() *stats.SyncStats {
+	u.updateMux.RLock()
+	defer u.updateMux.RUnlock()
+	return u.syncStats
+}
+
 // Update does the following:
 // 1. Pauses the remediator
-// 2. Validates and sterilizes the objects
-// 3. Updates the declared resource objects in memory
-// 4. Applies the objects
-// 5. Updates the remediator watches
-// 6. Restarts the remediator
+// 2. Runs the pre-sync hooks
+// 3. Validates and sterilizes the objects
+// 4. Updates the declared resource objects in memory
+// 5. Applies the objects
+// 6. Runs the post-sync hooks, or the sync-fail hooks if the pre-sync hooks
+// or the apply failed
+// 7. Updates the remediator watches
+// 8. Restarts the remediator
 //
 // Any errors returned will be prepended with any known conflict errors from the
 // remediator. This is required to preserve errors that have been reported by
@@ -98,6 +146,16 @@ func (u *Updater) update(ctx context.Context, cache *cacheForCommit) status.Mult
 	// Continue watching previously declared objects and updating the queue.
 	// Queued objects will be remediated when the workers are started again.
 	u.Remediator.Pause()
+	u.syncStats = nil
+
+	// Run the pre-sync hooks, unless the commit is already applied.
+	// A failed pre-sync hook blocks the apply.
+	u.resetHooks(cache.source.commit)
+	if !cache.applied {
+		if err := u.runHooks(ctx, metadata.PreSyncHook, cache); err != nil {
+			return status.Append(err, u.runHooks(ctx, metadata.SyncFailHook, cache))
+		}
+	}
 
 	// Update the declared resources (source of truth for the Remediator).
 	// After this, any objects removed from the declared resources will no
@@ -126,13 +184,16 @@ func (u *Updater) update(ctx context.Context, cache *cacheForCommit) status.Mult
 	// Apply the declared resources
 	if !cache.applied {
 		if err := u.apply(ctx, cache.source.commit); err != nil {
-			return err
+			return status.Append(err, u.runHooks(ctx, metadata.SyncFailHook, cache))
 		}
 		// Only mark the commit as applied if there were no (non-blocking) parse errors.
 		// This ensures the apply will be retried until parsing fully succeeds.
 		if cache.parse.parserErrs == nil {
 			cache.applied = true
 		}
+		if err := u.runHooks(ctx, metadata.PostSyncHook, cache); err != nil {
+			return err
+		}
 	}
 
 	// Update the resource watches (triggers for the Remediator).
@@ -188,20 +249,89 @@ func (u *Updater) apply(ctx context.Context, commit string) status.MultiError {
 			}
 		}
 	}
-	klog.Info("Applier starting...")
+	klog.InfoS("Applier starting", log.KeyCommit, commit)
 	start := time.Now()
 	u.SyncErrorCache.ResetApplyErrors()
+	u.EventRecorder.Normal(eventrecorder.ReasonApplyStarted, "Applying commit %s", commit)
 	objStatusMap, syncStats := u.Applier.Apply(ctx, eventHandler, u.Resources)
+	u.syncStats = syncStats
 	if !syncStats.Empty() {
 		klog.Infof("Applier made new progress: %s", syncStats.String())
 		objStatusMap.Log(klog.V(0))
 	}
 	metrics.RecordApplyDuration(ctx, metrics.StatusTagKey(err), commit, start)
+	objStatusMap.RecordMetrics(ctx, u.ObjectMetrics)
+	u.recordApplyEvents(commit, syncStats, err)
+	if err != nil {
+		for _, e := range err.Errors() {
+			klog.ErrorS(e, "Applier failed", append([]interface{}{log.KeyCommit, commit}, log.ErrorValues(e)...)...)
+		}
+		return err
+	}
+	klog.InfoS("Applier succeeded", log.KeyCommit, commit)
+	return nil
+}
+
+// recordApplyEvents records the Events for the end of an apply, with a summary
+// of the SyncStats.
+func (u *Updater) recordApplyEvents(commit string, syncStats *stats.SyncStats, err status.MultiError) {
+	if syncStats != nil && !syncStats.PruneEvent.Empty() {
+		u.EventRecorder.Normal(eventrecorder.ReasonPruned, "Pruned objects removed from commit %s: %s",
+			commit, syncStats.PruneEvent.String())
+	}
+	summary := "no changes"
+	if !syncStats.Empty() {
+		summary = syncStats.String()
+	}
+	if err != nil {
+		u.EventRecorder.Warning(eventrecorder.ReasonApplyFailed, "Failed to apply commit %s with %d errors: %s",
+			commit, len(err.Errors()), summary)
+		return
+	}
+	u.EventRecorder.Normal(eventrecorder.ReasonApplySucceeded, "Applied commit %s: %s", commit, summary)
+}
+
+// resetHooks forgets the hooks run for the previous commit.
+func (u *Updater) resetHooks(commit string) {
+	u.hookMux.Lock()
+	defer u.hookMux.Unlock()
+	if u.hookCommit == commit {
+		return
+	}
+	u.hookCommit = commit
+	u.hookStatuses = nil
+	u.SyncErrorCache.ResetHookErrs()
+}
+
+// recordHookStatus adds the hook status, replacing the previous status of
+// the same hook.
+func (u *Updater) recordHookStatus(hookStatus v1beta1.HookStatus) {
+	u.hookMux.Lock()
+	defer u.hookMux.Unlock()
+	for i, s := range u.hookStatuses {
+		if s.Phase == hookStatus.Phase && s.Group == hookStatus.Group && s.Kind == hookStatus.Kind &&
+			s.Namespace == hookStatus.Namespace && s.Name == hookStatus.Name {
+			u.hookStatuses[i] = hookStatus
+			return
+		}
+	}
+	u.hookStatuses = append(u.hookStatuses, hookStatus)
+}
+
+// runHooks runs the hooks of the phase for the source commit.
+func (u *Updater) runHooks(ctx context.Context, phase metadata.HookPhase, cache *cacheForCommit) status.MultiError {
+	if u.HookRunner == nil || len(cache.parse.hooks) == 0 {
+		return nil
+	}
+	klog.V(1).Infof("Running %s hooks...", phase)
+	hooks := filesystem.AsCoreObjects(cache.parse.hooks)
+	err := u.HookRunner.Run(ctx, u.recordHookStatus, phase, cache.source.commit, hooks)
+	u.SyncErrorCache.SetHookErrs(phase, err)
 	if err != nil {
-		klog.Warningf("Applier failed: %v", err)
+		klog.Warningf("Failed to run %s hooks: %v", phase, err)
 		return err
 	}
-	klog.Info("Applier succeeded")
+	klog.V(3).Infof("The %s hooks succeeded", phase)
 	return nil
 }
 
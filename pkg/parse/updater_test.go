@@ -0,0 +1,235 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/applier"
+	applierfake "kpt.dev/configsync/pkg/applier/fake"
+	"kpt.dev/configsync/pkg/applier/stats"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/remediator/conflict"
+	remediatorfake "kpt.dev/configsync/pkg/remediator/fake"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	syncertestfake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeHookRunner records the phases it runs, and fails the phases in errs.
+type fakeHookRunner struct {
+	phases []metadata.HookPhase
+	errs   map[metadata.HookPhase]status.Error
+}
+
+func (r *fakeHookRunner) Run(_ context.Context, statusHandler func(v1beta1.HookStatus), phase metadata.HookPhase, commit string, hooks []client.Object) status.MultiError {
+	r.phases = append(r.phases, phase)
+	for _, hook := range hooks {
+		if !metadata.HasHookPhase(hook, phase) {
+			continue
+		}
+		result := applier.HookSucceeded
+		if r.errs[phase] != nil {
+			result = applier.HookFailed
+		}
+		statusHandler(v1beta1.HookStatus{Phase: phase.String(), Kind: "Job", Name: hook.GetName(), Commit: commit, Result: result})
+		return r.errs[phase]
+	}
+	return nil
+}
+
+func TestUpdaterHooks(t *testing.T) {
+	hookErr := applier.Error(errors.New("hook failed"))
+	applyErr := applier.Error(errors.New("apply failed"))
+	hooks := []ast.FileObject{
+		k8sobjects.Unstructured(kinds.Job(), core.Name("migrate"), core.Namespace("shipping"),
+			core.Annotation(metadata.HookAnnotationKey, metadata.PreSyncHook.String())),
+		k8sobjects.Unstructured(kinds.Job(), core.Name("smoke-test"), core.Namespace("shipping"),
+			core.Annotation(metadata.HookAnnotationKey, metadata.PostSyncHook.String())),
+		k8sobjects.Unstructured(kinds.Job(), core.Name("rollback"), core.Namespace("shipping"),
+			core.Annotation(metadata.HookAnnotationKey, metadata.SyncFailHook.String())),
+	}
+
+	testCases := []struct {
+		name         string
+		hookErrs     map[metadata.HookPhase]status.Error
+		applyOutputs []applierfake.ApplierOutputs
+		wantPhases   []metadata.HookPhase
+		wantHooks    []string
+		wantApplied  bool
+		wantErr      status.MultiError
+	}{
+		{
+			name:         "post-sync hooks run after the apply",
+			applyOutputs: []applierfake.ApplierOutputs{{}},
+			wantPhases:   []metadata.HookPhase{metadata.PreSyncHook, metadata.PostSyncHook},
+			wantHooks:    []string{"migrate", "smoke-test"},
+			wantApplied:  true,
+		},
+		{
+			name:        "failed pre-sync hook blocks the apply",
+			hookErrs:    map[metadata.HookPhase]status.Error{metadata.PreSyncHook: hookErr},
+			wantPhases:  []metadata.HookPhase{metadata.PreSyncHook, metadata.SyncFailHook},
+			wantHooks:   []string{"migrate", "rollback"},
+			wantApplied: false,
+			wantErr:     hookErr,
+		},
+		{
+			name:         "sync-fail hooks run after a failed apply",
+			applyOutputs: []applierfake.ApplierOutputs{{Errors: []status.Error{applyErr}}},
+			wantPhases:   []metadata.HookPhase{metadata.PreSyncHook, metadata.SyncFailHook},
+			wantHooks:    []string{"migrate", "rollback"},
+			wantApplied:  false,
+			wantErr:      applyErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hookRunner := &fakeHookRunner{errs: tc.hookErrs}
+			fakeApplier := &applierfake.Applier{ApplyOutputs: tc.applyOutputs}
+			u := &Updater{
+				Scope:          declared.RootScope,
+				Resources:      &declared.Resources{},
+				Remediator:     &remediatorfake.Remediator{},
+				Applier:        fakeApplier,
+				SyncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
+				HookRunner:     hookRunner,
+			}
+			cache := &cacheForCommit{source: &sourceState{commit: "abc"}}
+			cache.UpdateParseResult(append([]ast.FileObject{k8sobjects.Namespace("namespaces/shipping")}, hooks...), nil, metav1.Now())
+
+			err := u.Update(context.Background(), cache)
+			testerrors.AssertEqual(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantPhases, hookRunner.phases)
+			var gotHooks []string
+			for _, s := range u.HookStatuses() {
+				gotHooks = append(gotHooks, s.Name)
+			}
+			assert.Equal(t, tc.wantHooks, gotHooks)
+			assert.Equal(t, tc.wantApplied, cache.applied)
+			if tc.wantApplied {
+				// Hooks are not sent to the applier.
+				assert.Len(t, fakeApplier.ApplyInputs[0].Objects, 1)
+			}
+		})
+	}
+}
+
+func TestUpdaterEvents(t *testing.T) {
+	applyErr := applier.Error(errors.New("apply failed"))
+
+	testCases := []struct {
+		name         string
+		applyOutputs []applierfake.ApplierOutputs
+		wantEvents   []string
+	}{
+		{
+			name:         "apply without changes",
+			applyOutputs: []applierfake.ApplierOutputs{{}},
+			wantEvents: []string{
+				"Normal ApplyStarted Applying commit abc",
+				"Normal ApplySucceeded Applied commit abc: no changes",
+			},
+		},
+		{
+			name: "apply with prunes",
+			applyOutputs: []applierfake.ApplierOutputs{{
+				SyncStats: stats.NewSyncStats().
+					WithApplyEvents(event.ApplySuccessful, 1).
+					WithPruneEvents(event.PruneSuccessful, 2),
+			}},
+			wantEvents: []string{
+				"Normal ApplyStarted Applying commit abc",
+				"Normal Pruned Pruned objects removed from commit abc: PruneEvents: 2 (Successful: 2)",
+				"Normal ApplySucceeded Applied commit abc: ApplyEvents: 1 (Successful: 1), PruneEvents: 2 (Successful: 2)",
+			},
+		},
+		{
+			name: "failed apply",
+			applyOutputs: []applierfake.ApplierOutputs{{
+				Errors:    []status.Error{applyErr},
+				SyncStats: stats.NewSyncStats().WithApplyEvents(event.ApplyFailed, 1),
+			}},
+			wantEvents: []string{
+				"Normal ApplyStarted Applying commit abc",
+				"Warning ApplyFailed Failed to apply commit abc with 1 errors: ApplyEvents: 1 (Failed: 1)",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeRecorder := record.NewFakeRecorder(10)
+			rsClient := syncertestfake.NewClient(t, core.Scheme, k8sobjects.RootSyncObjectV1Beta1(configsync.RootSyncName))
+			u := &Updater{
+				Scope:          declared.RootScope,
+				Resources:      &declared.Resources{},
+				Remediator:     &remediatorfake.Remediator{},
+				Applier:        &applierfake.Applier{ApplyOutputs: tc.applyOutputs},
+				SyncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
+				EventRecorder:  eventrecorder.New(context.Background(), fakeRecorder, rsClient, declared.RootScope, configsync.RootSyncName),
+			}
+			cache := &cacheForCommit{source: &sourceState{commit: "abc"}}
+			cache.UpdateParseResult([]ast.FileObject{k8sobjects.Namespace("namespaces/shipping")}, nil, metav1.Now())
+
+			_ = u.Update(context.Background(), cache)
+
+			close(fakeRecorder.Events)
+			var events []string
+			for e := range fakeRecorder.Events {
+				events = append(events, e)
+			}
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
+func TestUpdaterSyncStats(t *testing.T) {
+	syncStats := stats.NewSyncStats().WithApplyEvents(event.ApplySuccessful, 1)
+	u := &Updater{
+		Scope:          declared.RootScope,
+		Resources:      &declared.Resources{},
+		Remediator:     &remediatorfake.Remediator{},
+		Applier:        &applierfake.Applier{ApplyOutputs: []applierfake.ApplierOutputs{{SyncStats: syncStats}}},
+		SyncErrorCache: NewSyncErrorCache(conflict.NewHandler(nil), fight.NewHandler()),
+	}
+	cache := &cacheForCommit{source: &sourceState{commit: "abc"}}
+	cache.UpdateParseResult([]ast.FileObject{k8sobjects.Namespace("namespaces/shipping")}, nil, metav1.Now())
+
+	require.Nil(t, u.Update(context.Background(), cache))
+	assert.Equal(t, syncStats, u.SyncStats())
+
+	// The commit is already applied, so the next Update doesn't apply.
+	require.Nil(t, u.Update(context.Background(), cache))
+	assert.Nil(t, u.SyncStats())
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
+	"kpt.dev/configsync/pkg/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// initialLoadRetryPeriod is how long to wait between attempts to read the
+// labels of the source object on startup.
+const initialLoadRetryPeriod = 5 * time.Second
+
+// MembershipName is the name of the hub Membership of a cluster registered
+// to a fleet.
+const MembershipName = "membership"
+
+// ClusterLabelController is a controller that watches the cluster-local object
+// holding the cluster labels, and sets a flag to indicate whether the
+// Reconciler thread needs to re-parse the source to perform cluster selection
+// with the new labels.
+type ClusterLabelController struct {
+	client client.Client
+	state  *State
+	kind   configsync.ClusterLabelsSourceKind
+	key    client.ObjectKey
+}
+
+// New instantiates the cluster label controller for the given source.
+// name is the name of the ConfigMap, and is ignored for the Membership.
+func New(cl client.Client, state *State, kind configsync.ClusterLabelsSourceKind, name string) (*ClusterLabelController, error) {
+	c := &ClusterLabelController{
+		client: cl,
+		state:  state,
+		kind:   kind,
+	}
+	switch kind {
+	case configsync.ClusterLabelsSourceConfigMap:
+		if name == "" {
+			return nil, fmt.Errorf("the name of the cluster labels ConfigMap must not be empty")
+		}
+		c.key = client.ObjectKey{Namespace: configmanagement.ControllerNamespace, Name: name}
+	case configsync.ClusterLabelsSourceMembership:
+		c.key = client.ObjectKey{Name: MembershipName}
+	default:
+		return nil, fmt.Errorf("invalid cluster labels source kind %q: must be %q or %q",
+			kind, configsync.ClusterLabelsSourceConfigMap, configsync.ClusterLabelsSourceMembership)
+	}
+	return c, nil
+}
+
+// newObject returns an empty object of the source kind.
+func (c *ClusterLabelController) newObject() client.Object {
+	if c.kind == configsync.ClusterLabelsSourceConfigMap {
+		return &corev1.ConfigMap{}
+	}
+	return &hubv1.Membership{}
+}
+
+// CacheByObject returns the cache options of the manager which only cache the
+// source object, so that the watch doesn't cache every ConfigMap or Membership
+// of the cluster.
+func (c *ClusterLabelController) CacheByObject() map[client.Object]cache.ByObject {
+	byObject := cache.ByObject{
+		Field: fields.OneTermEqualSelector("metadata.name", c.key.Name),
+	}
+	if c.key.Namespace != "" {
+		byObject.Namespaces = map[string]cache.Config{c.key.Namespace: {}}
+	}
+	return map[client.Object]cache.ByObject{c.newObject(): byObject}
+}
+
+// Reconcile reads the labels of the source object, and sets the flag to
+// indicate whether a new reconciliation needs to be executed by the Reconciler
+// thread. A missing source object has no labels.
+func (c *ClusterLabelController) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	obj := c.newObject()
+	if err := c.client.Get(ctx, c.key, obj); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, status.APIServerError(err, fmt.Sprintf("failed to get %s %s", c.kind, c.key))
+		}
+		klog.Infof("The cluster labels %s %s is not found", c.kind, c.key)
+		c.state.setLabels(nil)
+		return ctrl.Result{}, nil
+	}
+	c.state.setLabels(clusterLabels(obj))
+	return ctrl.Result{}, nil
+}
+
+// clusterLabels returns the cluster labels held by the source object.
+func clusterLabels(obj client.Object) map[string]string {
+	if cm, ok := obj.(*corev1.ConfigMap); ok {
+		return cm.Data
+	}
+	return obj.GetLabels()
+}
+
+// initialLoad reads the labels of the source object until it succeeds, as no
+// watch event is received if the source object does not exist.
+func (c *ClusterLabelController) initialLoad(ctx context.Context) error {
+	err := wait.PollUntilContextCancel(ctx, initialLoadRetryPeriod, true, func(ctx context.Context) (bool, error) {
+		if _, err := c.Reconcile(ctx, ctrl.Request{NamespacedName: c.key}); err != nil {
+			klog.Warningf("Failed to load the cluster labels: %v", err)
+			return false, nil
+		}
+		return true, nil
+	})
+	if ctx.Err() != nil {
+		// The manager is shutting down.
+		return nil
+	}
+	return err
+}
+
+// SetupWithManager registers the cluster label controller.
+func (c *ClusterLabelController) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(manager.RunnableFunc(c.initialLoad)); err != nil {
+		return err
+	}
+	isSource := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return client.ObjectKeyFromObject(obj) == c.key
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("ClusterLabelController").
+		Watches(c.newObject(),
+			&handler.EnqueueRequestForObject{},
+			builder.WithPredicates(isSource)).
+		Complete(c)
+}
@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configmanagement"
+	"kpt.dev/configsync/pkg/api/configsync"
+	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/syncer/syncertest/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClusterLabelControllerReconcile(t *testing.T) {
+	labelsConfigMap := k8sobjects.ConfigMapObject(core.Name("cluster-labels"),
+		core.Namespace(configmanagement.ControllerNamespace))
+	labelsConfigMap.Data = map[string]string{"environment": "prod"}
+
+	membership := &hubv1.Membership{}
+	membership.SetName(MembershipName)
+	membership.SetLabels(map[string]string{"environment": "dev"})
+
+	testCases := []struct {
+		name       string
+		kind       configsync.ClusterLabelsSourceKind
+		sourceName string
+		objs       []client.Object
+		wantLabels map[string]string
+	}{
+		{
+			name:       "ConfigMap data",
+			kind:       configsync.ClusterLabelsSourceConfigMap,
+			sourceName: "cluster-labels",
+			objs:       []client.Object{labelsConfigMap},
+			wantLabels: map[string]string{"environment": "prod"},
+		},
+		{
+			name:       "Membership labels",
+			kind:       configsync.ClusterLabelsSourceMembership,
+			objs:       []client.Object{membership},
+			wantLabels: map[string]string{"environment": "dev"},
+		},
+		{
+			name:       "missing ConfigMap has no labels",
+			kind:       configsync.ClusterLabelsSourceConfigMap,
+			sourceName: "other-labels",
+			objs:       []client.Object{labelsConfigMap},
+			wantLabels: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := NewState()
+			c, err := New(fake.NewClient(t, core.Scheme, tc.objs...), state, tc.kind, tc.sourceName)
+			require.NoError(t, err)
+
+			_, err = c.Reconcile(context.Background(), ctrl.Request{NamespacedName: c.key})
+			require.NoError(t, err)
+
+			got, loaded := state.Labels()
+			assert.True(t, loaded)
+			assert.Equal(t, tc.wantLabels, got)
+		})
+	}
+}
+
+func TestNewInvalidSource(t *testing.T) {
+	_, err := New(nil, NewState(), configsync.ClusterLabelsSourceConfigMap, "")
+	assert.Error(t, err)
+	_, err = New(nil, NewState(), "Secret", "labels")
+	assert.Error(t, err)
+}
+
+func TestCacheByObject(t *testing.T) {
+	c, err := New(nil, NewState(), configsync.ClusterLabelsSourceConfigMap, "cluster-labels")
+	require.NoError(t, err)
+	byObject := c.CacheByObject()
+	require.Len(t, byObject, 1)
+	for obj, opts := range byObject {
+		assert.IsType(t, &corev1.ConfigMap{}, obj)
+		assert.Equal(t, map[string]cache.Config{configmanagement.ControllerNamespace: {}}, opts.Namespaces)
+		assert.Equal(t, "metadata.name=cluster-labels", opts.Field.String())
+	}
+
+	c, err = New(nil, NewState(), configsync.ClusterLabelsSourceMembership, "")
+	require.NoError(t, err)
+	byObject = c.CacheByObject()
+	require.Len(t, byObject, 1)
+	for obj, opts := range byObject {
+		assert.IsType(t, &hubv1.Membership{}, obj)
+		assert.Nil(t, opts.Namespaces, "the Membership is cluster-scoped")
+		assert.Equal(t, "metadata.name="+MembershipName, opts.Field.String())
+	}
+}
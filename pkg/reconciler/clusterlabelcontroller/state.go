@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// State stores the cluster labels read by the cluster label controller.
+type State struct {
+	// mux protects read/write to all the fields.
+	mux sync.Mutex
+
+	// loaded indicates whether the controller has read the labels at least
+	// once.
+	loaded bool
+
+	// labels are the latest labels of the cluster.
+	labels labels.Set
+
+	// isSyncPending indicates whether the labels changed since the last sync.
+	isSyncPending bool
+}
+
+// NewState instantiates the cluster label controller state.
+func NewState() *State {
+	return &State{}
+}
+
+// setLabels records the latest labels of the cluster, and requests a new sync
+// if they changed.
+func (s *State) setLabels(newLabels map[string]string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.loaded && labels.Equals(s.labels, newLabels) {
+		return
+	}
+	s.labels = labels.Set{}
+	for k, v := range newLabels {
+		s.labels[k] = v
+	}
+	// Don't request a sync for the initial labels, as the first sync has not
+	// parsed the source yet.
+	s.isSyncPending = s.loaded
+	s.loaded = true
+}
+
+// Labels returns a copy of the latest labels of the cluster, and whether they
+// have been loaded yet.
+func (s *State) Labels() (map[string]string, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if !s.loaded {
+		return nil, false
+	}
+	result := make(map[string]string, len(s.labels))
+	for k, v := range s.labels {
+		result[k] = v
+	}
+	return result, true
+}
+
+// ScheduleSync checks whether the labels changed since the last sync.
+// If true, a sync should be scheduled by the reconciler thread, and it flips
+// the internal flag to false.
+func (s *State) ScheduleSync() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	isSyncPending := s.isSyncPending
+	s.isSyncPending = false
+	return isSyncPending
+}
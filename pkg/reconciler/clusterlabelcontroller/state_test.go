@@ -0,0 +1,49 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterlabelcontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSetLabels(t *testing.T) {
+	state := NewState()
+
+	_, loaded := state.Labels()
+	assert.False(t, loaded, "labels should not be loaded before the first read")
+
+	state.setLabels(map[string]string{"environment": "prod"})
+	got, loaded := state.Labels()
+	assert.True(t, loaded)
+	assert.Equal(t, map[string]string{"environment": "prod"}, got)
+	assert.False(t, state.ScheduleSync(), "the initial labels should not schedule a sync")
+
+	state.setLabels(map[string]string{"environment": "prod"})
+	assert.False(t, state.ScheduleSync(), "unchanged labels should not schedule a sync")
+
+	state.setLabels(map[string]string{"environment": "dev"})
+	got, _ = state.Labels()
+	assert.Equal(t, map[string]string{"environment": "dev"}, got)
+	assert.True(t, state.ScheduleSync(), "changed labels should schedule a sync")
+	assert.False(t, state.ScheduleSync(), "the pending sync should only be scheduled once")
+
+	state.setLabels(nil)
+	got, loaded = state.Labels()
+	assert.True(t, loaded)
+	assert.Empty(t, got)
+	assert.True(t, state.ScheduleSync(), "removed labels should schedule a sync")
+}
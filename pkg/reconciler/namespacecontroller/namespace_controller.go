@@ -61,8 +61,9 @@ func (nc *NamespaceController) Reconcile(ctx context.Context, req ctrl.Request)
 
 	if nc.state.matchChanged(ns) {
 		// The Namespace is either previously selected but no longer selected, or it
-		// is not selected but its labels match at least one NamespaceSelector's labels.
-		klog.Infof("The Namespace %s is either selected or unselected", req.Name)
+		// is not selected but its labels match at least one NamespaceSelector's labels,
+		// or the namespace templates instantiated into it need its new metadata.
+		klog.Infof("The Namespace %s is either selected, unselected or has new template metadata", req.Name)
 		nc.state.setSyncPending()
 	}
 	// Ignore the event of the non-selected and non-matching namespaces
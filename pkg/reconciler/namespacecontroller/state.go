@@ -18,7 +18,9 @@ import (
 	"sync"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // State stores the state of the Namespace Controller.
@@ -33,21 +35,42 @@ type State struct {
 	// selectorMux is the mutex to protect read/write to 'nsSelectors' and 'selectedNamespaces'.
 	selectorMux sync.Mutex
 
-	// nsSelectors is a map of NamespaceSelector names to the LabelSelectors.
-	nsSelectors map[string]labels.Selector
+	// nsSelectors is a map of NamespaceSelector names to their matchers.
+	nsSelectors map[string]*selectors.NamespaceMatcher
 
 	// selectedNamespaces records the dynamic/on-cluster Namespaces selected by NamespaceSelectors.
-	// It is a map from the Namespace names to the list of NamespaceSelector's LabelSelectors.
+	// It is a map from the Namespace names to the list of NamespaceSelector's matchers.
 	// A Namespace can be selected by multiple NamespaceSelectors.
-	// The LabelSelectors determine whether a Namespace's select state is changed.
-	selectedNamespaces map[string][]labels.Selector
+	// The matchers determine whether a Namespace's select state is changed.
+	selectedNamespaces map[string][]*selectors.NamespaceMatcher
+
+	// templatedNamespaces records the labels and annotations of the
+	// dynamic/on-cluster Namespaces selected for namespace templates, which
+	// are instantiated with them. It is a map from the Namespace names to
+	// their metadata when the templates were last instantiated.
+	templatedNamespaces map[string]namespaceMetadata
+}
+
+// namespaceMetadata is the metadata of a Namespace which namespace templates
+// are instantiated with.
+type namespaceMetadata struct {
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// changed returns whether the labels or annotations of the Namespace differ
+// from the recorded metadata.
+func (m namespaceMetadata) changed(ns *corev1.Namespace) bool {
+	return !equality.Semantic.DeepEqual(m.labels, ns.GetLabels()) ||
+		!equality.Semantic.DeepEqual(m.annotations, ns.GetAnnotations())
 }
 
 // NewState instantiates the namespace controller state.
 func NewState() *State {
 	return &State{
-		nsSelectors:        make(map[string]labels.Selector),
-		selectedNamespaces: make(map[string][]labels.Selector),
+		nsSelectors:         make(map[string]*selectors.NamespaceMatcher),
+		selectedNamespaces:  make(map[string][]*selectors.NamespaceMatcher),
+		templatedNamespaces: make(map[string]namespaceMetadata),
 	}
 }
 
@@ -75,12 +98,12 @@ func (s *State) ScheduleSync() bool {
 }
 
 // SetSelectorCache is invoked by the reconciler to set the cache after parsing the NamespaceSelectors.
-func (s *State) SetSelectorCache(nsSelectors map[string]labels.Selector, selectedNamespaces map[string][]labels.Selector) {
+func (s *State) SetSelectorCache(nsSelectors map[string]*selectors.NamespaceMatcher, selectedNamespaces map[string][]*selectors.NamespaceMatcher) {
 	s.selectorMux.Lock()
 	defer s.selectorMux.Unlock()
 
-	nssMapCopy := make(map[string]labels.Selector, len(nsSelectors))
-	selectedNSMapCopy := make(map[string][]labels.Selector, len(selectedNamespaces))
+	nssMapCopy := make(map[string]*selectors.NamespaceMatcher, len(nsSelectors))
+	selectedNSMapCopy := make(map[string][]*selectors.NamespaceMatcher, len(selectedNamespaces))
 	for nss, selector := range nsSelectors {
 		nssMapCopy[nss] = selector
 	}
@@ -92,6 +115,23 @@ func (s *State) SetSelectorCache(nsSelectors map[string]labels.Selector, selecte
 	s.selectedNamespaces = selectedNSMapCopy
 }
 
+// SetTemplatedNamespaces is invoked by the reconciler to record the
+// dynamic/on-cluster Namespaces which namespace templates were instantiated
+// into, so that a change to their labels or annotations triggers a new sync.
+func (s *State) SetTemplatedNamespaces(namespaces []client.Object) {
+	s.selectorMux.Lock()
+	defer s.selectorMux.Unlock()
+
+	templatedNSMap := make(map[string]namespaceMetadata, len(namespaces))
+	for _, ns := range namespaces {
+		templatedNSMap[ns.GetName()] = namespaceMetadata{
+			labels:      ns.GetLabels(),
+			annotations: ns.GetAnnotations(),
+		}
+	}
+	s.templatedNamespaces = templatedNSMap
+}
+
 // isSelectedNamespace returns whether the namespace is previously selected.
 func (s *State) isSelectedNamespace(ns string) bool {
 	s.selectorMux.Lock()
@@ -101,7 +141,10 @@ func (s *State) isSelectedNamespace(ns string) bool {
 	return found
 }
 
-// matchChanged returns whether the match of Namespace's labels and the cached selector has changed.
+// matchChanged returns whether the match of the Namespace's labels, annotations
+// and name with the cached selectors has changed, or whether the labels or
+// annotations of a Namespace which namespace templates were instantiated into
+// have changed.
 func (s *State) matchChanged(ns *corev1.Namespace) bool {
 	s.selectorMux.Lock()
 	defer s.selectorMux.Unlock()
@@ -110,7 +153,7 @@ func (s *State) matchChanged(ns *corev1.Namespace) bool {
 	if !found {
 		for _, selector := range s.nsSelectors {
 			// The Namespace is not selected before, but is now selected.
-			if selector.Matches(labels.Set(ns.GetLabels())) {
+			if selector.Matches(ns) {
 				return true
 			}
 		}
@@ -120,10 +163,14 @@ func (s *State) matchChanged(ns *corev1.Namespace) bool {
 
 	for _, selector := range nsSelectors {
 		// The Namespace is previously selected, but no longer selected by the selector.
-		if !selector.Matches(labels.Set(ns.GetLabels())) {
+		if !selector.Matches(ns) {
 			return true
 		}
 	}
-	// The Namespace is selected both before and now.
+	// The Namespace is selected both before and now, so the namespace
+	// templates must be instantiated again if its metadata changed.
+	if metadata, found := s.templatedNamespaces[ns.Name]; found {
+		return metadata.changed(ns)
+	}
 	return false
 }
@@ -19,65 +19,141 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
-	devLabelSelector     = labels.SelectorFromSet(map[string]string{"environment": "dev"})
-	featureLabelSelector = labels.SelectorFromSet(map[string]string{"feature": "abc"})
+	devLabelSelector      = newMatcher(v1.NamespaceSelectorSpec{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"environment": "dev"}}})
+	featureLabelSelector  = newMatcher(v1.NamespaceSelectorSpec{Selector: metav1.LabelSelector{MatchLabels: map[string]string{"feature": "abc"}}})
+	teamAnnotationMatcher = newMatcher(v1.NamespaceSelectorSpec{AnnotationSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"portal/team": "payments"}}})
+	teamNameMatcher       = newMatcher(v1.NamespaceSelectorSpec{NamePatterns: []string{"team-*"}})
 )
 
+func newMatcher(spec v1.NamespaceSelectorSpec) *selectors.NamespaceMatcher {
+	nss := k8sobjects.NamespaceSelectorObject()
+	nss.Spec = spec
+	m, err := selectors.NewNamespaceMatcher(nss)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
 func TestMatchChanged(t *testing.T) {
 	testCases := []struct {
-		name               string
-		nsSelectors        map[string]labels.Selector
-		selectedNamespaces map[string][]labels.Selector
-		ns                 *corev1.Namespace
-		wantChanged        bool
+		name                string
+		nsSelectors         map[string]*selectors.NamespaceMatcher
+		selectedNamespaces  map[string][]*selectors.NamespaceMatcher
+		templatedNamespaces []client.Object
+		ns                  *corev1.Namespace
+		wantChanged         bool
 	}{
 		{
 			name:               "a Namespace was previously selected by two selectors, but not selected at all",
-			nsSelectors:        map[string]labels.Selector{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
-			selectedNamespaces: map[string][]labels.Selector{"test-ns": {devLabelSelector, featureLabelSelector}},
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"test-ns": {devLabelSelector, featureLabelSelector}},
 			ns:                 k8sobjects.NamespaceObject("test-ns", core.Label("environment", "prod")),
 			wantChanged:        true,
 		},
 		{
 			name:               "a Namespace was previously selected by two selectors, but only partially selected",
-			nsSelectors:        map[string]labels.Selector{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
-			selectedNamespaces: map[string][]labels.Selector{"test-ns": {devLabelSelector, featureLabelSelector}},
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"test-ns": {devLabelSelector, featureLabelSelector}},
 			ns:                 k8sobjects.NamespaceObject("test-ns", core.Label("environment", "dev")),
 			wantChanged:        true,
 		},
 		{
 			name:               "a Namespace was previously selected, and is also selected now",
-			nsSelectors:        map[string]labels.Selector{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
-			selectedNamespaces: map[string][]labels.Selector{"test-ns": {devLabelSelector, featureLabelSelector}},
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"dev-nss": devLabelSelector, "feature-nss": featureLabelSelector},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"test-ns": {devLabelSelector, featureLabelSelector}},
 			ns:                 k8sobjects.NamespaceObject("test-ns", core.Labels(map[string]string{"environment": "dev", "feature": "abc"})),
 			wantChanged:        false,
 		},
 		{
 			name:               "a Namespace was not selected before, but is selected now",
-			nsSelectors:        map[string]labels.Selector{"test-nss": devLabelSelector},
-			selectedNamespaces: map[string][]labels.Selector{"other-ns": {devLabelSelector}},
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"test-nss": devLabelSelector},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"other-ns": {devLabelSelector}},
 			ns:                 k8sobjects.NamespaceObject("test-ns", core.Label("environment", "dev")),
 			wantChanged:        true,
 		},
 		{
 			name:               "a Namespace is neither selected before nor selected now",
-			nsSelectors:        map[string]labels.Selector{"test-nss": devLabelSelector},
-			selectedNamespaces: map[string][]labels.Selector{"test-other": {devLabelSelector}},
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"test-nss": devLabelSelector},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"test-other": {devLabelSelector}},
 			ns:                 k8sobjects.NamespaceObject("test-ns", core.Label("environment", "prod")),
 			wantChanged:        false,
 		},
+		{
+			name:               "a Namespace was not selected before, but is annotated now",
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"team-nss": teamAnnotationMatcher},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{},
+			ns:                 k8sobjects.NamespaceObject("test-ns", core.Annotation("portal/team", "payments")),
+			wantChanged:        true,
+		},
+		{
+			name:               "a Namespace was previously selected by annotation, but the annotation changed",
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"team-nss": teamAnnotationMatcher},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"test-ns": {teamAnnotationMatcher}},
+			ns:                 k8sobjects.NamespaceObject("test-ns", core.Annotation("portal/team", "billing")),
+			wantChanged:        true,
+		},
+		{
+			name:               "a Namespace matching a name pattern is created",
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{},
+			ns:                 k8sobjects.NamespaceObject("team-a"),
+			wantChanged:        true,
+		},
+		{
+			name:               "a Namespace not matching a name pattern is created",
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{},
+			ns:                 k8sobjects.NamespaceObject("other-a"),
+			wantChanged:        false,
+		},
+		{
+			name:                "a templated Namespace is still selected, but its labels changed",
+			nsSelectors:         map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces:  map[string][]*selectors.NamespaceMatcher{"team-a": {teamNameMatcher}},
+			templatedNamespaces: []client.Object{k8sobjects.NamespaceObject("team-a", core.Label("cost-center", "1234"))},
+			ns:                  k8sobjects.NamespaceObject("team-a", core.Label("cost-center", "5678")),
+			wantChanged:         true,
+		},
+		{
+			name:                "a templated Namespace is still selected, but its annotations changed",
+			nsSelectors:         map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces:  map[string][]*selectors.NamespaceMatcher{"team-a": {teamNameMatcher}},
+			templatedNamespaces: []client.Object{k8sobjects.NamespaceObject("team-a")},
+			ns:                  k8sobjects.NamespaceObject("team-a", core.Annotation("owner", "alice")),
+			wantChanged:         true,
+		},
+		{
+			name:                "a templated Namespace is still selected, and its metadata is unchanged",
+			nsSelectors:         map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces:  map[string][]*selectors.NamespaceMatcher{"team-a": {teamNameMatcher}},
+			templatedNamespaces: []client.Object{k8sobjects.NamespaceObject("team-a", core.Label("cost-center", "1234"))},
+			ns:                  k8sobjects.NamespaceObject("team-a", core.Label("cost-center", "1234")),
+			wantChanged:         false,
+		},
+		{
+			name:               "a Namespace which is not templated is still selected, but its labels changed",
+			nsSelectors:        map[string]*selectors.NamespaceMatcher{"team-nss": teamNameMatcher},
+			selectedNamespaces: map[string][]*selectors.NamespaceMatcher{"team-a": {teamNameMatcher}},
+			ns:                 k8sobjects.NamespaceObject("team-a", core.Label("cost-center", "5678")),
+			wantChanged:        false,
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			state := NewState()
 			state.SetSelectorCache(tc.nsSelectors, tc.selectedNamespaces)
+			state.SetTemplatedNamespaces(tc.templatedNamespaces)
 			changed := state.matchChanged(tc.ns)
 			assert.Equal(t, tc.wantChanged, changed)
 		})
@@ -26,19 +26,26 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/applier"
 	"kpt.dev/configsync/pkg/applyset"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/client/restconfig"
+	"kpt.dev/configsync/pkg/commitstatus"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/importer/filesystem/cmpath"
 	"kpt.dev/configsync/pkg/importer/reader"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/notifier"
 	"kpt.dev/configsync/pkg/parse"
 	"kpt.dev/configsync/pkg/parse/events"
+	"kpt.dev/configsync/pkg/reconciler/clusterlabelcontroller"
 	"kpt.dev/configsync/pkg/reconciler/finalizer"
 	"kpt.dev/configsync/pkg/reconciler/namespacecontroller"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/remediator"
 	"kpt.dev/configsync/pkg/remediator/conflict"
@@ -53,6 +60,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
 // Options contains the settings for a reconciler process.
@@ -135,6 +143,28 @@ type Options struct {
 	WebhookEnabled bool
 	// ReconcilerSignalsDir is the absolute path to the directory of ready-to-render file shared with hydration-controller
 	ReconcilerSignalsDir cmpath.Absolute
+	// PrunePolicy limits which managed objects the applier may prune, and how
+	// many it may prune in a single sync.
+	PrunePolicy declared.PrunePolicy
+	// ObjectMetrics controls which objects have their apply, prune and wait
+	// outcomes and latencies recorded as metrics.
+	ObjectMetrics applier.ObjectMetricsOptions
+	// SubstitutionVariables are the values of the `${VAR}` placeholders
+	// substituted in the declared objects. Substitution is disabled when nil.
+	SubstitutionVariables map[string]string
+	// Notifications are the HTTP endpoints to notify when a commit syncs,
+	// fails to sync, or when the errors of a failing commit change.
+	Notifications []v1beta1.NotificationEndpoint
+	// CommitStatus configures the commit statuses posted to the Git provider.
+	// Commit statuses are disabled when nil.
+	CommitStatus *commitstatus.Options
+	// AuditLog configures the audit log of the writes to the managed objects.
+	// The writes are not audited when nil.
+	AuditLog *v1beta1.AuditLog
+	// PrometheusMetricsAddr is the address of the /metrics endpoint, which
+	// serves the metrics in the Prometheus format. The endpoint serves only
+	// the controller-runtime metrics, on the default address, if empty.
+	PrometheusMetricsAddr string
 }
 
 // RootOptions are the options specific to parsing Root repositories.
@@ -143,6 +173,13 @@ type RootOptions struct {
 	SourceFormat configsync.SourceFormat
 	// NamespaceStrategy indicates the NamespaceStrategy used by this reconciler.
 	NamespaceStrategy configsync.NamespaceStrategy
+	// ClusterLabelsSourceKind is the kind of the cluster-local object to read
+	// the cluster labels from, for matching ClusterSelectors.
+	// Empty to only match the labels of the declared Cluster objects.
+	ClusterLabelsSourceKind configsync.ClusterLabelsSourceKind
+	// ClusterLabelsSourceName is the name of the ConfigMap to read the cluster
+	// labels from. Ignored for Memberships.
+	ClusterLabelsSourceName string
 }
 
 // Run configures and starts the various components of a reconciler process.
@@ -187,10 +224,60 @@ func Run(opts Options) {
 		klog.Fatalf("failed to create client: %v", err)
 	}
 
+	// Configure the Events recorded on the RootSync or RepoSync.
+	k8sEventRecorder, stopEventRecorder, err := eventrecorder.NewEventRecorder(cfg, opts.ReconcilerName)
+	if err != nil {
+		klog.Fatalf("Error creating event recorder: %v", err)
+	}
+	defer stopEventRecorder()
+	eventRecorder := eventrecorder.New(signalCtx, k8sEventRecorder, cl, opts.ReconcilerScope, opts.SyncName)
+
+	// Configure the notifications of sync results.
+	var syncNotifier *notifier.Notifier
+	if len(opts.Notifications) > 0 {
+		syncNotifier, err = notifier.New(opts.Notifications, opts.ClusterName, opts.ReconcilerScope, opts.SyncName)
+		if err != nil {
+			klog.Fatalf("Error creating notifier: %v", err)
+		}
+		go syncNotifier.Run(signalCtx)
+	}
+
+	// Configure the commit statuses posted to the Git provider.
+	var commitStatusReporter *commitstatus.Reporter
+	if opts.CommitStatus != nil {
+		commitStatusOpts := *opts.CommitStatus
+		commitStatusOpts.Repo = opts.SourceRepo
+		commitStatusOpts.ClusterName = opts.ClusterName
+		commitStatusOpts.Scope = opts.ReconcilerScope
+		commitStatusOpts.SyncName = opts.SyncName
+		commitStatusReporter, err = commitstatus.New(commitStatusOpts)
+		if err != nil {
+			klog.Fatalf("Error creating commit status reporter: %v", err)
+		}
+		go commitStatusReporter.Run(signalCtx)
+	}
+
+	// Configure the audit log of the writes to the managed objects.
+	var auditLog *audit.Logger
+	if opts.AuditLog != nil {
+		auditLog, err = audit.New(*opts.AuditLog, audit.Options{
+			Dir:            reconcilermanager.AuditLogDir,
+			ReconcilerName: opts.ReconcilerName,
+			ClusterName:    opts.ClusterName,
+			SyncKind:       opts.ReconcilerScope.SyncKind(),
+			SyncNamespace:  opts.ReconcilerScope.SyncNamespace(),
+			SyncName:       opts.SyncName,
+		})
+		if err != nil {
+			klog.Fatalf("Error creating audit log: %v", err)
+		}
+		go auditLog.Run(signalCtx)
+	}
+
 	// Configure the Applier.
 	applySetID := applyset.IDFromSync(opts.SyncName, opts.ReconcilerScope)
 	genericClient := syncerclient.New(cl, metrics.APICallDuration)
-	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient, applySetID)
+	baseApplier, err := reconcile.NewApplierForMultiRepo(cfg, genericClient, applySetID, auditLog)
 	if err != nil {
 		klog.Fatalf("Instantiating Applier: %v", err)
 	}
@@ -206,7 +293,8 @@ func Run(opts Options) {
 	if err != nil {
 		klog.Fatalf("Error creating clients: %v", err)
 	}
-	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout)
+	clientSet.AuditLog = auditLog
+	supervisor := applier.NewSupervisor(clientSet, opts.ReconcilerScope, opts.SyncName, reconcileTimeout, opts.PrunePolicy)
 	if err := supervisor.UpdateStatusMode(signalCtx); err != nil {
 		klog.Fatalf("Error setting status mode on ResourceGroup: %v", err)
 	}
@@ -231,10 +319,10 @@ func Run(opts Options) {
 	}
 	watcherFactory := watch.WatcherFactoryFromListerWatcherFactory(lwFactory.ListerWatcher)
 	crdController := &controllers.CRDController{}
-	conflictHandler := conflict.NewHandler()
+	conflictHandler := conflict.NewHandler(eventRecorder)
 	fightHandler := fight.NewHandler()
 
-	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, crdController, decls, opts.NumWorkers)
+	rem, err := remediator.New(opts.ReconcilerScope, opts.SyncName, watcherFactory, mapper, baseApplier, conflictHandler, fightHandler, eventRecorder, crdController, decls, opts.NumWorkers)
 	if err != nil {
 		klog.Fatalf("Instantiating Remediator: %v", err)
 	}
@@ -266,6 +354,10 @@ func Run(opts Options) {
 		Files:             parse.Files{FileSource: fs},
 		WebhookEnabled:    opts.WebhookEnabled,
 		DeclaredResources: decls,
+		Variables:         opts.SubstitutionVariables,
+		EventRecorder:     eventRecorder,
+		Notifier:          syncNotifier,
+		CommitStatus:      commitStatusReporter,
 	}
 	// Only instantiate the converter when the webhook is enabled because the
 	// instantiation pulls fresh schemas from the openapi discovery endpoint.
@@ -298,6 +390,9 @@ func Run(opts Options) {
 			Applier:        supervisor,
 			Remediator:     rem,
 			SyncErrorCache: parse.NewSyncErrorCache(conflictHandler, fightHandler),
+			HookRunner:     applier.NewHookRunner(clientSet, reconcileTimeout),
+			ObjectMetrics:  opts.ObjectMetrics,
+			EventRecorder:  eventRecorder,
 		},
 		FullSyncPeriod:     opts.FullSyncPeriod,
 		StatusUpdatePeriod: opts.StatusUpdatePeriod,
@@ -305,6 +400,7 @@ func Run(opts Options) {
 	}
 
 	var nsControllerState *namespacecontroller.State
+	var clusterLabelsState *clusterlabelcontroller.State
 	if opts.ReconcilerScope == declared.RootScope {
 		rootParseOpts := &parse.RootOptions{
 			Options:                  parseOpts,
@@ -322,6 +418,12 @@ func Run(opts Options) {
 			// TODO: Trigger namespace events with a buffered channel from the NamespaceController
 			pgBuilder.NamespaceControllerPeriod = time.Second
 		}
+		if opts.ClusterLabelsSourceKind != "" {
+			clusterLabelsState = clusterlabelcontroller.NewState()
+			rootParseOpts.ClusterLabelsState = clusterLabelsState
+			// Enable cluster label events (every second)
+			pgBuilder.ClusterLabelsControllerPeriod = time.Second
+		}
 		reconciler = parse.NewRootSyncReconciler(reconcilerOpts, rootParseOpts)
 	} else {
 		reconciler = parse.NewRepoSyncReconciler(reconcilerOpts, parseOpts)
@@ -334,6 +436,7 @@ func Run(opts Options) {
 		MapperProvider: func(_ *rest.Config, _ *http.Client) (meta.RESTMapper, error) {
 			return mapper, nil
 		},
+		Metrics: metricsserver.Options{BindAddress: opts.PrometheusMetricsAddr},
 		BaseContext: func() context.Context {
 			return signalCtx
 		},
@@ -347,6 +450,18 @@ func Run(opts Options) {
 			string(opts.ReconcilerScope): {},
 		}
 	}
+	// Only create the Cluster Label Controller when the RootSync reads the
+	// cluster labels from a cluster-local object.
+	var clController *clusterlabelcontroller.ClusterLabelController
+	if clusterLabelsState != nil {
+		clController, err = clusterlabelcontroller.New(cl, clusterLabelsState,
+			opts.ClusterLabelsSourceKind, opts.ClusterLabelsSourceName)
+		if err != nil {
+			klog.Fatalf("Instantiating Cluster Label Controller: %v", err)
+		}
+		// Only cache the source object of the cluster labels.
+		mgrOptions.Cache.ByObject = clController.CacheByObject()
+	}
 	mgr, err := ctrl.NewManager(cfgForWatch, mgrOptions)
 	if err != nil {
 		klog.Fatalf("Instantiating Controller Manager: %v", err)
@@ -401,6 +516,13 @@ func Run(opts Options) {
 		}
 	}
 
+	// Register the Cluster Label Controller
+	if clController != nil {
+		if err := clController.SetupWithManager(mgr); err != nil {
+			klog.Fatalf("Instantiating Cluster Label Controller: %v", err)
+		}
+	}
+
 	klog.Info("Starting ControllerManager")
 	// TODO: Once everything is using the controller-manager, move mgr.Start to the top level.
 	doneChanForManager := make(chan struct{})
@@ -430,7 +552,7 @@ func Run(opts Options) {
 	funnel := &events.Funnel{
 		Publishers: pgBuilder.Build(),
 		// Wrap the parser with an event handler that triggers the RunFunc, as needed.
-		Subscriber: parse.NewEventHandler(ctx, reconciler, nsControllerState),
+		Subscriber: parse.NewEventHandler(ctx, reconciler, nsControllerState, clusterLabelsState),
 	}
 	doneChForParser := funnel.Start(ctx)
 
@@ -107,8 +107,98 @@ const (
 	// WebhookEnabled tells the reconciler container whether the Admission Webhook
 	// is installed and running on the cluster.
 	WebhookEnabled = "WEBHOOK_ENABLED"
+
+	// PruneMaxCount tells the reconciler container the maximum number of
+	// managed objects it may prune in a single sync.
+	PruneMaxCount = "PRUNE_MAX_COUNT"
+
+	// PruneMaxPercent tells the reconciler container the maximum percentage of
+	// managed objects it may prune in a single sync.
+	PruneMaxPercent = "PRUNE_MAX_PERCENT"
+
+	// PruneProtectedKinds tells the reconciler container which GroupKinds it
+	// must never prune, as a comma-separated list of `Kind.group`.
+	PruneProtectedKinds = "PRUNE_PROTECTED_KINDS"
+
+	// PruneConfirmedCommit tells the reconciler container which source commit
+	// is allowed to exceed the prune limits.
+	PruneConfirmedCommit = "PRUNE_CONFIRMED_COMMIT"
+
+	// SubstitutionVariables tells the reconciler container the variables to
+	// substitute in the declared objects, as a JSON object.
+	SubstitutionVariables = "SUBSTITUTION_VARIABLES"
+
+	// ClusterLabelsSourceKind tells the reconciler container the kind of the
+	// cluster-local object whose labels are matched by ClusterSelectors.
+	ClusterLabelsSourceKind = "CLUSTER_LABELS_SOURCE_KIND"
+
+	// ClusterLabelsSourceName tells the reconciler container the name of the
+	// ConfigMap whose data are matched by ClusterSelectors.
+	ClusterLabelsSourceName = "CLUSTER_LABELS_SOURCE_NAME"
+
+	// ObjectMetricsKinds tells the reconciler container which GroupKinds to
+	// record object metrics for, as a comma-separated list of `Kind.group`.
+	ObjectMetricsKinds = "OBJECT_METRICS_KINDS"
+
+	// ObjectMetricsNamespaces tells the reconciler container whether to add the
+	// namespace of the objects to the object metrics.
+	ObjectMetricsNamespaces = "OBJECT_METRICS_NAMESPACES"
+
+	// Notifications tells the reconciler container the HTTP endpoints to
+	// notify of sync results, as a JSON list of NotificationEndpoints.
+	Notifications = "NOTIFICATIONS"
+
+	// AuditLog tells the reconciler container where to record the audit log
+	// of its writes, as a JSON AuditLog. The writes are not audited if unset.
+	AuditLog = "AUDIT_LOG"
+
+	// PrometheusMetricsAddr tells the reconciler container the address of the
+	// /metrics endpoint, which serves the metrics in the Prometheus format.
+	// The Config Sync metrics are not served if unset.
+	PrometheusMetricsAddr = "PROMETHEUS_METRICS_ADDR"
+
+	// CommitStatusProvider tells the reconciler container the API of the Git
+	// provider to post commit statuses to. Commit statuses are disabled if
+	// unset.
+	CommitStatusProvider = "COMMIT_STATUS_PROVIDER"
+
+	// CommitStatusAPIURL tells the reconciler container the base URL of the
+	// API of the Git provider.
+	CommitStatusAPIURL = "COMMIT_STATUS_API_URL"
+
+	// CommitStatusTargetURL tells the reconciler container the link of the
+	// commit statuses.
+	CommitStatusTargetURL = "COMMIT_STATUS_TARGET_URL"
+
+	// CommitStatusToken is the token used to post commit statuses with the
+	// token auth type.
+	CommitStatusToken = "COMMIT_STATUS_TOKEN"
+
+	// CommitStatusGithubAppPrivateKey is the private key used to post commit
+	// statuses with the githubapp auth type.
+	CommitStatusGithubAppPrivateKey = "COMMIT_STATUS_GITHUB_APP_PRIVATE_KEY"
+
+	// CommitStatusGithubAppClientID is the client id used to post commit
+	// statuses with the githubapp auth type.
+	CommitStatusGithubAppClientID = "COMMIT_STATUS_GITHUB_APP_CLIENT_ID"
+
+	// CommitStatusGithubAppApplicationID is the app id used to post commit
+	// statuses with the githubapp auth type.
+	CommitStatusGithubAppApplicationID = "COMMIT_STATUS_GITHUB_APP_APPLICATION_ID"
+
+	// CommitStatusGithubAppInstallationID is the installation id used to post
+	// commit statuses with the githubapp auth type.
+	CommitStatusGithubAppInstallationID = "COMMIT_STATUS_GITHUB_APP_INSTALLATION_ID"
+
+	// CommitStatusGithubAppBaseURL is the optional GitHub API URL used to
+	// create the installation tokens of the githubapp auth type.
+	CommitStatusGithubAppBaseURL = "COMMIT_STATUS_GITHUB_APP_BASE_URL"
 )
 
+// AuditLogDir is the directory of the reconciler container where the volume
+// of the audit log file is mounted.
+const AuditLogDir = "/audit-log"
+
 const (
 	// SourceTypeKey is the OS env variable key for the type of the source repo, must be git or oci or helm.
 	SourceTypeKey = "SOURCE_TYPE"
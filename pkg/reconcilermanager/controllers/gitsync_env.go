@@ -22,6 +22,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 )
 
 const (
@@ -115,11 +117,43 @@ type githubAppSpec struct {
 	baseURL        string
 }
 
+// githubAppEnvNames are the names of the environment variables that the
+// githubapp configuration is mapped to.
+type githubAppEnvNames struct {
+	privateKey     string
+	clientID       string
+	appID          string
+	installationID string
+	baseURL        string
+}
+
 // GitSyncEnvVars maps the github app configuration to git-sync env vars
 func (g githubAppSpec) GitSyncEnvVars(secretRef string) []corev1.EnvVar {
+	return g.envVars(secretRef, githubAppEnvNames{
+		privateKey:     GithubAppPrivateKey,
+		clientID:       GithubAppClientID,
+		appID:          GithubAppApplicationID,
+		installationID: GithubAppInstallationID,
+		baseURL:        GithubAppBaseURL,
+	})
+}
+
+// CommitStatusEnvVars maps the github app configuration to the reconciler
+// env vars used to post commit statuses.
+func (g githubAppSpec) CommitStatusEnvVars(secretRef string) []corev1.EnvVar {
+	return g.envVars(secretRef, githubAppEnvNames{
+		privateKey:     reconcilermanager.CommitStatusGithubAppPrivateKey,
+		clientID:       reconcilermanager.CommitStatusGithubAppClientID,
+		appID:          reconcilermanager.CommitStatusGithubAppApplicationID,
+		installationID: reconcilermanager.CommitStatusGithubAppInstallationID,
+		baseURL:        reconcilermanager.CommitStatusGithubAppBaseURL,
+	})
+}
+
+func (g githubAppSpec) envVars(secretRef string, names githubAppEnvNames) []corev1.EnvVar {
 	envVars := []corev1.EnvVar{
 		{
-			Name: GithubAppPrivateKey,
+			Name: names.privateKey,
 			ValueFrom: &corev1.EnvVarSource{
 				SecretKeyRef: &corev1.SecretKeySelector{
 					LocalObjectReference: corev1.LocalObjectReference{
@@ -132,25 +166,25 @@ func (g githubAppSpec) GitSyncEnvVars(secretRef string) []corev1.EnvVar {
 	}
 	if g.clientID != "" {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  GithubAppClientID,
+			Name:  names.clientID,
 			Value: g.clientID,
 		})
 	}
 	if g.appID != "" {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  GithubAppApplicationID,
+			Name:  names.appID,
 			Value: g.appID,
 		})
 	}
 	if g.installationID != "" {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  GithubAppInstallationID,
+			Name:  names.installationID,
 			Value: g.installationID,
 		})
 	}
 	if g.baseURL != "" {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  GithubAppBaseURL,
+			Name:  names.baseURL,
 			Value: g.baseURL,
 		})
 	}
@@ -212,6 +246,48 @@ func gitSyncTokenAuthEnv(secretRef string) []corev1.EnvVar {
 	}
 }
 
+// commitStatusEnvs returns the environment variables for the reconciler
+// container to post commit statuses to the Git provider, with the credentials
+// of the git Secret. Returns nil if commit statuses are disabled.
+func commitStatusEnvs(git *v1beta1.Git, secretRef string, githubApp githubAppSpec) []corev1.EnvVar {
+	if git == nil || git.CommitStatus == nil {
+		return nil
+	}
+	result := []corev1.EnvVar{{
+		Name:  reconcilermanager.CommitStatusProvider,
+		Value: string(git.CommitStatus.Provider),
+	}}
+	if git.CommitStatus.APIURL != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.CommitStatusAPIURL,
+			Value: git.CommitStatus.APIURL,
+		})
+	}
+	if git.CommitStatus.TargetURL != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.CommitStatusTargetURL,
+			Value: git.CommitStatus.TargetURL,
+		})
+	}
+	switch git.Auth {
+	case configsync.AuthToken:
+		result = append(result, corev1.EnvVar{
+			Name: reconcilermanager.CommitStatusToken,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: secretRef,
+					},
+					Key: "token",
+				},
+			},
+		})
+	case configsync.AuthGithubApp:
+		result = append(result, githubApp.CommitStatusEnvVars(secretRef)...)
+	}
+	return result
+}
+
 // gitSyncHttpsProxyEnv returns environment variables for git-sync container for https_proxy env.
 func gitSyncHTTPSProxyEnv(secretRef string, keys map[string]bool) []corev1.EnvVar {
 	var envVars []corev1.EnvVar
@@ -19,6 +19,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 )
 
@@ -159,3 +161,69 @@ func TestGitSyncEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitStatusEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		git             *v1beta1.Git
+		githubApp       githubAppSpec
+		expectedEnvVars []corev1.EnvVar
+	}{
+		"commit status disabled": {
+			git:             &v1beta1.Git{Auth: configsync.AuthToken},
+			expectedEnvVars: nil,
+		},
+		"token": {
+			git: &v1beta1.Git{
+				Auth: configsync.AuthToken,
+				CommitStatus: &v1beta1.GitCommitStatus{
+					Provider:  configsync.GitProviderGitLab,
+					APIURL:    "https://gitlab.example.com/api/v4",
+					TargetURL: "https://console.example.com",
+				},
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: "COMMIT_STATUS_PROVIDER", Value: "gitlab"},
+				{Name: "COMMIT_STATUS_API_URL", Value: "https://gitlab.example.com/api/v4"},
+				{Name: "COMMIT_STATUS_TARGET_URL", Value: "https://console.example.com"},
+				{
+					Name: "COMMIT_STATUS_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "foo"},
+							Key:                  "token",
+						},
+					},
+				},
+			},
+		},
+		"githubapp": {
+			git: &v1beta1.Git{
+				Auth:         configsync.AuthGithubApp,
+				CommitStatus: &v1beta1.GitCommitStatus{Provider: configsync.GitProviderGitHub},
+			},
+			githubApp: githubAppSpec{
+				appID:          "app-id-0",
+				installationID: "installation-id-0",
+			},
+			expectedEnvVars: []corev1.EnvVar{
+				{Name: "COMMIT_STATUS_PROVIDER", Value: "github"},
+				{
+					Name: "COMMIT_STATUS_GITHUB_APP_PRIVATE_KEY",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "foo"},
+							Key:                  "github-app-private-key",
+						},
+					},
+				},
+				{Name: "COMMIT_STATUS_GITHUB_APP_APPLICATION_ID", Value: "app-id-0"},
+				{Name: "COMMIT_STATUS_GITHUB_APP_INSTALLATION_ID", Value: "installation-id-0"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEnvVars, commitStatusEnvs(tc.git, "foo", tc.githubApp))
+		})
+	}
+}
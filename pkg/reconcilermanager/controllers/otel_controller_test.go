@@ -52,7 +52,7 @@ const (
 	// otel-collector ConfigMap.
 	// See `CollectorConfigGooglecloud` in `pkg/metrics/otel.go`
 	// Used by TestOtelReconcilerGooglecloud.
-	depAnnotationGooglecloud = "e5cf31ab812961f26bb9307a5ed46a33"
+	depAnnotationGooglecloud = "22ef85aa16ec49b437188231530797f2"
 	// depAnnotationGooglecloud is the expected hash of the custom
 	// otel-collector ConfigMap test artifact.
 	// Used by TestOtelReconcilerCustom.
@@ -65,8 +65,11 @@ const (
 data:
   otel-collector-config.yaml: |
     exporters:
+      debug:
+        verbosity: basic
       googlecloud:
         metric:
+          instrumentation_library_labels: false
           prefix: custom.googleapis.com/opencensus/config_sync/
           resource_filters:
           - prefix: cloud.account.id
@@ -370,8 +373,12 @@ data:
         - env
         - gcp
     receivers:
-      opencensus:
-        endpoint: 0.0.0.0:55678
+      otlp:
+        protocols:
+          grpc:
+            endpoint: 0.0.0.0:4317
+          http:
+            endpoint: 0.0.0.0:4318
     service:
       extensions:
       - health_check
@@ -385,7 +392,7 @@ data:
           - metricstransform/cloudmonitoring
           - resourcedetection
           receivers:
-          - opencensus
+          - otlp
         metrics/kubernetes:
           exporters:
           - googlecloud/kubernetes
@@ -395,14 +402,21 @@ data:
           - metricstransform/kubernetes
           - resourcedetection
           receivers:
-          - opencensus
+          - otlp
         metrics/prometheus:
           exporters:
           - prometheus
           processors:
           - batch
           receivers:
-          - opencensus
+          - otlp
+        traces:
+          exporters:
+          - debug
+          processors:
+          - batch
+          receivers:
+          - otlp
 kind: ConfigMap
 metadata:
   labels:
@@ -20,9 +20,11 @@ import (
 
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconcilermanager"
+	"kpt.dev/configsync/pkg/util/log"
 )
 
 // ReconcilerContainerLogLevelDefaults are the default log level to use for the
@@ -124,3 +126,27 @@ func mutateContainerLogLevel(c *corev1.Container, override []v1beta1.ContainerLo
 	}
 	return nil
 }
+
+// mutateContainerLogFormat will add the log format to the args of the Config
+// Sync containers. The git-sync and otel-agent containers keep their own log
+// format.
+func mutateContainerLogFormat(c *corev1.Container, format configsync.LogFormat) {
+	switch c.Name {
+	case reconcilermanager.Reconciler, reconcilermanager.HydrationController,
+		reconcilermanager.OciSync, reconcilermanager.HelmSync,
+		reconcilermanager.GCENodeAskpassSidecar:
+	default:
+		return
+	}
+	prefix := fmt.Sprintf("--%s=", log.FormatFlag)
+	for i, arg := range c.Args {
+		if strings.HasPrefix(arg, prefix) {
+			c.Args = removeArg(c.Args, i)
+			break
+		}
+	}
+	// Text is the default of the binaries.
+	if format != "" && format != configsync.LogFormatText {
+		c.Args = append(c.Args, prefix+string(format))
+	}
+}
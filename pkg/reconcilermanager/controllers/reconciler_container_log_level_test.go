@@ -20,8 +20,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/metrics"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 )
 
 func TestMutateContainerLogLevelOtelAgent(t *testing.T) {
@@ -92,3 +94,43 @@ func TestMutateContainerLogLevelOtelAgent(t *testing.T) {
 		})
 	}
 }
+
+func TestMutateContainerLogFormat(t *testing.T) {
+	testCases := map[string]struct {
+		container    string
+		args         []string
+		format       configsync.LogFormat
+		expectedArgs []string
+	}{
+		"reconciler with json format": {
+			container:    reconcilermanager.Reconciler,
+			args:         []string{"-v=0"},
+			format:       configsync.LogFormatJSON,
+			expectedArgs: []string{"-v=0", "--log-format=json"},
+		},
+		"hydration-controller with text format": {
+			container:    reconcilermanager.HydrationController,
+			args:         []string{"--log-format=json"},
+			format:       configsync.LogFormatText,
+			expectedArgs: []string{},
+		},
+		"oci-sync with unset format": {
+			container: reconcilermanager.OciSync,
+			format:    "",
+		},
+		"git-sync keeps its own format": {
+			container: reconcilermanager.GitSync,
+			format:    configsync.LogFormatJSON,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			container := &corev1.Container{
+				Name: tc.container,
+				Args: tc.args,
+			}
+			mutateContainerLogFormat(container, tc.format)
+			assert.Equal(t, tc.expectedArgs, container.Args)
+		})
+	}
+}
@@ -541,8 +541,12 @@ func (r *RepoSyncReconciler) Register(mgr controllerruntime.Manager, watchFleetM
 func (r *RepoSyncReconciler) watchConfigMaps(ctx context.Context, rs *v1beta1.RepoSync) error {
 	// We add watches dynamically at runtime based on the RepoSync namespace
 	// in order to avoid watching ConfigMaps in the entire cluster.
-	if rs == nil || rs.Spec.SourceType != configsync.HelmSource || rs.Spec.Helm == nil ||
-		len(rs.Spec.Helm.ValuesFileRefs) == 0 {
+	if rs == nil {
+		return nil
+	}
+	hasValuesFileRefs := rs.Spec.SourceType == configsync.HelmSource && rs.Spec.Helm != nil &&
+		len(rs.Spec.Helm.ValuesFileRefs) > 0
+	if !hasValuesFileRefs && substitutionConfigMapName(repoSyncOverrideSpec(rs)) == "" {
 		// TODO: When it's available, we should remove unneeded watches from the controller
 		// when all RepoSyncs with ConfigMap references in a particular namespace are
 		// deleted (or are no longer referencing ConfigMaps).
@@ -796,7 +800,8 @@ func (r *RepoSyncReconciler) mapConfigMapToRepoSyncs(ctx context.Context, obj cl
 	for _, rs := range repoSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
 		//TODO: Use stdlib slices.Contains in Go 1.21+
-		if slices.Contains(repoSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(repoSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			substitutionConfigMapName(repoSyncOverrideSpec(&rs)) == objRef.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -895,6 +900,7 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			gitConfig:      rs.Spec.Git,
 			ociConfig:      rs.Spec.Oci,
 			scope:          declared.Scope(rs.Namespace),
+			syncName:       rs.Name,
 			reconcilerName: reconcilerName,
 			pollPeriod:     r.hydrationPollingPeriod.String(),
 		}),
@@ -916,10 +922,28 @@ func (r *RepoSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			// Namespace reconciler doesn't support NamespaceSelector at all.
 			dynamicNSSelectorEnabled: false,
 			webhookEnabled:           r.webhookEnabled,
+			prunePolicy:              rs.Spec.SafeOverride().PrunePolicy,
+			objectMetrics:            rs.Spec.SafeOverride().ObjectMetrics,
 		}),
 	}
 
-	var err error
+	substitutionEnv, err := substitutionEnvs(ctx, r.client, rs.Namespace, rs.Spec.SafeOverride().SubstitutionConfigMapRef)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], substitutionEnv...)
+	notificationEnv, err := notificationEnvs(rs.Spec.SafeOverride().Notifications)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], notificationEnv...)
+	auditLogEnv, err := auditLogEnvs(rs.Spec.SafeOverride().AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], auditLogEnv...)
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler],
+		prometheusMetricsEnvs(rs.Spec.SafeOverride().PrometheusMetrics)...)
 
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
@@ -973,7 +997,14 @@ func (r *RepoSyncReconciler) validateRepoSync(ctx context.Context, rs *v1beta1.R
 		return err
 	}
 
-	return r.validateDependencies(ctx, rs, reconcilerName)
+	if err := r.validateDependencies(ctx, rs, reconcilerName); err != nil {
+		return err
+	}
+
+	if rs.Spec.Override == nil {
+		return nil
+	}
+	return validate.SubstitutionConfigMapRef(ctx, r.client, r.syncGVK.Kind, rs.Namespace, rs.Spec.Override.SubstitutionConfigMapRef)
 }
 
 func (r *RepoSyncReconciler) validateDependencies(ctx context.Context, rs *v1beta1.RepoSync, reconcilerName string) status.Error {
@@ -1207,6 +1238,11 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 			switch container.Name {
 			case reconcilermanager.Reconciler:
 				container.Env = append(container.Env, containerEnvs[container.Name]...)
+				if rs.Spec.SourceType == configsync.GitSource {
+					container.Env = append(container.Env, commitStatusEnvs(rs.Spec.Git, secretName, r.githubApp)...)
+				}
+				mountAuditLogVolume(templateSpec, &container, overrides.AuditLog)
+				addPrometheusMetricsPort(&container, overrides.PrometheusMetrics)
 			case reconcilermanager.HydrationController:
 				if !r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey) {
 					// if the sync source does not require rendering, omit the hydration controller
@@ -1275,6 +1311,7 @@ func (r *RepoSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RepoS
 				if err := mutateContainerLogLevel(&container, containerLogLevels); err != nil {
 					return err
 				}
+				mutateContainerLogFormat(&container, overrides.LogFormat)
 				updatedContainers = append(updatedContainers, container)
 			}
 		}
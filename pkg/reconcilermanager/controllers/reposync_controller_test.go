@@ -4068,6 +4068,7 @@ func TestPopulateRepoContainerEnvs(t *testing.T) {
 			reconcilermanager.ScopeKey:               reposyncNs,
 			reconcilermanager.SourceTypeKey:          string(gitSource),
 			reconcilermanager.SyncDirKey:             reposyncDir,
+			reconcilermanager.SyncNameKey:            reposyncName,
 		},
 		reconcilermanager.Reconciler: {
 			reconcilermanager.ClusterNameKey:          testCluster,
@@ -527,7 +527,8 @@ func (r *RootSyncReconciler) mapConfigMapsToRootSyncs(ctx context.Context, obj c
 	var attachedRSNames []string
 	for _, rs := range rootSyncList.Items {
 		// Only enqueue a request for the RSync if it references the ConfigMap that triggered the event
-		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) {
+		if slices.Contains(rootSyncHelmValuesFileNames(&rs), objRef.Name) ||
+			substitutionConfigMapName(rootSyncOverrideSpec(&rs)) == objRef.Name {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKeyFromObject(&rs),
 			})
@@ -755,6 +756,7 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 			gitConfig:      rs.Spec.Git,
 			ociConfig:      rs.Spec.Oci,
 			scope:          declared.RootScope,
+			syncName:       rs.Name,
 			reconcilerName: reconcilerName,
 			pollPeriod:     r.hydrationPollingPeriod.String(),
 		}),
@@ -776,13 +778,33 @@ func (r *RootSyncReconciler) populateContainerEnvs(ctx context.Context, rs *v1be
 				requiresRendering:        r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey),
 				dynamicNSSelectorEnabled: r.isAnnotationValueTrue(ctx, rs, metadata.DynamicNSSelectorEnabledAnnotationKey),
 				webhookEnabled:           r.webhookEnabled,
+				prunePolicy:              rs.Spec.SafeOverride().PrunePolicy,
+				objectMetrics:            rs.Spec.SafeOverride().ObjectMetrics,
 			}),
 			sourceFormatEnv(rs.Spec.SourceFormat),
 			namespaceStrategyEnv(rs.Spec.SafeOverride().NamespaceStrategy),
 		),
 	}
 
-	var err error
+	substitutionEnv, err := substitutionEnvs(ctx, r.client, rs.Namespace, rs.Spec.SafeOverride().SubstitutionConfigMapRef)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], substitutionEnv...)
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler],
+		clusterLabelsSourceEnvs(rs.Spec.SafeOverride().ClusterLabelsSource)...)
+	notificationEnv, err := notificationEnvs(rs.Spec.SafeOverride().Notifications)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], notificationEnv...)
+	auditLogEnv, err := auditLogEnvs(rs.Spec.SafeOverride().AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler], auditLogEnv...)
+	result[reconcilermanager.Reconciler] = append(result[reconcilermanager.Reconciler],
+		prometheusMetricsEnvs(rs.Spec.SafeOverride().PrometheusMetrics)...)
 
 	switch rs.Spec.SourceType {
 	case configsync.GitSource:
@@ -835,7 +857,14 @@ func (r *RootSyncReconciler) validateRootSync(ctx context.Context, rs *v1beta1.R
 		return err
 	}
 
-	return r.validateDependencies(ctx, rs)
+	if err := r.validateDependencies(ctx, rs); err != nil {
+		return err
+	}
+
+	if rs.Spec.Override == nil {
+		return nil
+	}
+	return validate.SubstitutionConfigMapRef(ctx, r.client, r.syncGVK.Kind, rs.Namespace, rs.Spec.Override.SubstitutionConfigMapRef)
 }
 
 func (r *RootSyncReconciler) validateDependencies(ctx context.Context, rs *v1beta1.RootSync) status.Error {
@@ -1175,6 +1204,11 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 			switch container.Name {
 			case reconcilermanager.Reconciler:
 				container.Env = append(container.Env, containerEnvs[container.Name]...)
+				if rs.Spec.SourceType == configsync.GitSource {
+					container.Env = append(container.Env, commitStatusEnvs(rs.Spec.Git, secretRefName, r.githubApp)...)
+				}
+				mountAuditLogVolume(templateSpec, &container, overrides.AuditLog)
+				addPrometheusMetricsPort(&container, overrides.PrometheusMetrics)
 			case reconcilermanager.HydrationController:
 				if !r.isAnnotationValueTrue(ctx, rs, metadata.RequiresRenderingAnnotationKey) {
 					// if the sync source does not require rendering, omit the hydration controller
@@ -1244,6 +1278,7 @@ func (r *RootSyncReconciler) mutationsFor(ctx context.Context, rs *v1beta1.RootS
 				if err := mutateContainerLogLevel(&container, containerLogLevels); err != nil {
 					return err
 				}
+				mutateContainerLogFormat(&container, overrides.LogFormat)
 				updatedContainers = append(updatedContainers, container)
 			}
 		}
@@ -3938,6 +3938,7 @@ func TestPopulateRootContainerEnvs(t *testing.T) {
 			reconcilermanager.ScopeKey:               ":root",
 			reconcilermanager.SourceTypeKey:          string(gitSource),
 			reconcilermanager.SyncDirKey:             rootsyncDir,
+			reconcilermanager.SyncNameKey:            rootsyncName,
 		},
 		reconcilermanager.Reconciler: {
 			reconcilermanager.ClusterNameKey:          testCluster,
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// substitutionEnvs returns the environment variable with the substitution
+// variables for the reconciler container, read from the ConfigMap referenced
+// by spec.override.substitutionConfigMapRef in the RSync namespace.
+// Returns nil if no ConfigMap is referenced, which disables substitution.
+func substitutionEnvs(ctx context.Context, c client.Client, syncNamespace string, ref *v1beta1.ConfigMapReference) ([]corev1.EnvVar, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	cmRef := types.NamespacedName{Namespace: syncNamespace, Name: ref.Name}
+	if err := c.Get(ctx, cmRef, cm); err != nil {
+		return nil, fmt.Errorf("getting substitution ConfigMap %s: %w", cmRef, err)
+	}
+	vars := cm.Data
+	if vars == nil {
+		// An empty ConfigMap still enables the built-in variables.
+		vars = map[string]string{}
+	}
+	value, err := json.Marshal(vars)
+	if err != nil {
+		return nil, fmt.Errorf("encoding substitution ConfigMap %s: %w", cmRef, err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.SubstitutionVariables,
+		Value: string(value),
+	}}, nil
+}
+
+// substitutionConfigMapName returns the name of the ConfigMap referenced by
+// spec.override.substitutionConfigMapRef, or an empty string if unset.
+func substitutionConfigMapName(override *v1beta1.OverrideSpec) string {
+	if override == nil || override.SubstitutionConfigMapRef == nil {
+		return ""
+	}
+	return override.SubstitutionConfigMapRef.Name
+}
+
+func rootSyncOverrideSpec(rs *v1beta1.RootSync) *v1beta1.OverrideSpec {
+	if rs.Spec.Override == nil {
+		return nil
+	}
+	return &rs.Spec.Override.OverrideSpec
+}
+
+func repoSyncOverrideSpec(rs *v1beta1.RepoSync) *v1beta1.OverrideSpec {
+	if rs.Spec.Override == nil {
+		return nil
+	}
+	return &rs.Spec.Override.OverrideSpec
+}
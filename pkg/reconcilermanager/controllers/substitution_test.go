@@ -0,0 +1,73 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/reconcilermanager"
+	syncerFake "kpt.dev/configsync/pkg/syncer/syncertest/fake"
+)
+
+func TestSubstitutionEnvs(t *testing.T) {
+	withData := k8sobjects.ConfigMapObject(core.Name("cluster-vars"), core.Namespace("bookstore"))
+	withData.Data = map[string]string{"REGION": "us-east1", "DOMAIN": "example.com"}
+	empty := k8sobjects.ConfigMapObject(core.Name("empty-vars"), core.Namespace("bookstore"))
+
+	testCases := map[string]struct {
+		ref      *v1beta1.ConfigMapReference
+		expected []corev1.EnvVar
+		wantErr  bool
+	}{
+		"substitution disabled": {},
+		"ConfigMap with data": {
+			ref: &v1beta1.ConfigMapReference{Name: "cluster-vars"},
+			expected: []corev1.EnvVar{{
+				Name:  reconcilermanager.SubstitutionVariables,
+				Value: `{"DOMAIN":"example.com","REGION":"us-east1"}`,
+			}},
+		},
+		"ConfigMap without data": {
+			ref: &v1beta1.ConfigMapReference{Name: "empty-vars"},
+			expected: []corev1.EnvVar{{
+				Name:  reconcilermanager.SubstitutionVariables,
+				Value: `{}`,
+			}},
+		},
+		"missing ConfigMap": {
+			ref:     &v1beta1.ConfigMapReference{Name: "missing"},
+			wantErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := syncerFake.NewClient(t, core.Scheme, withData, empty)
+			envs, err := substitutionEnvs(context.Background(), fakeClient, "bookstore", tc.ref)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, envs)
+		})
+	}
+}
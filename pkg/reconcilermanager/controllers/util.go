@@ -15,6 +15,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -27,9 +28,11 @@ import (
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/importer/filesystem"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/reconcilermanager"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // updateHydrationControllerImage sets the image of hydration-controller based
@@ -46,6 +49,7 @@ type hydrationOptions struct {
 	gitConfig      *v1beta1.Git
 	ociConfig      *v1beta1.Oci
 	scope          declared.Scope
+	syncName       string
 	reconcilerName string
 	pollPeriod     string
 }
@@ -72,6 +76,10 @@ func hydrationEnvs(opts hydrationOptions) []corev1.EnvVar {
 			Name:  reconcilermanager.ScopeKey,
 			Value: string(opts.scope),
 		},
+		corev1.EnvVar{
+			Name:  reconcilermanager.SyncNameKey,
+			Value: opts.syncName,
+		},
 		corev1.EnvVar{
 			Name:  reconcilermanager.ReconcilerNameKey,
 			Value: opts.reconcilerName,
@@ -109,6 +117,8 @@ type reconcilerOptions struct {
 	requiresRendering        bool
 	dynamicNSSelectorEnabled bool
 	webhookEnabled           bool
+	prunePolicy              *v1beta1.PrunePolicy
+	objectMetrics            *v1beta1.ObjectMetrics
 }
 
 // reconcilerEnvs returns environment variables for namespace reconciler.
@@ -227,6 +237,9 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 		)
 	}
 
+	result = append(result, prunePolicyEnvs(opts.prunePolicy)...)
+	result = append(result, objectMetricsEnvs(opts.objectMetrics)...)
+
 	if syncBranch != "" {
 		result = append(result, corev1.EnvVar{
 			Name:  reconcilermanager.SourceBranchKey,
@@ -242,6 +255,67 @@ func reconcilerEnvs(opts reconcilerOptions) []corev1.EnvVar {
 	return result
 }
 
+// prunePolicyEnvs returns the environment variables for the prune policy in
+// the reconciler container. Unset limits are omitted.
+func prunePolicyEnvs(policy *v1beta1.PrunePolicy) []corev1.EnvVar {
+	if policy == nil {
+		return nil
+	}
+	var result []corev1.EnvVar
+	if policy.MaxPruneCount != nil && *policy.MaxPruneCount > 0 {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.PruneMaxCount,
+			Value: strconv.FormatInt(*policy.MaxPruneCount, 10),
+		})
+	}
+	if policy.MaxPrunePercent != nil && *policy.MaxPrunePercent > 0 {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.PruneMaxPercent,
+			Value: strconv.FormatInt(*policy.MaxPrunePercent, 10),
+		})
+	}
+	if len(policy.ProtectedKinds) > 0 {
+		var gks []string
+		for _, pk := range policy.ProtectedKinds {
+			gks = append(gks, schema.GroupKind{Group: pk.Group, Kind: pk.Kind}.String())
+		}
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.PruneProtectedKinds,
+			Value: strings.Join(gks, ","),
+		})
+	}
+	if policy.ConfirmedCommit != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.PruneConfirmedCommit,
+			Value: policy.ConfirmedCommit,
+		})
+	}
+	return result
+}
+
+// objectMetricsEnvs returns the environment variables for the object metrics
+// in the reconciler container. Object metrics are disabled without kinds.
+func objectMetricsEnvs(objectMetrics *v1beta1.ObjectMetrics) []corev1.EnvVar {
+	if objectMetrics == nil || len(objectMetrics.Kinds) == 0 {
+		return nil
+	}
+	var gks []string
+	for _, k := range objectMetrics.Kinds {
+		gks = append(gks, schema.GroupKind{Group: k.Group, Kind: k.Kind}.String())
+	}
+	result := []corev1.EnvVar{{
+		Name:  reconcilermanager.ObjectMetricsKinds,
+		Value: strings.Join(gks, ","),
+	}}
+	if objectMetrics.IncludeNamespace {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.ObjectMetricsNamespaces,
+			Value: strconv.FormatBool(objectMetrics.IncludeNamespace),
+		})
+	}
+	return result
+}
+
 // sourceFormatEnv returns the environment variable for SOURCE_FORMAT in the reconciler container.
 func sourceFormatEnv(format configsync.SourceFormat) corev1.EnvVar {
 	return corev1.EnvVar{
@@ -261,6 +335,93 @@ func namespaceStrategyEnv(strategy configsync.NamespaceStrategy) corev1.EnvVar {
 	}
 }
 
+// clusterLabelsSourceEnvs returns the environment variables for the cluster
+// labels source in the reconciler container. Returns nil if unset.
+func clusterLabelsSourceEnvs(src *v1beta1.ClusterLabelsSource) []corev1.EnvVar {
+	if src == nil {
+		return nil
+	}
+	result := []corev1.EnvVar{{
+		Name:  reconcilermanager.ClusterLabelsSourceKind,
+		Value: string(src.Kind),
+	}}
+	if src.Name != "" {
+		result = append(result, corev1.EnvVar{
+			Name:  reconcilermanager.ClusterLabelsSourceName,
+			Value: src.Name,
+		})
+	}
+	return result
+}
+
+// notificationEnvs returns the environment variable with the notification
+// endpoints for the reconciler container. Returns nil if there are no
+// endpoints, which disables notifications.
+func notificationEnvs(endpoints []v1beta1.NotificationEndpoint) ([]corev1.EnvVar, error) {
+	if len(endpoints) == 0 {
+		return nil, nil
+	}
+	value, err := json.Marshal(endpoints)
+	if err != nil {
+		return nil, fmt.Errorf("encoding notification endpoints: %w", err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.Notifications,
+		Value: string(value),
+	}}, nil
+}
+
+// auditLogEnvs returns the environment variable with the audit log for the
+// reconciler container. Returns nil if the audit log is not set, which
+// disables the audit log.
+func auditLogEnvs(auditLog *v1beta1.AuditLog) ([]corev1.EnvVar, error) {
+	if auditLog == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(auditLog)
+	if err != nil {
+		return nil, fmt.Errorf("encoding audit log: %w", err)
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.AuditLog,
+		Value: string(value),
+	}}, nil
+}
+
+// prometheusMetricsEnvs returns the environment variable with the address of
+// the /metrics endpoint for the reconciler container, if the Config Sync
+// metrics are served in the Prometheus format.
+func prometheusMetricsEnvs(prometheusMetrics *v1beta1.PrometheusMetrics) []corev1.EnvVar {
+	if prometheusMetrics == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name:  reconcilermanager.PrometheusMetricsAddr,
+		Value: fmt.Sprintf(":%d", prometheusMetricsPort(prometheusMetrics)),
+	}}
+}
+
+// addPrometheusMetricsPort adds the named port of the /metrics endpoint to
+// the reconciler container, if the Config Sync metrics are served in the
+// Prometheus format, so that the port can be scraped by a PodMonitor.
+func addPrometheusMetricsPort(c *corev1.Container, prometheusMetrics *v1beta1.PrometheusMetrics) {
+	if prometheusMetrics == nil {
+		return
+	}
+	c.Ports = append(c.Ports, corev1.ContainerPort{
+		Name:          metrics.PrometheusMetricsPortName,
+		ContainerPort: prometheusMetricsPort(prometheusMetrics),
+		Protocol:      corev1.ProtocolTCP,
+	})
+}
+
+func prometheusMetricsPort(prometheusMetrics *v1beta1.PrometheusMetrics) int32 {
+	if prometheusMetrics.Port == 0 {
+		return metrics.PrometheusMetricsPort
+	}
+	return prometheusMetrics.Port
+}
+
 type ociOptions struct {
 	image           string
 	auth            configsync.AuthType
@@ -19,9 +19,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	"kpt.dev/configsync/pkg/reconcilermanager"
@@ -221,3 +223,280 @@ func TestOCISyncEnvs(t *testing.T) {
 		})
 	}
 }
+
+func TestPrunePolicyEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		policy       *v1beta1.PrunePolicy
+		expectedEnvs []corev1.EnvVar
+	}{
+		"nil policy": {
+			policy:       nil,
+			expectedEnvs: nil,
+		},
+		"zero limits": {
+			policy: &v1beta1.PrunePolicy{
+				MaxPruneCount:   ptr.To[int64](0),
+				MaxPrunePercent: ptr.To[int64](0),
+			},
+			expectedEnvs: nil,
+		},
+		"all fields": {
+			policy: &v1beta1.PrunePolicy{
+				MaxPruneCount:   ptr.To[int64](10),
+				MaxPrunePercent: ptr.To[int64](25),
+				ProtectedKinds: []v1beta1.PruneProtectedKind{
+					{Kind: "PersistentVolumeClaim"},
+					{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"},
+				},
+				ConfirmedCommit: "abc123",
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: reconcilermanager.PruneMaxCount, Value: "10"},
+				{Name: reconcilermanager.PruneMaxPercent, Value: "25"},
+				{Name: reconcilermanager.PruneProtectedKinds, Value: "PersistentVolumeClaim,CustomResourceDefinition.apiextensions.k8s.io"},
+				{Name: reconcilermanager.PruneConfirmedCommit, Value: "abc123"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := prunePolicyEnvs(tc.policy)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestClusterLabelsSourceEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		src          *v1beta1.ClusterLabelsSource
+		expectedEnvs []corev1.EnvVar
+	}{
+		"nil source": {
+			src:          nil,
+			expectedEnvs: nil,
+		},
+		"ConfigMap source": {
+			src: &v1beta1.ClusterLabelsSource{
+				Kind: configsync.ClusterLabelsSourceConfigMap,
+				Name: "cluster-labels",
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: reconcilermanager.ClusterLabelsSourceKind, Value: "ConfigMap"},
+				{Name: reconcilermanager.ClusterLabelsSourceName, Value: "cluster-labels"},
+			},
+		},
+		"Membership source": {
+			src: &v1beta1.ClusterLabelsSource{
+				Kind: configsync.ClusterLabelsSourceMembership,
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: reconcilermanager.ClusterLabelsSourceKind, Value: "Membership"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := clusterLabelsSourceEnvs(tc.src)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestObjectMetricsEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		objectMetrics *v1beta1.ObjectMetrics
+		expectedEnvs  []corev1.EnvVar
+	}{
+		"nil object metrics": {
+			objectMetrics: nil,
+			expectedEnvs:  nil,
+		},
+		"no kinds": {
+			objectMetrics: &v1beta1.ObjectMetrics{
+				IncludeNamespace: true,
+			},
+			expectedEnvs: nil,
+		},
+		"kinds": {
+			objectMetrics: &v1beta1.ObjectMetrics{
+				Kinds: []v1beta1.ObjectMetricsKind{
+					{Kind: "ConfigMap"},
+					{Group: "apps", Kind: "Deployment"},
+				},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: reconcilermanager.ObjectMetricsKinds, Value: "ConfigMap,Deployment.apps"},
+			},
+		},
+		"kinds and namespaces": {
+			objectMetrics: &v1beta1.ObjectMetrics{
+				Kinds: []v1beta1.ObjectMetricsKind{
+					{Group: "apps", Kind: "Deployment"},
+				},
+				IncludeNamespace: true,
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: reconcilermanager.ObjectMetricsKinds, Value: "Deployment.apps"},
+				{Name: reconcilermanager.ObjectMetricsNamespaces, Value: "true"},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs := objectMetricsEnvs(tc.objectMetrics)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestNotificationEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		endpoints    []v1beta1.NotificationEndpoint
+		expectedEnvs []corev1.EnvVar
+	}{
+		"no endpoints": {
+			endpoints:    nil,
+			expectedEnvs: nil,
+		},
+		"endpoints": {
+			endpoints: []v1beta1.NotificationEndpoint{
+				{Name: "generic", URL: "https://example.com/hook"},
+				{
+					Name:     "slack",
+					URL:      "https://hooks.slack.com/services/abc",
+					Format:   configsync.NotificationFormatSlack,
+					Triggers: []configsync.NotificationTrigger{configsync.NotificationTriggerFailed},
+				},
+			},
+			expectedEnvs: []corev1.EnvVar{{
+				Name:  reconcilermanager.Notifications,
+				Value: `[{"name":"generic","url":"https://example.com/hook"},{"name":"slack","url":"https://hooks.slack.com/services/abc","format":"slack","triggers":["Failed"]}]`,
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs, err := notificationEnvs(tc.endpoints)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestAuditLogEnvs(t *testing.T) {
+	testCases := map[string]struct {
+		auditLog     *v1beta1.AuditLog
+		expectedEnvs []corev1.EnvVar
+	}{
+		"no audit log": {
+			auditLog:     nil,
+			expectedEnvs: nil,
+		},
+		"file and sink": {
+			auditLog: &v1beta1.AuditLog{
+				File:    &v1beta1.AuditLogFile{ClaimName: "audit", MaxSizeMB: 50},
+				SinkURL: "https://example.com/audit",
+			},
+			expectedEnvs: []corev1.EnvVar{{
+				Name:  reconcilermanager.AuditLog,
+				Value: `{"file":{"claimName":"audit","maxSizeMB":50},"sinkURL":"https://example.com/audit"}`,
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			envs, err := auditLogEnvs(tc.auditLog)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnvs, envs)
+		})
+	}
+}
+
+func TestMountAuditLogVolume(t *testing.T) {
+	mount := corev1.VolumeMount{Name: AuditLogVolume, MountPath: reconcilermanager.AuditLogDir}
+	testCases := map[string]struct {
+		auditLog       *v1beta1.AuditLog
+		expectedVolume *corev1.Volume
+	}{
+		"no audit log": {
+			auditLog: nil,
+		},
+		"sink only": {
+			auditLog: &v1beta1.AuditLog{SinkURL: "https://example.com/audit"},
+		},
+		"file without claim": {
+			auditLog: &v1beta1.AuditLog{File: &v1beta1.AuditLogFile{}},
+			expectedVolume: &corev1.Volume{
+				Name:         AuditLogVolume,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			},
+		},
+		"file with claim": {
+			auditLog: &v1beta1.AuditLog{File: &v1beta1.AuditLogFile{ClaimName: "audit"}},
+			expectedVolume: &corev1.Volume{
+				Name: AuditLogVolume,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "audit"},
+				},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			templateSpec := &corev1.PodSpec{}
+			container := &corev1.Container{Name: reconcilermanager.Reconciler}
+			mountAuditLogVolume(templateSpec, container, tc.auditLog)
+			if tc.expectedVolume == nil {
+				assert.Empty(t, templateSpec.Volumes)
+				assert.Empty(t, container.VolumeMounts)
+				return
+			}
+			assert.Equal(t, []corev1.Volume{*tc.expectedVolume}, templateSpec.Volumes)
+			assert.Equal(t, []corev1.VolumeMount{mount}, container.VolumeMounts)
+		})
+	}
+}
+
+func TestPrometheusMetrics(t *testing.T) {
+	testCases := map[string]struct {
+		prometheusMetrics *v1beta1.PrometheusMetrics
+		expectedEnvs      []corev1.EnvVar
+		expectedPorts     []corev1.ContainerPort
+	}{
+		"disabled": {
+			prometheusMetrics: nil,
+		},
+		"default port": {
+			prometheusMetrics: &v1beta1.PrometheusMetrics{},
+			expectedEnvs: []corev1.EnvVar{{
+				Name:  reconcilermanager.PrometheusMetricsAddr,
+				Value: ":9464",
+			}},
+			expectedPorts: []corev1.ContainerPort{{
+				Name:          "prom-metrics",
+				ContainerPort: 9464,
+				Protocol:      corev1.ProtocolTCP,
+			}},
+		},
+		"custom port": {
+			prometheusMetrics: &v1beta1.PrometheusMetrics{Port: 9100},
+			expectedEnvs: []corev1.EnvVar{{
+				Name:  reconcilermanager.PrometheusMetricsAddr,
+				Value: ":9100",
+			}},
+			expectedPorts: []corev1.ContainerPort{{
+				Name:          "prom-metrics",
+				ContainerPort: 9100,
+				Protocol:      corev1.ProtocolTCP,
+			}},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedEnvs, prometheusMetricsEnvs(tc.prometheusMetrics))
+			container := &corev1.Container{Name: reconcilermanager.Reconciler}
+			addPrometheusMetricsPort(container, tc.prometheusMetrics)
+			assert.Equal(t, tc.expectedPorts, container.Ports)
+		})
+	}
+}
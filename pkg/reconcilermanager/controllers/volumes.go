@@ -21,8 +21,10 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
 	hubv1 "kpt.dev/configsync/pkg/api/hub/v1"
 	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/reconcilermanager"
 )
 
 // GitCredentialVolume is the volume name of the git credentials.
@@ -40,6 +42,9 @@ const CACertSecretKey = "cert"
 // CACertPath is the path where the certificate is mounted.
 const CACertPath = "/etc/ca-cert"
 
+// AuditLogVolume is the volume name of the audit log file.
+const AuditLogVolume = "audit-log"
+
 // defaultMode is the default permission of the `gcp-ksa` volume.
 var defaultMode int32 = 0644
 
@@ -147,3 +152,29 @@ func volumeMounts(auth configsync.AuthType, caCertSecretRef string, sourceType c
 	})
 	return volumeMount
 }
+
+// mountAuditLogVolume adds the volume of the audit log file to the Pod, and
+// mounts it in the reconciler container, if the audit log is written to a
+// file. The volume is the PersistentVolumeClaim of the audit log, or an
+// emptyDir if it has none.
+func mountAuditLogVolume(templateSpec *corev1.PodSpec, c *corev1.Container, auditLog *v1beta1.AuditLog) {
+	if auditLog == nil || auditLog.File == nil {
+		return
+	}
+	source := corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	if auditLog.File.ClaimName != "" {
+		source = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: auditLog.File.ClaimName,
+			},
+		}
+	}
+	templateSpec.Volumes = append(templateSpec.Volumes, corev1.Volume{
+		Name:         AuditLogVolume,
+		VolumeSource: source,
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      AuditLogVolume,
+		MountPath: reconcilermanager.AuditLogDir,
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/metrics"
 	"kpt.dev/configsync/pkg/status"
 )
@@ -62,33 +63,40 @@ type handler struct {
 	// conflictErrs tracks all the conflict errors (KNV1060) the remediator encounters,
 	// and report to RootSync|RepoSync status.
 	conflictErrs *orderedmap.OrderedMap[core.ID, status.ManagementConflictError]
+	// eventRecorder records an Event for each new conflict.
+	eventRecorder *eventrecorder.Recorder
 }
 
 var _ Handler = &handler{}
 
-// NewHandler instantiates a conflict handler
-func NewHandler() Handler {
+// NewHandler instantiates a conflict handler.
+// If the eventRecorder is not nil, an Event is recorded for each new conflict.
+func NewHandler(eventRecorder *eventrecorder.Recorder) Handler {
 	return &handler{
-		conflictErrs: orderedmap.NewOrderedMap[core.ID, status.ManagementConflictError](),
+		conflictErrs:  orderedmap.NewOrderedMap[core.ID, status.ManagementConflictError](),
+		eventRecorder: eventRecorder,
 	}
 }
 
 func (h *handler) AddConflictError(id core.ID, newErr status.ManagementConflictError) {
 	h.mux.Lock()
-	defer h.mux.Unlock()
-
+	oldErr, found := h.conflictErrs.Get(id)
 	// Ignore KptManagementConflictError if a ManagementConflictError was already reported.
 	// KptManagementConflictError don't have a real ConflictingManager recorded.
 	// TODO: Remove if cli-utils supports reporting the conflicting manager in InventoryOverlapError.
-	if newErr.CurrentManager() == UnknownManager {
-		if oldErr, found := h.conflictErrs.Get(id); found {
-			if oldErr.CurrentManager() != UnknownManager {
-				return
-			}
-		}
+	if found && newErr.CurrentManager() == UnknownManager && oldErr.CurrentManager() != UnknownManager {
+		h.mux.Unlock()
+		return
 	}
-
 	h.conflictErrs.Set(id, newErr)
+	h.mux.Unlock()
+
+	// Record the Event outside the lock, because it may call the API server.
+	if !found {
+		h.eventRecorder.Warning(eventrecorder.ReasonManagementConflict,
+			"Object %s declared by %s is managed by %s. Remove the object from one of the sources so that it is only managed by one reconciler.",
+			id, newErr.DesiredManager(), newErr.CurrentManager())
+	}
 }
 
 // HasConflictError returns true when there is a conflict for the specified object ID.
@@ -18,8 +18,7 @@ import (
 	"context"
 	"testing"
 
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/metrics"
@@ -63,13 +62,13 @@ func TestWasDeleted(t *testing.T) {
 }
 
 func TestDeleted_InternalErrorMetricValidation(t *testing.T) {
-	m := testmetrics.RegisterMetrics(metrics.InternalErrorsView)
+	m := testmetrics.RegisterMetrics()
 	ctx := context.Background()
 	MarkDeleted(ctx, nil)
-	wantMetrics := []*view.Row{
-		{Data: &view.CountData{Value: 1}, Tags: []tag.Tag{{Key: metrics.KeyInternalErrorSource, Value: "remediator"}}},
+	wantMetrics := []*testmetrics.Row{
+		{Value: 1, Attributes: []attribute.KeyValue{metrics.KeyInternalErrorSource.String("remediator")}},
 	}
-	if diff := m.ValidateMetrics(metrics.InternalErrorsView, wantMetrics); diff != "" {
+	if diff := m.ValidateMetrics(metrics.InternalErrorsName, wantMetrics); diff != "" {
 		t.Error(diff)
 	}
 }
@@ -18,10 +18,14 @@ import (
 	"context"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/klog/v2"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/declared"
 	"kpt.dev/configsync/pkg/diff"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
@@ -31,9 +35,13 @@ import (
 	"kpt.dev/configsync/pkg/syncer/reconcile"
 	syncerreconcile "kpt.dev/configsync/pkg/syncer/reconcile"
 	"kpt.dev/configsync/pkg/syncer/reconcile/fight"
+	"kpt.dev/configsync/pkg/tracing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// keyObject tags remediation spans with the ID of the corrected object.
+var keyObject = attribute.Key("configsync.object")
+
 type reconcilerInterface interface {
 	Remediate(ctx context.Context, id core.ID, obj client.Object) status.Error
 	GetClient() client.Client
@@ -51,6 +59,8 @@ type reconciler struct {
 
 	conflictHandler conflict.Handler
 	fightHandler    fight.Handler
+	// eventRecorder records an Event for each corrected drift.
+	eventRecorder *eventrecorder.Recorder
 }
 
 // newReconciler instantiates a new reconciler.
@@ -61,6 +71,7 @@ func newReconciler(
 	declared *declared.Resources,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	eventRecorder *eventrecorder.Recorder,
 ) *reconciler {
 	return &reconciler{
 		scope:           scope,
@@ -69,6 +80,7 @@ func newReconciler(
 		declared:        declared,
 		conflictHandler: conflictHandler,
 		fightHandler:    fightHandler,
+		eventRecorder:   eventRecorder,
 	}
 }
 
@@ -90,7 +102,13 @@ func (r *reconciler) Remediate(ctx context.Context, id core.ID, obj client.Objec
 		Actual:   obj,
 	}
 
+	attrs := append(tracing.SyncAttributes(r.scope, r.syncName),
+		tracing.KeyCommit.String(commit),
+		keyObject.String(id.String()))
+	ctx, span := tracing.Start(ctx, "Remediate", attrs...)
+	ctx = audit.WithCommit(ctx, commit)
 	err := r.remediate(ctx, id, objDiff)
+	tracing.End(span, err)
 
 	// Record duration, even if there's an error
 	metrics.RecordRemediateDuration(ctx, metrics.StatusTagKey(err), start)
@@ -136,7 +154,11 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 			return err
 		}
 		klog.V(3).Infof("Remediator creating object: %v", id)
-		return r.applier.Create(ctx, declared)
+		if err := r.applier.Create(ctx, declared); err != nil {
+			return err
+		}
+		r.recordDriftCorrected(id, t)
+		return nil
 	case diff.Update:
 		declared, err := objDiff.UnstructuredDeclared()
 		if err != nil {
@@ -147,14 +169,18 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 			return err
 		}
 		klog.V(3).Infof("Remediator updating object: %v", id)
-		return r.applier.Update(ctx, declared, actual)
+		return r.update(ctx, id, t, declared, actual)
 	case diff.Delete:
 		actual, err := objDiff.UnstructuredActual()
 		if err != nil {
 			return err
 		}
 		klog.V(3).Infof("Remediator deleting object: %v", id)
-		return r.applier.Delete(ctx, actual)
+		if err := r.applier.Delete(ctx, actual); err != nil {
+			return err
+		}
+		r.recordDriftCorrected(id, t)
+		return nil
 	case diff.Error:
 		// This is the case where the annotation in the *repository* is invalid.
 		// Should never happen as the Parser would have thrown an error.
@@ -192,7 +218,7 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 			core.SetAnnotation(expected, metadata.LifecycleMutationAnnotation, "")
 		}
 
-		return r.applier.Update(ctx, expected, actual)
+		return r.update(ctx, id, t, expected, actual)
 	default:
 		// e.g. differ.DeleteNsConfig, which shouldn't be possible to get to any way.
 		metrics.RecordInternalError(ctx, "remediator")
@@ -200,6 +226,22 @@ func (r *reconciler) remediate(ctx context.Context, id core.ID, objDiff diff.Dif
 	}
 }
 
+// update updates the object, and records an Event if it drifted.
+func (r *reconciler) update(ctx context.Context, id core.ID, operation diff.Operation, intendedState, currentState *unstructured.Unstructured) status.Error {
+	updated, err := r.applier.Update(ctx, intendedState, currentState)
+	if err != nil {
+		return err
+	}
+	if updated {
+		r.recordDriftCorrected(id, operation)
+	}
+	return nil
+}
+
+func (r *reconciler) recordDriftCorrected(id core.ID, operation diff.Operation) {
+	r.eventRecorder.Normal(eventrecorder.ReasonDriftCorrected, "Corrected drift of object %s with operation %s", id, operation)
+}
+
 // GetClient returns the reconciler's underlying client.Client.
 func (r *reconciler) GetClient() client.Client {
 	return r.applier.GetClient()
@@ -19,15 +19,16 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"go.opencensus.io/stats/view"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/attribute"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/core/k8sobjects"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/importer/analyzer/validation/nonhierarchical"
 	"kpt.dev/configsync/pkg/metadata"
 	"kpt.dev/configsync/pkg/metrics"
@@ -48,7 +49,7 @@ func TestRemediator_Reconcile(t *testing.T) {
 		// version is Version (from GVK) of the object to try to remediate.
 		version string
 		// conflictHandler is the initial state of the conflict handler.
-		// Nil defaults to conflict.NewHandler().
+		// Nil defaults to conflict.NewHandler(nil).
 		conflictHandler conflict.Handler
 		// declared is the state of the object as returned by the Parser.
 		declared client.Object
@@ -359,7 +360,7 @@ func TestRemediator_Reconcile(t *testing.T) {
 			name: "management conflict resolved",
 			// Setup pre-existing conflict error
 			conflictHandler: func() conflict.Handler {
-				h := conflict.NewHandler()
+				h := conflict.NewHandler(nil)
 				obj := k8sobjects.ClusterRoleBinding(syncertest.ManagementEnabled,
 					core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, "other-root-sync")),
 					core.Label("selected-label", "unexpected-value"),
@@ -403,11 +404,11 @@ func TestRemediator_Reconcile(t *testing.T) {
 			d := makeDeclared(t, "unused", tc.declared)
 
 			if tc.conflictHandler == nil {
-				tc.conflictHandler = conflict.NewHandler()
+				tc.conflictHandler = conflict.NewHandler(nil)
 			}
 
 			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
-				tc.conflictHandler, testingfake.NewFightHandler())
+				tc.conflictHandler, testingfake.NewFightHandler(), nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -436,6 +437,81 @@ func TestRemediator_Reconcile(t *testing.T) {
 	}
 }
 
+func TestRemediator_Reconcile_Events(t *testing.T) {
+	rootManager := declared.ResourceManager(declared.RootScope, configsync.RootSyncName)
+	testCases := []struct {
+		name       string
+		declared   client.Object
+		actual     client.Object
+		wantEvents []string
+	}{
+		{
+			name: "create added object",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled,
+				core.Annotation(metadata.ResourceManagerKey, rootManager)),
+			wantEvents: []string{
+				"Normal DriftCorrected Corrected drift of object ClusterRoleBinding.rbac.authorization.k8s.io, /default-name with operation create",
+			},
+		},
+		{
+			name:     "don't delete unmanaged object",
+			declared: nil,
+			actual:   k8sobjects.ClusterRoleBindingObject(),
+		},
+		{
+			name: "management conflict",
+			declared: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled,
+				core.Annotation(metadata.ResourceManagerKey, rootManager)),
+			actual: k8sobjects.ClusterRoleBindingObject(syncertest.ManagementEnabled,
+				core.Annotation(metadata.ResourceManagerKey, declared.ResourceManager(declared.RootScope, "other-root-sync"))),
+			wantEvents: []string{
+				"Warning ManagementConflict Object ClusterRoleBinding.rbac.authorization.k8s.io, /default-name declared by :root is managed by :root_other-root-sync. Remove the object from one of the sources so that it is only managed by one reconciler.",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var existingObjs []client.Object
+			if tc.actual != nil {
+				existingObjs = append(existingObjs, tc.actual)
+			}
+			c := testingfake.NewClient(t, core.Scheme, existingObjs...)
+			d := makeDeclared(t, "example-commit", tc.declared)
+
+			fakeRecorder := record.NewFakeRecorder(10)
+			rsClient := testingfake.NewClient(t, core.Scheme, k8sobjects.RootSyncObjectV1Beta1(configsync.RootSyncName))
+			recorder := eventrecorder.New(context.Background(), fakeRecorder, rsClient, declared.RootScope, configsync.RootSyncName)
+
+			r := newReconciler(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), d,
+				conflict.NewHandler(recorder), testingfake.NewFightHandler(), recorder)
+
+			// Use the actual object from the cluster, like a watch event.
+			var actual client.Object
+			if tc.actual != nil {
+				actual = tc.actual.DeepCopyObject().(client.Object)
+				if err := c.Get(context.Background(), client.ObjectKeyFromObject(actual), actual); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var id core.ID
+			if tc.declared != nil {
+				id = core.IDOf(tc.declared)
+			} else {
+				id = core.IDOf(tc.actual)
+			}
+			_ = r.Remediate(context.Background(), id, actual)
+
+			close(fakeRecorder.Events)
+			var events []string
+			for e := range fakeRecorder.Events {
+				events = append(events, e)
+			}
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
 func TestRemediator_Reconcile_Metrics(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -452,7 +528,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 		// wantError is the expected error resulting from calling Reconcile
 		wantError error
 		// wantMetrics is the expected metrics resulting from calling Reconcile
-		wantMetrics map[*view.View][]*view.Row
+		wantMetrics map[string][]*testmetrics.Row
 	}{
 		{
 			name: "ConflictUpdateDoesNotExist",
@@ -474,12 +550,12 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 				apierrors.NewNotFound(schema.GroupResource{Group: "rbac", Resource: "roles"}, "example"),
 				k8sobjects.RoleObject(core.Namespace("example"), core.Name("example"))),
 			// Expect resource conflict error
-			wantMetrics: map[*view.View][]*view.Row{
-				metrics.ResourceConflictsView: {
-					{Data: &view.CountData{Value: 1}, Tags: []tag.Tag{
+			wantMetrics: map[string][]*testmetrics.Row{
+				metrics.ResourceConflictsName: {
+					{Value: 1, Attributes: []attribute.KeyValue{
 						// Re-enable "type" tag, if re-enabled in RecordResourceConflict
 						// {Key: metrics.KeyType, Value: kinds.Role().Kind},
-						{Key: metrics.KeyCommit, Value: "abc123"},
+						metrics.KeyCommit.String("abc123"),
 					}},
 				},
 			},
@@ -503,12 +579,12 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 				apierrors.NewNotFound(schema.GroupResource{Group: "rbac", Resource: "roles"}, "example"),
 				k8sobjects.RoleObject(core.Namespace("example"), core.Name("example"))),
 			// Expect resource conflict error
-			wantMetrics: map[*view.View][]*view.Row{
-				metrics.ResourceConflictsView: {
-					{Data: &view.CountData{Value: 1}, Tags: []tag.Tag{
+			wantMetrics: map[string][]*testmetrics.Row{
+				metrics.ResourceConflictsName: {
+					{Value: 1, Attributes: []attribute.KeyValue{
 						// Re-enable "type" tag, if re-enabled in RecordResourceConflict
 						// {Key: metrics.KeyType, Value: kinds.Role().Kind},
-						{Key: metrics.KeyCommit, Value: "abc123"},
+						metrics.KeyCommit.String("abc123"),
 					}},
 				},
 			},
@@ -542,12 +618,12 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 					core.UID("1"), core.ResourceVersion("2"), core.Generation(1)),
 				declared.ResourceManager(declared.RootScope, configsync.RootSyncName)),
 			// Expect resource conflict metric
-			wantMetrics: map[*view.View][]*view.Row{
-				metrics.ResourceConflictsView: {
-					{Data: &view.CountData{Value: 1}, Tags: []tag.Tag{
+			wantMetrics: map[string][]*testmetrics.Row{
+				metrics.ResourceConflictsName: {
+					{Value: 1, Attributes: []attribute.KeyValue{
 						// Re-enable "type" tag, if re-enabled in RecordResourceConflict
 						// {Key: metrics.KeyType, Value: kinds.Role().Kind},
-						{Key: metrics.KeyCommit, Value: "abc123"},
+						metrics.KeyCommit.String("abc123"),
 					}},
 				},
 			},
@@ -571,7 +647,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 			fakeApplier.DeleteError = tc.deleteError
 
 			reconciler := newReconciler(declared.RootScope, configsync.RootSyncName, fakeApplier, d,
-				testingfake.NewConflictHandler(), testingfake.NewFightHandler())
+				testingfake.NewConflictHandler(), testingfake.NewFightHandler(), nil)
 
 			// Get the triggering object for the reconcile event.
 			var obj client.Object
@@ -584,11 +660,7 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 				t.Fatal("at least one of actual or declared must be specified for a test")
 			}
 
-			var views []*view.View
-			for view := range tc.wantMetrics {
-				views = append(views, view)
-			}
-			m := testmetrics.RegisterMetrics(views...)
+			m := testmetrics.RegisterMetrics()
 
 			err := reconciler.Remediate(context.Background(), core.IDOf(obj), tc.actual)
 			testerrors.AssertEqual(t, tc.wantError, err)
@@ -599,9 +671,9 @@ func TestRemediator_Reconcile_Metrics(t *testing.T) {
 				fakeClient.Check(t, tc.want)
 			}
 
-			for view, rows := range tc.wantMetrics {
-				if diff := m.ValidateMetrics(view, rows); diff != "" {
-					t.Errorf("Unexpected metrics recorded (%s): %v", view.Name, diff)
+			for name, rows := range tc.wantMetrics {
+				if diff := m.ValidateMetrics(name, rows); diff != "" {
+					t.Errorf("Unexpected metrics recorded (%s): %v", name, diff)
 				}
 			}
 		})
@@ -136,7 +136,7 @@ func TestWorker_Run_Remediates(t *testing.T) {
 
 			d := makeDeclared(t, randomCommitHash(), tc.declaredObjs...)
 			w := NewWorker(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), q, d,
-				syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
+				syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler(), nil)
 
 			ctx, cancel := context.WithCancel(ctx)
 			defer cancel()
@@ -241,7 +241,7 @@ func TestWorker_Run_RemediatesExisting(t *testing.T) {
 
 	d := makeDeclared(t, randomCommitHash(), declaredObjs...)
 	w := NewWorker(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), q, d,
-		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
+		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler(), nil)
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -350,7 +350,7 @@ func TestWorker_ProcessNextObject(t *testing.T) {
 
 			d := makeDeclared(t, randomCommitHash(), tc.declared...)
 			w := NewWorker(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), q, d,
-				syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
+				syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler(), nil)
 
 			for _, obj := range tc.toProcess {
 				if err := w.processNextObject(context.Background()); err != nil {
@@ -371,7 +371,7 @@ func TestWorker_Run_CancelledWhenEmpty(t *testing.T) {
 	c := testingfake.NewClient(t, core.Scheme)
 	d := makeDeclared(t, randomCommitHash()) // no resources declared
 	w := NewWorker(declared.RootScope, configsync.RootSyncName, c.Applier(configsync.FieldManager), q, d,
-		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
+		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler(), nil)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -439,7 +439,7 @@ func TestWorker_Run_CancelledWhenNotEmpty(t *testing.T) {
 	d := makeDeclared(t, randomCommitHash(), declaredObjs...)
 	a := &testingfake.Applier{Client: c, FieldManager: configsync.FieldManager}
 	w := NewWorker(declared.RootScope, configsync.RootSyncName, a, q, d,
-		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler())
+		syncertestfake.NewConflictHandler(), syncertestfake.NewFightHandler(), nil)
 
 	// Run worker in the background
 	doneCh := make(chan struct{})
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/declared"
+	"kpt.dev/configsync/pkg/eventrecorder"
 	"kpt.dev/configsync/pkg/reconcilermanager/controllers"
 	"kpt.dev/configsync/pkg/remediator/conflict"
 	"kpt.dev/configsync/pkg/remediator/queue"
@@ -103,6 +104,7 @@ func New(
 	applier syncerreconcile.Applier,
 	conflictHandler conflict.Handler,
 	fightHandler fight.Handler,
+	eventRecorder *eventrecorder.Recorder,
 	crdController *controllers.CRDController,
 	decls *declared.Resources,
 	numWorkers int,
@@ -110,7 +112,7 @@ func New(
 	q := queue.New(scope.String())
 	workers := make([]*reconcile.Worker, numWorkers)
 	for i := 0; i < numWorkers; i++ {
-		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler)
+		workers[i] = reconcile.NewWorker(scope, syncName, applier, q, decls, conflictHandler, fightHandler, eventRecorder)
 	}
 
 	remediator := &Remediator{
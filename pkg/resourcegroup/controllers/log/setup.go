@@ -18,15 +18,17 @@ import (
 	"flag"
 
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/textlogger"
-	ctrl "sigs.k8s.io/controller-runtime"
+	utillog "kpt.dev/configsync/pkg/util/log"
 )
 
-// InitFlags registers the klog command flags with new defaults.
-// Call flag.Parse() afterwards to parse input from the command line.
+// InitFlags registers the klog and --log-format command flags with new
+// defaults.
+// Call flag.Parse() and then utillog.Configure() afterwards to parse input
+// from the command line and configure the logs.
 func InitFlags() {
 	// Register klog flags
 	klog.InitFlags(nil)
+	utillog.AddFlags(flag.CommandLine)
 
 	// Override klog default values
 	if err := flag.Set("v", "1"); err != nil {
@@ -35,7 +37,4 @@ func InitFlags() {
 	if err := flag.Set("logtostderr", "true"); err != nil {
 		klog.Fatal(err)
 	}
-
-	// Configure controller-runtime to use klog, via the klogr library
-	ctrl.SetLogger(textlogger.NewLogger(textlogger.NewConfig()))
 }
@@ -17,7 +17,8 @@ limitations under the License.
 package metrics
 
 import (
-	"go.opencensus.io/stats"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 )
 
 const (
@@ -41,69 +42,79 @@ const (
 	PipelineErrorName = "pipeline_error_observed"
 )
 
+// meter creates the instruments of the ResourceGroup controller with the
+// global MeterProvider.
+var meter = otel.Meter("kpt.dev/configsync/pkg/resourcegroup/controllers/metrics")
+
+const (
+	unitSeconds       = "s"
+	unitDimensionless = "1"
+)
+
 var (
 	// ReconcileDuration tracks the time duration in seconds of reconciling
 	// a ResourceGroup CR by the ResourceGroup controller.
 	// label `reason`: the `Reason` field of the `Stalled` condition in a ResourceGroup CR.
 	// reason can be: StartReconciling, FinishReconciling, ComponentFailed, ExceedTimeout.
 	// This metric should be updated in the ResourceGroup controller.
-	ReconcileDuration = stats.Float64(
+	ReconcileDuration, _ = meter.Float64Histogram(
 		RGReconcileDurationName,
-		"Time duration in seconds of reconciling a ResourceGroup CR by the ResourceGroup controller",
-		stats.UnitSeconds)
+		metric.WithDescription("The distribution of time taken to reconcile a ResourceGroup CR"),
+		metric.WithUnit(unitSeconds),
+		metric.WithExplicitBucketBoundaries(0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
 
 	// ResourceGroupTotal tracks the total number of ResourceGroup CRs in a cluster.
 	// This metric should be updated in the Root controller.
-	ResourceGroupTotal = stats.Int64(
+	ResourceGroupTotal, _ = meter.Int64Gauge(
 		ResourceGroupTotalName,
-		"Total number of ResourceGroup CRs in a cluster",
-		stats.UnitDimensionless)
+		metric.WithDescription("The current number of ResourceGroup CRs"),
+		metric.WithUnit(unitDimensionless))
 
 	// ResourceCount tracks the number of resources in a ResourceGroup CR.
 	// This metric should be updated in the Root controller.
-	ResourceCount = stats.Int64(
+	ResourceCount, _ = meter.Int64Gauge(
 		ResourceCountName,
-		"The number of resources in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of resources tracked by a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// ReadyResourceCount tracks the number of resources with Current status in a ResourceGroup CR.
 	// This metric should be updated in the ResourceGroup controller.
-	ReadyResourceCount = stats.Int64(
+	ReadyResourceCount, _ = meter.Int64Gauge(
 		ReadyResourceCountName,
-		"The number of resources with Current status in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of ready resources in a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// KCCResourceCount tracks the number of KCC resources in a ResourceGroup CR.
 	// This metric should be updated in the ResourceGroup controller.
-	KCCResourceCount = stats.Int64(
+	KCCResourceCount, _ = meter.Int64Gauge(
 		KCCResourceCountName,
-		"The number of KCC resources in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The total number of KCC resources in a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// NamespaceCount tracks the number of resource namespaces in a ResourceGroup CR.
 	// This metric should be updated in the Root controller.
-	NamespaceCount = stats.Int64(
+	NamespaceCount, _ = meter.Int64Gauge(
 		NamespaceCountName,
-		"The number of resource namespaces in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of namespaces used by resources in a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// ClusterScopedResourceCount tracks the number of cluster-scoped resources in a ResourceGroup CR.
 	// This metric should be updated in the Root controller.
-	ClusterScopedResourceCount = stats.Int64(
+	ClusterScopedResourceCount, _ = meter.Int64Gauge(
 		ClusterScopedResourceCountName,
-		"The number of cluster-scoped resources in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of cluster scoped resources in a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// CRDCount tracks the number of CRDs in a ResourceGroup CR.
 	// This metric should be updated in the Root controller.
-	CRDCount = stats.Int64(
+	CRDCount, _ = meter.Int64Gauge(
 		CRDCountName,
-		"The number of CRDs in a ResourceGroup CR",
-		stats.UnitDimensionless)
+		metric.WithDescription("The number of CRDs in a ResourceGroup"),
+		metric.WithUnit(unitDimensionless))
 
 	// PipelineError tracks the error that happened when syncing a commit
-	PipelineError = stats.Int64(
+	PipelineError, _ = meter.Int64Gauge(
 		PipelineErrorName,
-		"A boolean value indicates if error happened at readiness stage when syncing a commit",
-		stats.UnitDimensionless)
+		metric.WithDescription("A boolean value indicates if error happened from different stages when syncing a commit"),
+		metric.WithUnit(unitDimensionless))
 )
@@ -18,82 +18,67 @@ import (
 	"context"
 	"time"
 
-	"go.opencensus.io/stats"
-	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/metric"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/api/configsync"
 	"kpt.dev/configsync/pkg/core"
 )
 
-// RecordReconcileDuration produces a measurement for the ReconcileDuration view.
+// RecordReconcileDuration produces a measurement for the ReconcileDuration metric.
 func RecordReconcileDuration(ctx context.Context, stallStatus string, startTime time.Time) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyStallReason, stallStatus))
-	measurement := ReconcileDuration.M(time.Since(startTime).Seconds())
-	stats.Record(tagCtx, measurement)
+	ReconcileDuration.Record(ctx, time.Since(startTime).Seconds(),
+		metric.WithAttributes(KeyStallReason.String(stallStatus)))
 }
 
-// RecordReadyResourceCount produces a measurement for the ReadyResourceCount view.
+// RecordReadyResourceCount produces a measurement for the ReadyResourceCount metric.
 func RecordReadyResourceCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := ReadyResourceCount.M(count)
-	stats.Record(tagCtx, measurement)
+	ReadyResourceCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordKCCResourceCount produces a measurement for the KCCResourceCount view.
+// RecordKCCResourceCount produces a measurement for the KCCResourceCount metric.
 func RecordKCCResourceCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := KCCResourceCount.M(count)
-	stats.Record(tagCtx, measurement)
+	KCCResourceCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordResourceCount produces a measurement for the ResourceCount view.
+// RecordResourceCount produces a measurement for the ResourceCount metric.
 func RecordResourceCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := ResourceCount.M(count)
-	stats.Record(tagCtx, measurement)
+	ResourceCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordResourceGroupTotal produces a measurement for the ResourceGroupTotalView
+// RecordResourceGroupTotal produces a measurement for the ResourceGroupTotal metric
 func RecordResourceGroupTotal(ctx context.Context, count int64) {
-	stats.Record(ctx, ResourceGroupTotal.M(count))
+	ResourceGroupTotal.Record(ctx, count)
 }
 
-// RecordNamespaceCount produces a measurement for the NamespaceCount view.
+// RecordNamespaceCount produces a measurement for the NamespaceCount metric.
 func RecordNamespaceCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := NamespaceCount.M(count)
-	stats.Record(tagCtx, measurement)
+	NamespaceCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordClusterScopedResourceCount produces a measurement for ClusterScopedResourceCount view
+// RecordClusterScopedResourceCount produces a measurement for ClusterScopedResourceCount metric
 func RecordClusterScopedResourceCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := ClusterScopedResourceCount.M(count)
-	stats.Record(tagCtx, measurement)
+	ClusterScopedResourceCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordCRDCount produces a measurement for RecordCRDCount view
+// RecordCRDCount produces a measurement for RecordCRDCount metric
 func RecordCRDCount(ctx context.Context, nn types.NamespacedName, count int64) {
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyResourceGroup, nn.String()))
-	measurement := CRDCount.M(count)
-	stats.Record(tagCtx, measurement)
+	CRDCount.Record(ctx, count, metric.WithAttributes(KeyResourceGroup.String(nn.String())))
 }
 
-// RecordPipelineError produces a measurement for PipelineErrorView
+// RecordPipelineError produces a measurement for PipelineError metric
 func RecordPipelineError(ctx context.Context, nn types.NamespacedName, component string, hasErr bool) {
 	reconcilerName, reconcilerType := ComputeReconcilerNameType(nn)
-	tagCtx, _ := tag.New(ctx, tag.Upsert(KeyComponent, component), tag.Upsert(KeyName, reconcilerName),
-		tag.Upsert(KeyType, reconcilerType))
 	var metricVal int64
 	if hasErr {
 		metricVal = 1
 	} else {
 		metricVal = 0
 	}
-	stats.Record(tagCtx, PipelineError.M(metricVal))
+	PipelineError.Record(ctx, metricVal, metric.WithAttributes(KeyComponent.String(component), KeyName.String(reconcilerName),
+		KeyType.String(reconcilerType)))
 	klog.Infof("Recording %s metric at component: %s, namespace: %s, reconciler: %s, sync type: %s with value %v",
-		PipelineErrorView.Name, component, nn.Namespace, reconcilerName, nn.Name, metricVal)
+		PipelineErrorName, component, nn.Namespace, reconcilerName, nn.Name, metricVal)
 }
 
 // ComputeReconcilerNameType computes the reconciler name from the ResourceGroup CR name
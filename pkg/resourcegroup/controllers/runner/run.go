@@ -20,19 +20,21 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
-	"k8s.io/klog/v2/textlogger"
 	"kpt.dev/configsync/pkg/api/kpt.dev/v1alpha1"
+	"kpt.dev/configsync/pkg/metrics"
+	rgconstants "kpt.dev/configsync/pkg/resourcegroup"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/log"
-	ocmetrics "kpt.dev/configsync/pkg/resourcegroup/controllers/metrics"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/profiler"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/resourcegroup"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/resourcemap"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/root"
 	"kpt.dev/configsync/pkg/resourcegroup/controllers/typeresolver"
+	utillog "kpt.dev/configsync/pkg/util/log"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -66,29 +68,40 @@ func run() error {
 	var metricsAddr string
 	var enableLeaderElection bool
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	var prometheusMetricsAddr string
+	flag.StringVar(&prometheusMetricsAddr, "prometheus-metrics-addr", "",
+		"The address to serve the metrics on /metrics in the Prometheus format, like :9464. "+
+			"Overrides --metrics-addr, and also serves the Config Sync metrics. The Config Sync metrics are not served if empty.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.Parse()
 
 	profiler.Service()
-	logger := textlogger.NewLogger(textlogger.NewConfig())
+	logger := utillog.Configure()
+	// Configure controller-runtime to use the same logger as klog
+	ctrl.SetLogger(logger)
 	ctx := context.Background()
 
-	// Register the OpenCensus views
-	if err := ocmetrics.RegisterReconcilerMetricsViews(); err != nil {
-		return fmt.Errorf("failed to register OpenCensus views: %w", err)
+	// Register the OTLP metrics exporter, and the Prometheus reader if the
+	// Config Sync metrics are served on /metrics.
+	var metricReaders []sdkmetric.Reader
+	if prometheusMetricsAddr != "" {
+		reader, err := metrics.NewPrometheusReader()
+		if err != nil {
+			return fmt.Errorf("failed to create the Prometheus metrics reader: %w", err)
+		}
+		metricReaders = append(metricReaders, reader)
+		metricsAddr = prometheusMetricsAddr
 	}
-
-	// Register the OC Agent exporter
-	oce, err := ocmetrics.RegisterOCAgentExporter()
+	mp, err := metrics.RegisterOTelExporter(ctx, rgconstants.ManagerContainerName, metricReaders...)
 	if err != nil {
-		return fmt.Errorf("failed to register the OC Agent exporter: %w", err)
+		return fmt.Errorf("failed to register the OTLP metrics exporter: %w", err)
 	}
 
 	defer func() {
-		if err := oce.Stop(); err != nil {
-			klog.Error(err, "Unable to stop the OC Agent exporter")
+		if err := mp.Shutdown(ctx); err != nil {
+			klog.Error(err, "Unable to stop the OTLP metrics exporter")
 		}
 	}()
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
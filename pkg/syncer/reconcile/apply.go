@@ -38,6 +38,7 @@ import (
 	"k8s.io/kubectl/pkg/util"
 	"k8s.io/kubectl/pkg/util/openapi"
 	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/audit"
 	"kpt.dev/configsync/pkg/core"
 	"kpt.dev/configsync/pkg/kinds"
 	"kpt.dev/configsync/pkg/metadata"
@@ -55,7 +56,9 @@ const rmCreationTimestampPatch = "{\"metadata\":{\"creationTimestamp\":null}}"
 // Applier updates a resource from its current state to its intended state using apply operations.
 type Applier interface {
 	Create(ctx context.Context, obj *unstructured.Unstructured) status.Error
-	Update(ctx context.Context, intendedState, currentState *unstructured.Unstructured) status.Error
+	// Update returns true if the object was changed, and false if it was
+	// already up to date.
+	Update(ctx context.Context, intendedState, currentState *unstructured.Unstructured) (bool, status.Error)
 	// RemoveNomosMeta performs a PUT (rather than a PATCH) to ensure that labels and annotations are removed.
 	RemoveNomosMeta(ctx context.Context, intent *unstructured.Unstructured, controller string) status.Error
 	Delete(ctx context.Context, obj *unstructured.Unstructured) status.Error
@@ -70,16 +73,18 @@ type clientApplier struct {
 	client           *syncerclient.Client
 	fights           fight.Detector
 	applySetID       string
+	auditLog         *audit.Logger
 }
 
 var _ Applier = &clientApplier{}
 
 // NewApplierForMultiRepo returns a new clientApplier for callers with multi repo feature enabled.
-func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client, applySetID string) (Applier, error) {
-	return newApplier(cfg, client, applySetID)
+// The writes are recorded in the audit log, if not nil.
+func NewApplierForMultiRepo(cfg *rest.Config, client *syncerclient.Client, applySetID string, auditLog *audit.Logger) (Applier, error) {
+	return newApplier(cfg, client, applySetID, auditLog)
 }
 
-func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string) (Applier, error) {
+func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string, auditLog *audit.Logger) (Applier, error) {
 	c, err := dynamic.NewForConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -102,15 +107,20 @@ func newApplier(cfg *rest.Config, client *syncerclient.Client, applySetID string
 		client:           client,
 		fights:           fight.NewDetector(),
 		applySetID:       applySetID,
+		auditLog:         auditLog,
 	}, nil
 }
 
 // Create implements Applier.
 func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.Unstructured) status.Error {
 	var err status.Error
+	operation := audit.OperationApply
+	// The object is updated with the response, so keep the request body.
+	body := intendedState.DeepCopy()
 	// APIService is handled specially by client-side apply due to
 	// https://github.com/kubernetes/kubernetes/issues/89264
 	if intendedState.GroupVersionKind().GroupKind() == kinds.APIService().GroupKind() {
+		operation = audit.OperationCreate
 		err = c.create(ctx, intendedState)
 	} else {
 		if err1 := c.client.Patch(ctx, intendedState, client.Apply, client.FieldOwner(configsync.FieldManager)); err1 != nil {
@@ -124,6 +134,7 @@ func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.
 	}
 	metrics.Operations.WithLabelValues("create", metrics.StatusLabel(err)).Inc()
 	m.RecordApplyOperation(ctx, m.RemediatorController, "create", m.StatusTagKey(err))
+	c.auditLog.Record(ctx, m.RemediatorController, operation, core.IDOf(intendedState), body, err)
 
 	if err != nil {
 		klog.V(3).Infof("Failed to create object %v: %v", core.GKNN(intendedState), err)
@@ -140,20 +151,29 @@ func (c *clientApplier) Create(ctx context.Context, intendedState *unstructured.
 }
 
 // Update implements Applier.
-func (c *clientApplier) Update(ctx context.Context, intendedState, currentState *unstructured.Unstructured) status.Error {
+func (c *clientApplier) Update(ctx context.Context, intendedState, currentState *unstructured.Unstructured) (bool, status.Error) {
+	// The object is updated with the response, so keep the request body.
+	body := intendedState.DeepCopy()
 	patch, err := c.update(ctx, intendedState, currentState)
 	metrics.Operations.WithLabelValues("update", metrics.StatusLabel(err)).Inc()
 	m.RecordApplyOperation(ctx, m.RemediatorController, "update", m.StatusTagKey(err))
+	if err != nil || !isNoOpPatch(patch) {
+		if intendedState.GroupVersionKind().GroupKind() == kinds.APIService().GroupKind() {
+			c.auditLog.Record(ctx, m.RemediatorController, audit.OperationPatch, core.IDOf(intendedState), patch, err)
+		} else {
+			c.auditLog.Record(ctx, m.RemediatorController, audit.OperationApply, core.IDOf(intendedState), body, err)
+		}
+	}
 
 	switch {
 	case apierrors.IsConflict(err):
-		return syncerclient.ConflictUpdateOldVersion(err, intendedState)
+		return false, syncerclient.ConflictUpdateOldVersion(err, intendedState)
 	case apierrors.IsNotFound(err):
-		return syncerclient.ConflictUpdateObjectDoesNotExist(err, intendedState)
+		return false, syncerclient.ConflictUpdateObjectDoesNotExist(err, intendedState)
 	case meta.IsNoMatchError(err):
-		return syncerclient.ConflictUpdateResourceDoesNotExist(err, intendedState)
+		return false, syncerclient.ConflictUpdateResourceDoesNotExist(err, intendedState)
 	case err != nil:
-		return status.ResourceWrap(err, "unable to update resource", intendedState)
+		return false, status.ResourceWrap(err, "unable to update resource", intendedState)
 	}
 
 	updated := !isNoOpPatch(patch)
@@ -166,16 +186,18 @@ func (c *clientApplier) Update(ctx context.Context, intendedState, currentState
 		if err == nil {
 			klog.V(3).Infof("The object %v was updated with the patch %v", core.GKNN(currentState), string(patch))
 		}
-		return err
+		return true, err
 	}
 
 	klog.V(3).Infof("The object %v is up to date.", core.GKNN(currentState))
-	return nil
+	return false, nil
 }
 
 // RemoveNomosMeta implements Applier.
 func (c *clientApplier) RemoveNomosMeta(ctx context.Context, u *unstructured.Unstructured, controller string) status.Error {
 	var changed bool
+	// body is the last object sent to the server, if the metadata changed.
+	var body client.Object
 	_, err := c.client.Apply(ctx, u, func(obj client.Object) (client.Object, error) {
 		changed1 := metadata.RemoveConfigSyncMetadata(obj)
 		changed2 := metadata.RemoveApplySetPartOfLabel(obj, c.applySetID)
@@ -183,10 +205,14 @@ func (c *clientApplier) RemoveNomosMeta(ctx context.Context, u *unstructured.Uns
 		if !changed {
 			return obj, syncerclient.NoUpdateNeeded()
 		}
+		body = obj.DeepCopyObject().(client.Object)
 		return obj, nil
 	})
 	metrics.Operations.WithLabelValues("update", metrics.StatusLabel(err)).Inc()
 	m.RecordApplyOperation(ctx, controller, "update", m.StatusTagKey(err))
+	if body != nil {
+		c.auditLog.Record(ctx, controller, audit.OperationUpdate, core.IDOf(u), body, err)
+	}
 
 	if changed {
 		klog.V(3).Infof("RemoveNomosMeta changed the object %v", core.GKNN(u))
@@ -201,6 +227,7 @@ func (c *clientApplier) Delete(ctx context.Context, obj *unstructured.Unstructur
 	err := c.client.Delete(ctx, obj)
 	metrics.Operations.WithLabelValues("delete", metrics.StatusLabel(err)).Inc()
 	m.RecordApplyOperation(ctx, m.RemediatorController, "delete", m.StatusTagKey(err))
+	c.auditLog.Record(ctx, m.RemediatorController, audit.OperationDelete, core.IDOf(obj), nil, err)
 
 	if err != nil {
 		klog.V(3).Infof("Failed to delete object %v: %v", core.GKNN(obj), err)
@@ -51,15 +51,16 @@ func (a *Applier) Create(ctx context.Context, obj *unstructured.Unstructured) st
 }
 
 // Update implements reconcile.Applier.
-func (a *Applier) Update(ctx context.Context, intendedState, _ *unstructured.Unstructured) status.Error {
+// The object is always reported as changed.
+func (a *Applier) Update(ctx context.Context, intendedState, currentState *unstructured.Unstructured) (bool, status.Error) {
 	if a.UpdateError != nil {
-		return a.UpdateError
+		return false, a.UpdateError
 	}
 	err := a.Client.Update(ctx, intendedState, client.FieldOwner(a.FieldManager))
 	if err != nil {
-		return status.APIServerError(err, "updating")
+		return false, status.APIServerError(err, "updating")
 	}
-	return nil
+	return true, nil
 }
 
 // RemoveNomosMeta implements reconcile.Applier.
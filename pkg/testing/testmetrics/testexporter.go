@@ -15,64 +15,138 @@
 package testmetrics
 
 import (
+	"context"
 	"fmt"
-	"reflect"
 	"sort"
 	"strings"
-	"time"
+	"sync"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
-	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
-// TestExporter keeps exported metric view data in memory to aid in testing.
-type TestExporter struct {
-	rows []*view.Row
+var (
+	// reader reads the metrics recorded to the global MeterProvider.
+	// It uses delta temporality, so that each collection only includes the
+	// measurements recorded since the previous collection.
+	reader = sdkmetric.NewManualReader(sdkmetric.WithTemporalitySelector(
+		func(sdkmetric.InstrumentKind) metricdata.Temporality {
+			return metricdata.DeltaTemporality
+		}))
+	registerOnce sync.Once
+)
+
+// Row is a data point of an exported metric.
+type Row struct {
+	// Attributes are the attributes of the data point.
+	Attributes []attribute.KeyValue
+	// Value is the last value of a gauge, the sum of a counter, or the number
+	// of measurements of a histogram.
+	Value float64
 }
 
-// RowSort implements sort.Interface based on the string representation of Row.
-type RowSort []*view.Row
+// String returns the string representation of the Row.
+func (r *Row) String() string {
+	set := attribute.NewSet(r.Attributes...)
+	return fmt.Sprintf("{ {%s} %v }\n", set.Encoded(attribute.DefaultEncoder()), r.Value)
+}
 
-// ExportView records the view data.
-func (e *TestExporter) ExportView(data *view.Data) {
-	e.rows = data.Rows
+// TestExporter keeps exported metric data in memory to aid in testing.
+type TestExporter struct {
+	rows map[string][]*Row
 }
 
-// ValidateMetrics compares the exported view data with the expected metric data.
-func (e *TestExporter) ValidateMetrics(v *view.View, want []*view.Row) string {
-	view.Unregister(v)
+// RowSort implements sort.Interface based on the string representation of Row.
+type RowSort []*Row
+
+// ValidateMetrics compares the exported data of the named metric with the
+// expected metric data.
+func (e *TestExporter) ValidateMetrics(name string, want []*Row) string {
+	e.collect()
+	got := e.rows[name]
 	// Need to sort first because the exported row order is non-deterministic
-	sort.Sort(RowSort(e.rows))
+	sort.Sort(RowSort(got))
 	sort.Sort(RowSort(want))
-	return diff(e.rows, want)
+	return diff(got, want)
 }
 
-// RegisterMetrics collects data for the given views and reports data to the TestExporter.
-func RegisterMetrics(views ...*view.View) *TestExporter {
-	_ = view.Register(views...)
-	var e TestExporter
-	view.RegisterExporter(&e)
-	return &e
+// RegisterMetrics sets a global MeterProvider which reports the metrics
+// recorded from now on to the returned TestExporter.
+func RegisterMetrics() *TestExporter {
+	registerOnce.Do(func() {
+		otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	})
+	e := &TestExporter{rows: make(map[string][]*Row)}
+	// Drop the metrics recorded before.
+	e.collect()
+	e.rows = make(map[string][]*Row)
+	return e
 }
 
-// diff compares the exported rows' Tags and data Value with the expected
-// rows' Tags and data Value. It excludes the Start time field from the comparison.
-func diff(got, want []*view.Row) string {
+// collect reads the metrics recorded since the previous collection.
+func (e *TestExporter) collect() {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		panic(fmt.Sprintf("failed to collect metrics: %v", err))
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if rows := toRows(m.Data); len(rows) > 0 {
+				e.rows[m.Name] = rows
+			}
+		}
+	}
+}
+
+func toRows(data metricdata.Aggregation) []*Row {
+	var rows []*Row
+	switch d := data.(type) {
+	case metricdata.Gauge[int64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: float64(dp.Value)})
+		}
+	case metricdata.Gauge[float64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: dp.Value})
+		}
+	case metricdata.Sum[int64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: float64(dp.Value)})
+		}
+	case metricdata.Sum[float64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: dp.Value})
+		}
+	case metricdata.Histogram[int64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: float64(dp.Count)})
+		}
+	case metricdata.Histogram[float64]:
+		for _, dp := range d.DataPoints {
+			rows = append(rows, &Row{Attributes: dp.Attributes.ToSlice(), Value: float64(dp.Count)})
+		}
+	}
+	return rows
+}
+
+// diff compares the exported rows' Attributes and Value with the expected
+// rows' Attributes and Value.
+func diff(got, want []*Row) string {
 	for i := 0; i < len(got); i++ {
 		if i >= len(want) {
 			break
 		}
-		if got[i] == want[i] {
-			continue
-		}
-		if len(got[i].Tags) > 0 && len(want[i].Tags) > 0 && !reflect.DeepEqual(got[i].Tags, want[i].Tags) {
-			return fmt.Sprintf("Expected metric tags not found, -want, +got:\n- %s\n+ %s",
-				want[i].Tags, got[i].Tags)
+		gotAttrs := attribute.NewSet(got[i].Attributes...)
+		wantAttrs := attribute.NewSet(want[i].Attributes...)
+		if !gotAttrs.Equals(&wantAttrs) {
+			return fmt.Sprintf("Expected metric attributes not found, -want, +got:\n- %s\n+ %s",
+				wantAttrs.Encoded(attribute.DefaultEncoder()), gotAttrs.Encoded(attribute.DefaultEncoder()))
 		}
-		if !cmp.Equal(got[i].Data, want[i].Data, cmpopts.IgnoreTypes(time.Time{})) {
-			return fmt.Sprintf("Expected metric value not found, -want, +got:\n- %s\n+ %s",
-				want[i].Data, got[i].Data)
+		if got[i].Value != want[i].Value {
+			return fmt.Sprintf("Expected metric value not found, -want, +got:\n- %v\n+ %v",
+				want[i].Value, got[i].Value)
 		}
 	}
 	if len(got) > len(want) {
@@ -98,4 +172,4 @@ func diff(got, want []*view.Row) string {
 
 func (rs RowSort) Len() int           { return len(rs) }
 func (rs RowSort) Swap(i, j int)      { rs[i], rs[j] = rs[j], rs[i] }
-func (rs RowSort) Less(i, j int) bool { return fmt.Sprintf("%v", rs[i]) < fmt.Sprintf("%v", rs[j]) }
+func (rs RowSort) Less(i, j int) bool { return rs[i].String() < rs[j].String() }
@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"kpt.dev/configsync/pkg/metrics"
+)
+
+// RegisterOTelExporter creates the OTLP trace exporter, and sets the global
+// TracerProvider to export the recorded spans with it.
+//
+// The exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment
+// variables, like the metrics exporter. The protocol is set by
+// OTEL_EXPORTER_OTLP_TRACES_PROTOCOL or OTEL_EXPORTER_OTLP_PROTOCOL, and
+// defaults to grpc. Unless OTEL_EXPORTER_OTLP_INSECURE is set, spans are
+// exported without TLS to the otel-agent sidecar, which listens on localhost.
+// The sampler is configured by OTEL_TRACES_SAMPLER, and defaults to sampling
+// every trace.
+//
+// The caller must shut down the returned TracerProvider to flush the spans
+// before exiting.
+func RegisterOTelExporter(ctx context.Context, containerName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := newOTLPExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithAttributes(attribute.String("k8s.container.name", containerName)))
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	return tp, nil
+}
+
+func newOTLPExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	exporterOpts, err := metrics.OTLPExporterOptionsFromEnv(metrics.OTLPSignalTraces)
+	if err != nil {
+		return nil, err
+	}
+	if exporterOpts.Protocol == metrics.OTLPProtocolHTTP {
+		var opts []otlptracehttp.Option
+		if exporterOpts.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	var opts []otlptracegrpc.Option
+	if exporterOpts.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
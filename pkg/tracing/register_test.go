@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"kpt.dev/configsync/pkg/metrics"
+)
+
+func TestNewOTLPExporter(t *testing.T) {
+	testCases := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name: "gRPC by default",
+		},
+		{
+			name: "HTTP from the OTLP protocol",
+			env:  map[string]string{"OTEL_EXPORTER_OTLP_PROTOCOL": metrics.OTLPProtocolHTTP},
+		},
+		{
+			name: "traces protocol overrides the OTLP protocol",
+			env: map[string]string{
+				"OTEL_EXPORTER_OTLP_PROTOCOL":        "http/json",
+				"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": metrics.OTLPProtocolGRPC,
+			},
+		},
+		{
+			name:    "unsupported protocol",
+			env:     map[string]string{"OTEL_EXPORTER_OTLP_TRACES_PROTOCOL": "http/json"},
+			wantErr: `unsupported OTLP protocol "http/json": must be "grpc" or "http/protobuf"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			exporter, err := newOTLPExporter(context.Background())
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, &otlptrace.Exporter{}, exporter)
+			require.NoError(t, exporter.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing records OpenTelemetry spans for the stages of the sync
+// pipeline, so that the time spent in a slow sync can be broken down.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"kpt.dev/configsync/pkg/declared"
+)
+
+// tracerName is the name of the tracer of the spans.
+const tracerName = "kpt.dev/configsync/pkg/tracing"
+
+// tracer returns the tracer of the global TracerProvider.
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+var (
+	// KeySyncName tags spans with the name of the RootSync or RepoSync.
+	KeySyncName = attribute.Key("configsync.sync.name")
+
+	// KeySyncScope tags spans with the scope of the RootSync or RepoSync.
+	// Possible values: :root, or the namespace of the RepoSync.
+	KeySyncScope = attribute.Key("configsync.sync.scope")
+
+	// KeyCommit tags spans with the source commit being synced: the git
+	// commit, OCI image digest, or helm chart version.
+	KeyCommit = attribute.Key("configsync.sync.commit")
+
+	// KeyTrigger tags reconcile spans with what triggered the sync attempt.
+	// Possible values: the same as the trigger tag of the parser_duration_seconds metric.
+	KeyTrigger = attribute.Key("configsync.sync.trigger")
+)
+
+// SyncAttributes returns the span attributes that identify a RootSync or
+// RepoSync.
+func SyncAttributes(scope declared.Scope, syncName string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		KeySyncName.String(syncName),
+		KeySyncScope.String(string(scope)),
+	}
+}
+
+type attributesKey struct{}
+
+// WithAttributes returns a copy of the context carrying the specified
+// attributes, which are added to every span started from that context.
+// Attributes already carried by the context are kept, unless overridden by a
+// new attribute with the same key.
+func WithAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	return context.WithValue(ctx, attributesKey{}, append(attributesFrom(ctx), attrs...))
+}
+
+func attributesFrom(ctx context.Context) []attribute.KeyValue {
+	attrs, _ := ctx.Value(attributesKey{}).([]attribute.KeyValue)
+	// Copy to avoid sharing the backing array between contexts.
+	return append([]attribute.KeyValue(nil), attrs...)
+}
+
+// Start starts a span as a child of the span in the context, if any.
+// The span is tagged with the attributes carried by the context, followed by
+// the specified attributes.
+// The caller must end the returned span, usually with End.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(append(attributesFrom(ctx), attrs...)...))
+}
+
+// Record records a span which already ended, from start to end, as a child of
+// the span in the context, if any, like for a wait which spans several sync
+// attempts. The span is tagged like with Start.
+func Record(ctx context.Context, name string, start, end time.Time, attrs ...attribute.KeyValue) {
+	_, span := tracer().Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(append(attributesFrom(ctx), attrs...)...))
+	span.End(trace.WithTimestamp(end))
+}
+
+// End ends the span, and marks it as failed if the error is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"kpt.dev/configsync/pkg/declared"
+)
+
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := WithAttributes(context.Background(), SyncAttributes(declared.RootScope, "root-sync")...)
+	ctx, parent := Start(ctx, "Reconcile")
+	// Attributes added to a derived context are not added to the spans
+	// started from the original context.
+	childCtx := WithAttributes(ctx, KeyCommit.String("abc123"))
+	_, child := Start(childCtx, "Parse", attribute.Int("files", 2))
+	End(child, errors.New("parse failed"))
+	_, sibling := Start(ctx, "Fetch")
+	End(sibling, nil)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "Parse", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, []attribute.KeyValue{
+		KeySyncName.String("root-sync"),
+		KeySyncScope.String(":root"),
+		KeyCommit.String("abc123"),
+		attribute.Int("files", 2),
+	}, spans[0].Attributes())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "parse failed", spans[0].Status().Description)
+
+	assert.Equal(t, "Fetch", spans[1].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[1].Parent().SpanID())
+	assert.Equal(t, []attribute.KeyValue{
+		KeySyncName.String("root-sync"),
+		KeySyncScope.String(":root"),
+	}, spans[1].Attributes())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+
+	assert.Equal(t, "Reconcile", spans[2].Name())
+	assert.False(t, spans[2].Parent().IsValid())
+}
+
+func TestRecord(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := WithAttributes(context.Background(), SyncAttributes(declared.RootScope, "root-sync")...)
+	ctx, parent := Start(ctx, "Render")
+	start := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	Record(ctx, "RenderWait", start, end)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "RenderWait", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, start, spans[0].StartTime())
+	assert.Equal(t, end, spans[0].EndTime())
+	assert.Equal(t, []attribute.KeyValue{
+		KeySyncName.String("root-sync"),
+		KeySyncScope.String(":root"),
+	}, spans[0].Attributes())
+}
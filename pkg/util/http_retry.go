@@ -0,0 +1,101 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// HTTPRetryBackoff returns the backoff of the retries of SendWithRetry:
+// 5 attempts, starting 1 second apart and doubling.
+func HTTPRetryBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: time.Second,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    5,
+	}
+}
+
+// PostWithRetry POSTs the body to the URL with SendWithRetry.
+func PostWithRetry(ctx context.Context, client *http.Client, backoff wait.Backoff, description, url, contentType string, body []byte) error {
+	return SendWithRetry(ctx, client, backoff, description, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
+}
+
+// SendWithRetry sends the request built by newRequest, and retries with
+// exponential backoff if the request fails with a network error, a 429 or a
+// 5xx response, or if newRequest returns a RetriableError.
+// The description of the request is logged with each retry.
+// Returns the error of the last attempt.
+func SendWithRetry(ctx context.Context, client *http.Client, backoff wait.Backoff, description string, newRequest func(ctx context.Context) (*http.Request, error)) error {
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		err := send(ctx, client, newRequest)
+		if err == nil {
+			return true, nil
+		}
+		if !IsErrorRetriable(err) {
+			return false, err
+		}
+		klog.V(3).Infof("Retrying %s: %v", description, err)
+		lastErr = err
+		return false, nil
+	})
+	if wait.Interrupted(err) && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// send sends the request built by newRequest. Returns a RetriableError if the
+// request may be retried.
+func send(ctx context.Context, client *http.Client, newRequest func(ctx context.Context) (*http.Request, error)) error {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return NewRetriableError(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	// Drain the body to reuse the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return NewRetriableError(fmt.Errorf("unexpected response status %s", resp.Status))
+	default:
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+	"kpt.dev/configsync/pkg/api/configsync"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/status"
+)
+
+// FormatFlag is the name of the flag that sets the format of the logs.
+const FormatFlag = "log-format"
+
+// The keys of the structured logs, shared by all the Config Sync binaries so
+// the logs of the containers of a reconciler can be correlated.
+const (
+	// KeyScope is the scope of the RootSync or RepoSync: ":root" or its
+	// namespace.
+	KeyScope = "scope"
+	// KeySyncName is the name of the RootSync or RepoSync.
+	KeySyncName = "syncName"
+	// KeyCommit is the source commit, OCI image digest or Helm chart version.
+	KeyCommit = "commit"
+	// KeyObject is the ID of an object, like "apps/Deployment, bookstore/web".
+	KeyObject = "object"
+	// KeyObjects are the IDs of the objects of an error about several objects.
+	KeyObjects = "objects"
+	// KeyErrorCode is the KNV code of an error, like "2009".
+	KeyErrorCode = "errorCode"
+)
+
+// format is the value of the --log-format flag.
+var format = string(configsync.LogFormatText)
+
+// AddFlags registers the --log-format flag on the flag set.
+func AddFlags(fs *flag.FlagSet) {
+	fs.StringVar(&format, FormatFlag, format,
+		fmt.Sprintf("The format of the logs, must be %s or %s.", configsync.LogFormatText, configsync.LogFormatJSON))
+}
+
+// Configure configures klog to log in the format set by --log-format, and
+// returns the logger to use for the other logr users, like
+// controller-runtime. Must be called after the flags are parsed.
+func Configure() logr.Logger {
+	switch configsync.LogFormat(format) {
+	case configsync.LogFormatText:
+		return textlogger.NewLogger(textlogger.NewConfig())
+	case configsync.LogFormatJSON:
+		logger := newJSONLogger(os.Stderr, verbosity())
+		klog.SetLogger(logger)
+		return logger
+	default:
+		klog.Fatalf("Invalid --%s %q: must be %s or %s", FormatFlag, format, configsync.LogFormatText, configsync.LogFormatJSON)
+		return logr.Discard()
+	}
+}
+
+// WithValues returns the logger with the key/value pairs. When logging in
+// JSON, the key/value pairs are also added to all the logs of klog.
+func WithValues(logger logr.Logger, keysAndValues ...interface{}) logr.Logger {
+	logger = logger.WithValues(keysAndValues...)
+	if configsync.LogFormat(format) == configsync.LogFormatJSON {
+		klog.SetLogger(logger)
+	}
+	return logger
+}
+
+// newJSONLogger returns a logger that writes one JSON object per line, with
+// the timestamp, the caller, the verbosity level, the message and the
+// key/value pairs.
+func newJSONLogger(w io.Writer, v int) logr.Logger {
+	return funcr.NewJSON(func(obj string) {
+		_, _ = fmt.Fprintln(w, obj)
+	}, funcr.Options{
+		LogCaller:       funcr.All,
+		LogTimestamp:    true,
+		TimestampFormat: time.RFC3339Nano,
+		Verbosity:       v,
+	})
+}
+
+// verbosity returns the value of the klog -v flag.
+func verbosity() int {
+	f := flag.Lookup("v")
+	if f == nil {
+		return 0
+	}
+	v, err := strconv.Atoi(f.Value.String())
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// ErrorValues returns the key/value pairs that identify the error in
+// structured logs: its error code and, for errors about objects, their IDs.
+func ErrorValues(err status.Error) []interface{} {
+	keysAndValues := []interface{}{KeyErrorCode, err.Code()}
+	resErr, ok := err.(status.ResourceError)
+	if !ok {
+		return keysAndValues
+	}
+	resources := resErr.Resources()
+	switch len(resources) {
+	case 0:
+	case 1:
+		keysAndValues = append(keysAndValues, KeyObject, core.IDOf(resources[0]).String())
+	default:
+		ids := make([]string, len(resources))
+		for i, r := range resources {
+			ids[i] = core.IDOf(r).String()
+		}
+		keysAndValues = append(keysAndValues, KeyObjects, ids)
+	}
+	return keysAndValues
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/status"
+)
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newJSONLogger(&buf, 1)
+	logger = logger.WithValues(KeyScope, ":root", KeySyncName, "root-sync")
+
+	logger.Info("Applier starting", KeyCommit, "abc")
+	logger.V(1).Info("Verbose")
+	logger.V(2).Info("Too verbose")
+	logger.Error(errors.New("boom"), "Applier failed", KeyErrorCode, "2009")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var got []map[string]interface{}
+	for _, line := range lines {
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		assert.NotEmpty(t, entry["ts"])
+		assert.NotEmpty(t, entry["caller"])
+		delete(entry, "ts")
+		delete(entry, "caller")
+		delete(entry, "logger")
+		got = append(got, entry)
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{"level": 0.0, "msg": "Applier starting", "scope": ":root", "syncName": "root-sync", "commit": "abc"},
+		{"level": 1.0, "msg": "Verbose", "scope": ":root", "syncName": "root-sync"},
+		{"msg": "Applier failed", "error": "boom", "scope": ":root", "syncName": "root-sync", "errorCode": "2009"},
+	}, got)
+}
+
+func TestErrorValues(t *testing.T) {
+	deployment := k8sobjects.DeploymentObject()
+	namespace := k8sobjects.NamespaceObject("bookstore")
+
+	testCases := []struct {
+		name string
+		err  status.Error
+		want []interface{}
+	}{
+		{
+			name: "error without objects",
+			err:  status.InternalError("boom"),
+			want: []interface{}{KeyErrorCode, status.InternalErrorCode},
+		},
+		{
+			name: "error about an object",
+			err:  status.ResourceErrorBuilder.Sprint("boom").BuildWithResources(deployment),
+			want: []interface{}{KeyErrorCode, status.ResourceErrorCode, KeyObject, "Deployment.apps, /default-name"},
+		},
+		{
+			name: "error about several objects",
+			err:  status.ResourceErrorBuilder.Sprint("boom").BuildWithResources(deployment, namespace),
+			want: []interface{}{KeyErrorCode, status.ResourceErrorCode, KeyObjects, []string{"Deployment.apps, /default-name", "Namespace, /bookstore"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ErrorValues(tc.err))
+		})
+	}
+}
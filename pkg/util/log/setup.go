@@ -17,16 +17,22 @@ package log
 import (
 	"flag"
 
+	"github.com/go-logr/logr"
 	"k8s.io/klog/v2"
 	"kpt.dev/configsync/pkg/version"
 )
 
 // Setup sets up default logging configs for Nomos applications and logs the preamble.
-func Setup() {
+// Returns the logger in the format set by --log-format, for controller-runtime
+// and the other logr users.
+func Setup() logr.Logger {
 	klog.InitFlags(nil)
+	AddFlags(flag.CommandLine)
 	if err := flag.Set("logtostderr", "true"); err != nil {
 		klog.Fatal(err)
 	}
 	flag.Parse()
+	logger := Configure()
 	klog.Infof("Build Version: %s", version.VERSION)
+	return logger
 }
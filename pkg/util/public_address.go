@@ -0,0 +1,66 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+)
+
+// sharedAddressSpace is the shared address space of carrier-grade NAT, which
+// some clusters use for Pod and Service IPs.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublicAddress checks if the IP address is reachable on the internet: not
+// a loopback, private, link-local, shared or unspecified address, which can
+// be the address of a Pod, a Service, a node, or the metadata server.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() &&
+		!addr.IsPrivate() &&
+		!sharedAddressSpace.Contains(addr)
+}
+
+// PublicOnlyClient returns a copy of the HTTP client which only connects to
+// public IP addresses. The addresses are checked when connecting, after the
+// host name is resolved, so a host name or a redirect can't be used to reach
+// a non-public address.
+func PublicOnlyClient(client *http.Client) *http.Client {
+	dialer := &net.Dialer{
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("connecting to the non-public address %s is not allowed", addrPort.Addr())
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Connect directly, so the addresses of the endpoints are checked.
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, address)
+	}
+	c := *client
+	c.Transport = transport
+	return &c
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	testCases := map[string]bool{
+		"8.8.8.8":         true,
+		"2001:4860::8888": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"100.64.0.1":      false,
+		"169.254.169.254": false,
+		"fd00::1":         false,
+		"fe80::1":         false,
+		"0.0.0.0":         false,
+		"::ffff:10.0.0.1": false,
+	}
+	for address, want := range testCases {
+		t.Run(address, func(t *testing.T) {
+			assert.Equal(t, want, IsPublicAddress(netip.MustParseAddr(address)))
+		})
+	}
+}
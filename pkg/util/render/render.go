@@ -0,0 +1,213 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render runs in-process renderings, like Kustomize builds and Helm
+// templates, one at a time, with an optional memory limit.
+//
+// An in-process rendering can't be interrupted. When its caller stops waiting
+// for it, the rendering keeps running and holding its memory until it
+// completes. A rendering holds a process-wide lock until it actually
+// completes, and the next rendering waits for the lock. If an abandoned
+// rendering doesn't complete shortly after, the process exits, so that the
+// container restarts without it instead of blocking every later rendering.
+//
+// Because renderings run one at a time, the memory limit applies to the live
+// heap of the process while a rendering runs, which is mostly the memory of
+// the rendering.
+package render
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// memoryCheckPeriod is how often the heap is checked while rendering.
+	memoryCheckPeriod = 100 * time.Millisecond
+
+	// liveHeapMetric is the runtime metric of the heap which was live at the
+	// last garbage collection.
+	liveHeapMetric = "/gc/heap/live:bytes"
+
+	// defaultMemoryLimitPercent is the default memory limit, in percent of
+	// the memory limit of the container. The rest is left for the memory of
+	// the process outside of the heap.
+	defaultMemoryLimitPercent = 80
+)
+
+// abandonedGracePeriod is how long an abandoned rendering can keep running
+// before the process exits. It leaves the caller time to report the error.
+var abandonedGracePeriod = 5 * time.Second
+
+// exit stops the process when an abandoned rendering does not complete.
+var exit = func(err error) {
+	klog.Fatalf("Exiting to stop a rendering which did not complete after it was abandoned: %v", err)
+}
+
+// cgroupMemoryLimitFiles are the files with the memory limit of the
+// container, for cgroup v2 and v1.
+var cgroupMemoryLimitFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// ErrMemoryLimitExceeded is returned when the heap exceeds the memory limit
+// while rendering.
+var ErrMemoryLimitExceeded = errors.New("the memory limit for rendering is exceeded")
+
+var (
+	// lock is held by the goroutine of the running rendering until it exits.
+	lock = make(chan struct{}, 1)
+
+	// memoryLimit is the maximum live heap in bytes while rendering, or zero
+	// for no limit.
+	memoryLimit atomic.Int64
+)
+
+// SetMemoryLimit sets the maximum live heap in bytes while rendering, or zero
+// for no limit. It also sets the soft memory limit of the Go runtime, so that
+// the garbage collector runs more often close to the limit.
+func SetMemoryLimit(limit int64) {
+	memoryLimit.Store(limit)
+	if limit > 0 {
+		debug.SetMemoryLimit(limit)
+	}
+}
+
+// ParseMemoryLimit parses the memory limit, like "512Mi". If empty, it
+// defaults to 80% of the memory limit of the container, or zero if the
+// container has no memory limit.
+func ParseMemoryLimit(value string) (int64, error) {
+	if value == "" {
+		return containerMemoryLimit() * defaultMemoryLimitPercent / 100, nil
+	}
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit %q: %w", value, err)
+	}
+	return quantity.Value(), nil
+}
+
+// containerMemoryLimit returns the memory limit of the container from its
+// cgroup, or zero if it is unlimited or unknown.
+func containerMemoryLimit() int64 {
+	for _, file := range cgroupMemoryLimitFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		limit, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			// cgroup v2 writes "max" for no limit.
+			return 0
+		}
+		// cgroup v1 writes a huge page-aligned number for no limit.
+		if limit >= 1<<62 {
+			return 0
+		}
+		return limit
+	}
+	return 0
+}
+
+// liveHeap returns the size of the live heap in bytes.
+func liveHeap() int64 {
+	sample := []metrics.Sample{{Name: liveHeapMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return int64(sample[0].Value.Uint64())
+}
+
+// Run runs the rendering fn in a goroutine, after any previous rendering has
+// completed, and returns its error.
+//
+// Run returns early when ctx is done, either while waiting for a previous
+// rendering or while fn is running, or when the live heap exceeds the memory
+// limit while fn is running. The goroutine keeps running fn, and its result is
+// discarded. If fn is still running after abandonedGracePeriod, the process
+// exits.
+func Run(ctx context.Context, name string, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s did not start: %w", name, err)
+	}
+	select {
+	case lock <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("%s did not start, because a previous rendering is still running: %w", name, ctx.Err())
+	}
+
+	result := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer func() {
+			<-lock
+			close(done)
+		}()
+		defer func() {
+			if r := recover(); r != nil {
+				result <- fmt.Errorf("%s panicked: %v", name, r)
+			}
+		}()
+		result <- fn()
+	}()
+
+	var memoryCheck <-chan time.Time
+	limit := memoryLimit.Load()
+	if limit > 0 {
+		ticker := time.NewTicker(memoryCheckPeriod)
+		defer ticker.Stop()
+		memoryCheck = ticker.C
+	}
+	for {
+		select {
+		case err := <-result:
+			return err
+		case <-ctx.Done():
+			return abandon(done, fmt.Errorf("%s did not complete: %w", name, ctx.Err()))
+		case <-memoryCheck:
+			if heap := liveHeap(); heap > limit {
+				return abandon(done, fmt.Errorf("%s was abandoned with a heap of %d bytes over the limit of %d bytes: %w",
+					name, heap, limit, ErrMemoryLimitExceeded))
+			}
+		}
+	}
+}
+
+// abandon returns the error of the abandoned rendering, and exits the process
+// if the rendering is not done after abandonedGracePeriod.
+func abandon(done <-chan struct{}, err error) error {
+	timer := time.NewTimer(abandonedGracePeriod)
+	go func() {
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+			exit(err)
+		}
+	}()
+	return err
+}
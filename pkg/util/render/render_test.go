@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubExit replaces the process exit with a channel of the exit errors.
+func stubExit(t *testing.T) <-chan error {
+	t.Helper()
+	exits := make(chan error, 1)
+	defaultExit := exit
+	t.Cleanup(func() { exit = defaultExit })
+	exit = func(err error) { exits <- err }
+	return exits
+}
+
+func TestRun(t *testing.T) {
+	err := Run(context.Background(), "test rendering", func() error {
+		return errors.New("render error")
+	})
+	assert.EqualError(t, err, "render error")
+
+	err = Run(context.Background(), "test rendering", func() error {
+		panic("boom")
+	})
+	assert.EqualError(t, err, "test rendering panicked: boom")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	called := false
+	err = Run(ctx, "test rendering", func() error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, called)
+}
+
+func TestRunWaitsForAbandonedRendering(t *testing.T) {
+	exits := stubExit(t)
+	release := make(chan struct{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Run(ctx, "slow rendering", func() error {
+		<-release
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "slow rendering did not complete")
+
+	// The abandoned rendering is still running, so the next one can't start.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = Run(ctx, "next rendering", func() error {
+		t.Error("the rendering must not start while the previous one is running")
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "a previous rendering is still running")
+
+	// Once the abandoned rendering completes, the next one runs.
+	close(release)
+	err = Run(context.Background(), "next rendering", func() error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, exits, "the process must not exit when the abandoned rendering completes")
+}
+
+func TestRunExitsWhenAbandonedRenderingDoesNotComplete(t *testing.T) {
+	exits := stubExit(t)
+	defaultGracePeriod := abandonedGracePeriod
+	t.Cleanup(func() { abandonedGracePeriod = defaultGracePeriod })
+	abandonedGracePeriod = 10 * time.Millisecond
+
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := Run(ctx, "stuck rendering", func() error {
+		<-release
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	select {
+	case exitErr := <-exits:
+		assert.Equal(t, err, exitErr)
+	case <-time.After(time.Minute):
+		t.Fatal("the process did not exit")
+	}
+}
+
+func TestRunMemoryLimit(t *testing.T) {
+	// Set the limit without the soft memory limit of the Go runtime, which
+	// would make the garbage collector run continuously.
+	stubExit(t)
+	memoryLimit.Store(1)
+	t.Cleanup(func() { memoryLimit.Store(0) })
+	// The live heap is only known after a garbage collection.
+	runtime.GC()
+
+	release := make(chan struct{})
+	defer close(release)
+	err := Run(context.Background(), "large rendering", func() error {
+		<-release
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrMemoryLimitExceeded)
+	assert.Contains(t, err.Error(), "large rendering was abandoned with a heap of")
+}
+
+func TestParseMemoryLimit(t *testing.T) {
+	dir := t.TempDir()
+	writeLimit := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	defaultFiles := cgroupMemoryLimitFiles
+	t.Cleanup(func() { cgroupMemoryLimitFiles = defaultFiles })
+
+	testCases := []struct {
+		name        string
+		value       string
+		cgroupFiles []string
+		expected    int64
+		expectedErr string
+	}{
+		{
+			name:     "explicit limit",
+			value:    "512Mi",
+			expected: 512 << 20,
+		},
+		{
+			name:     "disabled",
+			value:    "0",
+			expected: 0,
+		},
+		{
+			name:        "invalid limit",
+			value:       "lots",
+			expectedErr: `invalid memory limit "lots"`,
+		},
+		{
+			name:        "default from the cgroup v2 limit",
+			cgroupFiles: []string{writeLimit("memory.max", "1000\n")},
+			expected:    800,
+		},
+		{
+			name:        "default from the cgroup v1 limit",
+			cgroupFiles: []string{filepath.Join(dir, "missing"), writeLimit("memory.limit_in_bytes", "2000\n")},
+			expected:    1600,
+		},
+		{
+			name:        "no cgroup v2 limit",
+			cgroupFiles: []string{writeLimit("unlimited.max", "max\n")},
+			expected:    0,
+		},
+		{
+			name:        "no cgroup v1 limit",
+			cgroupFiles: []string{writeLimit("unlimited_in_bytes", "9223372036854771712\n")},
+			expected:    0,
+		},
+		{
+			name:        "no cgroup",
+			cgroupFiles: []string{filepath.Join(dir, "missing")},
+			expected:    0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgroupMemoryLimitFiles = tc.cgroupFiles
+			limit, err := ParseMemoryLimit(tc.value)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+package sourcemetadata
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// GitCommitTime returns the committer time of the commit checked out in the
+// worktree, read from the object store of the repository.
+//
+// The commit is read with go-git, since the git CLI isn't in the reconciler
+// image. The worktree can be a linked worktree, like the worktrees of
+// git-sync, whose .git file points to the common Git directory of the
+// repository.
+func GitCommitTime(worktree, commit string) (time.Time, error) {
+	if !plumbing.IsHash(commit) {
+		return time.Time{}, fmt.Errorf("unsupported Git commit hash %q", commit)
+	}
+	repo, err := git.PlainOpenWithOptions(worktree, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("opening the Git repository of the worktree %s: %w", worktree, err)
+	}
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading Git commit %s: %w", commit, err)
+	}
+	return c.Committer.When.UTC(), nil
+}
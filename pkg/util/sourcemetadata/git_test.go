@@ -0,0 +1,86 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcemetadata
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var commitTime = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=author", "GIT_AUTHOR_EMAIL=author@example.com",
+		"GIT_COMMITTER_NAME=committer", "GIT_COMMITTER_EMAIL=committer@example.com",
+		// The author time differs, to check that the committer time is read.
+		"GIT_AUTHOR_DATE=2026-01-01T00:00:00Z",
+		"GIT_COMMITTER_DATE="+commitTime.Format(time.RFC3339))
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+// newGitRepo creates a repository with a commit, checked out in a linked
+// worktree like git-sync does. Returns the worktree and the commit.
+func newGitRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	runGit(t, root, "init", "--quiet")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "ns.yaml"), []byte("kind: Namespace\n"), 0644))
+	runGit(t, root, "add", "ns.yaml")
+	runGit(t, root, "commit", "--quiet", "--message", "Add a namespace")
+	commit := runGit(t, root, "rev-parse", "HEAD")
+	worktree := filepath.Join(root, ".worktrees", commit)
+	runGit(t, root, "worktree", "add", "--quiet", "--detach", worktree, commit)
+	return worktree, commit
+}
+
+func TestGitCommitTime(t *testing.T) {
+	worktree, commit := newGitRepo(t)
+
+	// Loose objects
+	got, err := GitCommitTime(worktree, commit)
+	require.NoError(t, err)
+	assert.Equal(t, commitTime, got)
+
+	// Packed objects
+	runGit(t, worktree, "gc", "--quiet", "--prune=now")
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(filepath.Dir(worktree)), ".git", "objects", "pack", "*.idx"))
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	got, err = GitCommitTime(worktree, commit)
+	require.NoError(t, err)
+	assert.Equal(t, commitTime, got)
+
+	_, err = GitCommitTime(worktree, strings.Repeat("0", 40))
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+	_, err = GitCommitTime(worktree, "not-a-commit")
+	assert.ErrorContains(t, err, "unsupported Git commit hash")
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sourcemetadata records and reads the time of the source commits
+// fetched by the *-sync sidecars to the shared source volume.
+//
+// The oci-sync and helm-sync sidecars record the time of the commit they fetch
+// in a metadata file in the source root. The time of a Git commit is read from
+// the object store of the repository cloned by git-sync.
+package sourcemetadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+const (
+	// File is the name of the metadata file in the source root.
+	File = "source-metadata.json"
+	// tmpFile is the name of the file written before it is renamed to File.
+	tmpFile = "tmp-source-metadata.json"
+)
+
+// Metadata is the metadata of the fetched source commit.
+type Metadata struct {
+	// Commit is the OCI image digest or the Helm chart version.
+	Commit string `json:"commit"`
+	// CommitTime is the creation time of the OCI image or the publish time of
+	// the Helm chart version, if known.
+	CommitTime *time.Time `json:"commitTime,omitempty"`
+}
+
+// Write writes the metadata file in the source root. The file is replaced
+// atomically, so it should be written before the symlink to the new commit is
+// updated, so that the metadata of the commit is available when the commit is.
+func Write(root string, metadata Metadata) error {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("encoding the source metadata: %w", err)
+	}
+	tmpPath := filepath.Join(root, tmpFile)
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("writing the source metadata: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(root, File)); err != nil {
+		return fmt.Errorf("replacing the source metadata: %w", err)
+	}
+	return nil
+}
+
+// read reads the metadata file in the source root. Returns nil if the file
+// doesn't exist.
+func read(root string) (*Metadata, error) {
+	data, err := os.ReadFile(filepath.Join(root, File))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the source metadata: %w", err)
+	}
+	metadata := &Metadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, fmt.Errorf("decoding the source metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// CommitTime returns the time of the commit fetched to the sourcePath, which
+// is the symlink to the commit directory in the source root. Returns the zero
+// time if it is unknown.
+func CommitTime(sourceType configsync.SourceType, sourcePath, commit string) (time.Time, error) {
+	if sourceType == configsync.GitSource {
+		worktree, err := filepath.EvalSymlinks(sourcePath)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("evaluating the source path %q: %w", sourcePath, err)
+		}
+		return GitCommitTime(worktree, commit)
+	}
+	metadata, err := read(filepath.Dir(sourcePath))
+	if err != nil {
+		return time.Time{}, err
+	}
+	// The metadata of another commit is ignored, like when the sidecar
+	// couldn't record the metadata of the fetched commit.
+	if metadata == nil || metadata.Commit != commit || metadata.CommitTime == nil {
+		return time.Time{}, nil
+	}
+	return *metadata.CommitTime, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sourcemetadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kpt.dev/configsync/pkg/api/configsync"
+)
+
+func TestCommitTime(t *testing.T) {
+	root := t.TempDir()
+	sourcePath := filepath.Join(root, "rev")
+	require.NoError(t, os.Mkdir(filepath.Join(root, "abc123"), 0755))
+	require.NoError(t, os.Symlink(filepath.Join(root, "abc123"), sourcePath))
+
+	// No metadata
+	got, err := CommitTime(configsync.OciSource, sourcePath, "abc123")
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	require.NoError(t, Write(root, Metadata{Commit: "abc123", CommitTime: &commitTime}))
+	got, err = CommitTime(configsync.OciSource, sourcePath, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, commitTime, got)
+	assert.NoFileExists(t, filepath.Join(root, tmpFile))
+
+	// The metadata of another commit
+	got, err = CommitTime(configsync.HelmSource, sourcePath, "def456")
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	// Git commits are read from the repository
+	worktree, commit := newGitRepo(t)
+	gitSourcePath := filepath.Join(filepath.Dir(filepath.Dir(worktree)), "rev")
+	require.NoError(t, os.Symlink(worktree, gitSourcePath))
+	got, err = CommitTime(configsync.GitSource, gitSourcePath, commit)
+	require.NoError(t, err)
+	assert.Equal(t, commitTime, got)
+}
@@ -58,6 +58,12 @@ type Raw struct {
 	NSControllerState *namespacecontroller.State
 	// WebhookEnabled indicates whether Webhook configuration is enabled
 	WebhookEnabled bool
+	// Variables are the values of the `${VAR}` placeholders substituted in the
+	// objects. Substitution is disabled when nil.
+	Variables map[string]string
+	// ClusterLabels are the labels of the cluster read from a cluster-local
+	// object, which take precedence over the labels of the declared Cluster.
+	ClusterLabels map[string]string
 }
 
 // Scoped builds a Scoped collection of objects from the Raw objects.
@@ -17,6 +17,7 @@ package final
 import (
 	"kpt.dev/configsync/pkg/importer/analyzer/ast"
 	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/final/hydrate"
 	"kpt.dev/configsync/pkg/validate/final/validate"
 )
 
@@ -45,3 +46,17 @@ func Validate(objs []ast.FileObject, opts Options) status.MultiError {
 	}
 	return errs
 }
+
+// Hydrate performs the final hydration of the given FileObjects. This should
+// be called after the final validation, so that it hydrates the final state
+// of the repo.
+func Hydrate(objs []ast.FileObject) status.MultiError {
+	var errs status.MultiError
+	hydrators := []validator{
+		hydrate.SyncWaves,
+	}
+	for _, hydrator := range hydrators {
+		errs = status.Append(errs, hydrator(objs))
+	}
+	return errs
+}
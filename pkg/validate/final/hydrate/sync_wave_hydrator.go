@@ -0,0 +1,252 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SyncWaves hydrates the given FileObjects by converting the
+// `configsync.gke.io/sync-wave` annotations into `config.kubernetes.io/depends-on`
+// annotations, so the applier applies and waits for each wave to reconcile
+// before applying the next one, and prunes the waves in reverse order.
+//
+// Rather than depending on every object in the previous wave, which would make
+// the annotations grow with the product of the sizes of the waves, every object
+// in a wave depends on a barrier of the previous wave: an object of that wave
+// which depends, directly or through other barriers, on all the other objects
+// of its wave. Objects without a sync wave are not ordered by waves.
+func SyncWaves(objs []ast.FileObject) status.MultiError {
+	waves := map[int][]ast.FileObject{}
+	waveOf := map[core.ID]int{}
+	var errs status.MultiError
+	for _, obj := range objs {
+		value, found := obj.GetAnnotations()[metadata.SyncWaveAnnotationKey]
+		if !found {
+			continue
+		}
+		wave, err := strconv.Atoi(value)
+		if err != nil {
+			errs = status.Append(errs, SyncWaveError(obj,
+				fmt.Sprintf("the value %q of the %s annotation must be an integer", value, metadata.SyncWaveAnnotationKey)))
+			continue
+		}
+		waves[wave] = append(waves[wave], obj)
+		waveOf[core.IDOf(obj)] = wave
+	}
+	if errs != nil {
+		return errs
+	}
+	if len(waves) == 0 {
+		return nil
+	}
+
+	crdWaves := map[schema.GroupKind]int{}
+	for _, obj := range objs {
+		wave, found := waveOf[core.IDOf(obj)]
+		if !found || obj.GetObjectKind().GroupVersionKind().GroupKind() != kinds.CustomResourceDefinition() {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		crdWaves[schema.GroupKind{Group: group, Kind: kind}] = wave
+	}
+
+	for _, obj := range objs {
+		id := core.IDOf(obj)
+		wave, found := waveOf[id]
+		if !found {
+			continue
+		}
+		if id.Namespace != "" {
+			nsID := core.ID{GroupKind: kinds.Namespace().GroupKind(), ObjectKey: client.ObjectKey{Name: id.Namespace}}
+			if nsWave, found := waveOf[nsID]; found && nsWave > wave {
+				errs = status.Append(errs, SyncWaveError(obj,
+					fmt.Sprintf("it is in sync wave %d, before its Namespace in sync wave %d", wave, nsWave)))
+			}
+		}
+		if crdWave, found := crdWaves[id.GroupKind]; found && crdWave > wave {
+			errs = status.Append(errs, SyncWaveError(obj,
+				fmt.Sprintf("it is in sync wave %d, before its CustomResourceDefinition in sync wave %d", wave, crdWave)))
+		}
+		deps, err := dependencies(obj)
+		if err != nil {
+			errs = status.Append(errs, err)
+			continue
+		}
+		for _, dep := range deps {
+			if depWave, found := waveOf[idFrom(dep)]; found && depWave > wave {
+				errs = status.Append(errs, SyncWaveError(obj,
+					fmt.Sprintf("it is in sync wave %d, before its dependency %s in sync wave %d", wave, dep, depWave)))
+			}
+		}
+	}
+	if errs != nil {
+		return errs
+	}
+
+	sortedWaves := make([]int, 0, len(waves))
+	for wave := range waves {
+		sortedWaves = append(sortedWaves, wave)
+	}
+	sort.Ints(sortedWaves)
+
+	var prevBarrier object.ObjMetadataSet
+	for _, wave := range sortedWaves {
+		if prevBarrier != nil {
+			for _, obj := range waves[wave] {
+				if err := addDependencies(obj, prevBarrier); err != nil {
+					errs = status.Append(errs, err)
+				}
+			}
+		}
+		barrier, err := addBarrier(applyOrder(waves[wave]))
+		if err != nil {
+			errs = status.Append(errs, err)
+		}
+		prevBarrier = object.ObjMetadataSet{objMetadata(core.IDOf(barrier))}
+	}
+	return errs
+}
+
+// maxBarrierDependencies is the maximum number of objects of a wave which a
+// barrier depends on, to bound the size of its depends-on annotation.
+const maxBarrierDependencies = 100
+
+// addBarrier makes the last object of each group of objects depend on the other
+// objects of the group, and then does the same for the last objects of the
+// groups, until a single object, the barrier of the wave, depends on all the
+// objects through each other. The objects must be in apply order, so that the
+// added dependencies always follow the existing ones and can't form a cycle.
+func addBarrier(objs []ast.FileObject) (ast.FileObject, status.MultiError) {
+	var errs status.MultiError
+	for len(objs) > 1 {
+		var lasts []ast.FileObject
+		for start := 0; start < len(objs); start += maxBarrierDependencies + 1 {
+			group := objs[start:min(start+maxBarrierDependencies+1, len(objs))]
+			last := group[len(group)-1]
+			var deps object.ObjMetadataSet
+			for _, obj := range group[:len(group)-1] {
+				deps = append(deps, objMetadata(core.IDOf(obj)))
+			}
+			if len(deps) > 0 {
+				if err := addDependencies(last, deps); err != nil {
+					errs = status.Append(errs, err)
+				}
+			}
+			lasts = append(lasts, last)
+		}
+		objs = lasts
+	}
+	return objs[0], errs
+}
+
+// applyOrder returns the objects of a wave in the order the applier applies
+// them, which respects the dependencies between them. If the dependencies form
+// a cycle, which the applier reports, the objects are returned as they are.
+func applyOrder(objs []ast.FileObject) []ast.FileObject {
+	byID := make(map[core.ID]ast.FileObject, len(objs))
+	set := make(object.UnstructuredSet, 0, len(objs))
+	for _, obj := range objs {
+		byID[core.IDOf(obj)] = obj
+		set = append(set, obj.Unstructured)
+	}
+	// Dependencies on objects outside of the wave are reported as errors, but
+	// they don't affect the order of the objects of the wave.
+	phases, _ := graph.SortObjs(set)
+	ordered := make([]ast.FileObject, 0, len(objs))
+	for _, phase := range phases {
+		for _, u := range phase {
+			ordered = append(ordered, byID[core.IDOf(u)])
+		}
+	}
+	if len(ordered) != len(objs) {
+		return objs
+	}
+	return ordered
+}
+
+// dependencies returns the explicit dependencies of the object.
+func dependencies(obj ast.FileObject) (dependson.DependencySet, status.Error) {
+	value, found := obj.GetAnnotations()[dependson.Annotation]
+	if !found {
+		return nil, nil
+	}
+	deps, err := dependson.ParseDependencySet(value)
+	if err != nil {
+		return nil, SyncWaveError(obj, fmt.Sprintf("unable to parse the %s annotation: %v", dependson.Annotation, err))
+	}
+	return deps, nil
+}
+
+// addDependencies adds the given objects to the explicit dependencies of the
+// object.
+func addDependencies(obj ast.FileObject, added object.ObjMetadataSet) status.Error {
+	deps, err := dependencies(obj)
+	if err != nil {
+		return err
+	}
+	depSet := object.ObjMetadataSet(deps).Union(added)
+	sort.Slice(depSet, func(i, j int) bool {
+		return depSet[i].String() < depSet[j].String()
+	})
+	value, fmtErr := dependson.FormatDependencySet(dependson.DependencySet(depSet))
+	if fmtErr != nil {
+		return SyncWaveError(obj, fmt.Sprintf("unable to format the %s annotation: %v", dependson.Annotation, fmtErr))
+	}
+	core.SetAnnotation(obj, dependson.Annotation, value)
+	return nil
+}
+
+func objMetadata(id core.ID) object.ObjMetadata {
+	return object.ObjMetadata{
+		Namespace: id.Namespace,
+		Name:      id.Name,
+		GroupKind: id.GroupKind,
+	}
+}
+
+func idFrom(m object.ObjMetadata) core.ID {
+	return core.ID{
+		GroupKind: m.GroupKind,
+		ObjectKey: client.ObjectKey{Namespace: m.Namespace, Name: m.Name},
+	}
+}
+
+// SyncWaveErrorCode is the error code for an object with an invalid sync wave.
+const SyncWaveErrorCode = "1073"
+
+var syncWaveErrorBuilder = status.NewErrorBuilder(SyncWaveErrorCode)
+
+// SyncWaveError reports that the sync wave of an object is invalid.
+func SyncWaveError(o client.Object, reason string) status.Error {
+	return syncWaveErrorBuilder.
+		Sprintf("The object has an invalid %s annotation: %s", metadata.SyncWaveAnnotationKey, reason).
+		BuildWithResources(o)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+)
+
+func syncWave(wave string) core.MetaMutator {
+	return core.Annotation(metadata.SyncWaveAnnotationKey, wave)
+}
+
+func dependsOn(deps string) core.MetaMutator {
+	return core.Annotation(dependson.Annotation, deps)
+}
+
+var anvilGVK = schema.GroupVersionKind{Group: "acme.com", Version: "v1", Kind: "Anvil"}
+
+func anvilCRD(opts ...core.MetaMutator) ast.FileObject {
+	return k8sobjects.FileObject(k8sobjects.CRDV1UnstructuredForGVK(anvilGVK, apiextensionsv1.NamespaceScoped, opts...), "cluster/crd.yaml")
+}
+
+func TestSyncWaves(t *testing.T) {
+	testCases := []struct {
+		name     string
+		objs     []ast.FileObject
+		want     []ast.FileObject
+		wantErrs status.MultiError
+	}{
+		{
+			name: "objects without sync waves are unchanged",
+			objs: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/shipping"),
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/shipping"),
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping")),
+			},
+		},
+		{
+			name: "each wave depends on the previous wave",
+			objs: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("2")),
+				k8sobjects.Namespace("namespaces/shipping", syncWave("-1")),
+				anvilCRD(syncWave("-1")),
+				k8sobjects.Unstructured(anvilGVK, core.Name("anvil"), core.Namespace("shipping"), syncWave("0")),
+				k8sobjects.Role(core.Name("unordered"), core.Namespace("shipping")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("2"),
+					dependsOn("acme.com/namespaces/shipping/Anvil/anvil")),
+				k8sobjects.Namespace("namespaces/shipping", syncWave("-1")),
+				anvilCRD(syncWave("-1"), dependsOn("/Namespace/shipping")),
+				k8sobjects.Unstructured(anvilGVK, core.Name("anvil"), core.Namespace("shipping"), syncWave("0"),
+					dependsOn("apiextensions.k8s.io/CustomResourceDefinition/anvils.acme.com")),
+				k8sobjects.Role(core.Name("unordered"), core.Namespace("shipping")),
+			},
+		},
+		{
+			name: "explicit dependencies are kept",
+			objs: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("first"), core.Namespace("shipping"), syncWave("0")),
+				k8sobjects.ConfigMap(core.Name("second"), core.Namespace("shipping"), syncWave("1"),
+					dependsOn("/namespaces/shipping/ConfigMap/unordered")),
+				k8sobjects.ConfigMap(core.Name("unordered"), core.Namespace("shipping")),
+			},
+			want: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("first"), core.Namespace("shipping"), syncWave("0")),
+				k8sobjects.ConfigMap(core.Name("second"), core.Namespace("shipping"), syncWave("1"),
+					dependsOn("/namespaces/shipping/ConfigMap/first,/namespaces/shipping/ConfigMap/unordered")),
+				k8sobjects.ConfigMap(core.Name("unordered"), core.Namespace("shipping")),
+			},
+		},
+		{
+			name: "sync wave must be an integer",
+			objs: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("first")),
+			},
+			wantErrs: SyncWaveError(k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("first")),
+				`the value "first" of the configsync.gke.io/sync-wave annotation must be an integer`),
+		},
+		{
+			name: "object before its Namespace",
+			objs: []ast.FileObject{
+				k8sobjects.Namespace("namespaces/shipping", syncWave("1")),
+				k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("0")),
+			},
+			wantErrs: SyncWaveError(k8sobjects.ConfigMap(core.Name("cm"), core.Namespace("shipping"), syncWave("0")),
+				"it is in sync wave 0, before its Namespace in sync wave 1"),
+		},
+		{
+			name: "custom resource before its CustomResourceDefinition",
+			objs: []ast.FileObject{
+				anvilCRD(syncWave("1")),
+				k8sobjects.Unstructured(anvilGVK, core.Name("anvil"), core.Namespace("shipping"), syncWave("0")),
+			},
+			wantErrs: SyncWaveError(k8sobjects.Unstructured(anvilGVK, core.Name("anvil"), core.Namespace("shipping"), syncWave("0")),
+				"it is in sync wave 0, before its CustomResourceDefinition in sync wave 1"),
+		},
+		{
+			name: "object before its explicit dependency",
+			objs: []ast.FileObject{
+				k8sobjects.ConfigMap(core.Name("first"), core.Namespace("shipping"), syncWave("0"),
+					dependsOn("/namespaces/shipping/ConfigMap/second")),
+				k8sobjects.ConfigMap(core.Name("second"), core.Namespace("shipping"), syncWave("1")),
+			},
+			wantErrs: SyncWaveError(k8sobjects.ConfigMap(core.Name("first"), core.Namespace("shipping"), syncWave("0"),
+				dependsOn("/namespaces/shipping/ConfigMap/second")),
+				"it is in sync wave 0, before its dependency shipping_second__ConfigMap in sync wave 1"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := SyncWaves(tc.objs)
+			if !errors.Is(errs, tc.wantErrs) {
+				t.Errorf("got SyncWaves() error %v, want %v", errs, tc.wantErrs)
+			}
+			if tc.wantErrs != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSyncWavesBarrier(t *testing.T) {
+	var objs []ast.FileObject
+	for i := 0; i < 2*maxBarrierDependencies+50; i++ {
+		objs = append(objs, k8sobjects.ConfigMap(core.Name(fmt.Sprintf("cm-%d", i)), core.Namespace("shipping"), syncWave("0")))
+	}
+	last := k8sobjects.ConfigMap(core.Name("last"), core.Namespace("shipping"), syncWave("1"))
+	objs = append(objs, last)
+
+	if errs := SyncWaves(objs); errs != nil {
+		t.Fatalf("got SyncWaves() error %v, want nil", errs)
+	}
+
+	depsOf := map[core.ID]dependson.DependencySet{}
+	for _, obj := range objs {
+		deps, err := dependencies(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deps) > maxBarrierDependencies {
+			t.Errorf("got %d dependencies of %s, want at most %d", len(deps), core.IDOf(obj), maxBarrierDependencies)
+		}
+		depsOf[core.IDOf(obj)] = deps
+	}
+	if deps := depsOf[core.IDOf(last)]; len(deps) != 1 {
+		t.Fatalf("got dependencies %v of the last wave, want a single barrier", deps)
+	}
+
+	// The object of the last wave must depend on every object of the first wave.
+	reached := map[core.ID]bool{}
+	queue := []core.ID{core.IDOf(last)}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range depsOf[id] {
+			if depID := idFrom(dep); !reached[depID] {
+				reached[depID] = true
+				queue = append(queue, depID)
+			}
+		}
+	}
+	for _, obj := range objs[:len(objs)-1] {
+		if !reached[core.IDOf(obj)] {
+			t.Errorf("the last wave does not depend on %s", core.IDOf(obj))
+		}
+	}
+}
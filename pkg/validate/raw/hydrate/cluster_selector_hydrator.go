@@ -97,7 +97,7 @@ func ClusterSelectors(objs *fileobjects.Raw) status.MultiError {
 // buildHydratorSet splits the given Raw objects into important types (Cluster,
 // ClusterSelector, Namespace) and populates a hydratorSet with them.
 func buildHydratorSet(objs *fileobjects.Raw) (*hydratorSet, status.MultiError) {
-	set := &hydratorSet{}
+	set := &hydratorSet{liveLabels: objs.ClusterLabels}
 	var errs status.MultiError
 	for _, object := range objs.Objects {
 		switch object.GetObjectKind().GroupVersionKind() {
@@ -160,6 +160,7 @@ func stateFromInlineClusterSelector(clusterName, selector string) state {
 
 type hydratorSet struct {
 	cluster    *clusterregistry.Cluster
+	liveLabels map[string]string
 	selectors  []*v1.ClusterSelector
 	namespaces []ast.FileObject
 	resources  []ast.FileObject
@@ -188,8 +189,10 @@ func (h *hydratorSet) activeSelectors() (map[string]bool, status.MultiError) {
 	activeSels := make(map[string]bool)
 	clusterLabels := labels.Set{}
 	if h.cluster != nil {
-		clusterLabels = h.cluster.Labels
+		clusterLabels = labels.Merge(clusterLabels, h.cluster.Labels)
 	}
+	// The labels read from the cluster override the declared ones.
+	clusterLabels = labels.Merge(clusterLabels, h.liveLabels)
 
 	var errs status.MultiError
 	for _, s := range h.selectors {
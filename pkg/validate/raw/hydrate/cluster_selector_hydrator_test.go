@@ -226,6 +226,45 @@ func TestClusterSelectors(t *testing.T) {
 				ClusterName: unknownClusterName,
 			},
 		},
+		{
+			name: "Keep object with legacy cluster selector matching live cluster labels",
+			objs: &fileobjects.Raw{
+				ClusterName:   unknownClusterName,
+				ClusterLabels: map[string]string{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("foo"), withDevLegacyClusterSelector),
+					devSelector,
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   unknownClusterName,
+				ClusterLabels: map[string]string{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("foo"), withDevLegacyClusterSelector),
+				},
+			},
+		},
+		{
+			name: "Live cluster labels override the labels of the declared Cluster",
+			objs: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("foo"), withDevLegacyClusterSelector),
+					k8sobjects.Role(core.Namespace("bar"), withProdLegacyClusterSelector),
+					prodCluster,
+					prodSelector,
+					devSelector,
+				},
+			},
+			want: &fileobjects.Raw{
+				ClusterName:   prodClusterName,
+				ClusterLabels: map[string]string{"environment": "dev"},
+				Objects: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("foo"), withDevLegacyClusterSelector),
+				},
+			},
+		},
 		{
 			name: "Keep object with inline cluster selector listing multiple clusters",
 			objs: &fileobjects.Raw{
@@ -0,0 +1,134 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterNameVariable is the built-in substitution variable set to the name
+// of the current cluster.
+const ClusterNameVariable = "CLUSTER_NAME"
+
+// variablePattern matches `${VAR}` placeholders, and `$${VAR}` escaped
+// placeholders which are replaced with a literal `${VAR}`.
+var variablePattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Substitute replaces the `${VAR}` placeholders in the string fields of the
+// given Raw objects with the values of the substitution variables, and the
+// built-in variables, like CLUSTER_NAME. Substitution is skipped when no
+// variables are configured, so that repositories which don't opt in can still
+// declare `${VAR}` strings, for example in scripts.
+func Substitute(objs *fileobjects.Raw) status.MultiError {
+	if objs.Variables == nil {
+		return nil
+	}
+	var errs status.MultiError
+	for _, obj := range objs.Objects {
+		vars := objectVariables(objs.Variables, obj)
+		missing := make(map[string]bool)
+		for key, value := range obj.Object {
+			if key == "apiVersion" || key == "kind" {
+				continue
+			}
+			obj.Object[key] = substituteValue(value, vars, missing)
+		}
+		if len(missing) > 0 {
+			errs = status.Append(errs, MissingVariablesError(obj, missing))
+		}
+	}
+	return errs
+}
+
+// objectVariables returns the substitution variables of the object, including
+// the built-in variables.
+func objectVariables(vars map[string]string, obj ast.FileObject) map[string]string {
+	result := make(map[string]string, len(vars)+1)
+	for k, v := range vars {
+		result[k] = v
+	}
+	if clusterName, found := obj.GetAnnotations()[metadata.ClusterNameAnnotationKey]; found {
+		result[ClusterNameVariable] = clusterName
+	}
+	return result
+}
+
+// substituteValue recursively replaces the placeholders in the string values
+// of an unstructured value, recording the undefined variables in missing.
+func substituteValue(value interface{}, vars map[string]string, missing map[string]bool) interface{} {
+	switch v := value.(type) {
+	case string:
+		return substituteString(v, vars, missing)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = substituteValue(item, vars, missing)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = substituteValue(item, vars, missing)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func substituteString(s string, vars map[string]string, missing map[string]bool) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+	return variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		name := match[2 : len(match)-1]
+		value, found := vars[name]
+		if !found {
+			missing[name] = true
+			return match
+		}
+		return value
+	})
+}
+
+// MissingVariablesErrorCode is the error code for an object which references
+// undefined substitution variables.
+const MissingVariablesErrorCode = "1071"
+
+var missingVariablesErrorBuilder = status.NewErrorBuilder(MissingVariablesErrorCode)
+
+// MissingVariablesError reports that an object references substitution
+// variables which are not defined.
+func MissingVariablesError(o client.Object, missing map[string]bool) status.Error {
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, "${"+name+"}")
+	}
+	sort.Strings(names)
+	return missingVariablesErrorBuilder.
+		Sprintf("The object references undefined substitution variables: %s. "+
+			"Define the variables in the ConfigMap referenced by spec.override.substitutionConfigMapRef, "+
+			"or escape the placeholders as $${VAR} to keep them as is.", strings.Join(names, ", ")).
+		BuildWithResources(o)
+}
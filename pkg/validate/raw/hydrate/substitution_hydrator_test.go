@@ -0,0 +1,120 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/validate/fileobjects"
+)
+
+func configMapWithData(data map[string]interface{}, opts ...core.MetaMutator) ast.FileObject {
+	obj := k8sobjects.UnstructuredAtPath(kinds.ConfigMap(), "namespaces/foo/cm.yaml",
+		append([]core.MetaMutator{core.Name("settings"), core.Namespace("foo")}, opts...)...)
+	_ = unstructured.SetNestedField(obj.Object, data, "data")
+	return obj
+}
+
+func TestSubstitute(t *testing.T) {
+	clusterName := core.Annotation(metadata.ClusterNameAnnotationKey, "prod-east")
+
+	testCases := []struct {
+		name     string
+		objs     *fileobjects.Raw
+		want     *fileobjects.Raw
+		wantErrs status.MultiError
+	}{
+		{
+			name: "substitution disabled without variables",
+			objs: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{"script": "echo ${HOME}"}),
+				},
+			},
+			want: &fileobjects.Raw{
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{"script": "echo ${HOME}"}),
+				},
+			},
+		},
+		{
+			name: "substitute variables and built-ins",
+			objs: &fileobjects.Raw{
+				Variables: map[string]string{"REGION": "us-east1", "DOMAIN": "example.com"},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{
+						"host":    "${CLUSTER_NAME}.${DOMAIN}",
+						"region":  "${REGION}",
+						"literal": "$${REGION}",
+					}, clusterName, core.Label("region", "${REGION}")),
+				},
+			},
+			want: &fileobjects.Raw{
+				Variables: map[string]string{"REGION": "us-east1", "DOMAIN": "example.com"},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{
+						"host":    "prod-east.example.com",
+						"region":  "us-east1",
+						"literal": "${REGION}",
+					}, clusterName, core.Label("region", "us-east1")),
+				},
+			},
+		},
+		{
+			name: "missing variables",
+			objs: &fileobjects.Raw{
+				Variables: map[string]string{},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{
+						"host":   "${CLUSTER_NAME}.${DOMAIN}",
+						"region": "${REGION}",
+					}),
+				},
+			},
+			want: &fileobjects.Raw{
+				Variables: map[string]string{},
+				Objects: []ast.FileObject{
+					configMapWithData(map[string]interface{}{
+						"host":   "${CLUSTER_NAME}.${DOMAIN}",
+						"region": "${REGION}",
+					}),
+				},
+			},
+			wantErrs: MissingVariablesError(configMapWithData(nil),
+				map[string]bool{"CLUSTER_NAME": true, "DOMAIN": true, "REGION": true}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := Substitute(tc.objs)
+			if !errors.Is(errs, tc.wantErrs) {
+				t.Errorf("Got Substitute() error %v, want %v", errs, tc.wantErrs)
+			}
+			if diff := cmp.Diff(tc.want, tc.objs, ast.CompareFileObject); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -45,6 +45,7 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Directory),
 		fileobjects.VisitAllRaw(validate.HNCLabels),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.Hook),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
@@ -72,6 +73,7 @@ func Hierarchical(objs *fileobjects.Raw) status.MultiError {
 		hydrate.ObjectNamespaces,
 		hydrate.ClusterSelectors,
 		hydrate.ClusterName,
+		hydrate.Substitute,
 		hydrate.Filepath,
 		hydrate.HNCDepth,
 		hydrate.PreventDeletion,
@@ -99,6 +101,7 @@ func Unstructured(objs *fileobjects.Raw) status.MultiError {
 		fileobjects.VisitAllRaw(validate.Name),
 		fileobjects.VisitAllRaw(validate.Namespace),
 		fileobjects.VisitAllRaw(validate.ManagementAnnotation),
+		fileobjects.VisitAllRaw(validate.Hook),
 		fileobjects.VisitAllRaw(validate.IllegalCRD),
 		fileobjects.VisitAllRaw(validate.CRDName),
 		fileobjects.VisitAllRaw(validate.SelfReconcile(declared.ReconcilerNameFromScope(objs.Scope, objs.SyncName))),
@@ -121,6 +124,7 @@ func Unstructured(objs *fileobjects.Raw) status.MultiError {
 		hydrate.DeclaredVersion,
 		hydrate.ClusterSelectors,
 		hydrate.ClusterName,
+		hydrate.Substitute,
 		hydrate.Filepath,
 		hydrate.PreventDeletion,
 	}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Hook returns an Error if the object is an invalid hook.
+// Hooks must be Jobs with a known phase, and are not applied in sync waves.
+func Hook(obj ast.FileObject) status.Error {
+	if !metadata.IsHook(obj) {
+		return nil
+	}
+	if obj.GetObjectKind().GroupVersionKind().GroupKind() != kinds.Job().GroupKind() {
+		return InvalidHookError(obj, "only Jobs can be hooks")
+	}
+	phase := core.GetAnnotation(obj, metadata.HookAnnotationKey)
+	valid := false
+	for _, p := range metadata.HookPhases {
+		if phase == p.String() {
+			valid = true
+		}
+	}
+	if !valid {
+		return InvalidHookError(obj, fmt.Sprintf("the phase %q must be one of %v", phase, metadata.HookPhases))
+	}
+	if _, found := obj.GetAnnotations()[metadata.SyncWaveAnnotationKey]; found {
+		return InvalidHookError(obj, fmt.Sprintf("hooks must not have the %s annotation", metadata.SyncWaveAnnotationKey))
+	}
+	return nil
+}
+
+// InvalidHookErrorCode is the code for an invalid hook.
+const InvalidHookErrorCode = "1074"
+
+var invalidHookErrorBuilder = status.NewErrorBuilder(InvalidHookErrorCode)
+
+// InvalidHookError reports that the hook annotation of an object is invalid.
+func InvalidHookError(o client.Object, reason string) status.Error {
+	return invalidHookErrorBuilder.
+		Sprintf("The object has an invalid %s annotation: %s", metadata.HookAnnotationKey, reason).
+		BuildWithResources(o)
+}
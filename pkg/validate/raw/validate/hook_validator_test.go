@@ -0,0 +1,71 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"kpt.dev/configsync/pkg/core"
+	"kpt.dev/configsync/pkg/core/k8sobjects"
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/kinds"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"kpt.dev/configsync/pkg/testing/testerrors"
+)
+
+func job(opts ...core.MetaMutator) ast.FileObject {
+	return k8sobjects.Unstructured(kinds.Job(), append([]core.MetaMutator{core.Name("migrate"), core.Namespace("shipping")}, opts...)...)
+}
+
+func TestHook(t *testing.T) {
+	preSync := core.Annotation(metadata.HookAnnotationKey, metadata.PreSyncHook.String())
+	testCases := []struct {
+		name string
+		obj  ast.FileObject
+		want status.Error
+	}{
+		{
+			name: "no hook annotation passes",
+			obj:  k8sobjects.Role(),
+		},
+		{
+			name: "Job hook passes",
+			obj:  job(preSync),
+		},
+		{
+			name: "other kinds fail",
+			obj:  k8sobjects.Role(preSync),
+			want: InvalidHookError(k8sobjects.Role(), "only Jobs can be hooks"),
+		},
+		{
+			name: "unknown phase fails",
+			obj:  job(core.Annotation(metadata.HookAnnotationKey, "pre-apply")),
+			want: InvalidHookError(job(), `the phase "pre-apply" must be one of [pre-sync post-sync sync-fail]`),
+		},
+		{
+			name: "sync wave fails",
+			obj:  job(preSync, core.Annotation(metadata.SyncWaveAnnotationKey, "1")),
+			want: InvalidHookError(job(), "hooks must not have the configsync.gke.io/sync-wave annotation"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Hook(tc.obj)
+			testerrors.AssertEqual(t, tc.want, err)
+		})
+	}
+}
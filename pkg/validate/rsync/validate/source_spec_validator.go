@@ -135,6 +135,16 @@ func GitSpec(git *v1beta1.Git, syncKind string) status.Error {
 		}
 	}
 
+	// Check that the commit statuses can be posted with the credentials.
+	if git.CommitStatus != nil {
+		switch {
+		case git.Auth == configsync.AuthToken:
+		case git.Auth == configsync.AuthGithubApp && git.CommitStatus.Provider == configsync.GitProviderGitHub:
+		default:
+			return InvalidCommitStatusAuthType(syncKind)
+		}
+	}
+
 	return nil
 }
 
@@ -264,6 +274,26 @@ func ValuesFileRefs(ctx context.Context, cl client.Client, syncKind, syncNamespa
 	return nil
 }
 
+// SubstitutionConfigMapRef verifies that the ConfigMap referenced by
+// spec.override.substitutionConfigMapRef exists.
+func SubstitutionConfigMapRef(ctx context.Context, cl client.Client, syncKind, syncNamespace string, ref *v1beta1.ConfigMapReference) status.Error {
+	if ref == nil {
+		return nil
+	}
+	if ref.Name == "" {
+		return MissingSubstitutionConfigMapName(syncKind)
+	}
+	objRef := types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: syncNamespace,
+	}
+	var cm corev1.ConfigMap
+	if err := cl.Get(ctx, objRef, &cm); err != nil {
+		return SubstitutionMissingConfigMap(syncKind, err)
+	}
+	return nil
+}
+
 // RootSyncOverrideSpec validates the RootSync Override specification.
 func RootSyncOverrideSpec(override *v1beta1.RootSyncOverrideSpec) status.Error {
 	if override == nil {
@@ -275,6 +305,10 @@ func RootSyncOverrideSpec(override *v1beta1.RootSyncOverrideSpec) status.Error {
 			return OverrideRoleRefNamespace(syncKind)
 		}
 	}
+	if src := override.ClusterLabelsSource; src != nil &&
+		src.Kind == configsync.ClusterLabelsSourceConfigMap && src.Name == "" {
+		return MissingClusterLabelsConfigMapName(syncKind)
+	}
 	return OverrideSpec(&override.OverrideSpec, syncKind)
 }
 
@@ -453,6 +487,15 @@ func NoOpProxy(syncKind string) status.Error {
 		Build()
 }
 
+// InvalidCommitStatusAuthType reports that a RootSync/RepoSync declares commit
+// statuses, but the credentials cannot be used to post them.
+func InvalidCommitStatusAuthType(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss which specify spec.git.commitStatus must also specify spec.git.auth as %q, or as %q when spec.git.commitStatus.provider is %q",
+			syncKind, configsync.AuthToken, configsync.AuthGithubApp, configsync.GitProviderGitHub).
+		Build()
+}
+
 // IllegalHelmChartName reports that a RootSync/RepoSync declares an invalid helm chart name.
 func IllegalHelmChartName(syncKind string) status.Error {
 	return invalidSyncBuilder.
@@ -639,6 +682,31 @@ func HelmValuesMissingConfigMap(syncKind string, err error) status.Error {
 		Build()
 }
 
+// MissingClusterLabelsConfigMapName reports that a RootSync is missing
+// spec.override.clusterLabelsSource.name for a ConfigMap.
+func MissingClusterLabelsConfigMapName(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.override.clusterLabelsSource.name when spec.override.clusterLabelsSource.kind is %q",
+			syncKind, configsync.ClusterLabelsSourceConfigMap).
+		Build()
+}
+
+// MissingSubstitutionConfigMapName reports that an RSync is missing
+// spec.override.substitutionConfigMapRef.name.
+func MissingSubstitutionConfigMapName(syncKind string) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must specify spec.override.substitutionConfigMapRef.name when spec.override.substitutionConfigMapRef is set", syncKind).
+		Build()
+}
+
+// SubstitutionMissingConfigMap reports that an RSync references a ConfigMap in
+// spec.override.substitutionConfigMapRef which cannot be read.
+func SubstitutionMissingConfigMap(syncKind string, err error) status.Error {
+	return invalidSyncBuilder.
+		Sprintf("%ss must reference a valid ConfigMap in spec.override.substitutionConfigMapRef: %s", syncKind, err.Error()).
+		Build()
+}
+
 // HelmValuesMissingConfigMapKey reports that an RSync is missing spec.helm.valuesFileRefs.valuesFile
 func HelmValuesMissingConfigMapKey(syncKind, cmName, cmNamespace, dataKey string) status.Error {
 	return invalidSyncBuilder.
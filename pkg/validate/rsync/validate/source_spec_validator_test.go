@@ -72,6 +72,14 @@ func secret(secretName string) func(*v1beta1.RepoSync) {
 	}
 }
 
+func commitStatus(provider configsync.GitProvider) func(*v1beta1.RepoSync) {
+	return func(sync *v1beta1.RepoSync) {
+		sync.Spec.Git.CommitStatus = &v1beta1.GitCommitStatus{
+			Provider: provider,
+		}
+	}
+}
+
 func gcpSAEmail(email string) func(sync *v1beta1.RepoSync) {
 	return func(sync *v1beta1.RepoSync) {
 		sync.Spec.GCPServiceAccountEmail = email
@@ -392,6 +400,24 @@ func TestValidateRepoSyncSpec(t *testing.T) {
 			obj:     repoSyncWithGit(secret("illegal secret")),
 			wantErr: IllegalSecretRef(configsync.GitSource, configsync.RepoSyncKind),
 		},
+		{
+			name: "valid commit status with token",
+			obj:  repoSyncWithGit(auth(configsync.AuthToken), secret("token"), commitStatus(configsync.GitProviderGitLab)),
+		},
+		{
+			name: "valid commit status with githubapp",
+			obj:  repoSyncWithGit(auth(configsync.AuthGithubApp), secret("githubapp"), commitStatus(configsync.GitProviderGitHub)),
+		},
+		{
+			name:    "commit status with githubapp on gitea",
+			obj:     repoSyncWithGit(auth(configsync.AuthGithubApp), secret("githubapp"), commitStatus(configsync.GitProviderGitea)),
+			wantErr: InvalidCommitStatusAuthType(configsync.RepoSyncKind),
+		},
+		{
+			name:    "commit status with ssh",
+			obj:     repoSyncWithGit(auth(configsync.AuthSSH), secret("ssh-key"), commitStatus(configsync.GitProviderGitHub)),
+			wantErr: InvalidCommitStatusAuthType(configsync.RepoSyncKind),
+		},
 		{
 			name:    "missing secret",
 			obj:     repoSyncWithGit(auth(configsync.AuthSSH)),
@@ -649,6 +675,23 @@ func TestValidateRootSyncSpec(t *testing.T) {
 			}),
 			wantErr: OverrideRoleRefNamespace(configsync.RootSyncKind),
 		},
+		{
+			name: "valid spec.override.clusterLabelsSource Membership",
+			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
+				rs.Spec.SafeOverride().ClusterLabelsSource = &v1beta1.ClusterLabelsSource{
+					Kind: configsync.ClusterLabelsSourceMembership,
+				}
+			}),
+		},
+		{
+			name: "missing spec.override.clusterLabelsSource ConfigMap name",
+			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
+				rs.Spec.SafeOverride().ClusterLabelsSource = &v1beta1.ClusterLabelsSource{
+					Kind: configsync.ClusterLabelsSourceConfigMap,
+				}
+			}),
+			wantErr: MissingClusterLabelsConfigMapName(configsync.RootSyncKind),
+		},
 		{
 			name: "valid spec.override.resources",
 			obj: rootSyncWithGit(func(rs *v1beta1.RootSync) {
@@ -21,7 +21,6 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
 	"kpt.dev/configsync/pkg/api/configsync/v1beta1"
@@ -44,7 +43,9 @@ import (
 // truth. If NamespaceSelector uses the dynamic mode, it also copies objects
 // into namespaces that are dynamically present on the cluster. It also sets a
 // default namespace on any namespace-scoped object that does not already
-// have a namespace or namespace selector set.
+// have a namespace or namespace selector set. Objects with the
+// namespace-template annotation are instantiated with the name, labels and
+// annotations of each selected namespace.
 func NamespaceSelectors(ctx context.Context, c client.Client, fieldManager string) fileobjects.ScopedVisitor {
 	return func(objs *fileobjects.Scoped) status.MultiError {
 		nsSelectors, errs := buildSelectorMap(ctx, c, fieldManager, objs)
@@ -52,8 +53,9 @@ func NamespaceSelectors(ctx context.Context, c client.Client, fieldManager strin
 			return errs
 		}
 		var result []ast.FileObject
+		templateSelectors := make(map[string]bool)
 		for _, obj := range objs.Namespace {
-			_, hasSelector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]
+			selector, hasSelector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]
 			if hasSelector {
 				copies, err := makeNamespaceCopies(obj, nsSelectors)
 				if err != nil {
@@ -61,7 +63,16 @@ func NamespaceSelectors(ctx context.Context, c client.Client, fieldManager strin
 				} else {
 					result = append(result, copies...)
 				}
+				if _, isTemplate := obj.GetAnnotations()[metadata.NamespaceTemplateAnnotationKey]; isTemplate {
+					templateSelectors[selector] = true
+				}
 			} else {
+				if _, isTemplate := obj.GetAnnotations()[metadata.NamespaceTemplateAnnotationKey]; isTemplate {
+					if _, err := namespaceTemplateEnabled(obj); err != nil {
+						errs = status.Append(errs, err)
+						continue
+					}
+				}
 				if obj.GetNamespace() == "" {
 					if objs.Scope == declared.RootScope {
 						obj.SetNamespace(metav1.NamespaceDefault)
@@ -76,18 +87,38 @@ func NamespaceSelectors(ctx context.Context, c client.Client, fieldManager strin
 			return errs
 		}
 		objs.Namespace = result
+
+		// Update the templated Namespace cache in the Namespace Controller.
+		if objs.NSControllerState != nil {
+			objs.NSControllerState.SetTemplatedNamespaces(templatedNamespaces(nsSelectors, templateSelectors))
+		}
 		return nil
 	}
 }
 
+// templatedNamespaces returns the dynamic/on-cluster Namespaces selected by the
+// NamespaceSelectors of namespace templates. The statically declared Namespaces
+// are skipped, because changes to them are synced from the source of truth.
+func templatedNamespaces(nsSelectors map[string][]client.Object, templateSelectors map[string]bool) []client.Object {
+	var namespaces []client.Object
+	for selector := range templateSelectors {
+		for _, ns := range nsSelectors[selector] {
+			if _, isDynamic := ns.(*corev1.Namespace); isDynamic {
+				namespaces = append(namespaces, ns)
+			}
+		}
+	}
+	return namespaces
+}
+
 // buildSelectorMap processes the given cluster-scoped objects to return a map
-// of NamespaceSelector names to the namespaces that are selected by each one.
+// of NamespaceSelector names to the Namespaces that are selected by each one.
 // Note that this modifies the Scoped objects to filter out the
 // NamespaceSelectors since they are no longer needed after this point.
-func buildSelectorMap(ctx context.Context, c client.Client, fieldManager string, objs *fileobjects.Scoped) (map[string][]string, status.MultiError) {
+func buildSelectorMap(ctx context.Context, c client.Client, fieldManager string, objs *fileobjects.Scoped) (map[string][]client.Object, status.MultiError) {
 	var namespaces, others []ast.FileObject
-	nsSelectorMap := make(map[string]labels.Selector)
-	selectedNamespaceMap := make(map[string][]labels.Selector)
+	nsSelectorMap := make(map[string]*selectors.NamespaceMatcher)
+	selectedNamespaceMap := make(map[string][]*selectors.NamespaceMatcher)
 	var errs status.MultiError
 	hasDynamicNSSelector := false
 
@@ -101,7 +132,7 @@ func buildSelectorMap(ctx context.Context, c client.Client, fieldManager string,
 				errs = status.Append(errs, err)
 				continue
 			}
-			selector, err := labelSelector(nsSelector)
+			selector, err := selectors.NewNamespaceMatcher(nsSelector)
 			if err != nil {
 				errs = status.Append(errs, err)
 				continue
@@ -138,26 +169,27 @@ func buildSelectorMap(ctx context.Context, c client.Client, fieldManager string,
 		}
 	}
 
-	selectorMap := make(map[string][]string)
+	selectorMap := make(map[string][]client.Object)
 	for nsSelector, selector := range nsSelectorMap {
-		var selectedNamespaces []string
-		// Select dynamic/on-cluster Namespaces that match the NamespaceSelector's labels.
-		for _, ns := range nsList.Items {
-			if ns.Status.Phase != corev1.NamespaceTerminating && selector.Matches(labels.Set(ns.GetLabels())) {
-				selectedNamespaces = append(selectedNamespaces, ns.GetName())
+		var selectedNamespaces []client.Object
+		// Select dynamic/on-cluster Namespaces that match the NamespaceSelector.
+		for i := range nsList.Items {
+			ns := &nsList.Items[i]
+			if ns.Status.Phase != corev1.NamespaceTerminating && selector.Matches(ns) {
+				selectedNamespaces = append(selectedNamespaces, ns)
 				// selectedNamespaceMap only stores dynamic Namespaces.
 				selectedNamespaceMap[ns.GetName()] = append(selectedNamespaceMap[ns.GetName()], selector)
 			}
 		}
 
-		// Select statically declared Namespaces that match the NamespaceSelector's labels.
+		// Select statically declared Namespaces that match the NamespaceSelector.
 		for _, namespace := range namespaces {
 			if _, found := selectedNamespaceMap[namespace.GetName()]; found {
 				// Ignore static Namespaces that are already selected as dynamic
 				continue
 			}
-			if selector.Matches(labels.Set(namespace.GetLabels())) {
-				selectedNamespaces = append(selectedNamespaces, namespace.GetName())
+			if selector.Matches(namespace) {
+				selectedNamespaces = append(selectedNamespaces, namespace)
 			}
 		}
 
@@ -178,31 +210,29 @@ func buildSelectorMap(ctx context.Context, c client.Client, fieldManager string,
 	return selectorMap, nil
 }
 
-// labelSelector returns the labelSelector from the NamespaceSelector object
-func labelSelector(nss *v1.NamespaceSelector) (labels.Selector, status.Error) {
-	selector, err := metav1.LabelSelectorAsSelector(&nss.Spec.Selector)
-	if err != nil {
-		return nil, selectors.InvalidSelectorError(nss, err)
-	}
-	if selector.Empty() {
-		return nil, selectors.EmptySelectorError(nss)
-	}
-	return selector, nil
-}
-
 // makeNamespaceCopies uses the given object's namespace selector to make a copy
 // of it into each namespace that is selected by it.
-func makeNamespaceCopies(obj ast.FileObject, nsSelectors map[string][]string) ([]ast.FileObject, status.Error) {
+func makeNamespaceCopies(obj ast.FileObject, nsSelectors map[string][]client.Object) ([]ast.FileObject, status.Error) {
 	selector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]
 	selected, exists := nsSelectors[selector]
 	if !exists {
 		return nil, selectors.ObjectHasUnknownNamespaceSelector(obj, selector)
 	}
 
+	isTemplate, err := namespaceTemplateEnabled(obj)
+	if err != nil {
+		return nil, err
+	}
+
 	var result []ast.FileObject
 	for _, ns := range selected {
 		objCopy := obj.DeepCopy()
-		objCopy.SetNamespace(ns)
+		objCopy.SetNamespace(ns.GetName())
+		if isTemplate {
+			if err := instantiateNamespaceTemplate(objCopy, ns); err != nil {
+				return nil, err
+			}
+		}
 		result = append(result, objCopy)
 	}
 	return result, nil
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	v1 "kpt.dev/configsync/pkg/api/configmanagement/v1"
 	"kpt.dev/configsync/pkg/api/configsync"
@@ -48,6 +49,11 @@ func nsSelector(nssName, mode, path string, labels map[string]string) ast.FileOb
 	return k8sobjects.NamespaceSelectorAtPath(path, core.Name(nssName), mutFunc)
 }
 
+var withNamespaceTemplate = core.Annotations(map[string]string{
+	metadata.NamespaceSelectorAnnotationKey: "dev-only",
+	metadata.NamespaceTemplateAnnotationKey: metadata.NamespaceTemplateEnabled,
+})
+
 func withNamespacePhase(phase corev1.NamespacePhase) core.MetaMutator {
 	return func(o client.Object) {
 		ns := o.(*corev1.Namespace)
@@ -68,6 +74,18 @@ var (
 
 	unknownModeNSS = nsSelector("unknown-mode", "unknown",
 		"unknown-nss.yaml", map[string]string{"environment": "dev"})
+
+	paymentsTeamNSS = k8sobjects.NamespaceSelectorAtPath("payments-team-nss.yaml", core.Name("payments-team"),
+		func(o client.Object) {
+			nss := o.(*v1.NamespaceSelector)
+			nss.Spec.Mode = v1.NSSelectorDynamicMode
+			nss.Spec.AnnotationSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "portal.example.com/team", Operator: metav1.LabelSelectorOpIn, Values: []string{"payments", "billing"}},
+				},
+			}
+			nss.Spec.NamePatterns = []string{"team-*"}
+		})
 )
 
 func TestNamespaceSelectors(t *testing.T) {
@@ -237,6 +255,115 @@ func TestNamespaceSelectors(t *testing.T) {
 			},
 			wantDynamicNSSelectorEnabledAnnotation: true,
 		},
+		{
+			name: "Select namespaces by annotations and name patterns",
+			objs: &fileobjects.Scoped{
+				Scope:    declared.RootScope,
+				SyncName: configsync.RootSyncName,
+				Cluster: []ast.FileObject{
+					paymentsTeamNSS,
+					k8sobjects.Namespace("namespaces/team-static", core.Annotation("portal.example.com/team", "billing")),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(core.Annotation(metadata.NamespaceSelectorAnnotationKey, "payments-team")),
+				},
+			},
+			onClusterObjects: []client.Object{
+				k8sobjects.NamespaceObject("team-payments", core.Annotation("portal.example.com/team", "payments")),
+				k8sobjects.NamespaceObject("team-search", core.Annotation("portal.example.com/team", "search")),
+				k8sobjects.NamespaceObject("payments", core.Annotation("portal.example.com/team", "payments")),
+			},
+			want: &fileobjects.Scoped{
+				Scope:    declared.RootScope,
+				SyncName: configsync.RootSyncName,
+				Cluster: []ast.FileObject{
+					k8sobjects.Namespace("namespaces/team-static", core.Annotation("portal.example.com/team", "billing")),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(
+						core.Namespace("team-payments"),
+						core.Annotation(metadata.NamespaceSelectorAnnotationKey, "payments-team")),
+					k8sobjects.Role(
+						core.Namespace("team-static"),
+						core.Annotation(metadata.NamespaceSelectorAnnotationKey, "payments-team")),
+				},
+			},
+			wantDynamicNSSelectorEnabledAnnotation: true,
+		},
+		{
+			name: "Instantiate namespace templates in selected namespaces",
+			objs: &fileobjects.Scoped{
+				Cluster: []ast.FileObject{
+					devOnlyNSS,
+					k8sobjects.Namespace("namespaces/dev1", core.Labels(map[string]string{"environment": "dev", "team": "payments"})),
+					k8sobjects.Namespace("namespaces/dev2", core.Labels(map[string]string{"environment": "dev", "team": "search"})),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(
+						core.Name("${namespace.name}-admin"),
+						core.Label("team", "${namespace.labels[team]}"),
+						core.Label("literal", "$${namespace.name}"),
+						withNamespaceTemplate),
+				},
+			},
+			want: &fileobjects.Scoped{
+				Cluster: []ast.FileObject{
+					k8sobjects.Namespace("namespaces/dev1", core.Labels(map[string]string{"environment": "dev", "team": "payments"})),
+					k8sobjects.Namespace("namespaces/dev2", core.Labels(map[string]string{"environment": "dev", "team": "search"})),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(
+						core.Name("dev1-admin"),
+						core.Namespace("dev1"),
+						core.Label("team", "payments"),
+						core.Label("literal", "${namespace.name}"),
+						withNamespaceTemplate),
+					k8sobjects.Role(
+						core.Name("dev2-admin"),
+						core.Namespace("dev2"),
+						core.Label("team", "search"),
+						core.Label("literal", "${namespace.name}"),
+						withNamespaceTemplate),
+				},
+			},
+		},
+		{
+			name: "Error for namespace template referencing an undefined label",
+			objs: &fileobjects.Scoped{
+				Cluster: []ast.FileObject{
+					devOnlyNSS,
+					k8sobjects.Namespace("namespaces/dev1", core.Label("environment", "dev")),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(core.Label("team", "${namespace.labels[team]}"), withNamespaceTemplate),
+				},
+			},
+			want: &fileobjects.Scoped{
+				Cluster: []ast.FileObject{
+					k8sobjects.Namespace("namespaces/dev1", core.Label("environment", "dev")),
+				},
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(core.Label("team", "${namespace.labels[team]}"), withNamespaceTemplate),
+				},
+			},
+			wantErrs: selectors.NamespaceTemplateError(k8sobjects.Role(), ""),
+		},
+		{
+			name: "Error for namespace template without a namespace selector",
+			objs: &fileobjects.Scoped{
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("dev1"),
+						core.Annotation(metadata.NamespaceTemplateAnnotationKey, metadata.NamespaceTemplateEnabled)),
+				},
+			},
+			want: &fileobjects.Scoped{
+				Namespace: []ast.FileObject{
+					k8sobjects.Role(core.Namespace("dev1"),
+						core.Annotation(metadata.NamespaceTemplateAnnotationKey, metadata.NamespaceTemplateEnabled)),
+				},
+			},
+			wantErrs: selectors.NamespaceTemplateError(k8sobjects.Role(), ""),
+		},
 		{
 			name: "Select namespace-scoped resources in both static namespaces and on-cluster namespaces, but they should not be double selected",
 			objs: &fileobjects.Scoped{
@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hydrate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"kpt.dev/configsync/pkg/importer/analyzer/ast"
+	"kpt.dev/configsync/pkg/importer/analyzer/transform/selectors"
+	"kpt.dev/configsync/pkg/metadata"
+	"kpt.dev/configsync/pkg/status"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// namespaceTemplatePattern matches the `${namespace.name}`,
+// `${namespace.labels[KEY]}` and `${namespace.annotations[KEY]}` placeholders,
+// and their `$${...}` escaped forms which are replaced with the literal
+// placeholder.
+var namespaceTemplatePattern = regexp.MustCompile(`\$?\$\{namespace\.(name|labels\[([^\]]+)\]|annotations\[([^\]]+)\])\}`)
+
+// namespaceTemplateEnabled returns whether the object is a Namespace template,
+// or an error if the namespace-template annotation is invalid.
+func namespaceTemplateEnabled(obj ast.FileObject) (bool, status.Error) {
+	value, found := obj.GetAnnotations()[metadata.NamespaceTemplateAnnotationKey]
+	if !found {
+		return false, nil
+	}
+	if value != metadata.NamespaceTemplateEnabled {
+		return false, selectors.NamespaceTemplateError(obj,
+			fmt.Sprintf("the annotation value must be %q, but got %q", metadata.NamespaceTemplateEnabled, value))
+	}
+	if _, hasSelector := obj.GetAnnotations()[metadata.NamespaceSelectorAnnotationKey]; !hasSelector {
+		return false, selectors.NamespaceTemplateError(obj,
+			fmt.Sprintf("the object must also declare the %q annotation", metadata.NamespaceSelectorAnnotationKey))
+	}
+	return true, nil
+}
+
+// instantiateNamespaceTemplate replaces the `${namespace.*}` placeholders in
+// the string fields of the object copy with the name, labels and annotations
+// of the given Namespace.
+func instantiateNamespaceTemplate(obj ast.FileObject, ns client.Object) status.Error {
+	missing := make(map[string]bool)
+	for key, value := range obj.Object {
+		if key == "apiVersion" || key == "kind" {
+			continue
+		}
+		obj.Object[key] = instantiateValue(value, ns, missing)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	placeholders := make([]string, 0, len(missing))
+	for placeholder := range missing {
+		placeholders = append(placeholders, placeholder)
+	}
+	sort.Strings(placeholders)
+	return selectors.NamespaceTemplateError(obj,
+		fmt.Sprintf("Namespace %q does not define %s", ns.GetName(), strings.Join(placeholders, ", ")))
+}
+
+// instantiateValue recursively replaces the placeholders in the string values
+// of an unstructured value, recording the undefined placeholders in missing.
+func instantiateValue(value interface{}, ns client.Object, missing map[string]bool) interface{} {
+	switch v := value.(type) {
+	case string:
+		return instantiateString(v, ns, missing)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = instantiateValue(item, ns, missing)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = instantiateValue(item, ns, missing)
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func instantiateString(s string, ns client.Object, missing map[string]bool) string {
+	if !strings.Contains(s, "${namespace.") {
+		return s
+	}
+	return namespaceTemplatePattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+		groups := namespaceTemplatePattern.FindStringSubmatch(match)
+		var value string
+		var found bool
+		switch {
+		case groups[1] == "name":
+			value, found = ns.GetName(), true
+		case groups[2] != "":
+			value, found = ns.GetLabels()[groups[2]]
+		default:
+			value, found = ns.GetAnnotations()[groups[3]]
+		}
+		if !found {
+			missing[match] = true
+			return match
+		}
+		return value
+	})
+}
@@ -65,6 +65,9 @@ func labelSelector(obj ast.FileObject) (labels.Selector, status.Error) {
 	if nss.Spec.Mode != "" && nss.Spec.Mode != v1.NSSelectorStaticMode {
 		return nil, selectors.UnknownNamespaceSelectorModeError(nss)
 	}
+	if nss.Spec.AnnotationSelector != nil || len(nss.Spec.NamePatterns) > 0 {
+		return nil, selectors.UnsupportedNamespaceSelectorFieldsError(nss)
+	}
 
 	selector, err := metav1.LabelSelectorAsSelector(&nss.Spec.Selector)
 	if err != nil {
@@ -44,6 +44,11 @@ func nsSelector(nssName, mode string) ast.FileObject {
 		core.Name(nssName), mutFunc)
 }
 
+var namePatternNSSelector = k8sobjects.NamespaceSelectorAtPath("namespaces/foo/selector.yaml",
+	core.Name("sre"), func(o client.Object) {
+		o.(*v1.NamespaceSelector).Spec.NamePatterns = []string{"sre-*"}
+	})
+
 func TestNamespaceSelectors(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -322,6 +327,15 @@ func TestNamespaceSelectors(t *testing.T) {
 			},
 			wantErrs: selectors.UnknownNamespaceSelectorModeError(nsSelector("sre", "unknown")),
 		},
+		{
+			name: "Use name patterns in hierarchy mode",
+			objs: &fileobjects.Tree{
+				NamespaceSelectors: map[string]ast.FileObject{
+					"sre": namePatternNSSelector,
+				},
+			},
+			wantErrs: selectors.UnsupportedNamespaceSelectorFieldsError(namePatternNSSelector),
+		},
 	}
 
 	for _, tc := range testCases {
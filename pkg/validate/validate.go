@@ -86,6 +86,14 @@ type Options struct {
 	NSControllerState *namespacecontroller.State
 	// WebhookEnabled indicates whether the admission webhook configuration is enabled
 	WebhookEnabled bool
+	// Variables are the values of the `${VAR}` placeholders substituted in the
+	// declared objects, in addition to the built-in variables like
+	// CLUSTER_NAME. Substitution is disabled when nil.
+	Variables map[string]string
+	// ClusterLabels are the labels of the cluster read from a cluster-local
+	// object. When set, they take precedence over the labels of the declared
+	// Cluster object when matching ClusterSelectors.
+	ClusterLabels map[string]string
 	// FieldManager to use when performing cluster operations
 	FieldManager string
 	// MaxObjectCount is the maximum number of objects allowed in a single
@@ -115,6 +123,8 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 		Scheme:            opts.Scheme,
 		AllowUnknownKinds: opts.AllowUnknownKinds,
 		WebhookEnabled:    opts.WebhookEnabled,
+		Variables:         opts.Variables,
+		ClusterLabels:     opts.ClusterLabels,
 	}
 
 	// nonBlockingErrs tracks the errors which do not block the apply stage
@@ -170,6 +180,12 @@ func Hierarchical(objs []ast.FileObject, opts Options) ([]ast.FileObject, status
 		return nil, status.Append(nonBlockingErrs, errs)
 	}
 
+	// Finally we convert the sync waves into explicit dependencies, so the
+	// applier applies each wave after the previous one is reconciled.
+	if errs = final.Hydrate(finalObjects); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
 	for _, visitor := range opts.Visitors {
 		finalObjects, errs = visitor(finalObjects)
 		if errs != nil {
@@ -205,6 +221,8 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		DynamicNSSelectorEnabled: opts.DynamicNSSelectorEnabled,
 		NSControllerState:        opts.NSControllerState,
 		WebhookEnabled:           opts.WebhookEnabled,
+		Variables:                opts.Variables,
+		ClusterLabels:            opts.ClusterLabels,
 	}
 
 	// nonBlockingErrs tracks the errors which do not block the apply stage
@@ -249,6 +267,12 @@ func Unstructured(ctx context.Context, c client.Client, objs []ast.FileObject, o
 		return nil, status.Append(nonBlockingErrs, errs)
 	}
 
+	// Finally we convert the sync waves into explicit dependencies, so the
+	// applier applies each wave after the previous one is reconciled.
+	if errs := final.Hydrate(finalObjects); errs != nil {
+		return nil, status.Append(nonBlockingErrs, errs)
+	}
+
 	for _, visitor := range opts.Visitors {
 		var errs status.MultiError
 		finalObjects, errs = visitor(finalObjects)